@@ -21,37 +21,236 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/ping": {
+        "/api/v1/admin/config": {
             "get": {
-                "description": "Responds with \"pong\" to verify the server is running.",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Responds with the running configuration keyed by environment variable, with secrets redacted.\nAvailable only when an admin token is configured.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "healthcheck"
+                    "admin"
                 ],
-                "summary": "Server healthcehck",
+                "summary": "Fetch configuration",
                 "responses": {
                     "200": {
-                        "description": "Server is running"
+                        "description": "Sanitized configuration",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/songs": {
+        "/api/v1/admin/migrations": {
             "get": {
-                "description": "Retrieves a list of songs from the library",
-                "consumes": [
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Responds with the version of the last applied database migration, whether it failed midway, and the available migration files.\nAvailable only when an admin token is configured.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Fetch migrations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.migrationsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/read-only": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Responds with whether the API rejects requests modifying songs.\nAvailable only when an admin token is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "admin"
                 ],
-                "summary": "Fetch multiple songs",
+                "summary": "Fetch read-only mode",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.readOnlyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Switches the read-only mode of the API on with PUT or off with DELETE. While it is on, requests modifying songs respond with 503 and a Retry-After header, and reads are served normally.\nAvailable only when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Switch read-only mode",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.readOnlyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Switches the read-only mode of the API on with PUT or off with DELETE. While it is on, requests modifying songs respond with 503 and a Retry-After header, and reads are served normally.\nAvailable only when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Switch read-only mode",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.readOnlyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/songs/link-check": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Starts requesting the link of every song in the background, with bounded concurrency, and storing the status codes. Songs whose last check got no response or an error status are then listed among songs with broken links.\nAvailable only when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Start a link check",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/http.linkCheckStartedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A link check is already running",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/songs/regex": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Retrieves the songs whose text matches the regular expression, ignoring case, like fetching songs with the other filters.\nPatterns longer than 256 characters and patterns nesting repetitions, such as (a+)+, are rejected because of their cost.\nAvailable only when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Fetch songs with lyrics matching a regex",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Regular expression the song text must match",
+                        "name": "regex",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "description": "Limit the number of items",
@@ -66,19 +265,23 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by group name",
+                        "description": "Filter by group name (ignored when empty)",
                         "name": "groupName",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by song name",
+                        "description": "Filter by song name (ignored when empty)",
                         "name": "name",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by release year",
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by release years, repeat to match any of several",
                         "name": "releaseYear",
                         "in": "query"
                     },
@@ -102,9 +305,49 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by song text",
+                        "description": "Filter by song text (ignored when empty)",
                         "name": "text",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at least the specified number of characters",
+                        "name": "minTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at most the specified number of characters",
+                        "name": "maxTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "found",
+                            "not_found",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by the outcome of the music info lookup",
+                        "name": "detailStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter songs with (true) or without (false) a release date",
+                        "name": "hasReleaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -114,46 +357,63 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.songsResponse"
                         }
                     },
+                    "400": {
+                        "description": "Missing or invalid regex",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/admin/songs/resplit": {
             "post": {
-                "description": "Adds a new song to the library",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
+                "description": "Splits the text of every song into verses again and stores the verse counts in batches.\nAvailable only when an admin token is configured.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
-                ],
-                "summary": "Add a new song",
-                "parameters": [
-                    {
-                        "description": "Add Song",
-                        "name": "song",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.addSongRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Resplit verses",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.songSchema"
+                            "$ref": "#/definitions/http.resplitVersesResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Missing or invalid admin token",
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
@@ -163,38 +423,40 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/songs/{songID}": {
-            "delete": {
-                "description": "Deletes a song using the song ID",
-                "tags": [
-                    "songs"
-                ],
-                "summary": "Remove a song",
-                "parameters": [
+        "/api/v1/admin/songs/sort-names": {
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Song ID",
-                        "name": "songID",
-                        "in": "path",
-                        "required": true
+                        "AdminToken": []
                     }
                 ],
+                "description": "Strips the configured leading articles from the group name of every song again and stores the sort names in batches.\nAvailable only when an admin token is configured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Recompute sort names",
                 "responses": {
-                    "204": {
-                        "description": "Song deleted successfully"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.errorResponse"
+                            "$ref": "#/definitions/http.recomputeSortNamesResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Missing or invalid admin token",
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
@@ -204,44 +466,224 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
                     }
                 }
-            },
-            "patch": {
-                "description": "Updates a song's information using the song ID",
-                "consumes": [
+            }
+        },
+        "/api/v1/admin/songs/touch": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Sets the update time of every song matching the filters to the current time without changing them, so that they are listed among recently updated songs again. Filters are required.\nAvailable only when an admin token is configured.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Touch songs matching filters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by group name (ignored when empty)",
+                        "name": "groupName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song name (ignored when empty)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by release years, repeat to match any of several",
+                        "name": "releaseYear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by exact release date (dd.MM.yyyy)",
+                        "name": "releaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released after the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released before the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song text (ignored when empty)",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at least the specified number of characters",
+                        "name": "minTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at most the specified number of characters",
+                        "name": "maxTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "found",
+                            "not_found",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by the outcome of the music info lookup",
+                        "name": "detailStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter songs with (true) or without (false) a release date",
+                        "name": "hasReleaseDate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsTouchedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "No filters provided",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{groupName}/facets": {
+            "get": {
+                "description": "Retrieves the distinct release years of the songs of a group with the number of songs in each year, earliest first. Songs without a release date are not counted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "groups"
                 ],
-                "summary": "Modify a song",
+                "summary": "Fetch group facets",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Song ID",
-                        "name": "songID",
+                        "description": "Group name",
+                        "name": "groupName",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.groupFacetsResponse"
+                        }
                     },
-                    {
-                        "description": "Update Song",
-                        "name": "song",
-                        "in": "body",
-                        "required": true,
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/http.updateSongRequest"
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/api/v1/groups/{groupName}/range": {
+            "get": {
+                "description": "Retrieves the earliest and latest release dates of the songs of a group with the names of the songs released on them. Songs without a release date are ignored.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Fetch group release range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name",
+                        "name": "groupName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.songSchema"
+                            "$ref": "#/definitions/http.groupReleaseRangeResponse"
                         }
                     },
                     "400": {
@@ -261,13 +703,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/songs/{songID}/text": {
-            "get": {
-                "description": "Retrieves a song along with its verses using the song ID",
+        "/api/v1/groups/{groupName}/release-date": {
+            "post": {
+                "description": "Sets the release date on every song of the group, matched exactly, that has no release date, in a single statement. Songs that already have a release date are left unchanged.",
                 "consumes": [
                     "application/json"
                 ],
@@ -275,20 +723,95 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "songs"
+                    "groups"
                 ],
-                "summary": "Fetch a song with verses",
+                "summary": "Set the release date of a group's songs missing one",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Song ID",
-                        "name": "songID",
+                        "description": "Group name",
+                        "name": "groupName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Release date",
+                        "name": "releaseDate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.updateReleaseDateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsUpdatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{groupName}/years/{year}/songs": {
+            "get": {
+                "description": "Retrieves the songs of a group released in a year, like fetching songs filtered by groupName and releaseYear.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Fetch songs of a group by year",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Group name",
+                        "name": "groupName",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Limit the number of verses",
+                        "description": "Release year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of items",
                         "name": "limit",
                         "in": "query"
                     },
@@ -297,13 +820,24 @@ const docTemplate = `{
                         "description": "Offset for pagination",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.songWithVersesResponse"
+                            "$ref": "#/definitions/http.songsResponse"
                         }
                     },
                     "400": {
@@ -313,7 +847,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "No songs of the group were released in the year",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
@@ -323,91 +863,3603 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/http.errorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "http.addSongRequest": {
-            "description": "Defines the expected structure for requests to add a new song.",
-            "type": "object",
-            "required": [
-                "group",
-                "song"
-            ],
-            "properties": {
-                "group": {
+        },
+        "/api/v1/imports/{importId}": {
+            "delete": {
+                "description": "Deletes every song added by a batch import, including songs modified since the import.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Roll back an import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "importId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.importRolledBackResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No songs of the import exist",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/imports/{importId}/songs": {
+            "get": {
+                "description": "Retrieves the songs added by a batch import that still exist, like fetching songs filtered by import.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Fetch imported songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import ID",
+                        "name": "importId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No songs of the import exist",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ping": {
+            "get": {
+                "description": "Responds with \"pong\" to verify the server is running.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "healthcheck"
+                ],
+                "summary": "Server healthcehck",
+                "responses": {
+                    "200": {
+                        "description": "Server is running"
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "description": "Retrieves the songs whose name, group name, or text match the query, the most relevant first. Matches in the song name weigh most, then matches in the group name, then matches in the text. The query is parsed like web search input: words must all match, \"quoted phrases\" match in order, or separates alternatives, and -word excludes a word. Words are matched whole, without stemming.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Search songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs": {
+            "get": {
+                "description": "Retrieves a list of songs from the library. Requested facets are counted over all songs matching the filters. With Accept: application/hal+json the songs are embedded with links to themselves and their verses, and the response links to the next and previous pages.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch multiple songs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by group name (ignored when empty)",
+                        "name": "groupName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song name (ignored when empty)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by release years, repeat to match any of several",
+                        "name": "releaseYear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by exact release date (dd.MM.yyyy)",
+                        "name": "releaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released after the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released before the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song text (ignored when empty)",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at least the specified number of characters",
+                        "name": "minTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at most the specified number of characters",
+                        "name": "maxTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "found",
+                            "not_found",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by the outcome of the music info lookup",
+                        "name": "detailStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter songs with (true) or without (false) a release date",
+                        "name": "hasReleaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated facets to count matching songs by (releaseYear, group)",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only count matching songs, responding with an empty songs array and the total",
+                        "name": "countOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the songs matching the filters across all pages, leaving the total out when false (default true unless the server is configured otherwise)",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new song to the library",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Add a new song",
+                "parameters": [
+                    {
+                        "description": "Add Song",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.addSongRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "return=representation",
+                            "return=minimal"
+                        ],
+                        "type": "string",
+                        "description": "Return preference",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.songSchema"
+                        }
+                    },
+                    "204": {
+                        "description": "Song added, returned with return=minimal",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the song representation"
+                            },
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the added song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates every song matching the filters with the fields of the body in a single statement. Without filters every song is modified, which must be confirmed with confirm=true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Modify songs matching filters",
+                "parameters": [
+                    {
+                        "description": "Update Song",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.updateSongRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Confirm modifying all songs when no filters are provided",
+                        "name": "confirm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by group name (ignored when empty)",
+                        "name": "groupName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song name (ignored when empty)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by release years, repeat to match any of several",
+                        "name": "releaseYear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by exact release date (dd.MM.yyyy)",
+                        "name": "releaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released after the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released before the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song text (ignored when empty)",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at least the specified number of characters",
+                        "name": "minTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at most the specified number of characters",
+                        "name": "maxTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "found",
+                            "not_found",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by the outcome of the music info lookup",
+                        "name": "detailStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter songs with (true) or without (false) a release date",
+                        "name": "hasReleaseDate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsUpdatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/alpha-index": {
+            "get": {
+                "description": "Counts the groups, by their sort names, or the songs, by their names, per uppercased first letter, ordered by letter. Names that don't start with a letter are counted under #, which comes first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch alphabetical index",
+                "parameters": [
+                    {
+                        "enum": [
+                            "group",
+                            "song"
+                        ],
+                        "type": "string",
+                        "default": "group",
+                        "description": "Whether to count groups or songs",
+                        "name": "by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.alphaIndexResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/batch-delete": {
+            "post": {
+                "description": "Deletes several songs using the song IDs in a single transaction. IDs of songs that don't exist are reported separately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Remove several songs",
+                "parameters": [
+                    {
+                        "description": "Song IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.songsDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsDeletedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/broken-links": {
+            "get": {
+                "description": "Retrieves songs whose link is set but is not a well-formed http or https URL, or whose last stored link check got no response or an error status, oldest first. Links are not requested here; they are checked by the admin link check job.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch songs with broken links",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/events": {
+            "get": {
+                "description": "Streams an event for every song added, modified, or removed as server-sent events, named after the kind of change.\nIdle streams receive heartbeat comments to keep the connection alive.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Stream song events",
+                "responses": {
+                    "200": {
+                        "description": "Stream of song events",
+                        "schema": {
+                            "$ref": "#/definitions/http.songEventSchema"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/export": {
+            "get": {
+                "description": "Streams a ZIP archive holding the songs matching the filters both as songs.csv, whose group and song columns can be imported again, and as songs.json, an array of songs. The songs are walked once for each member, so songs changed meanwhile can differ between them. A failure once streaming has started leaves the archive truncated.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Export songs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "zip"
+                        ],
+                        "type": "string",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by group name (ignored when empty)",
+                        "name": "groupName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song name (ignored when empty)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by release years, repeat to match any of several",
+                        "name": "releaseYear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by exact release date (dd.MM.yyyy)",
+                        "name": "releaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released after the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released before the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song text (ignored when empty)",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at least the specified number of characters",
+                        "name": "minTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at most the specified number of characters",
+                        "name": "maxTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "found",
+                            "not_found",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by the outcome of the music info lookup",
+                        "name": "detailStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter songs with (true) or without (false) a release date",
+                        "name": "hasReleaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "attachment; filename=\\\"songs.zip\\"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/import": {
+            "post": {
+                "description": "Imports songs from a multipart CSV file with \"group\" and \"song\" columns. Invalid rows are reported and skipped. The imported songs are tagged with the returned import ID, which lists or rolls back the import.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Import songs",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Import file format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Fetch song details from the music info API (best-effort, default true)",
+                        "name": "fetchInfo",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.importSongsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/import/template": {
+            "get": {
+                "description": "Returns a CSV file with the header row expected by the import endpoint and an example row.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch import template",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Import file format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV template",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "attachment; filename=songs-template.csv"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/incomplete": {
+            "get": {
+                "description": "Retrieves songs missing release date, text, or link, oldest first. Flags narrow the result to songs missing all of the selected details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch incomplete songs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only songs without release date",
+                        "name": "missingReleaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only songs without text",
+                        "name": "missingText",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only songs without link",
+                        "name": "missingLink",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/recent": {
+            "get": {
+                "description": "Retrieves the most recently added songs, newest first. The limit is capped at 50.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch recent songs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of songs to return (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.recentSongsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/recently-updated": {
+            "get": {
+                "description": "Retrieves songs, most recently updated first, with the same filters as the song list. Never edited songs can be left out. Combined with detailStatus and hasReleaseDate=false, editedOnly lists the manually edited songs still lacking details from the music info API.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch recently updated songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by group name",
+                        "name": "groupName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by release years, repeat to match any of several",
+                        "name": "releaseYear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by exact release date (dd.MM.yyyy)",
+                        "name": "releaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released after the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter songs released before the specified date (dd.MM.yyyy)",
+                        "name": "releaseDateBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by song text",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at least the specified number of characters",
+                        "name": "minTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter songs whose text has at most the specified number of characters",
+                        "name": "maxTextLen",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "found",
+                            "not_found",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by the outcome of the music info lookup",
+                        "name": "detailStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter songs with (true) or without (false) a release date",
+                        "name": "hasReleaseDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out songs that were never edited",
+                        "name": "editedOnly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/release-dates": {
+            "post": {
+                "description": "Updates the release dates of several songs in a single transaction and reports the outcome of every item. Invalid items are reported and skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Modify release dates of several songs",
+                "parameters": [
+                    {
+                        "description": "Release date updates",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.releaseDateUpdateRequest"
+                            }
+                        }
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.releaseDatesUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/text/batch": {
+            "post": {
+                "description": "Retrieves several songs along with their verses using the song IDs. The same pagination is applied to the verses of every song, and IDs of songs that don't exist are reported separately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch several songs with verses",
+                "parameters": [
+                    {
+                        "description": "Song IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.songsWithVersesRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of verses of every song",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination of verses of every song",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsWithVersesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}": {
+            "delete": {
+                "description": "Deletes a song using the song ID",
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Remove a song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Song deleted successfully"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a song's information using the song ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Modify a song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Song",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.updateSongRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "return=representation",
+                            "return=minimal"
+                        ],
+                        "type": "string",
+                        "description": "Return preference",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songSchema"
+                        }
+                    },
+                    "204": {
+                        "description": "Song modified, returned with return=minimal",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the song representation"
+                            },
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the modified song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/export": {
+            "get": {
+                "description": "Downloads the complete song, including its full text, as a JSON file named after the song",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Export a song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songSchema"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "attachment; filename=\\\"\u003cgroup\u003e\t-\t\u003csong\u003e.json\\"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response body"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time the song was last updated"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "description": "Downloads the complete song, including its full text, as a JSON file named after the song",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Export a song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songSchema"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "attachment; filename=\\\"\u003cgroup\u003e\t-\t\u003csong\u003e.json\\"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response body"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time the song was last updated"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/oembed": {
+            "get": {
+                "description": "Retrieves an oEmbed response for the song, with the group as the author and an HTML snippet linking to the song, so it can be embedded in other sites.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch an oEmbed description of a song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.oEmbedSchema"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/release-date": {
+            "patch": {
+                "description": "Updates only the release date of a song using the song ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Modify a song's release date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Release date",
+                        "name": "releaseDate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.updateReleaseDateRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "return=representation",
+                            "return=minimal"
+                        ],
+                        "type": "string",
+                        "description": "Return preference",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songSchema"
+                        }
+                    },
+                    "204": {
+                        "description": "Release date modified, returned with return=minimal",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the song representation"
+                            },
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the modified song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/similar": {
+            "get": {
+                "description": "Retrieves songs similar to a song, excluding the song itself. Songs of the same group are ranked first,\nthen songs with similar names.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch similar songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of items",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/text": {
+            "get": {
+                "description": "Retrieves a song along with its verses using the song ID. With granularity=line the text is broken into single lines instead, which are paginated the same way. A song without text gets an empty verses array, or 404 \"no lyrics available\" when the server is configured so. With q the occurrences of the query, ignoring case, are wrapped in highlight markers, \u003cem\u003e and \u003c/em\u003e by default. With verseFormat=indexed the verses are returned as objects with their text and their index in the whole text, counted from 0 across pages.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch a song with verses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of verses",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "verse",
+                            "line"
+                        ],
+                        "type": "string",
+                        "default": "verse",
+                        "description": "Units the text is broken into",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Collapse consecutive identical verses, reporting their repeats",
+                        "name": "dedupeVerses",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text to highlight in the verses, which are HTML-escaped then",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "plain",
+                            "indexed"
+                        ],
+                        "type": "string",
+                        "default": "plain",
+                        "description": "Shape of the verses",
+                        "name": "verseFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verses as a plain array, or songWithIndexedVersesResponse with verseFormat=indexed",
+                        "schema": {
+                            "$ref": "#/definitions/http.songWithVersesResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response body"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time the song was last updated"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "description": "Retrieves a song along with its verses using the song ID. With granularity=line the text is broken into single lines instead, which are paginated the same way. A song without text gets an empty verses array, or 404 \"no lyrics available\" when the server is configured so. With q the occurrences of the query, ignoring case, are wrapped in highlight markers, \u003cem\u003e and \u003c/em\u003e by default. With verseFormat=indexed the verses are returned as objects with their text and their index in the whole text, counted from 0 across pages.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch a song with verses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of verses",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "verse",
+                            "line"
+                        ],
+                        "type": "string",
+                        "default": "verse",
+                        "description": "Units the text is broken into",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Collapse consecutive identical verses, reporting their repeats",
+                        "name": "dedupeVerses",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text to highlight in the verses, which are HTML-escaped then",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "plain",
+                            "indexed"
+                        ],
+                        "type": "string",
+                        "default": "plain",
+                        "description": "Shape of the verses",
+                        "name": "verseFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verses as a plain array, or songWithIndexedVersesResponse with verseFormat=indexed",
+                        "schema": {
+                            "$ref": "#/definitions/http.songWithVersesResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response body"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time the song was last updated"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/text/diff/{otherSongID}": {
+            "get": {
+                "description": "Computes a line-level diff between the texts of two songs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Compare song texts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Other Song ID",
+                        "name": "otherSongID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songTextDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/text/lines": {
+            "get": {
+                "description": "Retrieves the lines of a song's text numbered from 1, for reference and citation. Blank lines separating verses are skipped and not counted by default, as with granularity=line of the verses endpoint; with blankLines=number they are numbered too. With format=text the lines are returned as plain text, one \"\u003cnumber\u003e: \u003ctext\u003e\" line each.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch a song's lyrics with line numbers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "number"
+                        ],
+                        "type": "string",
+                        "default": "skip",
+                        "description": "Handling of blank lines",
+                        "name": "blankLines",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "text"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songLinesResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response body"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time the song was last updated"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/text/order": {
+            "put": {
+                "description": "Rearranges the verses of a song, so that the verse at order[i] becomes the i-th verse. The text of the song is rewritten with its verses, separated by blank lines, in the new order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Reorder the verses of a song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Verse order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.reorderVersesRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "return=representation",
+                            "return=minimal"
+                        ],
+                        "type": "string",
+                        "description": "Return preference",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "eu",
+                            "iso",
+                            "us"
+                        ],
+                        "type": "string",
+                        "description": "Release date output format",
+                        "name": "dateFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.songSchema"
+                        }
+                    },
+                    "204": {
+                        "description": "Verses reordered, returned with return=minimal",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the song representation"
+                            },
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the modified song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Order isn't a permutation of the verse indices",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/text/random": {
+            "get": {
+                "description": "Picks one of the verses of a song at random, along with its index in the text. Songs without text have no verses and are reported as not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch a random verse of a song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.randomVerseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/text/search": {
+            "get": {
+                "description": "Finds the verses of a song containing the query, ignoring case. Every match is returned with up to context verses before and after it, fewer at the start and the end of the text. With highlight=true the occurrences of the query are wrapped in highlight markers, \u003cem\u003e and \u003c/em\u003e by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Search the verses of a song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of verses returned before and after every match",
+                        "name": "context",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Wrap the occurrences of the query in highlight markers, HTML-escaping the verses",
+                        "name": "highlight",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.verseMatchesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/{songID}/text/stats": {
+            "get": {
+                "description": "Counts the words, non-blank lines, verses, and distinct words, ignoring case, of the text of a song. Words are runs of letters and digits, which may contain apostrophes. Songs without text are reported as not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch song text stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Song ID",
+                        "name": "songID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.textStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stats/decades": {
+            "get": {
+                "description": "Counts songs by the decade of their release date, earliest first. Songs without a release date are not counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Fetch songs per decade",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.decadeStatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/suggest": {
+            "get": {
+                "description": "Retrieves distinct group or song names starting with the query, ignoring case. An exact match comes first, the others are ordered alphabetically.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Suggest names",
+                "parameters": [
+                    {
+                        "enum": [
+                            "group",
+                            "song"
+                        ],
+                        "type": "string",
+                        "description": "Field to suggest names for",
+                        "name": "field",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name prefix (at least 2 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of suggestions to return (default 10, max 25)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.suggestionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/text/preview": {
+            "post": {
+                "description": "Splits the provided text into verses the same way stored song texts are split",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "text"
+                ],
+                "summary": "Preview verse splitting",
+                "parameters": [
+                    {
+                        "description": "Text to split",
+                        "name": "text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.previewVersesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.versesPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/validate/release-date": {
+            "post": {
+                "description": "Checks whether the release date matches the 02.01.2006 format and returns it in ISO 8601 when valid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validate"
+                ],
+                "summary": "Validate release date",
+                "parameters": [
+                    {
+                        "description": "Release date to validate",
+                        "name": "releaseDate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.validateReleaseDateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.releaseDateValidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemap.xml": {
+            "get": {
+                "description": "Streams a sitemap listing the permalink of every song with the time it was last updated. A catalog with more songs than a sitemap may list (50000) gets a sitemap index linking to the pages instead, which are fetched with the page parameter.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "songs"
+                ],
+                "summary": "Fetch the sitemap",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sitemap page, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sitemap or sitemap index",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.errorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.addSongRequest": {
+            "description": "Defines the expected structure for requests to add a new song.",
+            "type": "object",
+            "required": [
+                "group",
+                "song"
+            ],
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "The Rolling Stones"
+                },
+                "song": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Paint It Black"
+                }
+            }
+        },
+        "http.alphaIndexResponse": {
+            "description": "Represents the structure of the response for fetching the alphabetical index of groups or songs.",
+            "type": "object",
+            "properties": {
+                "by": {
+                    "type": "string",
+                    "enum": [
+                        "group",
+                        "song"
+                    ],
+                    "example": "group"
+                },
+                "letters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.letterCountSchema"
+                    }
+                }
+            }
+        },
+        "http.decadeCountSchema": {
+            "description": "Represents the number of songs released within a decade.",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "decade": {
+                    "type": "integer",
+                    "example": 1970
+                }
+            }
+        },
+        "http.decadeStatsResponse": {
+            "description": "Represents the structure of the response for fetching song counts per decade.",
+            "type": "object",
+            "properties": {
+                "decades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.decadeCountSchema"
+                    }
+                }
+            }
+        },
+        "http.errorResponse": {
+            "description": "Represents the structure of error responses from the API.",
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Group name is required",
+                        "Song name is required"
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request body"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "http.facetCountSchema": {
+            "description": "Represents the number of matching songs sharing a value of a facet.",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "value": {
+                    "type": "string",
+                    "example": "1971"
+                }
+            }
+        },
+        "http.groupFacetsResponse": {
+            "description": "Represents the structure of the response for fetching the filterable values of a group.",
+            "type": "object",
+            "properties": {
+                "groupName": {
+                    "type": "string",
+                    "example": "Led Zeppelin"
+                },
+                "releaseYears": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.yearCountSchema"
+                    }
+                }
+            }
+        },
+        "http.groupReleaseRangeResponse": {
+            "description": "Represents the earliest and the latest released songs of a group.",
+            "type": "object",
+            "properties": {
+                "first": {
+                    "$ref": "#/definitions/http.songReleaseSchema"
+                },
+                "groupName": {
+                    "type": "string",
+                    "example": "The Beatles"
+                },
+                "last": {
+                    "$ref": "#/definitions/http.songReleaseSchema"
+                }
+            }
+        },
+        "http.importRolledBackResponse": {
+            "description": "Represents the structure of the response for rolling back a batch import.",
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "importId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174009"
+                }
+            }
+        },
+        "http.importRowErrorSchema": {
+            "description": "Describes why a row of an imported file was rejected.",
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "song: required field"
+                    ]
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "http.importSongsResponse": {
+            "description": "Represents the structure of the response for importing songs from a file.",
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.importRowErrorSchema"
+                    }
+                },
+                "importId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174009"
+                },
+                "songs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.songSchema"
+                    }
+                }
+            }
+        },
+        "http.letterCountSchema": {
+            "description": "Represents the number of groups or songs whose names start with a letter.",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "letter": {
+                    "type": "string",
+                    "example": "Q"
+                }
+            }
+        },
+        "http.lineDiffSchema": {
+            "description": "Represents a single line of a line-level diff between two song texts.",
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "string",
+                    "example": "Is this just fantasy?"
+                },
+                "operation": {
+                    "type": "string",
+                    "enum": [
+                        "unchanged",
+                        "added",
+                        "removed"
+                    ],
+                    "example": "added"
+                }
+            }
+        },
+        "http.linkCheckStartedResponse": {
+            "description": "Represents the structure of the response for starting a link check.",
+            "type": "object",
+            "properties": {
+                "started": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.migrationsResponse": {
+            "description": "Represents the structure of the response for the state of the database migrations.",
+            "type": "object",
+            "properties": {
+                "dirty": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "000001_create_tables.down.sql",
+                        "000001_create_tables.up.sql"
+                    ]
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 11
+                }
+            }
+        },
+        "http.numberedLineSchema": {
+            "description": "Represents a single line of a song text along with its line number.",
+            "type": "object",
+            "properties": {
+                "lineNumber": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Is this the real life?"
+                }
+            }
+        },
+        "http.oEmbedSchema": {
+            "description": "Represents an oEmbed response describing a song.",
+            "type": "object",
+            "properties": {
+                "author_name": {
+                    "type": "string",
+                    "example": "The Beatles"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "html": {
+                    "type": "string",
+                    "example": "\u003ca href=\"https://songs.example.com/api/v1/songs/123e4567-e89b-12d3-a456-426614174000/text\"\u003eHey Jude - The Beatles\u003c/a\u003e"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Hey Jude"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "rich"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 400
+                }
+            }
+        },
+        "http.paginationSchema": {
+            "description": "Represents pagination metadata for API responses.",
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "http.previewVersesRequest": {
+            "description": "Defines the expected structure for requests to preview verse splitting of a text.",
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "Is this the real life?\n\nIs this just fantasy?"
+                }
+            }
+        },
+        "http.randomVerseResponse": {
+            "description": "Represents the structure of the response for fetching a random verse of a song.",
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "songId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "verse": {
+                    "type": "string",
+                    "example": "Is this the real life?\nIs this just fantasy?"
+                }
+            }
+        },
+        "http.readOnlyResponse": {
+            "description": "Represents the structure of the response for the read-only mode of the API.",
+            "type": "object",
+            "properties": {
+                "readOnly": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.recentSongsResponse": {
+            "description": "Represents the structure of the response for fetching the most recently added songs.",
+            "type": "object",
+            "properties": {
+                "songs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.songSchema"
+                    }
+                }
+            }
+        },
+        "http.recomputeSortNamesResponse": {
+            "description": "Represents the structure of the response for recomputing the sort names of the songs.",
+            "type": "object",
+            "properties": {
+                "updated": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "http.releaseDateUpdateRequest": {
+            "description": "Defines the expected structure of a single item of a bulk release date update.",
+            "type": "object",
+            "required": [
+                "id",
+                "releaseDate"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "releaseDate": {
+                    "type": "string",
+                    "example": "08.11.1971"
+                }
+            }
+        },
+        "http.releaseDateUpdateResultSchema": {
+            "description": "Describes the outcome of a single item of a bulk release date update.",
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "releaseDate: invalid format",
+                        " must be like '02.01.2006'"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "song": {
+                    "$ref": "#/definitions/http.songSchema"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "updated",
+                        "notFound",
+                        "invalid"
+                    ],
+                    "example": "updated"
+                }
+            }
+        },
+        "http.releaseDateValidationResponse": {
+            "description": "Represents the structure of the response for validating a release date.",
+            "type": "object",
+            "properties": {
+                "releaseDate": {
+                    "type": "string",
+                    "example": "2006-06-19"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "http.releaseDatesUpdateResponse": {
+            "description": "Represents the structure of the response for updating the release dates of several songs.",
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.releaseDateUpdateResultSchema"
+                    }
+                }
+            }
+        },
+        "http.reorderVersesRequest": {
+            "description": "Defines the expected structure for requests to reorder the verses of a song. The verse at order[i] becomes the i-th verse, so order must hold every verse index exactly once.",
+            "type": "object",
+            "required": [
+                "order"
+            ],
+            "properties": {
+                "order": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        0,
+                        2
+                    ]
+                }
+            }
+        },
+        "http.resplitVersesResponse": {
+            "description": "Represents the structure of the response for recomputing the verse counts of the songs.",
+            "type": "object",
+            "properties": {
+                "updated": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "http.songDetailSchema": {
+            "description": "Represents detailed information about a song. Details that are not known are omitted.",
+            "type": "object",
+            "properties": {
+                "link": {
+                    "type": "string",
+                    "example": "https://example.com/heyjude"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "music.info.api"
+                },
+                "releaseDate": {
                     "type": "string",
-                    "example": "The Rolling Stones"
+                    "example": "02.01.1968"
                 },
-                "song": {
+                "text": {
                     "type": "string",
-                    "example": "Paint It Black"
+                    "example": "Hey Jude, don't make it bad..."
                 }
             }
         },
-        "http.errorResponse": {
-            "description": "Represents the structure of error responses from the API.",
+        "http.songEventSchema": {
+            "description": "Represents the data of a song change event sent on the song event stream.",
             "type": "object",
             "properties": {
-                "details": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "Group name is required",
-                        "Song name is required"
-                    ]
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "message": {
+                "songId": {
                     "type": "string",
-                    "example": "invalid request body"
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "status": {
+                "type": {
                     "type": "string",
-                    "example": "error"
+                    "enum": [
+                        "created",
+                        "updated",
+                        "deleted"
+                    ],
+                    "example": "updated"
                 }
             }
         },
-        "http.paginationSchema": {
-            "description": "Represents pagination metadata for API responses.",
+        "http.songLinesResponse": {
+            "description": "Represents the structure of the response for fetching the numbered lines of a song.",
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "integer",
-                    "example": 2
+                "groupName": {
+                    "type": "string",
+                    "example": "Queen"
                 },
-                "limit": {
-                    "type": "integer",
-                    "example": 10
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
                 },
-                "offset": {
-                    "type": "integer",
-                    "example": 0
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.numberedLineSchema"
+                    }
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 100
+                "name": {
+                    "type": "string",
+                    "example": "Bohemian Rhapsody"
                 }
             }
         },
-        "http.songDetailSchema": {
-            "description": "Represents detailed information about a song.",
+        "http.songReleaseSchema": {
+            "description": "Represents a song of a group identified by its name and release date.",
             "type": "object",
             "properties": {
-                "link": {
+                "name": {
                     "type": "string",
-                    "example": "https://example.com/heyjude"
+                    "example": "Love Me Do"
                 },
                 "releaseDate": {
                     "type": "string",
-                    "example": "02.01.1968"
-                },
-                "text": {
-                    "type": "string",
-                    "example": "Hey Jude, don't make it bad..."
+                    "example": "05.10.1962"
                 }
             }
         },
@@ -419,6 +4471,25 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2024-10-05T14:48:00Z"
                 },
+                "detailSource": {
+                    "type": "string",
+                    "enum": [
+                        "music_info_api",
+                        "client",
+                        "backfill",
+                        "heuristic"
+                    ],
+                    "example": "music_info_api"
+                },
+                "detailStatus": {
+                    "type": "string",
+                    "enum": [
+                        "found",
+                        "not_found",
+                        "failed"
+                    ],
+                    "example": "found"
+                },
                 "groupName": {
                     "type": "string",
                     "example": "The Beatles"
@@ -431,6 +4502,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Hey Jude"
                 },
+                "originalLink": {
+                    "type": "string",
+                    "example": "http://Example.com/heyjude?utm_source=newsletter"
+                },
                 "songDetail": {
                     "$ref": "#/definitions/http.songDetailSchema"
                 },
@@ -440,6 +4515,26 @@ const docTemplate = `{
                 }
             }
         },
+        "http.songTextDiffResponse": {
+            "description": "Represents the structure of the response for comparing the texts of two songs.",
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.lineDiffSchema"
+                    }
+                },
+                "otherSongId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                },
+                "songId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
         "http.songWithVersesResponse": {
             "description": "Represents the structure of the response for fetching a song with its verses.",
             "type": "object",
@@ -472,6 +4567,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Bohemian Rhapsody"
                 },
+                "repeats": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
                 "updated_at": {
                     "type": "string",
                     "example": "2024-10-06T09:12:00Z"
@@ -488,10 +4593,58 @@ const docTemplate = `{
                 }
             }
         },
+        "http.songsDeleteRequest": {
+            "description": "Defines the expected structure for requests to delete several songs.",
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "123e4567-e89b-12d3-a456-426614174000",
+                        "123e4567-e89b-12d3-a456-426614174001"
+                    ]
+                }
+            }
+        },
+        "http.songsDeletedResponse": {
+            "description": "Represents the structure of the response for deleting several songs.",
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notFound": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notFoundIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "123e4567-e89b-12d3-a456-426614174001"
+                    ]
+                }
+            }
+        },
         "http.songsResponse": {
             "description": "Represents the structure of the response for fetching multiple songs.",
             "type": "object",
             "properties": {
+                "facets": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/http.facetCountSchema"
+                        }
+                    }
+                },
                 "pagination": {
                     "$ref": "#/definitions/http.paginationSchema"
                 },
@@ -503,12 +4656,125 @@ const docTemplate = `{
                 }
             }
         },
+        "http.songsTouchedResponse": {
+            "description": "Represents the structure of the response for touching the songs matching filters.",
+            "type": "object",
+            "properties": {
+                "touched": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "http.songsUpdatedResponse": {
+            "description": "Represents the structure of the response for modifying all songs matching filters.",
+            "type": "object",
+            "properties": {
+                "updated": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "http.songsWithVersesRequest": {
+            "description": "Defines the expected structure for requests to fetch the verses of several songs.",
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "123e4567-e89b-12d3-a456-426614174000",
+                        "123e4567-e89b-12d3-a456-426614174001"
+                    ]
+                }
+            }
+        },
+        "http.songsWithVersesResponse": {
+            "description": "Represents the structure of the response for fetching the verses of several songs.",
+            "type": "object",
+            "properties": {
+                "notFound": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "123e4567-e89b-12d3-a456-426614174001"
+                    ]
+                },
+                "songs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.songWithVersesResponse"
+                    }
+                }
+            }
+        },
+        "http.suggestionsResponse": {
+            "description": "Represents the structure of the response for group or song name suggestions.",
+            "type": "object",
+            "properties": {
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Beastie Boys",
+                        "The Beatles"
+                    ]
+                }
+            }
+        },
+        "http.textStatsResponse": {
+            "description": "Represents the structure of the response for fetching the word and line counts of a song text.",
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "songId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "uniqueWords": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "verses": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "words": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "http.updateReleaseDateRequest": {
+            "description": "Defines the expected structure for requests to update only the release date of a song.",
+            "type": "object",
+            "required": [
+                "releaseDate"
+            ],
+            "properties": {
+                "releaseDate": {
+                    "type": "string",
+                    "example": "08.11.1971"
+                }
+            }
+        },
         "http.updateSongRequest": {
             "description": "Defines the expected structure for requests to update an existing song.",
             "type": "object",
             "properties": {
                 "groupName": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Led Zeppelin"
                 },
                 "link": {
@@ -517,6 +4783,7 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "Stairway to Heaven"
                 },
                 "releaseDate": {
@@ -528,6 +4795,101 @@ const docTemplate = `{
                     "example": "There's a lady who's sure..."
                 }
             }
+        },
+        "http.validateReleaseDateRequest": {
+            "description": "Defines the expected structure for requests to validate a release date.",
+            "type": "object",
+            "required": [
+                "releaseDate"
+            ],
+            "properties": {
+                "releaseDate": {
+                    "type": "string",
+                    "example": "19.06.2006"
+                }
+            }
+        },
+        "http.verseMatchSchema": {
+            "description": "Represents a verse matching a search query along with the verses around it.",
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "verses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Is this the real life?",
+                        "Is this just fantasy?"
+                    ]
+                }
+            }
+        },
+        "http.verseMatchesResponse": {
+            "description": "Represents the structure of the response for searching the verses of a song.",
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.verseMatchSchema"
+                    }
+                },
+                "query": {
+                    "type": "string",
+                    "example": "fantasy"
+                },
+                "songId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "http.versesPreviewResponse": {
+            "description": "Represents the structure of the response for previewing verse splitting of a text.",
+            "type": "object",
+            "properties": {
+                "verses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Is this the real life?",
+                        "Is this just fantasy?"
+                    ]
+                }
+            }
+        },
+        "http.yearCountSchema": {
+            "description": "Represents the number of songs released within a year.",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 1971
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Admin token sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultLinkCheckTimeout is the time a single link check may take when it is not set with NewLinkChecker.
+const DefaultLinkCheckTimeout = 5 * time.Second
+
+// LinkChecker requests song links to find out whether they still lead anywhere.
+type LinkChecker struct {
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewLinkChecker creates a new instance of LinkChecker with the provided HTTP client and timeout of every check.
+// If no client is provided, the default HTTP client is used. Non-positive timeouts fall back to DefaultLinkCheckTimeout.
+func NewLinkChecker(client *http.Client, timeout time.Duration) *LinkChecker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if timeout <= 0 {
+		timeout = DefaultLinkCheckTimeout
+	}
+
+	return &LinkChecker{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// CheckLink requests the link with a HEAD request and returns the status code of the response. Servers that don't
+// support HEAD are asked again with GET, of which the body is discarded. It returns an error when no response is received.
+func (c *LinkChecker) CheckLink(ctx context.Context, link string) (int, error) {
+	const op = "adapter.api.LinkChecker.CheckLink"
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	status, err := c.request(ctx, http.MethodHead, link)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = c.request(ctx, http.MethodGet, link)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return status, nil
+}
+
+// request sends a request with the method to the link and returns the status code of the response.
+func (c *LinkChecker) request(ctx context.Context, method, link string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to request link: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, DefaultMaxBodySize))
+
+	return resp.StatusCode, nil
+}
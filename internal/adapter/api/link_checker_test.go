@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkChecker_CheckLink(t *testing.T) {
+	var methods []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/head-unsupported":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			_, _ = w.Write([]byte("ok"))
+		case "/slow":
+			time.Sleep(50 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := NewLinkChecker(nil, 20*time.Millisecond)
+
+	t.Run("ok", func(t *testing.T) {
+		methods = nil
+
+		status, err := checker.CheckLink(context.Background(), server.URL+"/ok")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{http.MethodHead}, methods)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		status, err := checker.CheckLink(context.Background(), server.URL+"/missing")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("falls back to get", func(t *testing.T) {
+		methods = nil
+
+		status, err := checker.CheckLink(context.Background(), server.URL+"/head-unsupported")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{http.MethodHead, http.MethodGet}, methods)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		status, err := checker.CheckLink(context.Background(), server.URL+"/slow")
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Zero(t, status)
+	})
+
+	t.Run("malformed link", func(t *testing.T) {
+		status, err := checker.CheckLink(context.Background(), "http://exa mple.com")
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to create request")
+		assert.Zero(t, status)
+	})
+}
@@ -3,13 +3,17 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/pkg/servertiming"
 	"github.com/vadimbarashkov/online-song-library/pkg/validate"
 )
 
@@ -20,27 +24,236 @@ type songDetailSchema struct {
 	Link        string `json:"link" validate:"required"`
 }
 
+// songDetailRules maps the JSON names of song detail fields to their validation rules
+// for the cases when the field is required or optional.
+var songDetailRules = map[string]struct {
+	structField string
+	required    string
+	optional    string
+}{
+	"releaseDate": {structField: "ReleaseDate", required: "required,releaseDate", optional: "omitempty,releaseDate"},
+	"text":        {structField: "Text", required: "required", optional: ""},
+	"link":        {structField: "Link", required: "required", optional: ""},
+}
+
+// DefaultMaxBodySize is the maximum size in bytes of a response body read from the external API
+// when it is not set with WithMaxBodySize.
+const DefaultMaxBodySize int64 = 1 << 20
+
+// Names of the query parameters carrying the song's group name and title when they are not set with WithQueryParams.
+const (
+	DefaultGroupParam = "group"
+	DefaultSongParam  = "song"
+)
+
+// ErrResponseBodyTooLarge is returned when the external API responds with a body exceeding the maximum size.
+var ErrResponseBodyTooLarge = errors.New("response body too large")
+
 // MusicInfoAPI is an API client used to fetch song information from an external music service.
 type MusicInfoAPI struct {
-	baseURL  string
-	client   *http.Client
-	validate *validator.Validate
+	baseURL           string
+	name              string
+	client            *http.Client
+	validate          *validator.Validate
+	requiredFields    []string
+	networkRetries    int
+	networkRetryDelay time.Duration
+	maxBodySize       int64
+	responseMapping   map[string]string
+	groupParam        string
+	songParam         string
+}
+
+// Option represents a functional option for configuring the MusicInfoAPI client.
+type Option func(*MusicInfoAPI)
+
+// WithRequiredFields sets the song detail fields that the external API must return.
+// Fields are identified by their JSON names (releaseDate, text, link), which can be checked with ValidateFields;
+// unknown names are ignored. By default all fields are required.
+func WithRequiredFields(fields ...string) Option {
+	return func(api *MusicInfoAPI) {
+		api.requiredFields = fields
+	}
+}
+
+// WithNetworkRetry makes requests that fail with a DNS resolution or temporary network error be retried
+// up to retries times, waiting delay before every retry. Other errors are never retried. By default requests are not retried.
+func WithNetworkRetry(retries int, delay time.Duration) Option {
+	return func(api *MusicInfoAPI) {
+		api.networkRetries = retries
+		api.networkRetryDelay = delay
+	}
+}
+
+// WithMaxBodySize sets the maximum size in bytes of a response body read from the external API.
+// Responses with larger bodies are rejected with ErrResponseBodyTooLarge. Non-positive values fall back to DefaultMaxBodySize.
+func WithMaxBodySize(size int64) Option {
+	return func(api *MusicInfoAPI) {
+		api.maxBodySize = size
+	}
+}
+
+// WithName sets the name identifying the provider behind the client, reported as the provider of fetched details.
+// By default the host of the base URL is used.
+func WithName(name string) Option {
+	return func(api *MusicInfoAPI) {
+		api.name = name
+	}
+}
+
+// WithResponseMapping sets the keys under which the external API returns song detail fields, for providers
+// not using the default names. The mapping goes from a field's JSON name (releaseDate, text, link) to the key
+// used by the provider. Fields missing from the mapping are read under their own names.
+func WithResponseMapping(mapping map[string]string) Option {
+	return func(api *MusicInfoAPI) {
+		api.responseMapping = mapping
+	}
+}
+
+// WithQueryParams sets the names of the query parameters carrying the song's group name and title,
+// for providers not using the default names. Empty names fall back to DefaultGroupParam and DefaultSongParam.
+func WithQueryParams(groupParam, songParam string) Option {
+	return func(api *MusicInfoAPI) {
+		api.groupParam = groupParam
+		api.songParam = songParam
+	}
 }
 
 // NewMusicInfoAPI creates a new instance of MusicInfoAPI with the provided base URL and HTTP client.
-// If no client is provided, the default HTTP client is used. It also registers custom validations.
-func NewMusicInfoAPI(baseURL string, client *http.Client) *MusicInfoAPI {
+// If no client is provided, the default HTTP client is used. It also registers custom validations
+// and applies the provided configuration options.
+func NewMusicInfoAPI(baseURL string, client *http.Client, opts ...Option) *MusicInfoAPI {
 	if client == nil {
 		client = http.DefaultClient
 	}
 
+	api := &MusicInfoAPI{
+		baseURL: baseURL,
+		client:  client,
+	}
+
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	if api.maxBodySize <= 0 {
+		api.maxBodySize = DefaultMaxBodySize
+	}
+
+	if api.groupParam == "" {
+		api.groupParam = DefaultGroupParam
+	}
+	if api.songParam == "" {
+		api.songParam = DefaultSongParam
+	}
+
+	if api.name == "" {
+		api.name = baseURL
+		if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+			api.name = u.Host
+		}
+	}
+
 	v := validator.New()
 	_ = v.RegisterValidation("releaseDate", validate.ReleaseDateValidation)
 
-	return &MusicInfoAPI{
-		baseURL:  baseURL,
-		client:   client,
-		validate: v,
+	if api.requiredFields != nil {
+		v.RegisterStructValidationMapRules(requiredFieldsRules(api.requiredFields), songDetailSchema{})
+	}
+
+	api.validate = v
+
+	return api
+}
+
+// ValidateFields returns an error if any of fields isn't the JSON name of a song detail field
+// (releaseDate, text, link), such as a misspelled required field or response mapping key.
+func ValidateFields(fields ...string) error {
+	for _, field := range fields {
+		if _, ok := songDetailRules[field]; !ok {
+			return fmt.Errorf("unknown song detail field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// requiredFieldsRules builds validation rules for songDetailSchema, marking only the given fields as required.
+func requiredFieldsRules(fields []string) map[string]string {
+	required := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		required[field] = true
+	}
+
+	rules := make(map[string]string, len(songDetailRules))
+	for field, rule := range songDetailRules {
+		if required[field] {
+			rules[rule.structField] = rule.required
+		} else {
+			rules[rule.structField] = rule.optional
+		}
+	}
+
+	return rules
+}
+
+// Name returns the name identifying the provider behind the client.
+func (api *MusicInfoAPI) Name() string {
+	return api.name
+}
+
+// remapResponse rewrites a response body using the provider's response mapping, so that song detail fields
+// are found under their own names. Bodies are returned unchanged when no mapping is set.
+func (api *MusicInfoAPI) remapResponse(body []byte) ([]byte, error) {
+	if len(api.responseMapping) == 0 {
+		return body, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	remapped := make(map[string]json.RawMessage, len(songDetailRules))
+	for field := range songDetailRules {
+		key, ok := api.responseMapping[field]
+		if !ok {
+			key = field
+		}
+		if value, ok := fields[key]; ok {
+			remapped[field] = value
+		}
+	}
+
+	return json.Marshal(remapped)
+}
+
+// isTemporaryNetworkError reports whether err is a DNS resolution or temporary network error,
+// which may not happen again when the request is retried. DNS errors are all treated as temporary,
+// since "no such host" is also reported while the cluster DNS is briefly unavailable.
+func isTemporaryNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Temporary()
+}
+
+// do sends the request, retrying it when it fails with a DNS resolution or temporary network error.
+func (api *MusicInfoAPI) do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := api.client.Do(req)
+		if err == nil || attempt >= api.networkRetries || !isTemporaryNetworkError(err) {
+			return resp, err
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, err
+		case <-time.After(api.networkRetryDelay):
+		}
 	}
 }
 
@@ -57,10 +270,12 @@ func (api *MusicInfoAPI) songDetailSchemaToEntity(songDetail songDetailSchema) *
 }
 
 // FetchSongInfo retrieves song details from the external API by performing an HTTP GET request.
-// The song's group name and title are passed as query parameters. It returns a SongDetail entity or an error.
+// The song's group name and title are passed as query parameters, named as set with WithQueryParams. It returns a SongDetail entity or an error.
 func (api *MusicInfoAPI) FetchSongInfo(ctx context.Context, song entity.Song) (*entity.SongDetail, error) {
 	const op = "adapter.api.MusicInfoAPI.FetchSongInfo"
 
+	defer servertiming.Start(ctx, servertiming.MetricMusicInfo)()
+
 	path, err := url.JoinPath(api.baseURL, "/info")
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to form path: %w", op, err)
@@ -72,8 +287,8 @@ func (api *MusicInfoAPI) FetchSongInfo(ctx context.Context, song entity.Song) (*
 	}
 
 	query := url.Query()
-	query.Set("group", song.GroupName)
-	query.Set("song", song.Name)
+	query.Set(api.groupParam, song.GroupName)
+	query.Set(api.songParam, song.Name)
 	url.RawQuery = query.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
@@ -81,19 +296,38 @@ func (api *MusicInfoAPI) FetchSongInfo(ctx context.Context, song entity.Song) (*
 		return nil, fmt.Errorf("%s: failed to create request: %w", op, err)
 	}
 
-	resp, err := api.client.Do(req)
+	resp, err := api.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to fetch song info: %w", op, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrSongInfoNotFound)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("%s: unexpected status code: %d", op, resp.StatusCode)
 	}
 
+	// One byte over the limit is read to tell a body of exactly the maximum size from a larger one.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, api.maxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read response body: %w", op, err)
+	}
+
+	if int64(len(body)) > api.maxBodySize {
+		return nil, fmt.Errorf("%s: %w: exceeds %d bytes", op, ErrResponseBodyTooLarge, api.maxBodySize)
+	}
+
+	body, err = api.remapResponse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to decode response body: %w", op, err)
+	}
+
 	var songDetail songDetailSchema
 
-	if err := json.NewDecoder(resp.Body).Decode(&songDetail); err != nil {
+	if err := json.Unmarshal(body, &songDetail); err != nil {
 		return nil, fmt.Errorf("%s: failed to decode response body: %w", op, err)
 	}
 
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,108 @@ import (
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
 )
 
+// roundTripFunc is an http.RoundTripper calling the function for every request.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// failingTransport returns a transport failing the first failures requests with err and
+// responding with valid song details afterwards. The number of requests is stored in calls.
+func failingTransport(failures int, err error, calls *int) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*calls++
+		if *calls <= failures {
+			return nil, err
+		}
+
+		body := `{"releaseDate":"16.07.2006","text":"Test Text","link":"https://example.com"}`
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestMusicInfoAPI_FetchSongInfo_NetworkRetry(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "music-info", IsNotFound: true}
+	song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+
+	t.Run("dns error without retry", func(t *testing.T) {
+		var calls int
+		client := &http.Client{Transport: failingTransport(1, dnsErr, &calls)}
+
+		api := NewMusicInfoAPI("http://music-info", client)
+
+		songDetail, err := api.FetchSongInfo(context.Background(), song)
+
+		assert.Error(t, err)
+		assert.ErrorAs(t, err, &dnsErr)
+		assert.Nil(t, songDetail)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("dns error retried", func(t *testing.T) {
+		var calls int
+		client := &http.Client{Transport: failingTransport(1, dnsErr, &calls)}
+
+		api := NewMusicInfoAPI("http://music-info", client, WithNetworkRetry(2, time.Millisecond))
+
+		songDetail, err := api.FetchSongInfo(context.Background(), song)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Test Text", songDetail.Text)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		var calls int
+		client := &http.Client{Transport: failingTransport(3, dnsErr, &calls)}
+
+		api := NewMusicInfoAPI("http://music-info", client, WithNetworkRetry(2, time.Millisecond))
+
+		songDetail, err := api.FetchSongInfo(context.Background(), song)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "no such host")
+		assert.Nil(t, songDetail)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("permanent error not retried", func(t *testing.T) {
+		var calls int
+		client := &http.Client{Transport: failingTransport(1, errors.New("connection refused"), &calls)}
+
+		api := NewMusicInfoAPI("http://music-info", client, WithNetworkRetry(2, time.Millisecond))
+
+		songDetail, err := api.FetchSongInfo(context.Background(), song)
+
+		assert.Error(t, err)
+		assert.Nil(t, songDetail)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		var calls int
+		client := &http.Client{Transport: failingTransport(1, dnsErr, &calls)}
+
+		api := NewMusicInfoAPI("http://music-info", client, WithNetworkRetry(2, time.Minute))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		songDetail, err := api.FetchSongInfo(ctx, song)
+
+		assert.Error(t, err)
+		assert.Nil(t, songDetail)
+		assert.Equal(t, 1, calls)
+	})
+}
+
 func TestMusicInfoAPI_FetchSongInfo(t *testing.T) {
 	t.Run("invalid base url", func(t *testing.T) {
 		api := NewMusicInfoAPI("https://[::1]:namedport", nil)
@@ -52,6 +156,24 @@ func TestMusicInfoAPI_FetchSongInfo(t *testing.T) {
 		assert.Nil(t, songDetail)
 	})
 
+	t.Run("song not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		api := NewMusicInfoAPI(server.URL, nil)
+
+		songDetail, err := api.FetchSongInfo(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrSongInfoNotFound)
+		assert.Nil(t, songDetail)
+	})
+
 	t.Run("non-200 status code", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -89,6 +211,26 @@ func TestMusicInfoAPI_FetchSongInfo(t *testing.T) {
 		assert.Nil(t, songDetail)
 	})
 
+	t.Run("response body too large", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"releaseDate":"16.07.2006","text":"` + strings.Repeat("a", 1024) + `","link":"https://example.com"}`))
+		}))
+		defer server.Close()
+
+		api := NewMusicInfoAPI(server.URL, nil, WithMaxBodySize(512))
+
+		songDetail, err := api.FetchSongInfo(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, ErrResponseBodyTooLarge)
+		assert.ErrorContains(t, err, "exceeds 512 bytes")
+		assert.Nil(t, songDetail)
+	})
+
 	t.Run("validaton error", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			assert.Equal(t, "/info", r.URL.Path)
@@ -158,4 +300,150 @@ func TestMusicInfoAPI_FetchSongInfo(t *testing.T) {
 		assert.Equal(t, "Test Text", songDetail.Text)
 		assert.Equal(t, "https://example.com", songDetail.Link)
 	})
+
+	t.Run("configured query params", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Test Group", r.URL.Query().Get("artist"))
+			assert.Equal(t, "Test Song", r.URL.Query().Get("title"))
+			assert.False(t, r.URL.Query().Has("group"))
+			assert.False(t, r.URL.Query().Has("song"))
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"releaseDate":"16.07.2006","text":"Test Text","link":"https://example.com"}`))
+		}))
+		defer server.Close()
+
+		api := NewMusicInfoAPI(server.URL, nil, WithQueryParams("artist", "title"))
+
+		songDetail, err := api.FetchSongInfo(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, songDetail)
+	})
+
+	t.Run("empty query params fall back to defaults", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Test Group", r.URL.Query().Get(DefaultGroupParam))
+			assert.Equal(t, "Test Song", r.URL.Query().Get(DefaultSongParam))
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"releaseDate":"16.07.2006","text":"Test Text","link":"https://example.com"}`))
+		}))
+		defer server.Close()
+
+		api := NewMusicInfoAPI(server.URL, nil, WithQueryParams("", ""))
+
+		songDetail, err := api.FetchSongInfo(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, songDetail)
+	})
+
+	t.Run("success with optional link", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resp := songDetailSchema{
+				ReleaseDate: "16.07.2006",
+				Text:        "Test Text",
+			}
+
+			respData, err := json.Marshal(resp)
+			if err != nil {
+				t.Fatalf("Failed to marshal response: %v", err)
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(respData)
+		}))
+		defer server.Close()
+
+		api := NewMusicInfoAPI(server.URL, nil, WithRequiredFields("releaseDate", "text"))
+
+		songDetail, err := api.FetchSongInfo(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, songDetail)
+		assert.True(t, time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC).Equal(songDetail.ReleaseDate))
+		assert.Equal(t, "Test Text", songDetail.Text)
+		assert.Empty(t, songDetail.Link)
+	})
+
+	t.Run("validation error with optional link", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resp := songDetailSchema{
+				ReleaseDate: "invalid release date",
+				Text:        "Test Text",
+			}
+
+			respData, err := json.Marshal(resp)
+			if err != nil {
+				t.Fatalf("Failed to marshal response: %v", err)
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(respData)
+		}))
+		defer server.Close()
+
+		api := NewMusicInfoAPI(server.URL, nil, WithRequiredFields("releaseDate", "text"))
+
+		songDetail, err := api.FetchSongInfo(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "validation error")
+		assert.Nil(t, songDetail)
+	})
+
+	t.Run("missing link with default required fields", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resp := songDetailSchema{
+				ReleaseDate: "16.07.2006",
+				Text:        "Test Text",
+			}
+
+			respData, err := json.Marshal(resp)
+			if err != nil {
+				t.Fatalf("Failed to marshal response: %v", err)
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(respData)
+		}))
+		defer server.Close()
+
+		api := NewMusicInfoAPI(server.URL, nil)
+
+		songDetail, err := api.FetchSongInfo(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "validation error")
+		assert.Nil(t, songDetail)
+	})
+}
+
+func TestValidateFields(t *testing.T) {
+	t.Run("unknown field", func(t *testing.T) {
+		err := ValidateFields("releaseDate", "lyrics")
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, `unknown song detail field "lyrics"`)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		assert.NoError(t, ValidateFields("releaseDate", "text", "link"))
+	})
 }
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+// MusicInfoPool fetches song information from an ordered list of music info providers,
+// failing over to the next provider when one fails.
+type MusicInfoPool struct {
+	providers []*MusicInfoAPI
+}
+
+// NewMusicInfoPool creates a new instance of MusicInfoPool trying the providers in the given order.
+func NewMusicInfoPool(providers ...*MusicInfoAPI) *MusicInfoPool {
+	return &MusicInfoPool{providers: providers}
+}
+
+// FetchSongInfo retrieves song details from the first provider that succeeds, after that provider's own network retries.
+// A provider answering that it doesn't know the song ends the lookup with entity.ErrSongInfoNotFound, since
+// it is an answer rather than a failure. The returned details name the provider that served them.
+// When every provider fails, the errors of all of them are returned.
+func (p *MusicInfoPool) FetchSongInfo(ctx context.Context, song entity.Song) (*entity.SongDetail, error) {
+	const op = "adapter.api.MusicInfoPool.FetchSongInfo"
+
+	if len(p.providers) == 0 {
+		return nil, fmt.Errorf("%s: no providers configured", op)
+	}
+
+	errs := make([]error, 0, len(p.providers))
+
+	for _, provider := range p.providers {
+		songDetail, err := provider.FetchSongInfo(ctx, song)
+		if err == nil {
+			songDetail.Provider = provider.Name()
+			return songDetail, nil
+		}
+
+		if errors.Is(err, entity.ErrSongInfoNotFound) {
+			return nil, fmt.Errorf("%s: provider %s: %w", op, provider.Name(), err)
+		}
+
+		errs = append(errs, fmt.Errorf("provider %s: %w", provider.Name(), err))
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("%s: no provider succeeded: %w", op, errors.Join(errs...))
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+// providerServer starts a music info provider responding to every request with the status and body.
+// The number of requests is stored in calls.
+func providerServer(t *testing.T, status int, body string, calls *int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestMusicInfoPool_FetchSongInfo(t *testing.T) {
+	const validBody = `{"releaseDate":"16.07.2006","text":"Test Text","link":"https://example.com"}`
+
+	song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+
+	t.Run("no providers", func(t *testing.T) {
+		pool := NewMusicInfoPool()
+
+		songDetail, err := pool.FetchSongInfo(context.Background(), song)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "no providers configured")
+		assert.Nil(t, songDetail)
+	})
+
+	t.Run("primary succeeds", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		primary := providerServer(t, http.StatusOK, validBody, &primaryCalls)
+		secondary := providerServer(t, http.StatusOK, validBody, &secondaryCalls)
+
+		pool := NewMusicInfoPool(
+			NewMusicInfoAPI(primary.URL, nil, WithName("primary")),
+			NewMusicInfoAPI(secondary.URL, nil, WithName("secondary")),
+		)
+
+		songDetail, err := pool.FetchSongInfo(context.Background(), song)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "primary", songDetail.Provider)
+		assert.Equal(t, 1, primaryCalls)
+		assert.Zero(t, secondaryCalls)
+	})
+
+	t.Run("failover to secondary", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		primary := providerServer(t, http.StatusBadGateway, "", &primaryCalls)
+		secondary := providerServer(t, http.StatusOK, `{"released":"16.07.2006","lyrics":"Test Text","url":"https://example.com"}`, &secondaryCalls)
+
+		pool := NewMusicInfoPool(
+			NewMusicInfoAPI(primary.URL, nil),
+			NewMusicInfoAPI(secondary.URL, nil, WithResponseMapping(map[string]string{
+				"releaseDate": "released",
+				"text":        "lyrics",
+				"link":        "url",
+			})),
+		)
+
+		songDetail, err := pool.FetchSongInfo(context.Background(), song)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Test Text", songDetail.Text)
+		assert.Equal(t, "https://example.com", songDetail.Link)
+		assert.Equal(t, secondary.Listener.Addr().String(), songDetail.Provider)
+		assert.Equal(t, 1, primaryCalls)
+		assert.Equal(t, 1, secondaryCalls)
+	})
+
+	t.Run("not found does not fail over", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		primary := providerServer(t, http.StatusNotFound, "", &primaryCalls)
+		secondary := providerServer(t, http.StatusOK, validBody, &secondaryCalls)
+
+		pool := NewMusicInfoPool(NewMusicInfoAPI(primary.URL, nil), NewMusicInfoAPI(secondary.URL, nil))
+
+		songDetail, err := pool.FetchSongInfo(context.Background(), song)
+
+		assert.ErrorIs(t, err, entity.ErrSongInfoNotFound)
+		assert.Nil(t, songDetail)
+		assert.Zero(t, secondaryCalls)
+	})
+
+	t.Run("all providers fail", func(t *testing.T) {
+		var primaryCalls, secondaryCalls int
+		primary := providerServer(t, http.StatusBadGateway, "", &primaryCalls)
+		secondary := providerServer(t, http.StatusOK, `{"releaseDate":"16.07.2006"}`, &secondaryCalls)
+
+		pool := NewMusicInfoPool(
+			NewMusicInfoAPI(primary.URL, nil, WithName("primary")),
+			NewMusicInfoAPI(secondary.URL, nil, WithName("secondary")),
+		)
+
+		songDetail, err := pool.FetchSongInfo(context.Background(), song)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "provider primary: adapter.api.MusicInfoAPI.FetchSongInfo: unexpected status code: 502")
+		assert.ErrorContains(t, err, "provider secondary: adapter.api.MusicInfoAPI.FetchSongInfo: validation error")
+		assert.Nil(t, songDetail)
+	})
+
+	t.Run("canceled context stops failover", func(t *testing.T) {
+		var secondaryCalls int
+		secondary := providerServer(t, http.StatusOK, validBody, &secondaryCalls)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		pool := NewMusicInfoPool(NewMusicInfoAPI("http://music-info", nil), NewMusicInfoAPI(secondary.URL, nil))
+
+		songDetail, err := pool.FetchSongInfo(ctx, song)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, songDetail)
+		assert.Zero(t, secondaryCalls)
+	})
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTLSClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	writeFile := func(t *testing.T, data []byte) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("Failed to write ca file: %v", err)
+		}
+		return path
+	}
+
+	t.Run("default client", func(t *testing.T) {
+		client, err := NewTLSClient("", false)
+
+		assert.NoError(t, err)
+		assert.Nil(t, client)
+	})
+
+	t.Run("custom ca", func(t *testing.T) {
+		caFile := writeFile(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+		client, err := NewTLSClient(caFile, false)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Failed to request server: %v", err)
+		}
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		_, err := http.DefaultClient.Get(server.URL)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		client, err := NewTLSClient("", true)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Failed to request server: %v", err)
+		}
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("missing ca file", func(t *testing.T) {
+		client, err := NewTLSClient(filepath.Join(t.TempDir(), "missing.pem"), false)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.Nil(t, client)
+	})
+
+	t.Run("ca file without certificates", func(t *testing.T) {
+		client, err := NewTLSClient(writeFile(t, []byte("not a certificate")), false)
+
+		assert.ErrorContains(t, err, "no certificates found in ca file")
+		assert.Nil(t, client)
+	})
+}
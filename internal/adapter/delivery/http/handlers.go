@@ -1,13 +1,24 @@
 package http
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -16,6 +27,8 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/pkg/postgres"
+	"golang.org/x/text/unicode/norm"
 )
 
 // handlePing handles the ping request.
@@ -36,19 +49,147 @@ func handlePing(logger *slog.Logger) http.HandlerFunc {
 	})
 }
 
+// handleConfig handles the request for the running configuration.
+//
+//	@Summary		Fetch configuration
+//	@Description	Responds with the running configuration keyed by environment variable, with secrets redacted.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	map[string]any	"Sanitized configuration"
+//	@Failure		401	{object}	errorResponse	"Missing or invalid admin token"
+//	@Router			/api/v1/admin/config [get]
+func handleConfig(logger *slog.Logger, settings any) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetReqID(r.Context())
+		logger.Debug("handling config request", slog.String("reqID", reqID))
+
+		// The settings are keyed by the names of their environment variables, which must not be recased.
+		keepJSONKeys(w)
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, settings)
+	})
+}
+
+// handleMigrations handles the request for the state of the database migrations.
+//
+//	@Summary		Fetch migrations
+//	@Description	Responds with the version of the last applied database migration, whether it failed midway, and the available migration files.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	migrationsResponse
+//	@Failure		401	{object}	errorResponse	"Missing or invalid admin token"
+//	@Failure		500	{object}	errorResponse
+//	@Router			/api/v1/admin/migrations [get]
+func handleMigrations(logger *slog.Logger, status func() (*postgres.MigrationStatus, error)) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetReqID(r.Context())
+		logger.Debug("handling migrations request", slog.String("reqID", reqID))
+
+		migrations, err := status()
+		if err != nil {
+			httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+			logger.Debug("failed to read migration status", slog.String("reqID", reqID), slog.Any("err", err))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, serverErrResp)
+			return
+		}
+
+		files := make([]string, 0, len(migrations.Files))
+		files = append(files, migrations.Files...)
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, migrationsResponse{
+			Version: migrations.Version,
+			Dirty:   migrations.Dirty,
+			Files:   files,
+		})
+	})
+}
+
+// handleReadOnly handles the request for the read-only mode of the API.
+//
+//	@Summary		Fetch read-only mode
+//	@Description	Responds with whether the API rejects requests modifying songs.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	readOnlyResponse
+//	@Failure		401	{object}	errorResponse	"Missing or invalid admin token"
+//	@Router			/api/v1/admin/read-only [get]
+func handleReadOnly(logger *slog.Logger, mode *readOnlyMode) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetReqID(r.Context())
+		logger.Debug("handling read-only mode request", slog.String("reqID", reqID))
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, readOnlyResponse{ReadOnly: mode.enabled.Load()})
+	})
+}
+
+// handleSetReadOnly handles switching the read-only mode of the API on with PUT or off with DELETE.
+//
+//	@Summary		Switch read-only mode
+//	@Description	Switches the read-only mode of the API on with PUT or off with DELETE. While it is on, requests modifying songs respond with 503 and a Retry-After header, and reads are served normally.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	readOnlyResponse
+//	@Failure		401	{object}	errorResponse	"Missing or invalid admin token"
+//	@Router			/api/v1/admin/read-only [put]
+//	@Router			/api/v1/admin/read-only [delete]
+func handleSetReadOnly(logger *slog.Logger, mode *readOnlyMode, enabled bool) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetReqID(r.Context())
+		logger.Info("switching read-only mode", slog.String("reqID", reqID), slog.Bool("enabled", enabled))
+
+		mode.enabled.Store(enabled)
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, readOnlyResponse{ReadOnly: enabled})
+	})
+}
+
+// handleNotFound responds to requests for unknown routes with a JSON error.
+func handleNotFound(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusNotFound)
+	render.JSON(w, r, routeNotFoundResp)
+}
+
+// handleMethodNotAllowed responds to requests using a method unsupported by the matched route with a JSON error.
+func handleMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusMethodNotAllowed)
+	render.JSON(w, r, methodNotAllowedResp)
+}
+
 // songHandler struct handles HTTP requests related to songs.
 type songHandler struct {
 	logger      *slog.Logger
 	songUseCase songUseCase
 	validate    *validator.Validate
+	opts        RouterOptions
 }
 
 // newSongHandler initializes a new songHandler instance.
-func newSongHandler(logger *slog.Logger, songUseCase songUseCase, validate *validator.Validate) *songHandler {
+func newSongHandler(
+	logger *slog.Logger,
+	songUseCase songUseCase,
+	validate *validator.Validate,
+	opts RouterOptions,
+) *songHandler {
 	return &songHandler{
 		logger:      logger,
 		songUseCase: songUseCase,
 		validate:    validate,
+		opts:        opts,
 	}
 }
 
@@ -58,6 +199,41 @@ func (h *songHandler) prepareLogger(ctx context.Context) *slog.Logger {
 	return h.logger.With(slog.String("reqID", reqID))
 }
 
+// unknownFieldError is returned by decodeJSON when strict decoding meets a field
+// that is not part of the request schema.
+type unknownFieldError struct {
+	field string
+}
+
+func (e *unknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %s", e.field)
+}
+
+// decodeJSON decodes the request body into v. When strict JSON decoding is enabled,
+// unknown fields are rejected with an unknownFieldError.
+func (h *songHandler) decodeJSON(r io.Reader, v any) error {
+	if !h.opts.StrictJSON {
+		return render.DecodeJSON(r, v)
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, r)
+	}()
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return &unknownFieldError{field: strings.Trim(field, `"`)}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 // addSongRequestToEntity converts an addSongRequest to an entity.Song.
 func (h *songHandler) addSongRequestToEntity(req addSongRequest) entity.Song {
 	return entity.Song{
@@ -81,41 +257,186 @@ func (h *songHandler) updateSongRequestToEntity(req updateSongRequest) entity.So
 	}
 }
 
-// entityToSongSchema converts an entity.Song to songSchema for response.
-func (h *songHandler) entityToSongSchema(song *entity.Song) songSchema {
+// entityToSongSchema converts an entity.Song to songSchema for response, formatting the release date with layout.
+func (h *songHandler) entityToSongSchema(song *entity.Song, layout string) songSchema {
 	return songSchema{
+		ID:           song.ID,
+		GroupName:    song.GroupName,
+		Name:         song.Name,
+		SongDetail:   h.entityToSongDetailSchema(song.SongDetail, layout),
+		OriginalLink: song.OriginalLink,
+		DetailSource: string(song.DetailSource),
+		DetailStatus: string(song.DetailStatus),
+		CreatedAt:    song.CreatedAt,
+		UpdatedAt:    song.UpdatedAt,
+	}
+}
+
+// renderServerError responds to a failed use case call. When the song storage can't be reached it responds
+// with 503 Service Unavailable, so load balancers can route away, and with 500 Internal Server Error otherwise.
+func (h *songHandler) renderServerError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, entity.ErrStorageUnavailable) {
+		render.Status(r, http.StatusServiceUnavailable)
+		render.JSON(w, r, serviceUnavailableResp)
+		return
+	}
+
+	render.Status(r, http.StatusInternalServerError)
+	render.JSON(w, r, serverErrResp)
+}
+
+// renderSong writes a created or modified song, honoring the Prefer return preference of the request.
+// With return=minimal the response is 204 No Content carrying only the Location and ETag of the song.
+func (h *songHandler) renderSong(w http.ResponseWriter, r *http.Request, status int, song *entity.Song) {
+	schema := h.entityToSongSchema(song, dateLayout(r))
+
+	if !prefersMinimalReturn(r) {
+		addVary(w.Header(), "Accept")
+
+		if acceptsHAL(r) {
+			renderHAL(w, status, halSongSchema{songSchema: schema, Links: halSongLinksFor(song.ID)})
+			return
+		}
+
+		render.Status(r, status)
+		render.JSON(w, r, schema)
+		return
+	}
+
+	body, _ := json.Marshal(schema)
+
+	w.Header().Set("Location", songPath(song.ID))
+	w.Header().Set("ETag", entityTag(body))
+	w.Header().Set("Preference-Applied", "return=minimal")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// renderHAL writes v as a HAL+JSON response with the given status.
+func renderHAL(w http.ResponseWriter, status int, v any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", halMediaType)
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// entityToSongDetailSchema converts an entity.SongDetail to songDetailSchema for response.
+// It returns nil when the song has no details, and leaves out a zero release date.
+func (h *songHandler) entityToSongDetailSchema(detail entity.SongDetail, layout string) *songDetailSchema {
+	if detail == (entity.SongDetail{}) {
+		return nil
+	}
+
+	schema := &songDetailSchema{
+		Text:     detail.Text,
+		Link:     detail.Link,
+		Provider: detail.Provider,
+	}
+	if !detail.ReleaseDate.IsZero() {
+		schema.ReleaseDate = detail.ReleaseDate.Format(layout)
+	}
+
+	return schema
+}
+
+// entityToSongWithVersesSchema converts an entity.SongWithVerses to songWithVersesSchema for response.
+func (h *songHandler) entityToSongWithVersesSchema(song *entity.SongWithVerses) songWithVersesSchema {
+	verses := song.Verses
+	if verses == nil {
+		verses = make([]string, 0)
+	}
+
+	return songWithVersesSchema{
 		ID:        song.ID,
 		GroupName: song.GroupName,
 		Name:      song.Name,
-		SongDetail: songDetailSchema{
-			ReleaseDate: song.SongDetail.ReleaseDate.Format("02.01.2006"),
-			Text:        song.SongDetail.Text,
-			Link:        song.SongDetail.Link,
-		},
+		Verses:    verses,
+		Repeats:   song.Repeats,
 		CreatedAt: song.CreatedAt,
 		UpdatedAt: song.UpdatedAt,
 	}
 }
 
-// entityToSongWithVersesSchema converts an entity.SongWithVerses to songWithVersesSchema for response.
-func (h *songHandler) entityToSongWithVersesSchema(song *entity.SongWithVerses) songWithVersesSchema {
-	return songWithVersesSchema{
+// indexSongWithVerses converts songWithVersesSchema to songWithIndexedVersesSchema, numbering the verses
+// from offset, the position of the first verse of the page in the whole text.
+func (h *songHandler) indexSongWithVerses(song songWithVersesSchema, offset uint64) songWithIndexedVersesSchema {
+	verses := make([]indexedVerseSchema, 0, len(song.Verses))
+	for i, verse := range song.Verses {
+		verses = append(verses, indexedVerseSchema{
+			Index: int(offset) + i,
+			Text:  verse,
+		})
+	}
+
+	return songWithIndexedVersesSchema{
 		ID:        song.ID,
 		GroupName: song.GroupName,
 		Name:      song.Name,
-		Verses:    song.Verses,
+		Verses:    verses,
+		Repeats:   song.Repeats,
 		CreatedAt: song.CreatedAt,
 		UpdatedAt: song.UpdatedAt,
 	}
 }
 
+// entityToSongEventSchema converts an entity.SongEvent to songEventSchema for response.
+func (h *songHandler) entityToSongEventSchema(event entity.SongEvent) songEventSchema {
+	return songEventSchema{
+		Type:       string(event.Type),
+		SongID:     event.SongID,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// entityToLineDiffSchemas converts a slice of entity.LineDiff to lineDiffSchema for response.
+func (h *songHandler) entityToLineDiffSchemas(diff []entity.LineDiff) []lineDiffSchema {
+	operations := map[entity.DiffOperation]string{
+		entity.DiffUnchanged: "unchanged",
+		entity.DiffAdded:     "added",
+		entity.DiffRemoved:   "removed",
+	}
+
+	lines := make([]lineDiffSchema, 0, len(diff))
+	for _, line := range diff {
+		lines = append(lines, lineDiffSchema{
+			Operation: operations[line.Operation],
+			Line:      line.Line,
+		})
+	}
+
+	return lines
+}
+
+// entityToFacetsSchema converts song counts by facet field to facetCountSchema lists keyed by the facet names for response.
+func (h *songHandler) entityToFacetsSchema(facets map[entity.FacetField][]entity.FacetCount) map[string][]facetCountSchema {
+	schemas := make(map[string][]facetCountSchema, len(facets))
+
+	for field, counts := range facets {
+		items := make([]facetCountSchema, 0, len(counts))
+		for _, count := range counts {
+			items = append(items, facetCountSchema{Value: count.Value, Count: count.Count})
+		}
+
+		schemas[facetFieldName(field)] = items
+	}
+
+	return schemas
+}
+
 // entityToPaginationSchema converts entity.Pagination to paginationSchema for response.
+// The numbers are serialized as strings when RouterOptions.PaginationAsStrings is set.
 func (h *songHandler) entityToPaginationSchema(pagination *entity.Pagination) paginationSchema {
 	return paginationSchema{
-		Offset: pagination.Offset,
-		Limit:  pagination.Limit,
-		Items:  pagination.Items,
-		Total:  pagination.Total,
+		Offset:    pagination.Offset,
+		Limit:     pagination.Limit,
+		Items:     pagination.Items,
+		Total:     pagination.Total,
+		asStrings: h.opts.PaginationAsStrings,
+		omitTotal: pagination.SkipTotal,
 	}
 }
 
@@ -126,10 +447,20 @@ func (h *songHandler) entityToPaginationSchema(pagination *entity.Pagination) pa
 //	@Tags			songs
 //	@Accept			json
 //	@Produce		json
-//	@Param			song	body		addSongRequest	true	"Add Song"
-//	@Success		201		{object}	songSchema
-//	@Failure		400		{object}	errorResponse
-//	@Failure		500		{object}	errorResponse
+//	@Produce		application/hal+json
+//	@Param			song		body		addSongRequest	true	"Add Song"
+//	@Param			Prefer		header		string			false	"Return preference"				Enums(return=representation, return=minimal)
+//	@Param			dateFormat	query		string			false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		201			{object}	songSchema
+//	@Success		204			"Song added, returned with return=minimal"
+//	@Header			204			{string}	Location	"URL of the added song"
+//	@Header			204			{string}	ETag		"Hash of the song representation"
+//	@Failure		400			{object}	errorResponse
+//	@Failure		409			{object}	errorResponse
+//	@Failure		415			{object}	errorResponse
+//	@Failure		422			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
 //	@Router			/api/v1/songs [post]
 func (h *songHandler) addSong(w http.ResponseWriter, r *http.Request) {
 	logger := h.prepareLogger(r.Context())
@@ -137,7 +468,7 @@ func (h *songHandler) addSong(w http.ResponseWriter, r *http.Request) {
 
 	var req addSongRequest
 
-	if err := render.DecodeJSON(r.Body, &req); err != nil {
+	if err := h.decodeJSON(r.Body, &req); err != nil {
 		if errors.Is(err, io.EOF) {
 			logger.Debug("empty request body", slog.Any("err", err))
 
@@ -146,6 +477,15 @@ func (h *songHandler) addSong(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
 		logger.Debug("invalid request body", slog.Any("err", err))
 
 		render.Status(r, http.StatusBadRequest)
@@ -167,98 +507,3471 @@ func (h *songHandler) addSong(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
 
+		if errors.Is(err, entity.ErrGroupSongLimitExceeded) {
+			logger.Debug("group song limit exceeded", slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, groupSongLimitExceededResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrTooManyVerses) {
+			logger.Debug("too many verses", slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, tooManyVersesResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrLinkAlreadyExists) {
+			logger.Debug("link already exists", slog.Any("err", err))
+
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, linkAlreadyExistsResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrSongAlreadyExists) {
+			logger.Debug("song already exists", slog.Any("err", err))
+
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, songAlreadyExistsResp)
+			return
+		}
+
 		logger.Debug("failed to add song", slog.Any("err", err))
 
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, serverErrResp)
+		h.renderServerError(w, r, err)
 		return
 	}
 
 	logger.Debug("song added successfully", slog.Any("songID", song.ID))
 
-	render.Status(r, http.StatusCreated)
-	render.JSON(w, r, h.entityToSongSchema(song))
+	h.renderSong(w, r, http.StatusCreated, song)
 }
 
-// fetchSongs handles fetching multiple songs with optional filters and pagination.
+// importSongs handles importing songs from an uploaded file.
 //
-//	@Summary		Fetch multiple songs
-//	@Description	Retrieves a list of songs from the library
+//	@Summary		Import songs
+//	@Description	Imports songs from a multipart CSV file with "group" and "song" columns. Invalid rows are reported and skipped. The imported songs are tagged with the returned import ID, which lists or rolls back the import.
 //	@Tags			songs
-//	@Accept			json
+//	@Accept			multipart/form-data
 //	@Produce		json
-//	@Param			limit				query		int		false	"Limit the number of items"
-//	@Param			offset				query		int		false	"Offset for pagination"
-//	@Param			groupName			query		string	false	"Filter by group name"
-//	@Param			name				query		string	false	"Filter by song name"
-//	@Param			releaseYear			query		string	false	"Filter by release year"
-//	@Param			releaseDate			query		string	false	"Filter by exact release date (dd.MM.yyyy)"
-//	@Param			releaseDateAfter	query		string	false	"Filter songs released after the specified date (dd.MM.yyyy)"
-//	@Param			releaseDateBefore	query		string	false	"Filter songs released before the specified date (dd.MM.yyyy)"
-//	@Param			text				query		string	false	"Filter by song text"
-//	@Success		200					{object}	songsResponse
-//	@Failure		500					{object}	errorResponse
-//	@Router			/api/v1/songs [get]
-func (h *songHandler) fetchSongs(w http.ResponseWriter, r *http.Request) {
+//	@Param			file		formData	file	true	"CSV file"
+//	@Param			format		query		string	false	"Import file format"	Enums(csv)
+//	@Param			fetchInfo	query		bool	false	"Fetch song details from the music info API (best-effort, default true)"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		201			{object}	importSongsResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		413			{object}	errorResponse
+//	@Failure		422			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/import [post]
+func (h *songHandler) importSongs(w http.ResponseWriter, r *http.Request) {
 	logger := h.prepareLogger(r.Context())
-	logger.Debug("handling fetch songs request")
+	logger.Debug("handling import songs request")
+
+	query := r.URL.Query()
+
+	if format := query.Get("format"); format != "" && format != "csv" {
+		logger.Debug("unsupported import format", slog.String("format", format))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, unsupportedImportFormatResp)
+		return
+	}
+
+	fetchInfo := true
+	if param := query.Get("fetchInfo"); param != "" {
+		if value, err := strconv.ParseBool(param); err == nil {
+			fetchInfo = value
+		}
+	}
+
+	maxFileSize := h.opts.ImportMaxFileSize
+	if maxFileSize <= 0 {
+		maxFileSize = defaultImportMaxFileSize
+	}
+
+	maxRows := h.opts.ImportMaxRows
+	if maxRows <= 0 {
+		maxRows = defaultImportMaxRows
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxFileSize)
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			logger.Debug("import file too large", slog.Any("err", err))
+
+			render.Status(r, http.StatusRequestEntityTooLarge)
+			render.JSON(w, r, importFileTooLargeResp)
+			return
+		}
+
+		logger.Debug("invalid import file", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidImportFileResp)
+		return
+	}
+	defer file.Close()
+
+	rows, rowErrs, err := parseSongsCSV(file, maxRows)
+	if err != nil {
+		if errors.Is(err, errTooManyRows) {
+			logger.Debug("too many rows in import file", slog.Int("maxRows", maxRows))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, tooManyImportRowsResp)
+			return
+		}
+
+		logger.Debug("invalid import file", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidImportFileResp)
+		return
+	}
+
+	songs := make([]entity.Song, 0, len(rows))
+
+	for _, row := range rows {
+		if err := h.validate.Struct(row.req); err != nil {
+			rowErrs = append(rowErrs, importRowErrorSchema{
+				Row:     row.line,
+				Details: getValidationErrorDetails(err),
+			})
+			continue
+		}
+
+		songs = append(songs, h.addSongRequestToEntity(row.req))
+	}
+
+	sort.Slice(rowErrs, func(i, j int) bool {
+		return rowErrs[i].Row < rowErrs[j].Row
+	})
+
+	if len(songs) == 0 {
+		logger.Debug("no valid rows to import", slog.Int("rejected", len(rowErrs)))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, noValidImportRowsError(rowErrs))
+		return
+	}
+
+	logger.Debug(
+		"importing songs",
+		slog.Int("songs", len(songs)),
+		slog.Int("rejected", len(rowErrs)),
+		slog.Bool("fetchInfo", fetchInfo),
+	)
+
+	imported, err := h.songUseCase.ImportSongs(r.Context(), songs, fetchInfo)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrGroupSongLimitExceeded) {
+			logger.Debug("group song limit exceeded", slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, groupSongLimitExceededResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrTooManyVerses) {
+			logger.Debug("too many verses", slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, tooManyVersesResp)
+			return
+		}
+
+		logger.Debug("failed to import songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("songs imported successfully", slog.Int("imported", len(imported)))
+
+	layout := dateLayout(r)
+
+	resp := importSongsResponse{
+		Songs:  make([]songSchema, 0, len(imported)),
+		Errors: make([]importRowErrorSchema, 0, len(rowErrs)),
+	}
+	if len(imported) > 0 {
+		resp.ImportID = imported[0].ImportID
+	}
+	for _, song := range imported {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+	resp.Errors = append(resp.Errors, rowErrs...)
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+// fetchImportSongs handles fetching the songs added by a batch import with pagination.
+//
+//	@Summary		Fetch imported songs
+//	@Description	Retrieves the songs added by a batch import that still exist, like fetching songs filtered by import.
+//	@Tags			imports
+//	@Produce		json
+//	@Param			importId	path		string	true	"Import ID"
+//	@Param			limit		query		int		false	"Limit the number of items"
+//	@Param			offset		query		int		false	"Offset for pagination"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songsResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse	"No songs of the import exist"
+//	@Failure		422			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/imports/{importId}/songs [get]
+func (h *songHandler) fetchImportSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch import songs request")
+
+	importIDParam := chi.URLParam(r, "importId")
+
+	importID, err := parseIDParam(importIDParam)
+	if err != nil {
+		logger.Debug("invalid import ID", slog.String("importId", importIDParam), slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidImportIDParamResp)
+		return
+	}
+
+	pagination := parsePagination(r)
+
+	logger.Debug("fetching import songs", slog.Any("importId", importID), slog.Any("pagination", pagination))
+
+	songs, pgn, err := h.songUseCase.FetchSongs(r.Context(), pagination, entity.SongFilter{
+		Field: entity.SongImportFilterField,
+		Value: importID,
+	})
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrOffsetOutOfRange) {
+			logger.Debug("offset out of range", slog.Any("pagination", pagination), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, offsetOutOfRangeResp)
+			return
+		}
+
+		logger.Debug("failed to fetch import songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	if pgn.Total == 0 {
+		logger.Debug("no import songs", slog.Any("importId", importID))
+
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, importNotFoundResp)
+		return
+	}
+
+	logger.Debug("import songs fetched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// rollbackImport handles deleting every song added by a batch import.
+//
+//	@Summary		Roll back an import
+//	@Description	Deletes every song added by a batch import, including songs modified since the import.
+//	@Tags			imports
+//	@Produce		json
+//	@Param			importId	path		string	true	"Import ID"
+//	@Success		200			{object}	importRolledBackResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse	"No songs of the import exist"
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/imports/{importId} [delete]
+func (h *songHandler) rollbackImport(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling rollback import request")
+
+	importIDParam := chi.URLParam(r, "importId")
+
+	importID, err := parseIDParam(importIDParam)
+	if err != nil {
+		logger.Debug("invalid import ID", slog.String("importId", importIDParam), slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidImportIDParamResp)
+		return
+	}
+
+	logger.Debug("rolling back import", slog.Any("importId", importID))
+
+	deleted, err := h.songUseCase.RollbackImport(r.Context(), importID)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrImportNotFound) {
+			logger.Debug("import not found", slog.Any("importId", importID), slog.Any("err", err))
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, importNotFoundResp)
+			return
+		}
+
+		logger.Debug("failed to roll back import", slog.Any("importId", importID), slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("import rolled back successfully", slog.Any("importId", importID), slog.Int64("deleted", deleted))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, importRolledBackResponse{
+		ImportID: importID,
+		Deleted:  deleted,
+	})
+}
+
+// fetchImportTemplate handles fetching a template of the file accepted by the import endpoint.
+//
+//	@Summary		Fetch import template
+//	@Description	Returns a CSV file with the header row expected by the import endpoint and an example row.
+//	@Tags			songs
+//	@Produce		text/csv
+//	@Param			format	query		string				false	"Import file format"	Enums(csv)
+//	@Success		200		{file}		file				"CSV template"
+//	@Header			200		{string}	Content-Disposition	"attachment; filename=songs-template.csv"
+//	@Failure		400		{object}	errorResponse
+//	@Router			/api/v1/songs/import/template [get]
+func (h *songHandler) fetchImportTemplate(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch import template request")
+
+	if format := r.URL.Query().Get("format"); format != "" && format != "csv" {
+		logger.Debug("unsupported import format", slog.String("format", format))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, unsupportedImportFormatResp)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "songs-template.csv",
+	}))
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeSongsCSVTemplate(w); err != nil {
+		logger.Debug("failed to write import template", slog.Any("err", err))
+	}
+}
+
+// fetchSongs handles fetching multiple songs with optional filters and pagination.
+//
+//	@Summary		Fetch multiple songs
+//	@Description	Retrieves a list of songs from the library. Requested facets are counted over all songs matching the filters. With Accept: application/hal+json the songs are embedded with links to themselves and their verses, and the response links to the next and previous pages.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Produce		application/hal+json
+//	@Param			limit				query		int		false	"Limit the number of items"
+//	@Param			offset				query		int		false	"Offset for pagination"
+//	@Param			groupName			query		string	false	"Filter by group name (ignored when empty)"
+//	@Param			name				query		string	false	"Filter by song name (ignored when empty)"
+//	@Param			releaseYear			query		[]int	false	"Filter by release years, repeat to match any of several"	collectionFormat(multi)
+//	@Param			releaseDate			query		string	false	"Filter by exact release date (dd.MM.yyyy)"
+//	@Param			releaseDateAfter	query		string	false	"Filter songs released after the specified date (dd.MM.yyyy)"
+//	@Param			releaseDateBefore	query		string	false	"Filter songs released before the specified date (dd.MM.yyyy)"
+//	@Param			text				query		string	false	"Filter by song text (ignored when empty)"
+//	@Param			minTextLen			query		int		false	"Filter songs whose text has at least the specified number of characters"
+//	@Param			maxTextLen			query		int		false	"Filter songs whose text has at most the specified number of characters"
+//	@Param			detailStatus		query		string	false	"Filter by the outcome of the music info lookup"	Enums(found, not_found, failed)
+//	@Param			hasReleaseDate		query		bool	false	"Filter songs with (true) or without (false) a release date"
+//	@Param			facets				query		string	false	"Comma-separated facets to count matching songs by (releaseYear, group)"
+//	@Param			countOnly			query		bool	false	"Only count matching songs, responding with an empty songs array and the total"
+//	@Param			withTotal			query		bool	false	"Count the songs matching the filters across all pages, leaving the total out when false (default true unless the server is configured otherwise)"
+//	@Param			dateFormat			query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200					{object}	songsResponse
+//	@Failure		400					{object}	errorResponse
+//	@Failure		422					{object}	errorResponse
+//	@Failure		500					{object}	errorResponse
+//	@Failure		503					{object}	errorResponse
+//	@Router			/api/v1/songs [get]
+func (h *songHandler) fetchSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch songs request")
+
+	if params := disallowedSongFilterParams(r, h.opts.AllowedFilters); len(params) > 0 {
+		logger.Debug("disallowed filters", slog.Any("params", params))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, disallowedFiltersError(params))
+		return
+	}
+
+	if h.opts.StrictFilters {
+		if params := emptySongFilterParams(r); len(params) > 0 {
+			logger.Debug("empty filter values", slog.Any("params", params))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyFilterValuesError(params))
+			return
+		}
+	}
+
+	facetFields, ok := parseFacetFields(r)
+	if !ok {
+		logger.Debug("invalid facet field", slog.String("facets", r.URL.Query().Get("facets")))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidFacetFieldResp)
+		return
+	}
+
+	countOnly, _ := strconv.ParseBool(r.URL.Query().Get("countOnly"))
+	pagination := parsePagination(r)
+	pagination.SkipTotal = !countOnly && !parseWithTotal(r, !h.opts.TotalOnRequest)
+	filters := parseSongFilters(r, h.opts.DuplicateFilters)
+
+	logger.Debug(
+		"fetching songs",
+		slog.Any("pagination", pagination),
+		slog.Any("filters", filters),
+		slog.Any("facets", facetFields),
+		slog.Bool("countOnly", countOnly),
+	)
+
+	fetchSongs := h.songUseCase.FetchSongs
+	if countOnly {
+		fetchSongs = h.countSongsPage
+	}
+
+	songs, pgn, err := fetchSongs(r.Context(), pagination, filters...)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrOffsetOutOfRange) {
+			logger.Debug("offset out of range", slog.Any("pagination", pagination), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, offsetOutOfRangeResp)
+			return
+		}
+
+		logger.Debug("failed to fetch songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("songs fetched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	if len(facetFields) > 0 {
+		facets, err := h.songUseCase.FetchSongFacets(r.Context(), facetFields, filters...)
+		if err != nil {
+			httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+			logger.Debug("failed to fetch song facets", slog.Any("err", err))
+
+			h.renderServerError(w, r, err)
+			return
+		}
+
+		resp.Facets = h.entityToFacetsSchema(facets)
+	}
+
+	addVary(w.Header(), "Accept")
+
+	if acceptsHAL(r) {
+		renderHAL(w, http.StatusOK, h.songsResponseToHAL(r, resp, pgn))
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// songsResponseToHAL converts a page of songs to its HAL+JSON representation, embedding the songs
+// with links to themselves and their verses, and linking to the pages around it.
+func (h *songHandler) songsResponseToHAL(
+	r *http.Request,
+	resp songsResponse,
+	pgn *entity.Pagination,
+) halSongsResponse {
+	embedded := make([]halSongSchema, 0, len(resp.Songs))
+	for _, song := range resp.Songs {
+		embedded = append(embedded, halSongSchema{songSchema: song, Links: halSongLinksFor(song.ID)})
+	}
+
+	return halSongsResponse{
+		Links:      halPageLinks(r, pgn),
+		Embedded:   halSongsEmbedded{Songs: embedded},
+		Pagination: resp.Pagination,
+		Facets:     resp.Facets,
+	}
+}
+
+// countSongsPage counts the songs matching the filters without fetching them, returning an empty page
+// that reports the total. It serves song listings requested with countOnly.
+func (h *songHandler) countSongsPage(
+	ctx context.Context,
+	pagination entity.Pagination,
+	filters ...entity.SongFilter,
+) ([]*entity.Song, *entity.Pagination, error) {
+	total, err := h.songUseCase.CountSongs(ctx, filters...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if pagination.IsEmpty() {
+		pagination.SetDefault()
+	}
+
+	pagination.Items = 0
+	pagination.Total = total
+
+	return nil, &pagination, nil
+}
+
+// fetchIncompleteSongs handles fetching songs that lack some of their details.
+//
+//	@Summary		Fetch incomplete songs
+//	@Description	Retrieves songs missing release date, text, or link, oldest first. Flags narrow the result to songs missing all of the selected details.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit				query		int		false	"Limit the number of items"
+//	@Param			offset				query		int		false	"Offset for pagination"
+//	@Param			missingReleaseDate	query		bool	false	"Only songs without release date"
+//	@Param			missingText			query		bool	false	"Only songs without text"
+//	@Param			missingLink			query		bool	false	"Only songs without link"
+//	@Param			dateFormat			query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200					{object}	songsResponse
+//	@Failure		400					{object}	errorResponse
+//	@Failure		422					{object}	errorResponse
+//	@Failure		500					{object}	errorResponse
+//	@Failure		503					{object}	errorResponse
+//	@Router			/api/v1/songs/incomplete [get]
+func (h *songHandler) fetchIncompleteSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch incomplete songs request")
+
+	pagination := parsePagination(r)
+	filters := parseMissingDetailFilters(r)
+
+	logger.Debug(
+		"fetching incomplete songs",
+		slog.Any("pagination", pagination),
+		slog.Any("filters", filters),
+	)
+
+	songs, pgn, err := h.songUseCase.FetchIncompleteSongs(r.Context(), pagination, filters...)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrOffsetOutOfRange) {
+			logger.Debug("offset out of range", slog.Any("pagination", pagination), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, offsetOutOfRangeResp)
+			return
+		}
+
+		logger.Debug("failed to fetch incomplete songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("incomplete songs fetched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchSongsWithBrokenLinks handles fetching songs whose link is malformed or failed its last check.
+//
+//	@Summary		Fetch songs with broken links
+//	@Description	Retrieves songs whose link is set but is not a well-formed http or https URL, or whose last stored link check got no response or an error status, oldest first. Links are not requested here; they are checked by the admin link check job.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit		query		int		false	"Limit the number of items"
+//	@Param			offset		query		int		false	"Offset for pagination"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songsResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		422			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/broken-links [get]
+func (h *songHandler) fetchSongsWithBrokenLinks(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch songs with broken links request")
+
+	pagination := parsePagination(r)
+
+	logger.Debug("fetching songs with broken links", slog.Any("pagination", pagination))
+
+	songs, pgn, err := h.songUseCase.FetchSongsWithBrokenLinks(r.Context(), pagination)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrOffsetOutOfRange) {
+			logger.Debug("offset out of range", slog.Any("pagination", pagination), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, offsetOutOfRangeResp)
+			return
+		}
+
+		logger.Debug("failed to fetch songs with broken links", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("songs with broken links fetched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchRecentSongs handles fetching the most recently added songs.
+//
+//	@Summary		Fetch recent songs
+//	@Description	Retrieves the most recently added songs, newest first. The limit is capped at 50.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit		query		int		false	"Number of songs to return (default 10, max 50)"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	recentSongsResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/recent [get]
+func (h *songHandler) fetchRecentSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch recent songs request")
+
+	limit := parseRecentLimit(r)
+
+	logger.Debug("fetching recent songs", slog.Uint64("limit", limit))
+
+	songs, err := h.songUseCase.FetchRecentSongs(r.Context(), limit)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to fetch recent songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("recent songs fetched successfully", slog.Int("items", len(songs)))
+
+	layout := dateLayout(r)
+
+	resp := recentSongsResponse{
+		Songs: make([]songSchema, 0, len(songs)),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchRecentlyUpdatedSongs handles fetching songs ordered by their last update.
+//
+//	@Summary		Fetch recently updated songs
+//	@Description	Retrieves songs, most recently updated first, with the same filters as the song list. Never edited songs can be left out. Combined with detailStatus and hasReleaseDate=false, editedOnly lists the manually edited songs still lacking details from the music info API.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			groupName			query		string	false	"Filter by group name"
+//	@Param			name				query		string	false	"Filter by song name"
+//	@Param			releaseYear			query		[]int	false	"Filter by release years, repeat to match any of several"	collectionFormat(multi)
+//	@Param			releaseDate			query		string	false	"Filter by exact release date (dd.MM.yyyy)"
+//	@Param			releaseDateAfter	query		string	false	"Filter songs released after the specified date (dd.MM.yyyy)"
+//	@Param			releaseDateBefore	query		string	false	"Filter songs released before the specified date (dd.MM.yyyy)"
+//	@Param			text				query		string	false	"Filter by song text"
+//	@Param			minTextLen			query		int		false	"Filter songs whose text has at least the specified number of characters"
+//	@Param			maxTextLen			query		int		false	"Filter songs whose text has at most the specified number of characters"
+//	@Param			detailStatus		query		string	false	"Filter by the outcome of the music info lookup"	Enums(found, not_found, failed)
+//	@Param			hasReleaseDate		query		bool	false	"Filter songs with (true) or without (false) a release date"
+//	@Param			editedOnly			query		bool	false	"Leave out songs that were never edited"
+//	@Param			limit				query		int		false	"Limit the number of items"
+//	@Param			offset				query		int		false	"Offset for pagination"
+//	@Param			dateFormat			query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200					{object}	songsResponse
+//	@Failure		400					{object}	errorResponse
+//	@Failure		422					{object}	errorResponse
+//	@Failure		500					{object}	errorResponse
+//	@Failure		503					{object}	errorResponse
+//	@Router			/api/v1/songs/recently-updated [get]
+func (h *songHandler) fetchRecentlyUpdatedSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch recently updated songs request")
+
+	if params := disallowedSongFilterParams(r, h.opts.AllowedFilters); len(params) > 0 {
+		logger.Debug("disallowed filters", slog.Any("params", params))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, disallowedFiltersError(params))
+		return
+	}
+
+	pagination := parsePagination(r)
+	filters := parseSongFilters(r, h.opts.DuplicateFilters)
+
+	if param := r.URL.Query().Get("editedOnly"); param != "" {
+		if value, err := strconv.ParseBool(param); err == nil && value {
+			filters = append(filters, entity.SongFilter{
+				Field: entity.SongEditedFilterField,
+				Value: value,
+			})
+		}
+	}
+
+	logger.Debug(
+		"fetching recently updated songs",
+		slog.Any("pagination", pagination),
+		slog.Any("filters", filters),
+	)
+
+	songs, pgn, err := h.songUseCase.FetchRecentlyUpdatedSongs(r.Context(), pagination, filters...)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrOffsetOutOfRange) {
+			logger.Debug("offset out of range", slog.Any("pagination", pagination), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, offsetOutOfRangeResp)
+			return
+		}
+
+		logger.Debug("failed to fetch recently updated songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("recently updated songs fetched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// streamSongEvents handles streaming song changes to the client as server-sent events.
+//
+//	@Summary		Stream song events
+//	@Description	Streams an event for every song added, modified, or removed as server-sent events, named after the kind of change.
+//	@Description	Idle streams receive heartbeat comments to keep the connection alive.
+//	@Tags			songs
+//	@Produce		text/event-stream
+//	@Success		200	{object}	songEventSchema	"Stream of song events"
+//	@Router			/api/v1/songs/events [get]
+func (h *songHandler) streamSongEvents(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling stream song events request")
+
+	rc := http.NewResponseController(w)
+
+	// The stream outlives the write timeout of the server, so the deadline is lifted for this response.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Debug("failed to lift write deadline", slog.Any("err", err))
+	}
+
+	interval := h.opts.EventsHeartbeatInterval
+	if interval <= 0 {
+		interval = defaultEventsHeartbeatInterval
+	}
+
+	events := h.songUseCase.SubscribeSongEvents(r.Context())
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to flush song event stream", slog.Any("err", err))
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-r.Context().Done():
+			logger.Debug("song event stream closed by client")
+			return
+		case event, ok := <-events:
+			if !ok {
+				logger.Debug("song event subscription closed")
+				return
+			}
+
+			data, _ := json.Marshal(h.entityToSongEventSchema(event))
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		case <-ticker.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		}
+
+		if err == nil {
+			err = rc.Flush()
+		}
+
+		if err != nil {
+			logger.Debug("failed to write song event stream", slog.Any("err", err))
+			return
+		}
+	}
+}
+
+// fetchSitemap handles fetching a sitemap listing the permalinks of all songs.
+//
+//	@Summary		Fetch the sitemap
+//	@Description	Streams a sitemap listing the permalink of every song with the time it was last updated. A catalog with more songs than a sitemap may list (50000) gets a sitemap index linking to the pages instead, which are fetched with the page parameter.
+//	@Tags			songs
+//	@Produce		xml
+//	@Param			page	query		int		false	"Sitemap page, starting from 1"
+//	@Success		200		{string}	string	"Sitemap or sitemap index"
+//	@Failure		400		{object}	errorResponse
+//	@Failure		404		{object}	errorResponse
+//	@Failure		500		{object}	errorResponse
+//	@Failure		503		{object}	errorResponse
+//	@Router			/sitemap.xml [get]
+func (h *songHandler) fetchSitemap(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch sitemap request")
+
+	pageParam := r.URL.Query().Get("page")
+
+	page, err := parseSitemapPage(pageParam)
+	if err != nil {
+		logger.Debug(
+			"invalid sitemap page",
+			slog.String("page", pageParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSitemapPageResp)
+		return
+	}
+
+	count, err := h.songUseCase.CountSongs(r.Context())
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+		logger.Debug("failed to count songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	pages := sitemapPages(count)
+	if page > pages {
+		logger.Debug("sitemap page not found", slog.Uint64("page", page), slog.Uint64("pages", pages))
+
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, sitemapPageNotFoundResp)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, xml.Header)
+
+	enc := xml.NewEncoder(w)
+
+	if pageParam == "" && pages > 1 {
+		index := sitemapIndex{Xmlns: sitemapNamespace}
+		for p := uint64(1); p <= pages; p++ {
+			index.Sitemaps = append(index.Sitemaps, sitemapIndexEntry{
+				Loc: absoluteURL(r, "/sitemap.xml", url.Values{"page": {strconv.FormatUint(p, 10)}}),
+			})
+		}
+
+		if err := enc.Encode(index); err != nil {
+			logger.Debug("failed to write sitemap index", slog.Any("err", err))
+		}
+		return
+	}
+
+	// The songs of earlier pages are skipped, since the catalog can only be walked from its start.
+	var (
+		skip   = (page - 1) * sitemapMaxURLs
+		listed uint64
+	)
+
+	urlset := xml.StartElement{
+		Name: xml.Name{Local: "urlset"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: sitemapNamespace}},
+	}
+	if err := enc.EncodeToken(urlset); err != nil {
+		logger.Debug("failed to write sitemap", slog.Any("err", err))
+		return
+	}
+
+	err = h.songUseCase.WalkSongs(r.Context(), func(song *entity.Song) error {
+		if skip > 0 {
+			skip--
+			return nil
+		}
+		if listed == sitemapMaxURLs {
+			return errSitemapPageFull
+		}
+		listed++
+
+		return enc.Encode(sitemapURL{
+			Loc:     songPermalink(r, song.ID),
+			LastMod: song.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	})
+	if err != nil && !errors.Is(err, errSitemapPageFull) {
+		// The status is already sent, so the sitemap is left truncated.
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+		logger.Debug("failed to write sitemap", slog.Any("err", err))
+		return
+	}
+
+	if err := enc.EncodeToken(urlset.End()); err != nil {
+		logger.Debug("failed to write sitemap", slog.Any("err", err))
+		return
+	}
+	if err := enc.Flush(); err != nil {
+		logger.Debug("failed to write sitemap", slog.Any("err", err))
+		return
+	}
+
+	logger.Debug("sitemap fetched successfully", slog.Uint64("page", page), slog.Uint64("songs", listed))
+}
+
+// fetchAlphaIndex handles counting groups or songs per first letter for an alphabetical index.
+//
+//	@Summary		Fetch alphabetical index
+//	@Description	Counts the groups, by their sort names, or the songs, by their names, per uppercased first letter, ordered by letter. Names that don't start with a letter are counted under #, which comes first.
+//	@Tags			songs
+//	@Produce		json
+//	@Param			by	query		string	false	"Whether to count groups or songs"	Enums(group, song)	default(group)
+//	@Success		200	{object}	alphaIndexResponse
+//	@Failure		400	{object}	errorResponse
+//	@Failure		500	{object}	errorResponse
+//	@Failure		503	{object}	errorResponse
+//	@Router			/api/v1/songs/alpha-index [get]
+func (h *songHandler) fetchAlphaIndex(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch alpha index request")
+
+	by := r.URL.Query().Get("by")
+
+	field, ok := parseAlphaIndexField(by)
+	if !ok {
+		logger.Debug("invalid alpha index field", slog.String("by", by))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidAlphaIndexFieldResp)
+		return
+	}
+	if by == "" {
+		by = "group"
+	}
+
+	counts, err := h.songUseCase.FetchAlphaIndex(r.Context(), field)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to fetch alpha index", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("alpha index fetched successfully", slog.Int("letters", len(counts)))
+
+	resp := alphaIndexResponse{
+		By:      by,
+		Letters: make([]letterCountSchema, 0, len(counts)),
+	}
+	for _, count := range counts {
+		resp.Letters = append(resp.Letters, letterCountSchema{
+			Letter: count.Letter,
+			Count:  count.Count,
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchDecadeStats handles counting songs per release decade.
+//
+//	@Summary		Fetch songs per decade
+//	@Description	Counts songs by the decade of their release date, earliest first. Songs without a release date are not counted.
+//	@Tags			stats
+//	@Produce		json
+//	@Success		200	{object}	decadeStatsResponse
+//	@Failure		500	{object}	errorResponse
+//	@Failure		503	{object}	errorResponse
+//	@Router			/api/v1/stats/decades [get]
+func (h *songHandler) fetchDecadeStats(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch decade stats request")
+
+	counts, err := h.songUseCase.FetchDecadeStats(r.Context())
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to fetch decade stats", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("decade stats fetched successfully", slog.Int("decades", len(counts)))
+
+	resp := decadeStatsResponse{
+		Decades: make([]decadeCountSchema, 0, len(counts)),
+	}
+	for _, count := range counts {
+		resp.Decades = append(resp.Decades, decadeCountSchema{
+			Decade: count.Decade,
+			Count:  count.Count,
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// resplitVerses handles recomputing the stored verse counts of all songs.
+//
+//	@Summary		Resplit verses
+//	@Description	Splits the text of every song into verses again and stores the verse counts in batches.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	resplitVersesResponse
+//	@Failure		401	{object}	errorResponse	"Missing or invalid admin token"
+//	@Failure		500	{object}	errorResponse
+//	@Failure		503	{object}	errorResponse
+//	@Router			/api/v1/admin/songs/resplit [post]
+func (h *songHandler) resplitVerses(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling resplit verses request")
+
+	updated, err := h.songUseCase.ResplitVerses(r.Context())
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to resplit verses", slog.Any("err", err), slog.Int64("updated", updated))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("verses resplit successfully", slog.Int64("updated", updated))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resplitVersesResponse{Updated: updated})
+}
+
+// recomputeSortNames handles recomputing the stored sort names of all songs.
+//
+//	@Summary		Recompute sort names
+//	@Description	Strips the configured leading articles from the group name of every song again and stores the sort names in batches.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		200	{object}	recomputeSortNamesResponse
+//	@Failure		401	{object}	errorResponse	"Missing or invalid admin token"
+//	@Failure		500	{object}	errorResponse
+//	@Failure		503	{object}	errorResponse
+//	@Router			/api/v1/admin/songs/sort-names [post]
+func (h *songHandler) recomputeSortNames(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling recompute sort names request")
+
+	updated, err := h.songUseCase.RecomputeSortNames(r.Context())
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to recompute sort names", slog.Any("err", err), slog.Int64("updated", updated))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("sort names recomputed successfully", slog.Int64("updated", updated))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, recomputeSortNamesResponse{Updated: updated})
+}
+
+// startLinkCheck handles starting a background check of the links of all songs.
+//
+//	@Summary		Start a link check
+//	@Description	Starts requesting the link of every song in the background, with bounded concurrency, and storing the status codes. Songs whose last check got no response or an error status are then listed among songs with broken links.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Success		202	{object}	linkCheckStartedResponse
+//	@Failure		401	{object}	errorResponse	"Missing or invalid admin token"
+//	@Failure		409	{object}	errorResponse	"A link check is already running"
+//	@Failure		500	{object}	errorResponse
+//	@Failure		503	{object}	errorResponse
+//	@Router			/api/v1/admin/songs/link-check [post]
+func (h *songHandler) startLinkCheck(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling start link check request")
+
+	// The check outlives the request, so it is only detached from its cancellation and keeps its values.
+	ctx := context.WithoutCancel(r.Context())
+
+	err := h.songUseCase.StartLinkCheck(ctx, func(checked int64, err error) {
+		if err != nil {
+			logger.Error("link check failed", slog.Int64("checked", checked), slog.Any("err", err))
+			return
+		}
+
+		logger.Info("link check finished", slog.Int64("checked", checked))
+	})
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrLinkCheckRunning) {
+			logger.Debug("link check already running", slog.Any("err", err))
+
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, linkCheckRunningResp)
+			return
+		}
+
+		logger.Debug("failed to start link check", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("link check started")
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, linkCheckStartedResponse{Started: true})
+}
+
+// touchSongs handles setting the update time of every song matching the filters to the current time.
+//
+//	@Summary		Touch songs matching filters
+//	@Description	Sets the update time of every song matching the filters to the current time without changing them, so that they are listed among recently updated songs again. Filters are required.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Param			groupName			query		string	false	"Filter by group name (ignored when empty)"
+//	@Param			name				query		string	false	"Filter by song name (ignored when empty)"
+//	@Param			releaseYear			query		[]int	false	"Filter by release years, repeat to match any of several"	collectionFormat(multi)
+//	@Param			releaseDate			query		string	false	"Filter by exact release date (dd.MM.yyyy)"
+//	@Param			releaseDateAfter	query		string	false	"Filter songs released after the specified date (dd.MM.yyyy)"
+//	@Param			releaseDateBefore	query		string	false	"Filter songs released before the specified date (dd.MM.yyyy)"
+//	@Param			text				query		string	false	"Filter by song text (ignored when empty)"
+//	@Param			minTextLen			query		int		false	"Filter songs whose text has at least the specified number of characters"
+//	@Param			maxTextLen			query		int		false	"Filter songs whose text has at most the specified number of characters"
+//	@Param			detailStatus		query		string	false	"Filter by the outcome of the music info lookup"	Enums(found, not_found, failed)
+//	@Param			hasReleaseDate		query		bool	false	"Filter songs with (true) or without (false) a release date"
+//	@Success		200					{object}	songsTouchedResponse
+//	@Failure		400					{object}	errorResponse	"No filters provided"
+//	@Failure		401					{object}	errorResponse	"Missing or invalid admin token"
+//	@Failure		500					{object}	errorResponse
+//	@Failure		503					{object}	errorResponse
+//	@Router			/api/v1/admin/songs/touch [post]
+func (h *songHandler) touchSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling touch songs request")
+
+	if params := disallowedSongFilterParams(r, h.opts.AllowedFilters); len(params) > 0 {
+		logger.Debug("disallowed filters", slog.Any("params", params))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, disallowedFiltersError(params))
+		return
+	}
+
+	if h.opts.StrictFilters {
+		if params := emptySongFilterParams(r); len(params) > 0 {
+			logger.Debug("empty filter values", slog.Any("params", params))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyFilterValuesError(params))
+			return
+		}
+	}
+
+	filters := parseSongFilters(r, h.opts.DuplicateFilters)
+
+	if len(filters) == 0 {
+		logger.Debug("no filters to touch songs")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, noTouchFiltersResp)
+		return
+	}
+
+	logger.Debug("songs touch", slog.Any("filters", filters))
+
+	touched, err := h.songUseCase.TouchSongs(r.Context(), filters...)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrNoFilters) {
+			logger.Debug("no filters to touch songs", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, noTouchFiltersResp)
+			return
+		}
+
+		logger.Debug("failed to touch songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("songs touched successfully", slog.Int64("touched", touched))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, songsTouchedResponse{Touched: touched})
+}
+
+// fetchRegexSongs handles fetching the songs whose text matches a regular expression with optional filters and pagination.
+//
+//	@Summary		Fetch songs with lyrics matching a regex
+//	@Description	Retrieves the songs whose text matches the regular expression, ignoring case, like fetching songs with the other filters.
+//	@Description	Patterns longer than 256 characters and patterns nesting repetitions, such as (a+)+, are rejected because of their cost.
+//	@Description	Available only when an admin token is configured.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		AdminToken
+//	@Param			regex				query		string	true	"Regular expression the song text must match"
+//	@Param			limit				query		int		false	"Limit the number of items"
+//	@Param			offset				query		int		false	"Offset for pagination"
+//	@Param			groupName			query		string	false	"Filter by group name (ignored when empty)"
+//	@Param			name				query		string	false	"Filter by song name (ignored when empty)"
+//	@Param			releaseYear			query		[]int	false	"Filter by release years, repeat to match any of several"	collectionFormat(multi)
+//	@Param			releaseDate			query		string	false	"Filter by exact release date (dd.MM.yyyy)"
+//	@Param			releaseDateAfter	query		string	false	"Filter songs released after the specified date (dd.MM.yyyy)"
+//	@Param			releaseDateBefore	query		string	false	"Filter songs released before the specified date (dd.MM.yyyy)"
+//	@Param			text				query		string	false	"Filter by song text (ignored when empty)"
+//	@Param			minTextLen			query		int		false	"Filter songs whose text has at least the specified number of characters"
+//	@Param			maxTextLen			query		int		false	"Filter songs whose text has at most the specified number of characters"
+//	@Param			detailStatus		query		string	false	"Filter by the outcome of the music info lookup"	Enums(found, not_found, failed)
+//	@Param			hasReleaseDate		query		bool	false	"Filter songs with (true) or without (false) a release date"
+//	@Param			dateFormat			query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200					{object}	songsResponse
+//	@Failure		400					{object}	errorResponse	"Missing or invalid regex"
+//	@Failure		401					{object}	errorResponse	"Missing or invalid admin token"
+//	@Failure		422					{object}	errorResponse
+//	@Failure		500					{object}	errorResponse
+//	@Failure		503					{object}	errorResponse
+//	@Router			/api/v1/admin/songs/regex [get]
+func (h *songHandler) fetchRegexSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch regex songs request")
+
+	if params := disallowedSongFilterParams(r, h.opts.AllowedFilters); len(params) > 0 {
+		logger.Debug("disallowed filters", slog.Any("params", params))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, disallowedFiltersError(params))
+		return
+	}
+
+	if h.opts.StrictFilters {
+		if params := emptySongFilterParams(r); len(params) > 0 {
+			logger.Debug("empty filter values", slog.Any("params", params))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyFilterValuesError(params))
+			return
+		}
+	}
+
+	pattern := r.URL.Query().Get("regex")
+
+	if pattern == "" {
+		logger.Debug("missing regex")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, missingLyricsRegexResp)
+		return
+	}
+
+	if err := validateLyricsRegex(pattern); err != nil {
+		logger.Debug("invalid regex", slog.String("regex", pattern), slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidLyricsRegexError(err))
+		return
+	}
+
+	pagination := parsePagination(r)
+	filters := append(parseSongFilters(r, h.opts.DuplicateFilters), entity.SongFilter{
+		Field: entity.SongTextRegexFilterField,
+		Value: pattern,
+	})
+
+	logger.Debug(
+		"fetching regex songs",
+		slog.Any("pagination", pagination),
+		slog.Any("filters", filters),
+	)
+
+	songs, pgn, err := h.songUseCase.FetchSongs(r.Context(), pagination, filters...)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrOffsetOutOfRange) {
+			logger.Debug("offset out of range", slog.Any("pagination", pagination), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, offsetOutOfRangeResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrInvalidRegex) {
+			logger.Debug("regex rejected by the database", slog.String("regex", pattern), slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unsupportedLyricsRegexResp)
+			return
+		}
+
+		logger.Debug("failed to fetch regex songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("regex songs fetched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchGroupFacets handles fetching the distinct filterable values of the songs of a group.
+//
+//	@Summary		Fetch group facets
+//	@Description	Retrieves the distinct release years of the songs of a group with the number of songs in each year, earliest first. Songs without a release date are not counted.
+//	@Tags			groups
+//	@Produce		json
+//	@Param			groupName	path		string	true	"Group name"
+//	@Success		200			{object}	groupFacetsResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/groups/{groupName}/facets [get]
+func (h *songHandler) fetchGroupFacets(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch group facets request")
+
+	groupName := pathParam(r, "groupName")
+
+	logger.Debug("fetching group facets", slog.String("groupName", groupName))
+
+	facets, err := h.songUseCase.FetchGroupFacets(r.Context(), groupName)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrGroupNotFound) {
+			logger.Debug(
+				"group not found",
+				slog.String("groupName", groupName),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, groupNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to fetch group facets",
+			slog.String("groupName", groupName),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("group facets fetched successfully", slog.Int("releaseYears", len(facets.ReleaseYears)))
+
+	resp := groupFacetsResponse{
+		GroupName:    facets.GroupName,
+		ReleaseYears: make([]yearCountSchema, 0, len(facets.ReleaseYears)),
+	}
+	for _, count := range facets.ReleaseYears {
+		resp.ReleaseYears = append(resp.ReleaseYears, yearCountSchema{
+			Year:  count.Year,
+			Count: count.Count,
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchGroupReleaseRange handles fetching the earliest and latest released songs of a group.
+//
+//	@Summary		Fetch group release range
+//	@Description	Retrieves the earliest and latest release dates of the songs of a group with the names of the songs released on them. Songs without a release date are ignored.
+//	@Tags			groups
+//	@Produce		json
+//	@Param			groupName	path		string	true	"Group name"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	groupReleaseRangeResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/groups/{groupName}/range [get]
+func (h *songHandler) fetchGroupReleaseRange(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch group release range request")
+
+	groupName := pathParam(r, "groupName")
+
+	logger.Debug("fetching group release range", slog.String("groupName", groupName))
+
+	releaseRange, err := h.songUseCase.FetchGroupReleaseRange(r.Context(), groupName)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrGroupNotFound) {
+			logger.Debug(
+				"group not found",
+				slog.String("groupName", groupName),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, groupNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to fetch group release range",
+			slog.String("groupName", groupName),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("group release range fetched successfully")
+
+	layout := dateLayout(r)
+
+	resp := groupReleaseRangeResponse{
+		GroupName: releaseRange.GroupName,
+		First: songReleaseSchema{
+			Name:        releaseRange.First.Name,
+			ReleaseDate: releaseRange.First.ReleaseDate.Format(layout),
+		},
+		Last: songReleaseSchema{
+			Name:        releaseRange.Last.Name,
+			ReleaseDate: releaseRange.Last.ReleaseDate.Format(layout),
+		},
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchGroupYearSongs handles fetching the songs of a group released in a year with pagination.
+//
+//	@Summary		Fetch songs of a group by year
+//	@Description	Retrieves the songs of a group released in a year, like fetching songs filtered by groupName and releaseYear.
+//	@Tags			groups
+//	@Produce		json
+//	@Param			groupName	path		string	true	"Group name"
+//	@Param			year		path		int		true	"Release year"
+//	@Param			limit		query		int		false	"Limit the number of items"
+//	@Param			offset		query		int		false	"Offset for pagination"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songsResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse	"No songs of the group were released in the year"
+//	@Failure		422			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/groups/{groupName}/years/{year}/songs [get]
+func (h *songHandler) fetchGroupYearSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch group year songs request")
+
+	groupName := pathParam(r, "groupName")
+
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil {
+		logger.Debug("invalid year", slog.String("year", chi.URLParam(r, "year")), slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidYearResp)
+		return
+	}
+
+	pagination := parsePagination(r)
+	filters := []entity.SongFilter{
+		{Field: entity.SongGroupNameFilterField, Value: groupName},
+		{Field: entity.SongReleaseYearFilterField, Value: year},
+	}
+
+	logger.Debug(
+		"fetching group year songs",
+		slog.String("groupName", groupName),
+		slog.Int("year", year),
+		slog.Any("pagination", pagination),
+	)
+
+	songs, pgn, err := h.songUseCase.FetchSongs(r.Context(), pagination, filters...)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrOffsetOutOfRange) {
+			logger.Debug("offset out of range", slog.Any("pagination", pagination), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, offsetOutOfRangeResp)
+			return
+		}
+
+		logger.Debug("failed to fetch group year songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	if pgn.Total == 0 {
+		logger.Debug("no group year songs", slog.String("groupName", groupName), slog.Int("year", year))
+
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, groupYearSongsNotFoundResp)
+		return
+	}
+
+	logger.Debug("group year songs fetched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// setGroupReleaseDate handles setting a release date on the songs of a group that have none.
+//
+//	@Summary		Set the release date of a group's songs missing one
+//	@Description	Sets the release date on every song of the group, matched exactly, that has no release date, in a single statement. Songs that already have a release date are left unchanged.
+//	@Tags			groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			groupName	path		string						true	"Group name"
+//	@Param			releaseDate	body		updateReleaseDateRequest	true	"Release date"
+//	@Success		200			{object}	songsUpdatedResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		415			{object}	errorResponse
+//	@Failure		422			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/groups/{groupName}/release-date [post]
+func (h *songHandler) setGroupReleaseDate(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling set group release date request")
+
+	groupName := pathParam(r, "groupName")
+
+	var req updateReleaseDateRequest
+
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Debug("validation error", slog.Any("err", err))
+
+		status := http.StatusBadRequest
+
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) && validationErrs[0].Tag() == "releaseDate" {
+			status = http.StatusUnprocessableEntity
+		}
+
+		render.Status(r, status)
+		render.JSON(w, r, validationError(err))
+		return
+	}
+
+	releaseDate, _ := time.Parse("02.01.2006", req.ReleaseDate)
+
+	filters := []entity.SongFilter{
+		{Field: entity.SongGroupFilterField, Value: groupName},
+		{Field: entity.SongReleaseDateMissingFilterField, Value: true},
+	}
+
+	logger.Debug("group release date modification", slog.String("groupName", groupName))
+
+	updated, err := h.songUseCase.ModifySongsWhere(r.Context(), entity.Song{
+		SongDetail: entity.SongDetail{ReleaseDate: releaseDate},
+	}, filters...)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug(
+			"failed to set group release date",
+			slog.String("groupName", groupName),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("group release date set successfully", slog.String("groupName", groupName), slog.Int64("updated", updated))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, songsUpdatedResponse{Updated: updated})
+}
+
+// searchSongs handles searching songs by free text across their names, group names, and texts.
+//
+//	@Summary		Search songs
+//	@Description	Retrieves the songs whose name, group name, or text match the query, the most relevant first. Matches in the song name weigh most, then matches in the group name, then matches in the text. The query is parsed like web search input: words must all match, "quoted phrases" match in order, or separates alternatives, and -word excludes a word. Words are matched whole, without stemming.
+//	@Tags			songs
+//	@Produce		json
+//	@Param			q			query		string	true	"Search query"
+//	@Param			limit		query		int		false	"Limit the number of items"
+//	@Param			offset		query		int		false	"Offset for pagination"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songsResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		422			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/search [get]
+func (h *songHandler) searchSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling search songs request")
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		logger.Debug("missing search query")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, missingSearchQueryResp)
+		return
+	}
+
+	pagination := parsePagination(r)
+
+	logger.Debug("searching songs", slog.String("q", query), slog.Any("pagination", pagination))
+
+	songs, pgn, err := h.songUseCase.SearchSongs(r.Context(), query, pagination)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrOffsetOutOfRange) {
+			logger.Debug("offset out of range", slog.Any("pagination", pagination), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, offsetOutOfRangeResp)
+			return
+		}
+
+		logger.Debug("failed to search songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("songs searched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// suggestNames handles suggesting group or song names for search-as-you-type.
+//
+//	@Summary		Suggest names
+//	@Description	Retrieves distinct group or song names starting with the query, ignoring case. An exact match comes first, the others are ordered alphabetically.
+//	@Tags			songs
+//	@Produce		json
+//	@Param			field	query		string	true	"Field to suggest names for"	Enums(group, song)
+//	@Param			q		query		string	true	"Name prefix (at least 2 characters)"
+//	@Param			limit	query		int		false	"Number of suggestions to return (default 10, max 25)"
+//	@Success		200		{object}	suggestionsResponse
+//	@Failure		400		{object}	errorResponse
+//	@Failure		500		{object}	errorResponse
+//	@Failure		503		{object}	errorResponse
+//	@Router			/api/v1/suggest [get]
+func (h *songHandler) suggestNames(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling suggest names request")
+
+	query := r.URL.Query()
+
+	field, ok := parseSuggestField(query.Get("field"))
+	if !ok {
+		logger.Debug("invalid suggest field", slog.String("field", query.Get("field")))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSuggestFieldResp)
+		return
+	}
+
+	prefix := norm.NFC.String(strings.TrimSpace(query.Get("q")))
+	if utf8.RuneCountInString(prefix) < entity.MinSuggestQueryLength {
+		logger.Debug("suggest query too short", slog.String("q", prefix))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, suggestQueryTooShortResp)
+		return
+	}
+
+	limit := parseSuggestLimit(r)
+
+	logger.Debug(
+		"suggesting names",
+		slog.String("field", query.Get("field")),
+		slog.String("q", prefix),
+		slog.Uint64("limit", limit),
+	)
+
+	names, err := h.songUseCase.SuggestNames(r.Context(), field, prefix, limit)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to suggest names", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("names suggested successfully", slog.Int("suggestions", len(names)))
+
+	if names == nil {
+		names = make([]string, 0)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, suggestionsResponse{Suggestions: names})
+}
+
+// exportSongs handles downloading the songs matching the filters as a ZIP archive.
+//
+//	@Summary		Export songs
+//	@Description	Streams a ZIP archive holding the songs matching the filters both as songs.csv, whose group and song columns can be imported again, and as songs.json, an array of songs. The songs are walked once for each member, so songs changed meanwhile can differ between them. A failure once streaming has started leaves the archive truncated.
+//	@Tags			songs
+//	@Produce		application/zip
+//	@Param			format				query		string	true	"Export format"	Enums(zip)
+//	@Param			groupName			query		string	false	"Filter by group name (ignored when empty)"
+//	@Param			name				query		string	false	"Filter by song name (ignored when empty)"
+//	@Param			releaseYear			query		[]int	false	"Filter by release years, repeat to match any of several"	collectionFormat(multi)
+//	@Param			releaseDate			query		string	false	"Filter by exact release date (dd.MM.yyyy)"
+//	@Param			releaseDateAfter	query		string	false	"Filter songs released after the specified date (dd.MM.yyyy)"
+//	@Param			releaseDateBefore	query		string	false	"Filter songs released before the specified date (dd.MM.yyyy)"
+//	@Param			text				query		string	false	"Filter by song text (ignored when empty)"
+//	@Param			minTextLen			query		int		false	"Filter songs whose text has at least the specified number of characters"
+//	@Param			maxTextLen			query		int		false	"Filter songs whose text has at most the specified number of characters"
+//	@Param			detailStatus		query		string	false	"Filter by the outcome of the music info lookup"	Enums(found, not_found, failed)
+//	@Param			hasReleaseDate		query		bool	false	"Filter songs with (true) or without (false) a release date"
+//	@Param			dateFormat			query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200					{file}		binary
+//	@Header			200					{string}	Content-Disposition	"attachment; filename=\"songs.zip\""
+//	@Failure		400					{object}	errorResponse
+//	@Router			/api/v1/songs/export [get]
+func (h *songHandler) exportSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling export songs request")
+
+	if format := r.URL.Query().Get("format"); format != exportFormatZIP {
+		logger.Debug("invalid export format", slog.String("format", format))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidExportFormatResp)
+		return
+	}
+
+	if params := disallowedSongFilterParams(r, h.opts.AllowedFilters); len(params) > 0 {
+		logger.Debug("disallowed filters", slog.Any("params", params))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, disallowedFiltersError(params))
+		return
+	}
+
+	if h.opts.StrictFilters {
+		if params := emptySongFilterParams(r); len(params) > 0 {
+			logger.Debug("empty filter values", slog.Any("params", params))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyFilterValuesError(params))
+			return
+		}
+	}
+
+	filters := parseSongFilters(r, h.opts.DuplicateFilters)
+	layout := dateLayout(r)
+
+	logger.Debug("exporting songs", slog.Any("filters", filters))
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "songs.zip",
+	}))
+	w.WriteHeader(http.StatusOK)
+
+	zw := zip.NewWriter(w)
+
+	// The status is already sent, so a failure leaves the archive truncated.
+	if err := h.writeSongsExportCSV(r.Context(), zw, layout, filters); err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+		logger.Debug("failed to write csv export", slog.Any("err", err))
+		return
+	}
+	if err := h.writeSongsExportJSON(r.Context(), zw, layout, filters); err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+		logger.Debug("failed to write json export", slog.Any("err", err))
+		return
+	}
+	if err := zw.Close(); err != nil {
+		logger.Debug("failed to write export archive", slog.Any("err", err))
+		return
+	}
+
+	logger.Debug("songs exported successfully")
+}
+
+// writeSongsExportCSV writes the songs matching the filters to the CSV member of a songs export archive.
+func (h *songHandler) writeSongsExportCSV(
+	ctx context.Context,
+	zw *zip.Writer,
+	layout string,
+	filters []entity.SongFilter,
+) error {
+	f, err := zw.Create(exportCSVMember)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", exportCSVMember, err)
+	}
+
+	writer := csv.NewWriter(f)
+	if err := writer.Write(csvExportHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	err = h.songUseCase.WalkSongs(ctx, func(song *entity.Song) error {
+		return writer.Write(songCSVRecord(h.entityToSongSchema(song, layout)))
+	}, filters...)
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeSongsExportJSON writes the songs matching the filters to the JSON member of a songs export archive,
+// as an array encoded one song at a time.
+func (h *songHandler) writeSongsExportJSON(
+	ctx context.Context,
+	zw *zip.Writer,
+	layout string,
+	filters []entity.SongFilter,
+) error {
+	f, err := zw.Create(exportJSONMember)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", exportJSONMember, err)
+	}
+
+	if _, err := io.WriteString(f, "["); err != nil {
+		return err
+	}
+
+	first := true
+	err = h.songUseCase.WalkSongs(ctx, func(song *entity.Song) error {
+		if !first {
+			if _, err := io.WriteString(f, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		b, err := json.Marshal(h.entityToSongSchema(song, layout))
+		if err != nil {
+			return err
+		}
+
+		_, err = f.Write(b)
+		return err
+	}, filters...)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(f, "]\n")
+	return err
+}
+
+// exportSong handles downloading the complete record of a song as a JSON file.
+//
+//	@Summary		Export a song
+//	@Description	Downloads the complete song, including its full text, as a JSON file named after the song
+//	@Tags			songs
+//	@Produce		json
+//	@Param			songID		path		string	true	"Song ID"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songSchema
+//	@Header			200			{string}	Content-Disposition	"attachment; filename=\"<group>	-	<song>.json\""
+//	@Header			200			{string}	ETag				"Hash of the response body"
+//	@Header			200			{string}	Last-Modified		"Time the song was last updated"
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/export [get]
+//	@Router			/api/v1/songs/{songID}/export [head]
+func (h *songHandler) exportSong(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling export song request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	logger.Debug("exporting song", slog.Any("songID", songID))
+
+	song, err := h.songUseCase.FetchSong(r.Context(), songID)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to export song",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("song exported successfully", slog.Any("songID", song.ID))
+
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": exportFileName(song.GroupName, song.Name),
+	}))
+	h.setLastModified(w, song.UpdatedAt)
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, h.entityToSongSchema(song, dateLayout(r)))
+}
+
+// setLastModified sets the Last-Modified header of a response to the given time, unless it is zero
+// or the header is disabled with NoLastModified.
+func (h *songHandler) setLastModified(w http.ResponseWriter, t time.Time) {
+	if h.opts.NoLastModified || t.IsZero() {
+		return
+	}
+	w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+}
+
+// fetchSongOEmbed handles fetching an oEmbed description of a song by song ID.
+//
+//	@Summary		Fetch an oEmbed description of a song
+//	@Description	Retrieves an oEmbed response for the song, with the group as the author and an HTML snippet linking to the song, so it can be embedded in other sites.
+//	@Tags			songs
+//	@Produce		json
+//	@Param			songID	path		string	true	"Song ID"
+//	@Success		200		{object}	oEmbedSchema
+//	@Failure		400		{object}	errorResponse
+//	@Failure		404		{object}	errorResponse
+//	@Failure		500		{object}	errorResponse
+//	@Failure		503		{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/oembed [get]
+func (h *songHandler) fetchSongOEmbed(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch song oEmbed request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	logger.Debug("fetching song oEmbed", slog.Any("songID", songID))
+
+	song, err := h.songUseCase.FetchSong(r.Context(), songID)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to fetch song oEmbed",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("song oEmbed fetched successfully", slog.Any("songID", song.ID))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, oEmbedSchema{
+		Version:    "1.0",
+		Type:       "rich",
+		Title:      song.Name,
+		AuthorName: song.GroupName,
+		HTML:       songOEmbedHTML(songPermalink(r, song.ID), song.GroupName, song.Name),
+		Width:      oEmbedWidth,
+		Height:     oEmbedHeight,
+	})
+}
+
+// fetchSongWithVerses handles fetching a song along with its verses by song ID.
+//
+//	@Summary		Fetch a song with verses
+//	@Description	Retrieves a song along with its verses using the song ID. With granularity=line the text is broken into single lines instead, which are paginated the same way. A song without text gets an empty verses array, or 404 "no lyrics available" when the server is configured so. With q the occurrences of the query, ignoring case, are wrapped in highlight markers, <em> and </em> by default. With verseFormat=indexed the verses are returned as objects with their text and their index in the whole text, counted from 0 across pages.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			songID			path		string					true	"Song ID"
+//	@Param			limit			query		int						false	"Limit the number of verses"
+//	@Param			offset			query		int						false	"Offset for pagination"
+//	@Param			granularity		query		string					false	"Units the text is broken into"										Enums(verse, line)	default(verse)
+//	@Param			dedupeVerses	query		bool					false	"Collapse consecutive identical verses, reporting their repeats"	default(false)
+//	@Param			q				query		string					false	"Text to highlight in the verses, which are HTML-escaped then"
+//	@Param			verseFormat		query		string					false	"Shape of the verses"	Enums(plain, indexed)	default(plain)
+//	@Success		200				{object}	songWithVersesResponse	"Verses as a plain array, or songWithIndexedVersesResponse with verseFormat=indexed"
+//	@Header			200				{string}	ETag					"Hash of the response body"
+//	@Header			200				{string}	Last-Modified			"Time the song was last updated"
+//	@Failure		400				{object}	errorResponse
+//	@Failure		404				{object}	errorResponse
+//	@Failure		500				{object}	errorResponse
+//	@Failure		503				{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/text [get]
+//	@Router			/api/v1/songs/{songID}/text [head]
+func (h *songHandler) fetchSongWithVerses(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch song with verses request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	granularityParam := r.URL.Query().Get("granularity")
+
+	granularity, ok := parseTextGranularity(granularityParam)
+	if !ok {
+		logger.Debug("invalid granularity", slog.String("granularity", granularityParam))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidGranularityResp)
+		return
+	}
+
+	verseFormatParam := r.URL.Query().Get("verseFormat")
+
+	indexed, ok := parseVerseFormat(verseFormatParam)
+	if !ok {
+		logger.Debug("invalid verse format", slog.String("verseFormat", verseFormatParam))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidVerseFormatResp)
+		return
+	}
+
+	pagination := parsePagination(r)
+	dedupe, _ := strconv.ParseBool(r.URL.Query().Get("dedupeVerses"))
+
+	logger.Debug(
+		"fetching song with verses",
+		slog.Any("songID", songID),
+		slog.Any("pagination", pagination),
+		slog.String("granularity", granularityParam),
+		slog.Bool("dedupeVerses", dedupe),
+	)
+
+	song, pgn, err := h.songUseCase.FetchSongWithVerses(r.Context(), songID, pagination, granularity, dedupe)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to fetch song with verses",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	// Texts are stored as NULL when empty, so a song without verses is one without lyrics.
+	if h.opts.NoLyricsNotFound && pgn.Total == 0 {
+		logger.Debug("song has no lyrics", slog.Any("songID", songID))
+
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, noLyricsAvailableResp)
+		return
+	}
+
+	logger.Debug("song with verses fetched successfully")
+
+	resp := songWithVersesResponse{
+		Song:       h.entityToSongWithVersesSchema(song),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	if q := strings.TrimSpace(r.URL.Query().Get("q")); q != "" {
+		resp.Song.Verses = h.highlightVerses(resp.Song.Verses, q)
+	}
+
+	h.setLastModified(w, song.UpdatedAt)
+
+	if indexed {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, songWithIndexedVersesResponse{
+			Song:       h.indexSongWithVerses(resp.Song, pgn.Offset),
+			Pagination: resp.Pagination,
+		})
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchSongLines handles fetching the numbered lines of a song's text by song ID.
+//
+//	@Summary		Fetch a song's lyrics with line numbers
+//	@Description	Retrieves the lines of a song's text numbered from 1, for reference and citation. Blank lines separating verses are skipped and not counted by default, as with granularity=line of the verses endpoint; with blankLines=number they are numbered too. With format=text the lines are returned as plain text, one "<number>: <text>" line each.
+//	@Tags			songs
+//	@Produce		json
+//	@Produce		plain
+//	@Param			songID		path		string	true	"Song ID"
+//	@Param			blankLines	query		string	false	"Handling of blank lines"	Enums(skip, number)	default(skip)
+//	@Param			format		query		string	false	"Response format"			Enums(json, text)	default(json)
+//	@Success		200			{object}	songLinesResponse
+//	@Header			200			{string}	ETag			"Hash of the response body"
+//	@Header			200			{string}	Last-Modified	"Time the song was last updated"
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/text/lines [get]
+func (h *songHandler) fetchSongLines(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch song lines request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	blankLinesParam := r.URL.Query().Get("blankLines")
+
+	numberBlank, ok := parseBlankLines(blankLinesParam)
+	if !ok {
+		logger.Debug("invalid blank lines handling", slog.String("blankLines", blankLinesParam))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidBlankLinesResp)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "text" {
+		logger.Debug("invalid lines format", slog.String("format", format))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidLinesFormatResp)
+		return
+	}
+
+	logger.Debug(
+		"fetching song lines",
+		slog.Any("songID", songID),
+		slog.Bool("numberBlank", numberBlank),
+		slog.String("format", format),
+	)
+
+	song, lines, err := h.songUseCase.FetchSongLines(r.Context(), songID, numberBlank)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to fetch song lines",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	if h.opts.NoLyricsNotFound && len(lines) == 0 {
+		logger.Debug("song has no lyrics", slog.Any("songID", songID))
+
+		render.Status(r, http.StatusNotFound)
+		render.JSON(w, r, noLyricsAvailableResp)
+		return
+	}
+
+	logger.Debug("song lines fetched successfully", slog.Int("lines", len(lines)))
+
+	h.setLastModified(w, song.UpdatedAt)
+
+	if format == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+
+		if err := writeNumberedLines(w, lines); err != nil {
+			logger.Debug("failed to write song lines", slog.Any("err", err))
+		}
+		return
+	}
+
+	resp := songLinesResponse{
+		ID:        song.ID,
+		GroupName: song.GroupName,
+		Name:      song.Name,
+		Lines:     make([]numberedLineSchema, 0, len(lines)),
+	}
+	for _, line := range lines {
+		resp.Lines = append(resp.Lines, numberedLineSchema{LineNumber: line.Number, Text: line.Text})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchSongsWithVerses handles fetching several songs along with their verses by song IDs.
+//
+//	@Summary		Fetch several songs with verses
+//	@Description	Retrieves several songs along with their verses using the song IDs. The same pagination is applied to the verses of every song, and IDs of songs that don't exist are reported separately.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		songsWithVersesRequest	true	"Song IDs"
+//	@Param			limit	query		int						false	"Limit the number of verses of every song"
+//	@Param			offset	query		int						false	"Offset for pagination of verses of every song"
+//	@Success		200		{object}	songsWithVersesResponse
+//	@Failure		400		{object}	errorResponse
+//	@Failure		415		{object}	errorResponse
+//	@Failure		500		{object}	errorResponse
+//	@Failure		503		{object}	errorResponse
+//	@Router			/api/v1/songs/text/batch [post]
+func (h *songHandler) fetchSongsWithVerses(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch songs with verses request")
+
+	var req songsWithVersesRequest
+
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		logger.Debug("no song ids provided")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, noSongIDsResp)
+		return
+	}
+
+	if len(req.IDs) > maxSongsWithVersesBatchSize {
+		logger.Debug("too many song ids", slog.Int("count", len(req.IDs)))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, tooManySongIDsResp)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, validationError(err))
+		return
+	}
+
+	songIDs := make([]uuid.UUID, len(req.IDs))
+	for i, id := range req.IDs {
+		songIDs[i], _ = uuid.Parse(id)
+	}
+
+	pagination := parsePagination(r)
+
+	logger.Debug(
+		"fetching songs with verses",
+		slog.Int("count", len(songIDs)),
+		slog.Any("pagination", pagination),
+	)
+
+	pages, notFound, err := h.songUseCase.FetchSongsWithVerses(r.Context(), songIDs, pagination)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to fetch songs with verses", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug(
+		"songs with verses fetched successfully",
+		slog.Int("found", len(pages)),
+		slog.Int("notFound", len(notFound)),
+	)
+
+	resp := songsWithVersesResponse{
+		Songs:    make([]songWithVersesResponse, 0, len(pages)),
+		NotFound: make([]uuid.UUID, 0, len(notFound)),
+	}
+	for _, page := range pages {
+		resp.Songs = append(resp.Songs, songWithVersesResponse{
+			Song:       h.entityToSongWithVersesSchema(&page.Song),
+			Pagination: h.entityToPaginationSchema(&page.Pagination),
+		})
+	}
+	resp.NotFound = append(resp.NotFound, notFound...)
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchSimilarSongs handles fetching songs similar to a song using its unique ID.
+//
+//	@Summary		Fetch similar songs
+//	@Description	Retrieves songs similar to a song, excluding the song itself. Songs of the same group are ranked first,
+//	@Description	then songs with similar names.
+//	@Tags			songs
+//	@Produce		json
+//	@Param			songID		path		string	true	"Song ID"
+//	@Param			limit		query		int		false	"Limit the number of items"
+//	@Param			offset		query		int		false	"Offset for pagination"
+//	@Param			dateFormat	query		string	false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songsResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/similar [get]
+func (h *songHandler) fetchSimilarSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch similar songs request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	pagination := parsePagination(r)
+
+	logger.Debug(
+		"fetching similar songs",
+		slog.Any("songID", songID),
+		slog.Any("pagination", pagination),
+	)
+
+	songs, pgn, err := h.songUseCase.FetchSimilarSongs(r.Context(), songID, pagination)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to fetch similar songs",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("similar songs fetched successfully", slog.Uint64("items", pgn.Items))
+
+	layout := dateLayout(r)
+
+	resp := songsResponse{
+		Songs:      make([]songSchema, 0),
+		Pagination: h.entityToPaginationSchema(pgn),
+	}
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, h.entityToSongSchema(song, layout))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// searchSongVerses handles finding the verses of a song matching a search query.
+//
+//	@Summary		Search the verses of a song
+//	@Description	Finds the verses of a song containing the query, ignoring case. Every match is returned with up to context verses before and after it, fewer at the start and the end of the text. With highlight=true the occurrences of the query are wrapped in highlight markers, <em> and </em> by default.
+//	@Tags			songs
+//	@Produce		json
+//	@Param			songID		path		string	true	"Song ID"
+//	@Param			q			query		string	true	"Text to search for"
+//	@Param			context		query		int		false	"Number of verses returned before and after every match"							minimum(0)	maximum(10)	default(0)
+//	@Param			highlight	query		bool	false	"Wrap the occurrences of the query in highlight markers, HTML-escaping the verses"	default(false)
+//	@Success		200			{object}	verseMatchesResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/text/search [get]
+func (h *songHandler) searchSongVerses(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling search song verses request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	query := r.URL.Query()
+
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		logger.Debug("empty search query")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, emptySearchQueryResp)
+		return
+	}
+
+	contextVerses, ok := parseVerseContext(query.Get("context"))
+	if !ok {
+		logger.Debug("invalid verse context", slog.String("context", query.Get("context")))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidVerseContextResp)
+		return
+	}
+
+	logger.Debug(
+		"searching song verses",
+		slog.Any("songID", songID),
+		slog.String("query", q),
+		slog.Int("context", contextVerses),
+	)
+
+	matches, err := h.songUseCase.SearchSongVerses(r.Context(), songID, q, contextVerses)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to search song verses",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("song verses searched successfully", slog.Int("matches", len(matches)))
+
+	highlighted, _ := strconv.ParseBool(query.Get("highlight"))
+
+	resp := verseMatchesResponse{
+		SongID:  songID,
+		Query:   q,
+		Matches: make([]verseMatchSchema, 0, len(matches)),
+	}
+	for _, match := range matches {
+		verses := match.Verses
+		if highlighted {
+			verses = h.highlightVerses(verses, q)
+		}
+
+		resp.Matches = append(resp.Matches, verseMatchSchema{
+			Index:  match.Index,
+			Start:  match.Start,
+			Verses: verses,
+		})
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// fetchRandomVerse handles fetching a randomly picked verse of a song.
+//
+//	@Summary		Fetch a random verse of a song
+//	@Description	Picks one of the verses of a song at random, along with its index in the text. Songs without text have no verses and are reported as not found.
+//	@Tags			songs
+//	@Produce		json
+//	@Param			songID	path		string	true	"Song ID"
+//	@Success		200		{object}	randomVerseResponse
+//	@Failure		400		{object}	errorResponse
+//	@Failure		404		{object}	errorResponse
+//	@Failure		500		{object}	errorResponse
+//	@Failure		503		{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/text/random [get]
+func (h *songHandler) fetchRandomVerse(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch random verse request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	logger.Debug("fetching random verse", slog.Any("songID", songID))
+
+	verse, err := h.songUseCase.FetchRandomVerse(r.Context(), songID)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrNoVerses) {
+			logger.Debug("song has no verses", slog.Any("songID", songID))
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, noLyricsAvailableResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to fetch random verse",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("random verse fetched successfully", slog.Int("index", verse.Index))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, randomVerseResponse{
+		SongID: songID,
+		Index:  verse.Index,
+		Verse:  verse.Text,
+	})
+}
+
+// fetchTextStats handles fetching the word and line counts of a song text.
+//
+//	@Summary		Fetch song text stats
+//	@Description	Counts the words, non-blank lines, verses, and distinct words, ignoring case, of the text of a song. Words are runs of letters and digits, which may contain apostrophes. Songs without text are reported as not found.
+//	@Tags			songs
+//	@Produce		json
+//	@Param			songID	path		string	true	"Song ID"
+//	@Success		200		{object}	textStatsResponse
+//	@Failure		400		{object}	errorResponse
+//	@Failure		404		{object}	errorResponse
+//	@Failure		500		{object}	errorResponse
+//	@Failure		503		{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/text/stats [get]
+func (h *songHandler) fetchTextStats(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling fetch text stats request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	logger.Debug("fetching text stats", slog.Any("songID", songID))
+
+	stats, err := h.songUseCase.FetchTextStats(r.Context(), songID)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrNoVerses) {
+			logger.Debug("song has no text", slog.Any("songID", songID))
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, noLyricsAvailableResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to fetch text stats",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("text stats fetched successfully", slog.Any("songID", songID))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, textStatsResponse{
+		SongID:      songID,
+		Words:       stats.Words,
+		Lines:       stats.Lines,
+		Verses:      stats.Verses,
+		UniqueWords: stats.UniqueWords,
+	})
+}
+
+// compareSongTexts handles computing a line-level diff between the texts of two songs.
+//
+//	@Summary		Compare song texts
+//	@Description	Computes a line-level diff between the texts of two songs
+//	@Tags			songs
+//	@Produce		json
+//	@Param			songID		path		string	true	"Song ID"
+//	@Param			otherSongID	path		string	true	"Other Song ID"
+//	@Success		200			{object}	songTextDiffResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/text/diff/{otherSongID} [get]
+func (h *songHandler) compareSongTexts(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling compare song texts request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	otherSongIDParam := chi.URLParam(r, "otherSongID")
+
+	otherSongID, err := parseIDParam(otherSongIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid other song ID",
+			slog.String("otherSongID", otherSongIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidOtherSongIDParamResp)
+		return
+	}
+
+	logger.Debug(
+		"comparing song texts",
+		slog.Any("songID", songID),
+		slog.Any("otherSongID", otherSongID),
+	)
+
+	diff, err := h.songUseCase.CompareSongTexts(r.Context(), songID, otherSongID)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("otherSongID", otherSongID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to compare song texts",
+			slog.Any("songID", songID),
+			slog.Any("otherSongID", otherSongID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("song texts compared successfully", slog.Int("lines", len(diff)))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, songTextDiffResponse{
+		SongID:      songID,
+		OtherSongID: otherSongID,
+		Lines:       h.entityToLineDiffSchemas(diff),
+	})
+}
+
+// previewVerses handles splitting arbitrary text into verses without saving it.
+//
+//	@Summary		Preview verse splitting
+//	@Description	Splits the provided text into verses the same way stored song texts are split
+//	@Tags			text
+//	@Accept			json
+//	@Produce		json
+//	@Param			text	body		previewVersesRequest	true	"Text to split"
+//	@Success		200		{object}	versesPreviewResponse
+//	@Failure		400		{object}	errorResponse
+//	@Failure		415		{object}	errorResponse
+//	@Router			/api/v1/text/preview [post]
+func (h *songHandler) previewVerses(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling preview verses request")
+
+	var req previewVersesRequest
+
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Debug("validation error", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, validationError(err))
+		return
+	}
+
+	verses := h.songUseCase.PreviewVerses(req.Text)
+
+	logger.Debug("verses previewed successfully", slog.Int("verses", len(verses)))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, versesPreviewResponse{Verses: verses})
+}
+
+// validateReleaseDate handles checking a release date against the rules used for stored songs.
+//
+//	@Summary		Validate release date
+//	@Description	Checks whether the release date matches the 02.01.2006 format and returns it in ISO 8601 when valid
+//	@Tags			validate
+//	@Accept			json
+//	@Produce		json
+//	@Param			releaseDate	body		validateReleaseDateRequest	true	"Release date to validate"
+//	@Success		200			{object}	releaseDateValidationResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		415			{object}	errorResponse
+//	@Router			/api/v1/validate/release-date [post]
+func (h *songHandler) validateReleaseDate(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling validate release date request")
+
+	var req validateReleaseDateRequest
+
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Debug("validation error", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, validationError(err))
+		return
+	}
+
+	if err := h.validate.Var(req.ReleaseDate, "releaseDate"); err != nil {
+		logger.Debug("release date is invalid", slog.String("releaseDate", req.ReleaseDate))
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, releaseDateValidationResponse{Valid: false})
+		return
+	}
+
+	releaseDate, _ := time.Parse("02.01.2006", req.ReleaseDate)
+
+	logger.Debug("release date is valid", slog.String("releaseDate", req.ReleaseDate))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, releaseDateValidationResponse{
+		Valid:       true,
+		ReleaseDate: releaseDate.Format(time.DateOnly),
+	})
+}
+
+// modifySong handles modifying a song's details using its unique ID.
+//
+//	@Summary		Modify a song
+//	@Description	Updates a song's information using the song ID
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Produce		application/hal+json
+//	@Param			songID		path		string				true	"Song ID"
+//	@Param			song		body		updateSongRequest	true	"Update Song"
+//	@Param			Prefer		header		string				false	"Return preference"				Enums(return=representation, return=minimal)
+//	@Param			dateFormat	query		string				false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songSchema
+//	@Success		204			"Song modified, returned with return=minimal"
+//	@Header			204			{string}	Location	"URL of the modified song"
+//	@Header			204			{string}	ETag		"Hash of the song representation"
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		409			{object}	errorResponse
+//	@Failure		415			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/{songID} [patch]
+func (h *songHandler) modifySong(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling modify song request")
+
+	songIDParam := chi.URLParam(r, "songID")
+
+	songID, err := parseIDParam(songIDParam)
+	if err != nil {
+		logger.Debug(
+			"invalid song ID",
+			slog.String("songID", songIDParam),
+			slog.Any("err", err),
+		)
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidSongIDParamResp)
+		return
+	}
+
+	var req updateSongRequest
+
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Debug("validation error", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, validationError(err))
+		return
+	}
+
+	updates := h.updateSongRequestToEntity(req)
+	if updates == (entity.Song{}) {
+		logger.Debug("no updatable fields provided", slog.Any("songID", songID))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, noFieldsToUpdateErrResp)
+		return
+	}
+
+	logger.Debug("song modification", slog.Any("songID", songID))
+
+	song, err := h.songUseCase.ModifySong(r.Context(), songID, updates)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		if errors.Is(err, entity.ErrSongNotFound) {
+			logger.Debug(
+				"song not found",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, songNotFoundErrResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrNoFieldsToUpdate) {
+			logger.Debug(
+				"no fields to update",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, noFieldsToUpdateErrResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrLinkAlreadyExists) {
+			logger.Debug(
+				"link already exists",
+				slog.Any("songID", songID),
+				slog.Any("err", err),
+			)
+
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, linkAlreadyExistsResp)
+			return
+		}
+
+		logger.Debug(
+			"failed to modify song",
+			slog.Any("songID", songID),
+			slog.Any("err", err),
+		)
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug("song modified successfully", slog.Any("songID", song.ID))
+
+	h.renderSong(w, r, http.StatusOK, song)
+}
+
+// modifySongsWhere handles modifying the same fields of every song matching the filters.
+//
+//	@Summary		Modify songs matching filters
+//	@Description	Updates every song matching the filters with the fields of the body in a single statement. Without filters every song is modified, which must be confirmed with confirm=true.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			song				body		updateSongRequest	true	"Update Song"
+//	@Param			confirm				query		bool				false	"Confirm modifying all songs when no filters are provided"
+//	@Param			groupName			query		string				false	"Filter by group name (ignored when empty)"
+//	@Param			name				query		string				false	"Filter by song name (ignored when empty)"
+//	@Param			releaseYear			query		[]int				false	"Filter by release years, repeat to match any of several"	collectionFormat(multi)
+//	@Param			releaseDate			query		string				false	"Filter by exact release date (dd.MM.yyyy)"
+//	@Param			releaseDateAfter	query		string				false	"Filter songs released after the specified date (dd.MM.yyyy)"
+//	@Param			releaseDateBefore	query		string				false	"Filter songs released before the specified date (dd.MM.yyyy)"
+//	@Param			text				query		string				false	"Filter by song text (ignored when empty)"
+//	@Param			minTextLen			query		int					false	"Filter songs whose text has at least the specified number of characters"
+//	@Param			maxTextLen			query		int					false	"Filter songs whose text has at most the specified number of characters"
+//	@Param			detailStatus		query		string				false	"Filter by the outcome of the music info lookup"	Enums(found, not_found, failed)
+//	@Param			hasReleaseDate		query		bool				false	"Filter songs with (true) or without (false) a release date"
+//	@Success		200					{object}	songsUpdatedResponse
+//	@Failure		400					{object}	errorResponse
+//	@Failure		409					{object}	errorResponse
+//	@Failure		415					{object}	errorResponse
+//	@Failure		500					{object}	errorResponse
+//	@Failure		503					{object}	errorResponse
+//	@Router			/api/v1/songs [patch]
+func (h *songHandler) modifySongsWhere(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling modify songs where request")
+
+	if params := disallowedSongFilterParams(r, h.opts.AllowedFilters); len(params) > 0 {
+		logger.Debug("disallowed filters", slog.Any("params", params))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, disallowedFiltersError(params))
+		return
+	}
+
+	if h.opts.StrictFilters {
+		if params := emptySongFilterParams(r); len(params) > 0 {
+			logger.Debug("empty filter values", slog.Any("params", params))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyFilterValuesError(params))
+			return
+		}
+	}
+
+	filters := parseSongFilters(r, h.opts.DuplicateFilters)
+
+	if len(filters) == 0 {
+		if confirm, _ := strconv.ParseBool(r.URL.Query().Get("confirm")); !confirm {
+			logger.Debug("unfiltered update not confirmed")
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unfilteredUpdateNotConfirmedResp)
+			return
+		}
+	}
+
+	var req updateSongRequest
+
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
 
-	pagination := parsePagination(r)
-	filters := parseSongFilters(r)
+	if err := h.validate.Struct(req); err != nil {
+		logger.Debug("validation error", slog.Any("err", err))
 
-	logger.Debug(
-		"fetching songs",
-		slog.Any("pagination", pagination),
-		slog.Any("filters", filters),
-	)
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, validationError(err))
+		return
+	}
 
-	songs, pgn, err := h.songUseCase.FetchSongs(r.Context(), pagination, filters...)
+	updates := h.updateSongRequestToEntity(req)
+	if updates == (entity.Song{}) {
+		logger.Debug("no updatable fields provided")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, noFieldsToUpdateErrResp)
+		return
+	}
+
+	logger.Debug("songs modification", slog.Any("filters", filters))
+
+	updated, err := h.songUseCase.ModifySongsWhere(r.Context(), updates, filters...)
 	if err != nil {
 		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
 
-		logger.Debug("failed to fetch songs", slog.Any("err", err))
+		if errors.Is(err, entity.ErrNoFieldsToUpdate) {
+			logger.Debug("no fields to update", slog.Any("err", err))
 
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, serverErrResp)
-		return
-	}
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, noFieldsToUpdateErrResp)
+			return
+		}
 
-	logger.Debug("songs fetched successfully", slog.Uint64("items", pgn.Items))
+		if errors.Is(err, entity.ErrLinkAlreadyExists) {
+			logger.Debug("link already exists", slog.Any("err", err))
 
-	resp := songsResponse{
-		Songs:      make([]songSchema, 0),
-		Pagination: h.entityToPaginationSchema(pgn),
-	}
-	for _, song := range songs {
-		resp.Songs = append(resp.Songs, h.entityToSongSchema(song))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, linkAlreadyExistsResp)
+			return
+		}
+
+		logger.Debug("failed to modify songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
 	}
 
+	logger.Debug("songs modified successfully", slog.Int64("updated", updated))
+
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, resp)
+	render.JSON(w, r, songsUpdatedResponse{Updated: updated})
 }
 
-// fetchSongWithVerses handles fetching a song along with its verses by song ID.
+// modifySongReleaseDate handles updating only the release date of a song using its unique ID.
 //
-//	@Summary		Fetch a song with verses
-//	@Description	Retrieves a song along with its verses using the song ID
+//	@Summary		Modify a song's release date
+//	@Description	Updates only the release date of a song using the song ID
 //	@Tags			songs
 //	@Accept			json
 //	@Produce		json
-//	@Param			songID	path		string	true	"Song ID"
-//	@Param			limit	query		int		false	"Limit the number of verses"
-//	@Param			offset	query		int		false	"Offset for pagination"
-//	@Success		200		{object}	songWithVersesResponse
-//	@Failure		400		{object}	errorResponse
-//	@Failure		404		{object}	errorResponse
-//	@Failure		500		{object}	errorResponse
-//	@Router			/api/v1/songs/{songID}/text [get]
-func (h *songHandler) fetchSongWithVerses(w http.ResponseWriter, r *http.Request) {
+//	@Produce		application/hal+json
+//	@Param			songID		path		string						true	"Song ID"
+//	@Param			releaseDate	body		updateReleaseDateRequest	true	"Release date"
+//	@Param			Prefer		header		string						false	"Return preference"				Enums(return=representation, return=minimal)
+//	@Param			dateFormat	query		string						false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songSchema
+//	@Success		204			"Release date modified, returned with return=minimal"
+//	@Header			204			{string}	Location	"URL of the modified song"
+//	@Header			204			{string}	ETag		"Hash of the song representation"
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		415			{object}	errorResponse
+//	@Failure		422			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/release-date [patch]
+func (h *songHandler) modifySongReleaseDate(w http.ResponseWriter, r *http.Request) {
 	logger := h.prepareLogger(r.Context())
-	logger.Debug("handling fetch song with verses request")
+	logger.Debug("handling modify song release date request")
 
 	songIDParam := chi.URLParam(r, "songID")
 
-	songID, err := uuid.Parse(songIDParam)
+	songID, err := parseIDParam(songIDParam)
 	if err != nil {
 		logger.Debug(
 			"invalid song ID",
@@ -271,15 +3984,55 @@ func (h *songHandler) fetchSongWithVerses(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	pagination := parsePagination(r)
+	var req updateReleaseDateRequest
 
-	logger.Debug(
-		"fetching song with verses",
-		slog.Any("songID", songID),
-		slog.Any("pagination", pagination),
-	)
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
 
-	song, pgn, err := h.songUseCase.FetchSongWithVerses(r.Context(), songID, pagination)
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Debug("validation error", slog.Any("err", err))
+
+		status := http.StatusBadRequest
+
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) && validationErrs[0].Tag() == "releaseDate" {
+			status = http.StatusUnprocessableEntity
+		}
+
+		render.Status(r, status)
+		render.JSON(w, r, validationError(err))
+		return
+	}
+
+	releaseDate, _ := time.Parse("02.01.2006", req.ReleaseDate)
+
+	logger.Debug("song release date modification", slog.Any("songID", songID))
+
+	song, err := h.songUseCase.ModifySong(r.Context(), songID, entity.Song{
+		SongDetail: entity.SongDetail{ReleaseDate: releaseDate},
+	})
 	if err != nil {
 		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
 
@@ -296,48 +4049,50 @@ func (h *songHandler) fetchSongWithVerses(w http.ResponseWriter, r *http.Request
 		}
 
 		logger.Debug(
-			"failed to fetch song with verses",
+			"failed to modify song release date",
 			slog.Any("songID", songID),
 			slog.Any("err", err),
 		)
 
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, serverErrResp)
+		h.renderServerError(w, r, err)
 		return
 	}
 
-	logger.Debug("song with verses fetched successfully")
-
-	resp := songWithVersesResponse{
-		Song:       h.entityToSongWithVersesSchema(song),
-		Pagination: h.entityToPaginationSchema(pgn),
-	}
+	logger.Debug("song release date modified successfully", slog.Any("songID", song.ID))
 
-	render.Status(r, http.StatusOK)
-	render.JSON(w, r, resp)
+	h.renderSong(w, r, http.StatusOK, song)
 }
 
-// modifySong handles modifying a song's details using its unique ID.
+// reorderVerses handles rearranging the verses of a song using its unique ID.
 //
-//	@Summary		Modify a song
-//	@Description	Updates a song's information using the song ID
+//	@Summary		Reorder the verses of a song
+//	@Description	Rearranges the verses of a song, so that the verse at order[i] becomes the i-th verse. The text of the song is rewritten with its verses, separated by blank lines, in the new order.
 //	@Tags			songs
 //	@Accept			json
 //	@Produce		json
-//	@Param			songID	path		string				true	"Song ID"
-//	@Param			song	body		updateSongRequest	true	"Update Song"
-//	@Success		200		{object}	songSchema
-//	@Failure		400		{object}	errorResponse
-//	@Failure		404		{object}	errorResponse
-//	@Failure		500		{object}	errorResponse
-//	@Router			/api/v1/songs/{songID} [patch]
-func (h *songHandler) modifySong(w http.ResponseWriter, r *http.Request) {
+//	@Produce		application/hal+json
+//	@Param			songID		path		string					true	"Song ID"
+//	@Param			order		body		reorderVersesRequest	true	"Verse order"
+//	@Param			Prefer		header		string					false	"Return preference"				Enums(return=representation, return=minimal)
+//	@Param			dateFormat	query		string					false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	songSchema
+//	@Success		204			"Verses reordered, returned with return=minimal"
+//	@Header			204			{string}	Location	"URL of the modified song"
+//	@Header			204			{string}	ETag		"Hash of the song representation"
+//	@Failure		400			{object}	errorResponse
+//	@Failure		404			{object}	errorResponse
+//	@Failure		415			{object}	errorResponse
+//	@Failure		422			{object}	errorResponse	"Order isn't a permutation of the verse indices"
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/{songID}/text/order [put]
+func (h *songHandler) reorderVerses(w http.ResponseWriter, r *http.Request) {
 	logger := h.prepareLogger(r.Context())
-	logger.Debug("handling modify song request")
+	logger.Debug("handling reorder verses request")
 
 	songIDParam := chi.URLParam(r, "songID")
 
-	songID, err := uuid.Parse(songIDParam)
+	songID, err := parseIDParam(songIDParam)
 	if err != nil {
 		logger.Debug(
 			"invalid song ID",
@@ -350,9 +4105,9 @@ func (h *songHandler) modifySong(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req updateSongRequest
+	var req reorderVersesRequest
 
-	if err := render.DecodeJSON(r.Body, &req); err != nil {
+	if err := h.decodeJSON(r.Body, &req); err != nil {
 		if errors.Is(err, io.EOF) {
 			logger.Debug("empty request body", slog.Any("err", err))
 
@@ -361,6 +4116,15 @@ func (h *songHandler) modifySong(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
 		logger.Debug("invalid request body", slog.Any("err", err))
 
 		render.Status(r, http.StatusBadRequest)
@@ -376,9 +4140,9 @@ func (h *songHandler) modifySong(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Debug("song modification", slog.Any("songID", songID))
+	logger.Debug("song verses reordering", slog.Any("songID", songID), slog.Any("order", req.Order))
 
-	song, err := h.songUseCase.ModifySong(r.Context(), songID, h.updateSongRequestToEntity(req))
+	song, err := h.songUseCase.ReorderVerses(r.Context(), songID, req.Order)
 	if err != nil {
 		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
 
@@ -394,21 +4158,265 @@ func (h *songHandler) modifySong(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, entity.ErrNoVerses) {
+			logger.Debug("song has no verses", slog.Any("songID", songID))
+
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, noLyricsAvailableResp)
+			return
+		}
+
+		if errors.Is(err, entity.ErrInvalidVerseOrder) {
+			logger.Debug("invalid verse order", slog.Any("songID", songID), slog.Any("err", err))
+
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, invalidVerseOrderResp)
+			return
+		}
+
 		logger.Debug(
-			"failed to modify song",
+			"failed to reorder verses",
 			slog.Any("songID", songID),
 			slog.Any("err", err),
 		)
 
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, serverErrResp)
+		h.renderServerError(w, r, err)
 		return
 	}
 
-	logger.Debug("song modified successfully", slog.Any("songID", song.ID))
+	logger.Debug("song verses reordered successfully", slog.Any("songID", song.ID))
+
+	h.renderSong(w, r, http.StatusOK, song)
+}
+
+// modifySongsReleaseDates handles updating the release dates of several songs at once.
+//
+//	@Summary		Modify release dates of several songs
+//	@Description	Updates the release dates of several songs in a single transaction and reports the outcome of every item. Invalid items are reported and skipped.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			updates		body		[]releaseDateUpdateRequest	true	"Release date updates"
+//	@Param			dateFormat	query		string						false	"Release date output format"	Enums(eu, iso, us)
+//	@Success		200			{object}	releaseDatesUpdateResponse
+//	@Failure		400			{object}	errorResponse
+//	@Failure		415			{object}	errorResponse
+//	@Failure		500			{object}	errorResponse
+//	@Failure		503			{object}	errorResponse
+//	@Router			/api/v1/songs/release-dates [post]
+func (h *songHandler) modifySongsReleaseDates(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling modify songs release dates request")
+
+	var req []releaseDateUpdateRequest
+
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
+
+	if len(req) == 0 {
+		logger.Debug("no release date updates provided")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, noReleaseDateUpdatesResp)
+		return
+	}
+
+	maxUpdates := h.opts.ImportMaxRows
+	if maxUpdates <= 0 {
+		maxUpdates = defaultImportMaxRows
+	}
+
+	if len(req) > maxUpdates {
+		logger.Debug("too many release date updates", slog.Int("maxUpdates", maxUpdates))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, tooManyReleaseDateUpdatesResp)
+		return
+	}
+
+	results := make([]releaseDateUpdateResultSchema, len(req))
+	updates := make([]entity.SongUpdate, 0, len(req))
+	indexes := make([]int, 0, len(req))
+
+	for i, item := range req {
+		results[i] = releaseDateUpdateResultSchema{Index: i, ID: item.ID}
+
+		if err := h.validate.Struct(item); err != nil {
+			results[i].Status = releaseDateUpdateStatusInvalid
+			results[i].Details = getValidationErrorDetails(err)
+			continue
+		}
+
+		songID, _ := uuid.Parse(item.ID)
+		releaseDate, _ := time.Parse("02.01.2006", item.ReleaseDate)
+
+		updates = append(updates, entity.SongUpdate{
+			SongID: songID,
+			Song: entity.Song{
+				SongDetail: entity.SongDetail{ReleaseDate: releaseDate},
+			},
+		})
+		indexes = append(indexes, i)
+	}
+
+	if len(updates) > 0 {
+		logger.Debug("songs release dates modification", slog.Int("count", len(updates)))
+
+		songs, err := h.songUseCase.ModifySongs(r.Context(), updates)
+		if err != nil {
+			httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+			logger.Debug("failed to modify songs release dates", slog.Any("err", err))
+
+			h.renderServerError(w, r, err)
+			return
+		}
+
+		layout := dateLayout(r)
+
+		for i, song := range songs {
+			result := &results[indexes[i]]
+
+			if song == nil {
+				result.Status = releaseDateUpdateStatusNotFound
+				continue
+			}
+
+			songSchema := h.entityToSongSchema(song, layout)
+			result.Status = releaseDateUpdateStatusUpdated
+			result.Song = &songSchema
+		}
+	}
+
+	logger.Debug("songs release dates modified successfully", slog.Int("count", len(updates)))
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, releaseDatesUpdateResponse{Results: results})
+}
+
+// removeSongs handles deleting several songs by their unique IDs.
+//
+//	@Summary		Remove several songs
+//	@Description	Deletes several songs using the song IDs in a single transaction. IDs of songs that don't exist are reported separately.
+//	@Tags			songs
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		songsDeleteRequest	true	"Song IDs"
+//	@Success		200		{object}	songsDeletedResponse
+//	@Failure		400		{object}	errorResponse
+//	@Failure		415		{object}	errorResponse
+//	@Failure		500		{object}	errorResponse
+//	@Failure		503		{object}	errorResponse
+//	@Router			/api/v1/songs/batch-delete [post]
+func (h *songHandler) removeSongs(w http.ResponseWriter, r *http.Request) {
+	logger := h.prepareLogger(r.Context())
+	logger.Debug("handling remove songs request")
+
+	var req songsDeleteRequest
+
+	if err := h.decodeJSON(r.Body, &req); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Debug("empty request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, emptyRequestBodyResp)
+			return
+		}
+
+		var unknownFieldErr *unknownFieldError
+		if errors.As(err, &unknownFieldErr) {
+			logger.Debug("unknown field in request body", slog.Any("err", err))
+
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, unknownFieldErrorResp(unknownFieldErr))
+			return
+		}
+
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, invalidRequestBodyResp)
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		logger.Debug("no song ids provided")
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, noSongIDsResp)
+		return
+	}
+
+	if len(req.IDs) > maxSongsDeleteBatchSize {
+		logger.Debug("too many song ids", slog.Int("count", len(req.IDs)))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, tooManySongIDsToDeleteResp)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Debug("invalid request body", slog.Any("err", err))
+
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, validationError(err))
+		return
+	}
+
+	songIDs := make([]uuid.UUID, len(req.IDs))
+	for i, id := range req.IDs {
+		songIDs[i], _ = uuid.Parse(id)
+	}
+
+	logger.Debug("removing songs", slog.Int("count", len(songIDs)))
+
+	deleted, notFound, err := h.songUseCase.RemoveSongs(r.Context(), songIDs)
+	if err != nil {
+		httplog.LogEntrySetField(r.Context(), "err", slog.AnyValue(err))
+
+		logger.Debug("failed to remove songs", slog.Any("err", err))
+
+		h.renderServerError(w, r, err)
+		return
+	}
+
+	logger.Debug(
+		"songs removed successfully",
+		slog.Int("deleted", len(deleted)),
+		slog.Int("notFound", len(notFound)),
+	)
+
+	resp := songsDeletedResponse{
+		Deleted:     len(deleted),
+		NotFound:    len(notFound),
+		NotFoundIDs: make([]uuid.UUID, 0, len(notFound)),
+	}
+	resp.NotFoundIDs = append(resp.NotFoundIDs, notFound...)
 
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, h.entityToSongSchema(song))
+	render.JSON(w, r, resp)
 }
 
 // removeSong handles deleting a song by its unique ID.
@@ -421,6 +4429,7 @@ func (h *songHandler) modifySong(w http.ResponseWriter, r *http.Request) {
 //	@Failure		400		{object}	errorResponse
 //	@Failure		404		{object}	errorResponse
 //	@Failure		500		{object}	errorResponse
+//	@Failure		503		{object}	errorResponse
 //	@Router			/api/v1/songs/{songID} [delete]
 func (h *songHandler) removeSong(w http.ResponseWriter, r *http.Request) {
 	logger := h.prepareLogger(r.Context())
@@ -428,7 +4437,7 @@ func (h *songHandler) removeSong(w http.ResponseWriter, r *http.Request) {
 
 	songIDParam := chi.URLParam(r, "songID")
 
-	songID, err := uuid.Parse(songIDParam)
+	songID, err := parseIDParam(songIDParam)
 	if err != nil {
 		logger.Debug(
 			"invalid song ID",
@@ -465,13 +4474,20 @@ func (h *songHandler) removeSong(w http.ResponseWriter, r *http.Request) {
 			slog.Any("err", err),
 		)
 
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, serverErrResp)
+		h.renderServerError(w, r, err)
 		return
 	}
 
 	if removed > 1 {
-		logger.Error("removed more than one object", slog.Int64("removed", removed))
+		if h.opts.FailOnMultipleRemoved {
+			logger.Error("removed more than one object", slog.Any("songID", songID), slog.Int64("removed", removed))
+
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, serverErrResp)
+			return
+		}
+
+		logger.Warn("removed more than one object", slog.Any("songID", songID), slog.Int64("removed", removed))
 	}
 
 	w.WriteHeader(http.StatusNoContent)
@@ -1,19 +1,31 @@
 package http
 
 import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/go-chi/httplog/v2"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/pkg/servertiming"
 
 	httpMock "github.com/vadimbarashkov/online-song-library/mocks/http"
 )
@@ -23,12 +35,21 @@ var (
 	fixedTime = time.Now()
 )
 
+// halContentOpts makes httpexpect accept HAL+JSON response bodies as JSON.
+var halContentOpts = httpexpect.ContentOpts{MediaType: halMediaType}
+
 func setupServer(t testing.TB) (*httpexpect.Expect, *httpMock.MockSongUseCase) {
 	t.Helper()
 
+	return setupServerWithOptions(t, nil)
+}
+
+func setupServerWithOptions(t testing.TB, opts *RouterOptions) (*httpexpect.Expect, *httpMock.MockSongUseCase) {
+	t.Helper()
+
 	logger := httplog.NewLogger("", httplog.Options{Writer: io.Discard})
 	songUseCaseMock := httpMock.NewMockSongUseCase(t)
-	r := NewRouter(logger, songUseCaseMock, nil)
+	r := NewRouter(logger, songUseCaseMock, opts)
 
 	server := httptest.NewServer(r)
 	t.Cleanup(func() {
@@ -83,45 +104,41 @@ func TestSongHandler_AddSong(t *testing.T) {
 		resp.HasValue("message", invalidRequestBodyResp.Message)
 	})
 
-	t.Run("validation error", func(t *testing.T) {
-		e, _ := setupServer(t)
+	t.Run("unknown field in strict mode", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{StrictJSON: true})
 
 		resp := e.POST(path).
 			WithJSON(map[string]any{
-				"group": "Test Group",
+				"grup": "Test Group",
+				"song": "Test Song",
 			}).
 			Expect().
 			Status(http.StatusBadRequest).
 			JSON().Object()
 
 		resp.HasValue("status", statusError)
-		resp.ContainsKey("message")
-		resp.Value("details").Array().Length().IsEqual(1)
+		resp.HasValue("message", "unknown field in request body")
+		resp.Value("details").Array().IsEqual([]string{"grup"})
 	})
 
-	t.Run("server error", func(t *testing.T) {
-		e, songUseCaseMock := setupServer(t)
-
-		songUseCaseMock.
-			On("AddSong", mock.Anything, mock.Anything).
-			Once().
-			Return(nil, errors.New("unknown error"))
+	t.Run("unknown field in lenient mode", func(t *testing.T) {
+		e, _ := setupServer(t)
 
 		resp := e.POST(path).
 			WithJSON(map[string]any{
-				"group": "Test Group",
-				"song":  "Test Song",
+				"grup": "Test Group",
+				"song": "Test Song",
 			}).
 			Expect().
-			Status(http.StatusInternalServerError).
+			Status(http.StatusBadRequest).
 			JSON().Object()
 
 		resp.HasValue("status", statusError)
-		resp.HasValue("message", serverErrResp.Message)
+		resp.HasValue("message", "validation error")
 	})
 
-	t.Run("success", func(t *testing.T) {
-		e, songUseCaseMock := setupServer(t)
+	t.Run("success in strict mode", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{StrictJSON: true})
 
 		songUseCaseMock.
 			On("AddSong", mock.Anything, entity.Song{
@@ -133,246 +150,130 @@ func TestSongHandler_AddSong(t *testing.T) {
 				ID:        fixedUUID,
 				GroupName: "Test Group",
 				Name:      "Test Song",
-				SongDetail: entity.SongDetail{
-					ReleaseDate: fixedTime,
-					Text:        "Test Text",
-					Link:        "https://example.com",
-				},
 				CreatedAt: fixedTime,
 				UpdatedAt: fixedTime,
 			}, nil)
 
-		resp := e.POST(path).
+		e.POST(path).
 			WithJSON(map[string]any{
 				"group": "Test Group",
 				"song":  "Test Song",
 			}).
 			Expect().
-			Status(http.StatusCreated).
-			JSON().Object()
-
-		resp.HasValue("id", fixedUUID)
-		resp.HasValue("groupName", "Test Group")
-		resp.HasValue("name", "Test Song")
-		resp.Value("songDetail").Object().
-			HasValue("releaseDate", fixedTime.Format("02.01.2006")).
-			HasValue("text", "Test Text").
-			HasValue("link", "https://example.com")
-		resp.HasValue("created_at", fixedTime)
-		resp.HasValue("updated_at", fixedTime)
+			Status(http.StatusCreated)
 	})
-}
-
-func TestSongHandler_FetchSongs(t *testing.T) {
-	const path = "/api/v1/songs"
-
-	t.Run("server error", func(t *testing.T) {
-		e, songUseCaseMock := setupServer(t)
 
-		songUseCaseMock.
-			On("FetchSongs", mock.Anything, mock.Anything).
-			Once().
-			Return(nil, nil, errors.New("unknown error"))
+	t.Run("unsupported content type", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{StrictContentType: true})
 
-		resp := e.GET(path).
+		resp := e.POST(path).
+			WithText(`{"group":"Test Group","song":"Test Song"}`).
 			Expect().
-			Status(http.StatusInternalServerError).
+			Status(http.StatusUnsupportedMediaType).
 			JSON().Object()
 
 		resp.HasValue("status", statusError)
-		resp.HasValue("message", serverErrResp.Message)
-	})
-
-	t.Run("success", func(t *testing.T) {
-		e, songUseCaseMock := setupServer(t)
-
-		songUseCaseMock.
-			On("FetchSongs", mock.Anything, mock.Anything).
-			Once().
-			Return([]*entity.Song{
-				{
-					ID:        fixedUUID,
-					GroupName: "Test Group",
-					Name:      "Test Song",
-					SongDetail: entity.SongDetail{
-						ReleaseDate: fixedTime,
-						Text:        "Test Text",
-						Link:        "https://example.com",
-					},
-					CreatedAt: fixedTime,
-					UpdatedAt: fixedTime,
-				},
-			}, &entity.Pagination{
-				Offset: entity.DefaultOffset,
-				Limit:  entity.DefaultLimit,
-				Items:  1,
-				Total:  1,
-			}, nil)
-
-		resp := e.GET(path).
-			Expect().
-			Status(http.StatusOK).
-			JSON().Object()
-
-		songs := resp.Value("songs").Array()
-		song := songs.Value(0).Object()
-
-		songs.Length().IsEqual(1)
-		song.HasValue("id", fixedUUID)
-		song.HasValue("groupName", "Test Group")
-		song.HasValue("name", "Test Song")
-		song.Value("songDetail").Object().
-			HasValue("releaseDate", fixedTime.Format("02.01.2006")).
-			HasValue("text", "Test Text").
-			HasValue("link", "https://example.com")
-		song.HasValue("created_at", fixedTime)
-		song.HasValue("updated_at", fixedTime)
-
-		resp.Value("pagination").Object().
-			HasValue("offset", entity.DefaultOffset).
-			HasValue("limit", entity.DefaultLimit).
-			HasValue("items", 1).
-			HasValue("total", 1)
+		resp.HasValue("message", unsupportedMediaTypeResp.Message)
 	})
-}
-
-func TestSongHandler_FetchSongWithVerses(t *testing.T) {
-	const path = "/api/v1/songs/{songID}/text"
 
-	t.Run("invalid song id", func(t *testing.T) {
-		e, _ := setupServer(t)
+	t.Run("missing content type", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{StrictContentType: true})
 
-		resp := e.GET(path, "invalid uuid").
+		resp := e.POST(path).
+			WithBytes([]byte(`{"group":"Test Group","song":"Test Song"}`)).
 			Expect().
-			Status(http.StatusBadRequest).
+			Status(http.StatusUnsupportedMediaType).
 			JSON().Object()
 
 		resp.HasValue("status", statusError)
-		resp.HasValue("message", invalidSongIDParamResp.Message)
+		resp.HasValue("message", unsupportedMediaTypeResp.Message)
 	})
 
-	t.Run("song not found", func(t *testing.T) {
-		e, songUseCaseMock := setupServer(t)
+	t.Run("json content type with charset", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{StrictContentType: true})
 
 		songUseCaseMock.
-			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything).
+			On("AddSong", mock.Anything, entity.Song{
+				GroupName: "Test Group",
+				Name:      "Test Song",
+			}).
 			Once().
-			Return(nil, nil, entity.ErrSongNotFound)
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
 
-		resp := e.GET(path, fixedUUID).
+		e.POST(path).
+			WithHeader("Content-Type", "application/json; charset=utf-8").
+			WithBytes([]byte(`{"group":"Test Group","song":"Test Song"}`)).
 			Expect().
-			Status(http.StatusNotFound).
-			JSON().Object()
-
-		resp.HasValue("status", statusError)
-		resp.HasValue("message", songNotFoundErrResp.Message)
+			Status(http.StatusCreated)
 	})
 
-	t.Run("server error", func(t *testing.T) {
+	t.Run("song already exists", func(t *testing.T) {
 		e, songUseCaseMock := setupServer(t)
 
 		songUseCaseMock.
-			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything).
+			On("AddSong", mock.Anything, entity.Song{
+				GroupName: "Test Group",
+				Name:      "Test Song",
+			}).
 			Once().
-			Return(nil, nil, errors.New("unknown error"))
+			Return(nil, fmt.Errorf("usecase: %w", entity.ErrSongAlreadyExists))
 
-		resp := e.GET(path, fixedUUID).
+		e.POST(path).
+			WithJSON(map[string]any{"group": "Test Group", "song": "Test Song"}).
 			Expect().
-			Status(http.StatusInternalServerError).
-			JSON().Object()
+			Status(http.StatusConflict).
+			JSON().Object().IsEqual(songAlreadyExistsResp)
+	})
 
-		resp.HasValue("status", statusError)
-		resp.HasValue("message", serverErrResp.Message)
+	t.Run("invalid utf-8 body", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{RequireUTF8Body: true})
+
+		// "Beyoncé" encoded in latin-1, where é is the single byte 0xe9.
+		e.POST(path).
+			WithHeader("Content-Type", "application/json").
+			WithBytes([]byte("{\"group\":\"Beyonc\xe9\",\"song\":\"Halo\"}")).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidBodyEncodingResp)
 	})
 
-	t.Run("success", func(t *testing.T) {
-		e, songUseCaseMock := setupServer(t)
+	t.Run("valid utf-8 body", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{RequireUTF8Body: true})
 
 		songUseCaseMock.
-			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything).
+			On("AddSong", mock.Anything, entity.Song{
+				GroupName: "Beyoncé",
+				Name:      "Halo",
+			}).
 			Once().
-			Return(&entity.SongWithVerses{
+			Return(&entity.Song{
 				ID:        fixedUUID,
-				GroupName: "Test Group",
-				Name:      "Test Name",
-				Verses:    []string{"Line1\nLine2\n", "Line3\nLine4\n"},
+				GroupName: "Beyoncé",
+				Name:      "Halo",
 				CreatedAt: fixedTime,
 				UpdatedAt: fixedTime,
-			}, &entity.Pagination{
-				Offset: entity.DefaultOffset,
-				Limit:  entity.DefaultLimit,
-				Items:  2,
-				Total:  2,
 			}, nil)
 
-		resp := e.GET(path, fixedUUID).
-			Expect().
-			Status(http.StatusOK).
-			JSON().Object()
-
-		song := resp.Value("song").Object()
-
-		song.HasValue("id", fixedUUID)
-		song.HasValue("groupName", "Test Group")
-		song.HasValue("name", "Test Name")
-		song.Value("verses").Array().Length().IsEqual(2)
-		song.HasValue("created_at", fixedTime)
-		song.HasValue("updated_at", fixedTime)
-
-		resp.Value("pagination").Object().
-			HasValue("offset", entity.DefaultOffset).
-			HasValue("limit", entity.DefaultLimit).
-			HasValue("items", 2).
-			HasValue("total", 2)
-	})
-}
-
-func TestSongHandler_ModifySong(t *testing.T) {
-	const path = "/api/v1/songs/{songID}"
-
-	t.Run("invalid song id", func(t *testing.T) {
-		e, _ := setupServer(t)
-
-		resp := e.PATCH(path, "invalid uuid").
-			Expect().
-			Status(http.StatusBadRequest).
-			JSON().Object()
-
-		resp.HasValue("status", statusError)
-		resp.HasValue("message", invalidSongIDParamResp.Message)
-	})
-
-	t.Run("empty request body", func(t *testing.T) {
-		e, _ := setupServer(t)
-
-		resp := e.PATCH(path, fixedUUID).
-			Expect().
-			Status(http.StatusBadRequest).
-			JSON().Object()
-
-		resp.HasValue("status", statusError)
-		resp.HasValue("message", emptyRequestBodyResp.Message)
-	})
-
-	t.Run("invalid request body", func(t *testing.T) {
-		e, _ := setupServer(t)
-
-		resp := e.PATCH(path, fixedUUID).
-			WithJSON("invalid body").
+		e.POST(path).
+			WithHeader("Content-Type", "application/json").
+			WithBytes([]byte(`{"group":"Beyoncé","song":"Halo"}`)).
 			Expect().
-			Status(http.StatusBadRequest).
-			JSON().Object()
-
-		resp.HasValue("status", statusError)
-		resp.HasValue("message", invalidRequestBodyResp.Message)
+			Status(http.StatusCreated).
+			JSON().Object().HasValue("groupName", "Beyoncé")
 	})
 
 	t.Run("validation error", func(t *testing.T) {
 		e, _ := setupServer(t)
 
-		resp := e.PATCH(path, fixedUUID).
+		resp := e.POST(path).
 			WithJSON(map[string]any{
-				"link": "invalid url",
+				"group": "Test Group",
 			}).
 			Expect().
 			Status(http.StatusBadRequest).
@@ -383,90 +284,4769 @@ func TestSongHandler_ModifySong(t *testing.T) {
 		resp.Value("details").Array().Length().IsEqual(1)
 	})
 
-	t.Run("song not found", func(t *testing.T) {
+	t.Run("group song limit exceeded", func(t *testing.T) {
 		e, songUseCaseMock := setupServer(t)
 
 		songUseCaseMock.
-			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			On("AddSong", mock.Anything, mock.Anything).
 			Once().
-			Return(nil, entity.ErrSongNotFound)
+			Return(nil, fmt.Errorf("usecase.AddSong: failed to add song: %w", entity.ErrGroupSongLimitExceeded))
 
-		resp := e.PATCH(path, fixedUUID).
+		resp := e.POST(path).
 			WithJSON(map[string]any{
-				"text": "New Test Text",
-				"link": "https://new-example.com",
+				"group": "Test Group",
+				"song":  "Test Song",
 			}).
 			Expect().
-			Status(http.StatusNotFound).
+			Status(http.StatusUnprocessableEntity).
 			JSON().Object()
 
 		resp.HasValue("status", statusError)
-		resp.HasValue("message", songNotFoundErrResp.Message)
+		resp.HasValue("message", groupSongLimitExceededResp.Message)
 	})
 
-	t.Run("server error", func(t *testing.T) {
+	t.Run("too many verses", func(t *testing.T) {
 		e, songUseCaseMock := setupServer(t)
 
 		songUseCaseMock.
-			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			On("AddSong", mock.Anything, mock.Anything).
 			Once().
-			Return(nil, errors.New("unknown error"))
+			Return(nil, fmt.Errorf("usecase.AddSong: 40 verses, at most 30 allowed: %w", entity.ErrTooManyVerses))
 
-		resp := e.PATCH(path, fixedUUID).
+		e.POST(path).
 			WithJSON(map[string]any{
-				"text": "New Test Text",
-				"link": "https://new-example.com",
+				"group": "Test Group",
+				"song":  "Test Song",
 			}).
 			Expect().
-			Status(http.StatusInternalServerError).
-			JSON().Object()
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object().IsEqual(tooManyVersesResp)
+	})
+
+	t.Run("link already exists", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("AddSong", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, fmt.Errorf("usecase.AddSong: failed to add song: %w", entity.ErrLinkAlreadyExists))
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{
+				"group": "Test Group",
+				"song":  "Test Song",
+			}).
+			Expect().
+			Status(http.StatusConflict).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", linkAlreadyExistsResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("AddSong", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{
+				"group": "Test Group",
+				"song":  "Test Song",
+			}).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("AddSong", mock.Anything, entity.Song{
+				GroupName: "Test Group",
+				Name:      "Test Song",
+			}).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				SongDetail: entity.SongDetail{
+					ReleaseDate: fixedTime,
+					Text:        "Test Text",
+					Link:        "https://example.com",
+				},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				CreatedAt:    fixedTime,
+				UpdatedAt:    fixedTime,
+			}, nil)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{
+				"group": "Test Group",
+				"song":  "Test Song",
+			}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object()
+
+		resp.HasValue("id", fixedUUID)
+		resp.HasValue("groupName", "Test Group")
+		resp.HasValue("name", "Test Song")
+		resp.Value("songDetail").Object().
+			HasValue("releaseDate", fixedTime.Format("02.01.2006")).
+			HasValue("text", "Test Text").
+			HasValue("link", "https://example.com")
+		resp.HasValue("detailSource", "music_info_api")
+		resp.HasValue("created_at", fixedTime)
+		resp.HasValue("updated_at", fixedTime)
+	})
+
+	t.Run("prefer return representation", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("AddSong", mock.Anything, mock.Anything).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		resp := e.POST(path).
+			WithHeader("Prefer", "return=representation").
+			WithJSON(map[string]any{
+				"group": "Test Group",
+				"song":  "Test Song",
+			}).
+			Expect().
+			Status(http.StatusCreated)
+
+		resp.Header("Preference-Applied").IsEmpty()
+		resp.JSON().Object().HasValue("id", fixedUUID)
+	})
+
+	t.Run("prefer return minimal", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("AddSong", mock.Anything, mock.Anything).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		resp := e.POST(path).
+			WithHeader("Prefer", "respond-async, return=minimal").
+			WithJSON(map[string]any{
+				"group": "Test Group",
+				"song":  "Test Song",
+			}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		resp.Header("Location").IsEqual("/api/v1/songs/" + fixedUUID.String())
+		resp.Header("ETag").NotEmpty()
+		resp.Header("Preference-Applied").IsEqual("return=minimal")
+		resp.Body().IsEmpty()
+	})
+}
+
+func TestSongHandler_AddSong_WideNames(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	// Every rune takes 4 bytes in UTF-8, so the names are far longer than 255 bytes.
+	emojiGroup := strings.Repeat("🎸", 255)
+	cjkSong := strings.Repeat("𠜎", 255)
+
+	t.Run("names of 255 runes", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("AddSong", mock.Anything, entity.Song{
+				GroupName: emojiGroup,
+				Name:      cjkSong,
+			}).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: emojiGroup,
+				Name:      cjkSong,
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"group": emojiGroup, "song": cjkSong}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object()
+
+		resp.Value("groupName").IsEqual(emojiGroup)
+		resp.Value("name").IsEqual(cjkSong)
+	})
+
+	t.Run("names longer than 255 runes", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"group": emojiGroup + "🥁", "song": "東京" + cjkSong}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("message", "validation error")
+		resp.Value("details").Array().IsEqual([]string{"group: too long", "song: too long"})
+	})
+}
+
+func TestSongHandler_AddSong_ServerTiming(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	addSong := func(songUseCaseMock *httpMock.MockSongUseCase) {
+		songUseCaseMock.
+			On("AddSong", mock.Anything, mock.Anything).
+			Once().
+			Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				servertiming.Start(ctx, servertiming.MetricMusicInfo)()
+				servertiming.Start(ctx, servertiming.MetricDB)()
+			}).
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+		addSong(songUseCaseMock)
+
+		e.POST(path).
+			WithJSON(map[string]any{"group": "Test Group", "song": "Test Song"}).
+			Expect().
+			Status(http.StatusCreated).
+			Header("Server-Timing").IsEmpty()
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{ServerTiming: true})
+		addSong(songUseCaseMock)
+
+		e.POST(path).
+			WithJSON(map[string]any{"group": "Test Group", "song": "Test Song"}).
+			Expect().
+			Status(http.StatusCreated).
+			Header("Server-Timing").
+			Match(`^music-info;dur=\d+\.\d{2}, db;dur=\d+\.\d{2}, total;dur=\d+\.\d{2}$`)
+	})
+}
+
+func TestSongHandler_JSONKeyCase(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	fetchSongs := func(songUseCaseMock *httpMock.MockSongUseCase) {
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:        fixedUUID,
+					GroupName: "Queen",
+					Name:      "Bohemian Rhapsody",
+					SongDetail: entity.SongDetail{
+						ReleaseDate: time.Date(1975, time.October, 31, 0, 0, 0, 0, time.UTC),
+					},
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
+				},
+			}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+	}
+
+	t.Run("camel case", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{JSONKeyCase: KeyCaseCamel})
+		fetchSongs(songUseCaseMock)
+
+		song := e.GET(path).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Value("songs").Array().Value(0).Object()
+
+		song.Keys().ContainsOnly("id", "groupName", "name", "songDetail", "createdAt", "updatedAt")
+		song.Value("songDetail").Object().Keys().ContainsOnly("releaseDate")
+	})
+
+	t.Run("snake case from accept profile", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{JSONKeyCase: KeyCaseCamel})
+		fetchSongs(songUseCaseMock)
+
+		song := e.GET(path).
+			WithHeader("Accept", "application/json; profile=snake").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Value("songs").Array().Value(0).Object()
+
+		song.Keys().ContainsOnly("id", "group_name", "name", "song_detail", "created_at", "updated_at")
+		song.Value("song_detail").Object().Keys().ContainsOnly("release_date")
+	})
+}
+
+func TestSongHandler_PrettyJSON(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text/random"
+
+	compact := fmt.Sprintf(`{"status":%q,"message":%q}`+"\n", statusError, songNotFoundErrResp.Message)
+	indented := fmt.Sprintf("{\n  \"status\": %q,\n  \"message\": %q\n}\n", statusError, songNotFoundErrResp.Message)
+
+	fetchRandomVerse := func(songUseCaseMock *httpMock.MockSongUseCase) {
+		songUseCaseMock.
+			On("FetchRandomVerse", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+		fetchRandomVerse(songUseCaseMock)
+
+		e.GET(path, fixedUUID).
+			WithQuery("pretty", true).
+			Expect().
+			Status(http.StatusNotFound).
+			Body().IsEqual(compact)
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{PrettyJSON: true})
+		fetchRandomVerse(songUseCaseMock)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			Body().IsEqual(compact)
+	})
+
+	t.Run("requested", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{PrettyJSON: true})
+		fetchRandomVerse(songUseCaseMock)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("pretty", true).
+			Expect().
+			Status(http.StatusNotFound)
+
+		resp.Header("Content-Type").HasPrefix("application/json")
+		resp.Header("Content-Length").IsEqual(strconv.Itoa(len(indented)))
+		resp.Body().IsEqual(indented)
+	})
+}
+
+func TestSongHandler_MaxResponseSize(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text/random"
+
+	verse := &entity.Verse{Index: 0, Text: strings.Repeat("La la la\n", 100)}
+
+	t.Run("within limit", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{MaxResponseSize: 4096})
+
+		songUseCaseMock.
+			On("FetchRandomVerse", mock.Anything, fixedUUID).
+			Once().
+			Return(verse, nil)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().HasValue("verse", verse.Text)
+	})
+
+	t.Run("oversized response", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{MaxResponseSize: 512})
+
+		songUseCaseMock.
+			On("FetchRandomVerse", mock.Anything, fixedUUID).
+			Once().
+			Return(verse, nil)
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusRequestEntityTooLarge).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", responseTooLargeResp.Message)
+	})
+}
+
+func TestSongHandler_DeprecationHeaders(t *testing.T) {
+	opts := &RouterOptions{
+		DeprecatedRoutes: map[string]time.Time{
+			"DELETE /api/v1/songs/{songID}":          time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC),
+			"GET /api/v1/songs/{songID}/text/random": {},
+		},
+	}
+
+	t.Run("deprecated route with sunset", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, opts)
+
+		songUseCaseMock.
+			On("RemoveSong", mock.Anything, fixedUUID).
+			Once().
+			Return(int64(1), nil)
+
+		resp := e.DELETE("/api/v1/songs/{songID}", fixedUUID).
+			Expect().
+			Status(http.StatusNoContent)
+
+		resp.Header("Deprecation").IsEqual("true")
+		resp.Header("Sunset").IsEqual("Thu, 31 Dec 2026 00:00:00 GMT")
+	})
+
+	t.Run("deprecated route without sunset", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, opts)
+
+		songUseCaseMock.
+			On("FetchRandomVerse", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		resp := e.GET("/api/v1/songs/{songID}/text/random", fixedUUID).
+			Expect().
+			Status(http.StatusNotFound)
+
+		resp.Header("Deprecation").IsEqual("true")
+		resp.Header("Sunset").IsEmpty()
+	})
+
+	t.Run("other route", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, opts)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+			Once().
+			Return(nil, nil, entity.ErrSongNotFound)
+
+		resp := e.GET("/api/v1/songs/{songID}/text", fixedUUID).
+			Expect().
+			Status(http.StatusNotFound)
+
+		resp.Header("Deprecation").IsEmpty()
+		resp.Header("Sunset").IsEmpty()
+	})
+}
+
+func TestSongHandler_FetchImportTemplate(t *testing.T) {
+	const path = "/api/v1/songs/import/template"
+
+	t.Run("unsupported format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path).
+			WithQuery("format", "xlsx").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", unsupportedImportFormatResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path).
+			WithQuery("format", "csv").
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Header("Content-Type").IsEqual("text/csv; charset=utf-8")
+		resp.Header("Content-Disposition").IsEqual(`attachment; filename=songs-template.csv`)
+		resp.Body().IsEqual("group,song\nMuse,Supermassive Black Hole\n")
+	})
+}
+
+func TestSongHandler_ImportSongs(t *testing.T) {
+	const path = "/api/v1/songs/import"
+
+	importID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174009")
+
+	t.Run("unsupported format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithQuery("format", "xlsx").
+			WithMultipart().
+			WithFileBytes("file", "songs.csv", []byte("group,song\n")).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", unsupportedImportFormatResp.Message)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithMultipart().
+			WithFormField("group", "Test Group").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidImportFileResp.Message)
+	})
+
+	t.Run("file too large", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{ImportMaxFileSize: 64})
+
+		resp := e.POST(path).
+			WithMultipart().
+			WithFileBytes("file", "songs.csv", []byte("group,song\n"+strings.Repeat("Test Group,Test Song\n", 10))).
+			Expect().
+			Status(http.StatusRequestEntityTooLarge).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", importFileTooLargeResp.Message)
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{ImportMaxRows: 1})
+
+		resp := e.POST(path).
+			WithMultipart().
+			WithFileBytes("file", "songs.csv", []byte("group,song\nTest Group,Test Song 1\nTest Group,Test Song 2\n")).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", tooManyImportRowsResp.Message)
+	})
+
+	t.Run("no valid rows", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithMultipart().
+			WithFileBytes("file", "songs.csv", []byte("group,song\nTest Group,\n")).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.Value("details").Array().IsEqual([]string{"row 2: song: required field"})
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ImportSongs", mock.Anything, mock.Anything, true).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.POST(path).
+			WithMultipart().
+			WithFileBytes("file", "songs.csv", []byte("group,song\nTest Group,Test Song\n")).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success with malformed row", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ImportSongs", mock.Anything, []entity.Song{
+				{GroupName: "Test Group", Name: "Test Song 1"},
+				{GroupName: "Test Group", Name: "Test Song 2"},
+			}, false).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song 1", ImportID: importID, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song 2", ImportID: importID, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			}, nil)
+
+		data := "group,song\nTest Group,Test Song 1\nmalformed row\nTest Group,Test Song 2\nTest Group,\n"
+
+		resp := e.POST(path).
+			WithQuery("format", "csv").
+			WithQuery("fetchInfo", false).
+			WithMultipart().
+			WithFileBytes("file", "songs.csv", []byte(data)).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object()
+
+		resp.HasValue("importId", importID)
+		resp.Value("songs").Array().Length().IsEqual(2)
+
+		errs := resp.Value("errors").Array()
+		errs.Length().IsEqual(2)
+		errs.Value(0).Object().HasValue("row", 3)
+		errs.Value(1).Object().HasValue("row", 5)
+		errs.Value(1).Object().Value("details").Array().IsEqual([]string{"song: required field"})
+	})
+}
+
+func TestSongHandler_SearchSongs(t *testing.T) {
+	const path = "/api/v1/search"
+
+	t.Run("missing query", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path).
+			WithQuery("q", "  ").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(missingSearchQueryResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("SearchSongs", mock.Anything, "bohemian", entity.Pagination{Limit: entity.DefaultLimit}).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		e.GET(path).
+			WithQuery("q", "bohemian").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("ranked songs", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		nameMatchID := uuid.New()
+		groupMatchID := uuid.New()
+		textMatchID := uuid.New()
+
+		songUseCaseMock.
+			On("SearchSongs", mock.Anything, "bohemian", entity.Pagination{Limit: 3}).
+			Once().
+			Return([]*entity.Song{
+				{ID: nameMatchID, GroupName: "Queen", Name: "Bohemian Rhapsody", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+				{ID: groupMatchID, GroupName: "Bohemian Band", Name: "Song", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+				{ID: textMatchID, GroupName: "Other Group", Name: "Other Song", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			}, &entity.Pagination{Limit: 3, Items: 3, Total: 7}, nil)
+
+		resp := e.GET(path).
+			WithQuery("q", " bohemian ").
+			WithQuery("limit", 3).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+		songs.Length().IsEqual(3)
+		songs.Value(0).Object().HasValue("id", nameMatchID)
+		songs.Value(1).Object().HasValue("id", groupMatchID)
+		songs.Value(2).Object().HasValue("id", textMatchID)
+		resp.Value("pagination").Object().HasValue("total", 7)
+	})
+}
+
+func TestSongHandler_FetchImportSongs(t *testing.T) {
+	const path = "/api/v1/imports/{importId}/songs"
+
+	importID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174009")
+	filter := entity.SongFilter{Field: entity.SongImportFilterField, Value: importID}
+
+	t.Run("invalid import id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidImportIDParamResp)
+	})
+
+	t.Run("import not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, entity.Pagination{Limit: entity.DefaultLimit}, filter).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		e.GET(path, importID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(importNotFoundResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, entity.Pagination{Limit: entity.DefaultLimit}, filter).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		e.GET(path, importID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, entity.Pagination{Limit: 1}, filter).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song", ImportID: importID, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			}, &entity.Pagination{Limit: 1, Items: 1, Total: 3}, nil)
+
+		resp := e.GET(path, importID).
+			WithQuery("limit", 1).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+		songs.Length().IsEqual(1)
+		songs.Value(0).Object().HasValue("id", fixedUUID)
+		resp.Value("pagination").Object().HasValue("total", 3)
+	})
+}
+
+func TestSongHandler_RollbackImport(t *testing.T) {
+	const path = "/api/v1/imports/{importId}"
+
+	importID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174009")
+
+	t.Run("invalid import id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.DELETE(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidImportIDParamResp)
+	})
+
+	t.Run("import not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("RollbackImport", mock.Anything, importID).
+			Once().
+			Return(int64(0), fmt.Errorf("usecase: %w", entity.ErrImportNotFound))
+
+		e.DELETE(path, importID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(importNotFoundResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("RollbackImport", mock.Anything, importID).
+			Once().
+			Return(int64(0), errors.New("unknown error"))
+
+		e.DELETE(path, importID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("RollbackImport", mock.Anything, importID).
+			Once().
+			Return(int64(3), nil)
+
+		e.DELETE(path, importID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(importRolledBackResponse{ImportID: importID, Deleted: 3})
+	})
+}
+
+func TestSongHandler_FetchSongs(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	t.Run("empty filters ignored", func(t *testing.T) {
+		for _, param := range []string{"groupName", "name", "text"} {
+			t.Run(param, func(t *testing.T) {
+				e, songUseCaseMock := setupServer(t)
+
+				songUseCaseMock.
+					On("FetchSongs", mock.Anything, mock.Anything).
+					Once().
+					Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+				e.GET(path).
+					WithQuery(param, "").
+					Expect().
+					Status(http.StatusOK)
+			})
+		}
+	})
+
+	t.Run("duplicate filters", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{DuplicateFilters: DuplicateFiltersAll})
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything,
+				entity.SongFilter{Field: entity.SongNameFilterField, Value: "Stairway"},
+				entity.SongFilter{Field: entity.SongNameFilterField, Value: "Heaven"},
+			).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		e.GET(path).
+			WithQuery("name", "Stairway").
+			WithQuery("name", "Heaven").
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("storage unavailable", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, nil, fmt.Errorf("usecase.FetchSongs: %w", entity.ErrStorageUnavailable))
+
+		e.GET(path).
+			Expect().
+			Status(http.StatusServiceUnavailable).
+			JSON().Object().IsEqual(serviceUnavailableResp)
+	})
+
+	t.Run("offset out of range", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, entity.Pagination{Offset: 1000, Limit: entity.DefaultLimit}).
+			Once().
+			Return(nil, nil, fmt.Errorf("usecase.FetchSongs: %w", entity.ErrOffsetOutOfRange))
+
+		e.GET(path).
+			WithQuery("offset", 1000).
+			Expect().
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object().IsEqual(offsetOutOfRangeResp)
+	})
+
+	t.Run("multiple release years", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything, entity.SongFilter{
+				Field: entity.SongReleaseYearFilterField,
+				Value: []int{1968, 1969},
+			}).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		e.GET(path).
+			WithQuery("releaseYear", 1968).
+			WithQuery("releaseYear", 1969).
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("empty filters in strict mode", func(t *testing.T) {
+		for _, param := range []string{"groupName", "name", "text"} {
+			t.Run(param, func(t *testing.T) {
+				e, _ := setupServerWithOptions(t, &RouterOptions{StrictFilters: true})
+
+				resp := e.GET(path).
+					WithQuery(param, "").
+					Expect().
+					Status(http.StatusBadRequest).
+					JSON().Object()
+
+				resp.HasValue("status", statusError)
+				resp.HasValue("message", "empty filter values")
+				resp.Value("details").Array().ContainsOnly(param + ": empty value")
+			})
+		}
+	})
+
+	t.Run("detail status filter", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything, entity.SongFilter{
+				Field: entity.SongDetailStatusFilterField,
+				Value: entity.DetailStatusNotFound,
+			}).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:           fixedUUID,
+					GroupName:    "Unknown Group",
+					Name:         "Unknown Song",
+					DetailStatus: entity.DetailStatusNotFound,
+					CreatedAt:    fixedTime,
+					UpdatedAt:    fixedTime,
+				},
+			}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+
+		resp := e.GET(path).
+			WithQuery("detailStatus", "not_found").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		song := resp.Value("songs").Array().Value(0).Object()
+		song.HasValue("id", fixedUUID)
+		song.HasValue("detailStatus", "not_found")
+		song.NotContainsKey("songDetail")
+	})
+
+	t.Run("allowed filters", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AllowedFilters: []string{"groupName", "releaseYear"}})
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything,
+				entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Muse"},
+				entity.SongFilter{Field: entity.SongReleaseYearFilterField, Value: 2009},
+			).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		e.GET(path).
+			WithQuery("groupName", "Muse").
+			WithQuery("releaseYear", 2009).
+			WithQuery("limit", 5).
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("disallowed filters", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AllowedFilters: []string{"groupName"}})
+
+		resp := e.GET(path).
+			WithQuery("groupName", "Muse").
+			WithQuery("text", "uprising").
+			WithQuery("minTextLen", 10).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", "filters not allowed")
+		resp.Value("details").Array().IsEqual([]string{"text: filter not allowed", "minTextLen: filter not allowed"})
+	})
+
+	t.Run("non-empty filters in strict mode", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{StrictFilters: true})
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything, entity.SongFilter{
+				Field: entity.SongGroupNameFilterField,
+				Value: "Muse",
+			}).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		e.GET(path).
+			WithQuery("groupName", "Muse").
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:        fixedUUID,
+					GroupName: "Test Group",
+					Name:      "Test Song",
+					SongDetail: entity.SongDetail{
+						ReleaseDate: fixedTime,
+						Text:        "Test Text",
+						Link:        "https://example.com",
+					},
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
+				},
+			}, &entity.Pagination{
+				Offset: entity.DefaultOffset,
+				Limit:  entity.DefaultLimit,
+				Items:  1,
+				Total:  1,
+			}, nil)
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+		song := songs.Value(0).Object()
+
+		songs.Length().IsEqual(1)
+		song.HasValue("id", fixedUUID)
+		song.HasValue("groupName", "Test Group")
+		song.HasValue("name", "Test Song")
+		song.Value("songDetail").Object().
+			HasValue("releaseDate", fixedTime.Format("02.01.2006")).
+			HasValue("text", "Test Text").
+			HasValue("link", "https://example.com")
+		song.HasValue("created_at", fixedTime)
+		song.HasValue("updated_at", fixedTime)
+
+		resp.Value("pagination").Object().
+			HasValue("offset", entity.DefaultOffset).
+			HasValue("limit", entity.DefaultLimit).
+			HasValue("items", 1).
+			HasValue("total", 1)
+	})
+
+	t.Run("hal+json", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, entity.Pagination{Offset: 10, Limit: 10},
+				entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"},
+			).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, GroupName: "Queen", Name: "Bohemian Rhapsody"},
+			}, &entity.Pagination{Offset: 10, Limit: 10, Items: 1, Total: 25}, nil)
+
+		resp := e.GET(path).
+			WithHeader("Accept", "application/hal+json").
+			WithQuery("groupName", "Queen").
+			WithQuery("offset", 10).
+			WithQuery("limit", 10).
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Header("Content-Type").IsEqual("application/hal+json")
+
+		obj := resp.JSON(halContentOpts).Object()
+		obj.NotContainsKey("songs")
+		obj.Value("_links").Object().IsEqual(map[string]any{
+			"self": map[string]any{"href": "/api/v1/songs?groupName=Queen&limit=10&offset=10"},
+			"next": map[string]any{"href": "/api/v1/songs?groupName=Queen&limit=10&offset=20"},
+			"prev": map[string]any{"href": "/api/v1/songs?groupName=Queen&limit=10&offset=0"},
+		})
+
+		song := obj.Value("_embedded").Object().Value("songs").Array().Value(0).Object()
+		song.HasValue("id", fixedUUID.String())
+		song.HasValue("name", "Bohemian Rhapsody")
+		song.Value("_links").Object().IsEqual(map[string]any{
+			"self":   map[string]any{"href": "/api/v1/songs/" + fixedUUID.String()},
+			"verses": map[string]any{"href": "/api/v1/songs/" + fixedUUID.String() + "/text"},
+		})
+		obj.Value("pagination").Object().HasValue("total", 25)
+	})
+
+	t.Run("hal+json first and last page", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		links := e.GET(path).
+			WithHeader("Accept", "application/hal+json, application/json;q=0.9").
+			Expect().
+			Status(http.StatusOK).
+			JSON(halContentOpts).Object().Value("_links").Object()
+
+		links.Value("self").Object().HasValue("href", "/api/v1/songs")
+		links.NotContainsKey("next")
+		links.NotContainsKey("prev")
+	})
+
+	t.Run("plain json by default", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything).
+			Once().
+			Return([]*entity.Song{{ID: fixedUUID}}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Header("Content-Type").IsEqual("application/json")
+		resp.JSON().Object().NotContainsKey("_links")
+	})
+}
+
+func TestSongHandler_FetchSongs_Facets(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	groupFilter := entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Led"}
+
+	t.Run("invalid facet", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path).
+			WithQuery("facets", "releaseYear,unknown").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidFacetFieldResp.Message)
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.NotContainsKey("facets")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		songUseCaseMock.
+			On("FetchSongFacets", mock.Anything, []entity.FacetField{entity.FacetGroupNameField}).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.GET(path).
+			WithQuery("facets", "group").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, mock.Anything, groupFilter).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, GroupName: "Led Zeppelin", Name: "Kashmir", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 3}, nil)
+
+		songUseCaseMock.
+			On("FetchSongFacets", mock.Anything, []entity.FacetField{entity.FacetReleaseYearField, entity.FacetGroupNameField}, groupFilter).
+			Once().
+			Return(map[entity.FacetField][]entity.FacetCount{
+				entity.FacetReleaseYearField: {{Value: "1971", Count: 2}, {Value: "1975", Count: 1}},
+				entity.FacetGroupNameField:   {{Value: "Led Zeppelin", Count: 3}},
+			}, nil)
+
+		resp := e.GET(path).
+			WithQuery("groupName", "Led").
+			WithQuery("facets", "releaseYear,group,releaseYear").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("songs").Array().Length().IsEqual(1)
+
+		facets := resp.Value("facets").Object()
+
+		releaseYears := facets.Value("releaseYear").Array()
+		releaseYears.Length().IsEqual(2)
+		releaseYears.Value(0).Object().HasValue("value", "1971").HasValue("count", 2)
+		releaseYears.Value(1).Object().HasValue("value", "1975").HasValue("count", 1)
+
+		groups := facets.Value("group").Array()
+		groups.Length().IsEqual(1)
+		groups.Value(0).Object().HasValue("value", "Led Zeppelin").HasValue("count", 3)
+	})
+}
+
+func TestSongHandler_FetchSongs_DateFormat(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	releaseDate := time.Date(1971, time.November, 8, 0, 0, 0, 0, time.UTC)
+
+	t.Run("invalid date format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path).
+			WithQuery("dateFormat", "unix").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidDateFormatResp.Message)
+	})
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{name: "default", format: "", want: "08.11.1971"},
+		{name: "eu", format: "eu", want: "08.11.1971"},
+		{name: "iso", format: "iso", want: "1971-11-08"},
+		{name: "us", format: "us", want: "11/08/1971"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, songUseCaseMock := setupServer(t)
+
+			songUseCaseMock.
+				On("FetchSongs", mock.Anything, mock.Anything).
+				Once().
+				Return([]*entity.Song{
+					{
+						ID:        fixedUUID,
+						GroupName: "Led Zeppelin",
+						Name:      "Black Dog",
+						SongDetail: entity.SongDetail{
+							ReleaseDate: releaseDate,
+						},
+						CreatedAt: fixedTime,
+						UpdatedAt: fixedTime,
+					},
+				}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+
+			req := e.GET(path)
+			if tt.format != "" {
+				req = req.WithQuery("dateFormat", tt.format)
+			}
+
+			resp := req.
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object()
+
+			resp.Value("songs").Array().Value(0).Object().
+				Value("songDetail").Object().
+				HasValue("releaseDate", tt.want)
+		})
+	}
+}
+
+func TestSongHandler_FetchSongs_CountOnly(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CountSongs", mock.Anything).
+			Once().
+			Return(uint64(0), errors.New("unknown error"))
+
+		e.GET(path).
+			WithQuery("countOnly", "true").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CountSongs", mock.Anything, entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Led"}).
+			Once().
+			Return(uint64(42), nil)
+
+		resp := e.GET(path).
+			WithQuery("countOnly", "true").
+			WithQuery("groupName", "Led").
+			WithQuery("offset", 20).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("songs").Array().IsEmpty()
+		resp.Value("pagination").Object().IsEqual(map[string]any{
+			"offset": 20,
+			"limit":  entity.DefaultLimit,
+			"items":  0,
+			"total":  42,
+		})
+	})
+}
+
+func TestSongHandler_FetchSongs_PaginationAsStrings(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	tests := []struct {
+		name  string
+		opts  *RouterOptions
+		total uint64
+		want  map[string]any
+	}{
+		{
+			name:  "numbers by default",
+			opts:  nil,
+			total: 100,
+			want:  map[string]any{"offset": 0, "limit": 10, "items": 0, "total": 100},
+		},
+		{
+			name:  "strings when enabled",
+			opts:  &RouterOptions{PaginationAsStrings: true},
+			total: 1<<53 + 1,
+			want:  map[string]any{"offset": "0", "limit": "10", "items": "0", "total": "9007199254740993"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, songUseCaseMock := setupServerWithOptions(t, tt.opts)
+
+			songUseCaseMock.
+				On("FetchSongs", mock.Anything, mock.Anything).
+				Once().
+				Return([]*entity.Song{}, &entity.Pagination{Limit: 10, Total: tt.total}, nil)
+
+			e.GET(path).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object().
+				Value("pagination").Object().
+				IsEqual(tt.want)
+		})
+	}
+}
+
+func TestSongHandler_FetchSongs_WithTotal(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	tests := []struct {
+		name      string
+		opts      *RouterOptions
+		withTotal string
+		skipTotal bool
+		total     uint64
+		want      map[string]any
+	}{
+		{
+			name:      "counted by default",
+			opts:      nil,
+			skipTotal: false,
+			total:     100,
+			want:      map[string]any{"offset": 0, "limit": 10, "items": 1, "total": 100},
+		},
+		{
+			name:      "skipped when not requested",
+			opts:      nil,
+			withTotal: "false",
+			skipTotal: true,
+			want:      map[string]any{"offset": 0, "limit": 10, "items": 1},
+		},
+		{
+			name:      "skipped by default when only on request",
+			opts:      &RouterOptions{TotalOnRequest: true},
+			skipTotal: true,
+			want:      map[string]any{"offset": 0, "limit": 10, "items": 1},
+		},
+		{
+			name:      "counted when requested",
+			opts:      &RouterOptions{TotalOnRequest: true},
+			withTotal: "true",
+			skipTotal: false,
+			total:     100,
+			want:      map[string]any{"offset": 0, "limit": 10, "items": 1, "total": 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, songUseCaseMock := setupServerWithOptions(t, tt.opts)
+
+			songUseCaseMock.
+				On("FetchSongs", mock.Anything, mock.MatchedBy(func(p entity.Pagination) bool {
+					return p.SkipTotal == tt.skipTotal
+				})).
+				Once().
+				Return([]*entity.Song{{ID: fixedUUID}}, &entity.Pagination{
+					Limit:     10,
+					Items:     1,
+					Total:     tt.total,
+					SkipTotal: tt.skipTotal,
+				}, nil)
+
+			req := e.GET(path)
+			if tt.withTotal != "" {
+				req = req.WithQuery("withTotal", tt.withTotal)
+			}
+
+			req.Expect().
+				Status(http.StatusOK).
+				JSON().Object().
+				Value("pagination").Object().
+				IsEqual(tt.want)
+		})
+	}
+}
+
+func TestSongHandler_FetchSongsWithBrokenLinks(t *testing.T) {
+	const path = "/api/v1/songs/broken-links"
+
+	t.Run("offset out of range", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongsWithBrokenLinks", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, nil, entity.ErrOffsetOutOfRange)
+
+		e.GET(path).
+			WithQuery("offset", 1000).
+			Expect().
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object().IsEqual(offsetOutOfRangeResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongsWithBrokenLinks", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		e.GET(path).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongsWithBrokenLinks", mock.Anything, entity.Pagination{Offset: 0, Limit: 10}).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:         fixedUUID,
+					GroupName:  "Test Group",
+					Name:       "Test Song",
+					SongDetail: entity.SongDetail{Link: "www.example.com/song"},
+					CreatedAt:  fixedTime,
+					UpdatedAt:  fixedTime,
+				},
+			}, &entity.Pagination{Limit: 10, Items: 1, Total: 1}, nil)
+
+		resp := e.GET(path).
+			WithQuery("limit", 10).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+
+		songs.Length().IsEqual(1)
+		songs.Value(0).Object().Value("songDetail").Object().HasValue("link", "www.example.com/song")
+
+		resp.Value("pagination").Object().
+			HasValue("items", 1).
+			HasValue("total", 1)
+	})
+}
+
+func TestSongHandler_FetchIncompleteSongs(t *testing.T) {
+	const path = "/api/v1/songs/incomplete"
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchIncompleteSongs", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchIncompleteSongs", mock.Anything, mock.Anything, entity.SongFilter{
+				Field: entity.SongTextMissingFilterField,
+				Value: true,
+			}).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:        fixedUUID,
+					GroupName: "Test Group",
+					Name:      "Test Song",
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
+				},
+			}, &entity.Pagination{
+				Offset: entity.DefaultOffset,
+				Limit:  entity.DefaultLimit,
+				Items:  1,
+				Total:  1,
+			}, nil)
+
+		resp := e.GET(path).
+			WithQuery("missingText", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+
+		songs.Length().IsEqual(1)
+		songs.Value(0).Object().HasValue("id", fixedUUID)
+
+		resp.Value("pagination").Object().
+			HasValue("items", 1).
+			HasValue("total", 1)
+	})
+}
+
+func TestSongHandler_FetchRecentlyUpdatedSongs(t *testing.T) {
+	const path = "/api/v1/songs/recently-updated"
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchRecentlyUpdatedSongs", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		editedID := uuid.New()
+
+		songUseCaseMock.
+			On("FetchRecentlyUpdatedSongs", mock.Anything, entity.Pagination{Offset: 0, Limit: 2}).
+			Once().
+			Return([]*entity.Song{
+				{ID: editedID, CreatedAt: fixedTime, UpdatedAt: fixedTime.Add(time.Hour)},
+				{ID: fixedUUID, CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			}, &entity.Pagination{
+				Offset: 0,
+				Limit:  2,
+				Items:  2,
+				Total:  5,
+			}, nil)
+
+		resp := e.GET(path).
+			WithQuery("offset", 0).
+			WithQuery("limit", 2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+
+		songs.Length().IsEqual(2)
+		songs.Value(0).Object().HasValue("id", editedID)
+		songs.Value(1).Object().HasValue("id", fixedUUID)
+
+		resp.Value("pagination").Object().
+			HasValue("items", 2).
+			HasValue("total", 5)
+	})
+
+	t.Run("edited only", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchRecentlyUpdatedSongs", mock.Anything, mock.Anything, entity.SongFilter{
+				Field: entity.SongEditedFilterField,
+				Value: true,
+			}).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, CreatedAt: fixedTime, UpdatedAt: fixedTime.Add(time.Hour)},
+			}, &entity.Pagination{
+				Offset: entity.DefaultOffset,
+				Limit:  entity.DefaultLimit,
+				Items:  1,
+				Total:  1,
+			}, nil)
+
+		resp := e.GET(path).
+			WithQuery("editedOnly", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+
+		songs.Length().IsEqual(1)
+		songs.Value(0).Object().HasValue("id", fixedUUID)
+	})
+}
+
+func TestSongHandler_FetchRecentSongs(t *testing.T) {
+	const path = "/api/v1/songs/recent"
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchRecentSongs", mock.Anything, entity.DefaultRecentLimit).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("limit above cap", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchRecentSongs", mock.Anything, entity.MaxRecentLimit).
+			Once().
+			Return([]*entity.Song{}, nil)
+
+		resp := e.GET(path).
+			WithQuery("limit", 1000).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("songs").Array().IsEmpty()
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		newerID := uuid.New()
+
+		songUseCaseMock.
+			On("FetchRecentSongs", mock.Anything, uint64(2)).
+			Once().
+			Return([]*entity.Song{
+				{ID: newerID, GroupName: "Test Group", Name: "Test Song 2", CreatedAt: fixedTime.Add(time.Hour)},
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song 1", CreatedAt: fixedTime},
+			}, nil)
+
+		resp := e.GET(path).
+			WithQuery("limit", 2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+		songs.Length().IsEqual(2)
+		songs.Value(0).Object().HasValue("id", newerID)
+		songs.Value(1).Object().HasValue("id", fixedUUID)
+	})
+}
+
+func TestSongHandler_FetchSitemap(t *testing.T) {
+	const path = "/sitemap.xml"
+
+	type sitemap struct {
+		XMLName xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+		URLs    []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"url"`
+	}
+
+	type index struct {
+		XMLName  xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+		Sitemaps []struct {
+			Loc string `xml:"loc"`
+		} `xml:"sitemap"`
+	}
+
+	otherID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	t.Run("invalid page", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path).
+			WithQuery("page", "0").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSitemapPageResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CountSongs", mock.Anything).
+			Once().
+			Return(uint64(0), errors.New("unknown error"))
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("page not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CountSongs", mock.Anything).
+			Once().
+			Return(uint64(2), nil)
+
+		resp := e.GET(path).
+			WithQuery("page", "2").
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", sitemapPageNotFoundResp.Message)
+	})
+
+	t.Run("small catalog", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CountSongs", mock.Anything).
+			Once().
+			Return(uint64(2), nil)
+		songUseCaseMock.
+			On("WalkSongs", mock.Anything, mock.Anything).
+			Once().
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(song *entity.Song) error)
+				_ = fn(&entity.Song{ID: fixedUUID, UpdatedAt: fixedTime})
+				_ = fn(&entity.Song{ID: otherID, UpdatedAt: fixedTime.Add(time.Hour)})
+			}).
+			Return(nil)
+
+		body := e.GET(path).
+			WithHost("songs.example.com").
+			Expect().
+			Status(http.StatusOK).
+			HasContentType("application/xml").
+			Body().Raw()
+
+		assert.True(t, strings.HasPrefix(body, xml.Header))
+
+		var got sitemap
+		assert.NoError(t, xml.Unmarshal([]byte(body), &got))
+
+		if assert.Len(t, got.URLs, 2) {
+			assert.Equal(t, "http://songs.example.com/api/v1/songs/"+fixedUUID.String()+"/text", got.URLs[0].Loc)
+			assert.Equal(t, fixedTime.UTC().Format(time.RFC3339), got.URLs[0].LastMod)
+			assert.Equal(t, "http://songs.example.com/api/v1/songs/"+otherID.String()+"/text", got.URLs[1].Loc)
+			assert.Equal(t, fixedTime.Add(time.Hour).UTC().Format(time.RFC3339), got.URLs[1].LastMod)
+		}
+	})
+
+	t.Run("empty catalog", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CountSongs", mock.Anything).
+			Once().
+			Return(uint64(0), nil)
+		songUseCaseMock.
+			On("WalkSongs", mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+
+		body := e.GET(path).
+			Expect().
+			Status(http.StatusOK).
+			Body().Raw()
+
+		var got sitemap
+		assert.NoError(t, xml.Unmarshal([]byte(body), &got))
+		assert.Empty(t, got.URLs)
+	})
+
+	t.Run("sitemap index", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CountSongs", mock.Anything).
+			Once().
+			Return(uint64(2*sitemapMaxURLs+1), nil)
+
+		body := e.GET(path).
+			WithHost("songs.example.com").
+			Expect().
+			Status(http.StatusOK).
+			HasContentType("application/xml").
+			Body().Raw()
+
+		var got index
+		assert.NoError(t, xml.Unmarshal([]byte(body), &got))
+
+		if assert.Len(t, got.Sitemaps, 3) {
+			assert.Equal(t, "http://songs.example.com/sitemap.xml?page=1", got.Sitemaps[0].Loc)
+			assert.Equal(t, "http://songs.example.com/sitemap.xml?page=3", got.Sitemaps[2].Loc)
+		}
+	})
+}
+
+func TestSongHandler_FetchDecadeStats(t *testing.T) {
+	const path = "/api/v1/stats/decades"
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchDecadeStats", mock.Anything).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchDecadeStats", mock.Anything).
+			Once().
+			Return([]entity.DecadeCount{
+				{Decade: 1970, Count: 3},
+				{Decade: 1990, Count: 1},
+			}, nil)
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("decades").Array().IsEqual([]map[string]any{
+			{"decade": 1970, "count": 3},
+			{"decade": 1990, "count": 1},
+		})
+	})
+}
+
+func TestSongHandler_FetchAlphaIndex(t *testing.T) {
+	const path = "/api/v1/songs/alpha-index"
+
+	t.Run("invalid field", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path).
+			WithQuery("by", "year").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidAlphaIndexFieldResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchAlphaIndex", mock.Anything, entity.AlphaIndexGroupField).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		e.GET(path).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success by group", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchAlphaIndex", mock.Anything, entity.AlphaIndexGroupField).
+			Once().
+			Return([]entity.LetterCount{
+				{Letter: entity.AlphaIndexOther, Count: 1},
+				{Letter: "Q", Count: 2},
+			}, nil)
+
+		e.GET(path).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{
+			"by": "group",
+			"letters": []map[string]any{
+				{"letter": "#", "count": 1},
+				{"letter": "Q", "count": 2},
+			},
+		})
+	})
+
+	t.Run("success by song", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchAlphaIndex", mock.Anything, entity.AlphaIndexSongField).
+			Once().
+			Return([]entity.LetterCount{}, nil)
+
+		e.GET(path).
+			WithQuery("by", "song").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{
+			"by":      "song",
+			"letters": []any{},
+		})
+	})
+}
+
+func TestSongHandler_FetchGroupReleaseRange(t *testing.T) {
+	const path = "/api/v1/groups/{groupName}/range"
+
+	t.Run("group not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchGroupReleaseRange", mock.Anything, "Unknown Group").
+			Once().
+			Return(nil, entity.ErrGroupNotFound)
+
+		e.GET(path, "Unknown Group").
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(groupNotFoundErrResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchGroupReleaseRange", mock.Anything, "The Beatles").
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		e.GET(path, "The Beatles").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("invalid date format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, "The Beatles").
+			WithQuery("dateFormat", "unix").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidDateFormatResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchGroupReleaseRange", mock.Anything, "The Beatles").
+			Once().
+			Return(&entity.GroupReleaseRange{
+				GroupName: "The Beatles",
+				First:     entity.SongRelease{Name: "Love Me Do", ReleaseDate: time.Date(1962, time.October, 5, 0, 0, 0, 0, time.UTC)},
+				Last:      entity.SongRelease{Name: "Let It Be", ReleaseDate: time.Date(1970, time.May, 8, 0, 0, 0, 0, time.UTC)},
+			}, nil)
+
+		resp := e.GET(path, "The Beatles").
+			WithQuery("dateFormat", "iso").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("groupName", "The Beatles")
+		resp.Value("first").Object().HasValue("name", "Love Me Do").HasValue("releaseDate", "1962-10-05")
+		resp.Value("last").Object().HasValue("name", "Let It Be").HasValue("releaseDate", "1970-05-08")
+	})
+}
+
+func TestSongHandler_SetGroupReleaseDate(t *testing.T) {
+	const path = "/api/v1/groups/{groupName}/release-date"
+
+	releaseDate := time.Date(1975, time.October, 31, 0, 0, 0, 0, time.UTC)
+	filters := []any{
+		entity.SongFilter{Field: entity.SongGroupFilterField, Value: "Queen"},
+		entity.SongFilter{Field: entity.SongReleaseDateMissingFilterField, Value: true},
+	}
+
+	t.Run("empty request body", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.POST(path, "Queen").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(emptyRequestBodyResp)
+	})
+
+	t.Run("missing release date", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path, "Queen").
+			WithJSON(map[string]any{}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("message", "validation error")
+	})
+
+	t.Run("invalid release date format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path, "Queen").
+			WithJSON(map[string]any{"releaseDate": "1975-10-31"}).
+			Expect().
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object()
+
+		resp.HasValue("message", "validation error")
+		resp.Value("details").Array().IsEqual([]string{"releaseDate: invalid format, must be like '02.01.2006'"})
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySongsWhere", append([]any{mock.Anything, entity.Song{
+				SongDetail: entity.SongDetail{ReleaseDate: releaseDate},
+			}}, filters...)...).
+			Once().
+			Return(int64(0), errors.New("unknown error"))
+
+		e.POST(path, "Queen").
+			WithJSON(map[string]any{"releaseDate": "31.10.1975"}).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySongsWhere", append([]any{mock.Anything, entity.Song{
+				SongDetail: entity.SongDetail{ReleaseDate: releaseDate},
+			}}, filters...)...).
+			Once().
+			Return(int64(4), nil)
+
+		e.POST(path, "Queen").
+			WithJSON(map[string]any{"releaseDate": "31.10.1975"}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{"updated": 4})
+	})
+}
+
+func TestSongHandler_FetchGroupYearSongs(t *testing.T) {
+	const path = "/api/v1/groups/{groupName}/years/{year}/songs"
+
+	filters := []any{
+		entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "The Beatles"},
+		entity.SongFilter{Field: entity.SongReleaseYearFilterField, Value: 1969},
+	}
+
+	t.Run("invalid year", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, "The Beatles", "sixties").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidYearResp)
+	})
+
+	t.Run("no songs", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", append([]any{mock.Anything, entity.Pagination{Limit: entity.DefaultLimit}}, filters...)...).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Limit: entity.DefaultLimit}, nil)
+
+		e.GET(path, "The Beatles", 1969).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(groupYearSongsNotFoundResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", append([]any{mock.Anything, entity.Pagination{Limit: entity.DefaultLimit}}, filters...)...).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		e.GET(path, "The Beatles", 1969).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongs", append([]any{mock.Anything, entity.Pagination{Offset: 1, Limit: 1}}, filters...)...).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:        fixedUUID,
+					GroupName: "The Beatles",
+					Name:      "Something",
+					SongDetail: entity.SongDetail{
+						ReleaseDate: time.Date(1969, time.October, 6, 0, 0, 0, 0, time.UTC),
+					},
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
+				},
+			}, &entity.Pagination{Offset: 1, Limit: 1, Items: 1, Total: 2}, nil)
+
+		resp := e.GET(path, "The Beatles", 1969).
+			WithQuery("offset", 1).
+			WithQuery("limit", 1).
+			WithQuery("dateFormat", "iso").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+		songs.Length().IsEqual(1)
+		song := songs.Value(0).Object()
+		song.HasValue("name", "Something")
+		song.Value("songDetail").Object().HasValue("releaseDate", "1969-10-06")
+		resp.Value("pagination").Object().HasValue("total", 2)
+	})
+}
+
+func TestSongHandler_FetchGroupFacets(t *testing.T) {
+	const path = "/api/v1/groups/{groupName}/facets"
+
+	t.Run("group not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchGroupFacets", mock.Anything, "Unknown Group").
+			Once().
+			Return(nil, entity.ErrGroupNotFound)
+
+		resp := e.GET(path, "Unknown Group").
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", groupNotFoundErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchGroupFacets", mock.Anything, "Led Zeppelin").
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.GET(path, "Led Zeppelin").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchGroupFacets", mock.Anything, "Led Zeppelin").
+			Once().
+			Return(&entity.GroupFacets{
+				GroupName: "Led Zeppelin",
+				ReleaseYears: []entity.YearCount{
+					{Year: 1969, Count: 2},
+					{Year: 1971, Count: 8},
+				},
+			}, nil)
+
+		resp := e.GET(path, "Led Zeppelin").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("groupName", "Led Zeppelin")
+
+		years := resp.Value("releaseYears").Array()
+		years.Length().IsEqual(2)
+		years.Value(0).Object().HasValue("year", 1969).HasValue("count", 2)
+		years.Value(1).Object().HasValue("year", 1971).HasValue("count", 8)
+	})
+}
+
+func TestSongHandler_SuggestNames(t *testing.T) {
+	const path = "/api/v1/suggest"
+
+	t.Run("invalid field", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path).
+			WithQuery("field", "album").
+			WithQuery("q", "bea").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSuggestFieldResp.Message)
+	})
+
+	t.Run("query too short", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path).
+			WithQuery("field", "group").
+			WithQuery("q", "b").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", suggestQueryTooShortResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("SuggestNames", mock.Anything, entity.SuggestGroupNameField, "bea", entity.DefaultSuggestLimit).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.GET(path).
+			WithQuery("field", "group").
+			WithQuery("q", "bea").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("limit above cap", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("SuggestNames", mock.Anything, entity.SuggestSongNameField, "hey", entity.MaxSuggestLimit).
+			Once().
+			Return(nil, nil)
+
+		resp := e.GET(path).
+			WithQuery("field", "song").
+			WithQuery("q", "hey").
+			WithQuery("limit", 1000).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("suggestions").Array().IsEmpty()
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("SuggestNames", mock.Anything, entity.SuggestGroupNameField, "bea", uint64(2)).
+			Once().
+			Return([]string{"Beastie Boys", "Beatles"}, nil)
+
+		resp := e.GET(path).
+			WithQuery("field", "group").
+			WithQuery("q", "bea").
+			WithQuery("limit", 2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("suggestions").Array().IsEqual([]string{"Beastie Boys", "Beatles"})
+	})
+}
+
+func TestSongHandler_ExportSongs(t *testing.T) {
+	const path = "/api/v1/songs/export"
+
+	filter := entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"}
+
+	t.Run("invalid format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path).
+			WithQuery("format", "tar").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidExportFormatResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("WalkSongs", mock.Anything, mock.Anything, filter).
+			Twice().
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(song *entity.Song) error)
+				_ = fn(&entity.Song{
+					ID:        fixedUUID,
+					GroupName: "Queen",
+					Name:      "Bohemian Rhapsody",
+					SongDetail: entity.SongDetail{
+						ReleaseDate: time.Date(1975, time.October, 31, 0, 0, 0, 0, time.UTC),
+						Text:        "Is this the real life?\nIs this just fantasy?",
+					},
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
+				})
+				_ = fn(&entity.Song{ID: fixedUUID, GroupName: "Queen", Name: "Somebody to Love"})
+			}).
+			Return(nil)
+
+		resp := e.GET(path).
+			WithQuery("format", "zip").
+			WithQuery("groupName", "Queen").
+			WithQuery("dateFormat", "iso").
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Header("Content-Type").IsEqual("application/zip")
+		resp.Header("Content-Disposition").IsEqual(`attachment; filename=songs.zip`)
+
+		body := []byte(resp.Body().Raw())
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		assert.NoError(t, err)
+		assert.Len(t, archive.File, 2)
+
+		members := make(map[string][]byte)
+		for _, f := range archive.File {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+
+			content, err := io.ReadAll(rc)
+			assert.NoError(t, err)
+			_ = rc.Close()
+
+			members[f.Name] = content
+		}
+
+		records, err := csv.NewReader(bytes.NewReader(members["songs.csv"])).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			csvExportHeader,
+			{
+				"Queen", "Bohemian Rhapsody", fixedUUID.String(), "1975-10-31", "Is this the real life?\nIs this just fantasy?", "",
+				fixedTime.UTC().Format(time.RFC3339), fixedTime.UTC().Format(time.RFC3339),
+			},
+			{
+				"Queen", "Somebody to Love", fixedUUID.String(), "", "", "",
+				"0001-01-01T00:00:00Z", "0001-01-01T00:00:00Z",
+			},
+		}, records)
+
+		var songs []map[string]any
+		assert.NoError(t, json.Unmarshal(members["songs.json"], &songs))
+		assert.Len(t, songs, 2)
+		assert.Equal(t, "Bohemian Rhapsody", songs[0]["name"])
+		assert.Equal(t, map[string]any{
+			"releaseDate": "1975-10-31",
+			"text":        "Is this the real life?\nIs this just fantasy?",
+		}, songs[0]["songDetail"])
+		assert.Equal(t, "Somebody to Love", songs[1]["name"])
+	})
+}
+
+func TestSongHandler_ExportSong(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/export"
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSongIDParamResp.Message)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSong", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", songNotFoundErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSong", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSong", mock.Anything, fixedUUID).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "AC/DC",
+				Name:      "Highway to Hell",
+				SongDetail: entity.SongDetail{
+					ReleaseDate: fixedTime,
+					Text:        "Living easy, living free\n\nSeason ticket on a one-way ride",
+					Link:        "https://example.com",
+				},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		r := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK).
+			HasContentType("application/json")
+
+		r.Header("Content-Disposition").IsEqual(`attachment; filename="AC_DC - Highway to Hell.json"`)
+
+		resp := r.JSON().Object()
+
+		resp.HasValue("id", fixedUUID)
+		resp.HasValue("groupName", "AC/DC")
+		resp.HasValue("name", "Highway to Hell")
+		resp.Value("songDetail").Object().
+			HasValue("releaseDate", fixedTime.Format("02.01.2006")).
+			HasValue("text", "Living easy, living free\n\nSeason ticket on a one-way ride").
+			HasValue("link", "https://example.com")
+	})
+
+	t.Run("head song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSong", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		r := e.HEAD(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound)
+
+		r.Body().IsEmpty()
+		r.Headers().NotContainsKey("Etag")
+	})
+
+	t.Run("head success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSong", mock.Anything, fixedUUID).
+			Twice().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "AC/DC",
+				Name:      "Highway to Hell",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		get := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK)
+
+		head := e.HEAD(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK)
+
+		head.Body().IsEmpty()
+		head.Header("ETag").NotEmpty().IsEqual(get.Header("ETag").Raw())
+		head.Header("Last-Modified").IsEqual(fixedTime.UTC().Format(http.TimeFormat))
+		head.Header("Content-Length").IsEqual(strconv.Itoa(len(get.Body().Raw())))
+		head.Header("Content-Disposition").IsEqual(`attachment; filename="AC_DC - Highway to Hell.json"`)
+	})
+}
+
+func TestSongHandler_LastModified(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text"
+
+	updatedAt := time.Date(2024, time.March, 10, 12, 30, 45, 500_000_000, time.UTC)
+
+	fetchSongWithVerses := func(songUseCaseMock *httpMock.MockSongUseCase) {
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+			Once().
+			Return(&entity.SongWithVerses{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Name",
+				Verses:    []string{"Line1\nLine2\n"},
+				CreatedAt: updatedAt,
+				UpdatedAt: updatedAt,
+			}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+	}
+
+	t.Run("not modified", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+		fetchSongWithVerses(songUseCaseMock)
+
+		resp := e.GET(path, fixedUUID).
+			WithHeader("If-Modified-Since", "Sun, 10 Mar 2024 12:30:45 GMT").
+			Expect().
+			Status(http.StatusNotModified)
+
+		resp.Body().IsEmpty()
+		resp.Header("Last-Modified").IsEqual("Sun, 10 Mar 2024 12:30:45 GMT")
+		resp.Header("ETag").NotEmpty()
+	})
+
+	t.Run("modified since", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+		fetchSongWithVerses(songUseCaseMock)
+
+		resp := e.GET(path, fixedUUID).
+			WithHeader("If-Modified-Since", "Sun, 10 Mar 2024 12:30:44 GMT").
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Header("Last-Modified").IsEqual("Sun, 10 Mar 2024 12:30:45 GMT")
+		resp.JSON().Object().Value("song").Object().HasValue("name", "Test Name")
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+		fetchSongWithVerses(songUseCaseMock)
+
+		e.GET(path, fixedUUID).
+			WithHeader("If-Modified-Since", "yesterday").
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("if-none-match takes precedence", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+		fetchSongWithVerses(songUseCaseMock)
+
+		e.GET(path, fixedUUID).
+			WithHeader("If-Modified-Since", "Sun, 10 Mar 2024 12:30:45 GMT").
+			WithHeader("If-None-Match", `"abc"`).
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{NoLastModified: true})
+		fetchSongWithVerses(songUseCaseMock)
+
+		resp := e.GET(path, fixedUUID).
+			WithHeader("If-Modified-Since", "Sun, 10 Mar 2024 12:30:45 GMT").
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Headers().NotContainsKey("Last-Modified")
+	})
+}
+
+func TestSongHandler_FetchSongOEmbed(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/oembed"
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSongIDParamResp.Message)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSong", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", songNotFoundErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSong", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSong", mock.Anything, fixedUUID).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Guns N' Roses",
+				Name:      "Sweet Child <O> Mine",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			WithHost("songs.example.com").
+			Expect().
+			Status(http.StatusOK).
+			HasContentType("application/json").
+			JSON().Object()
+
+		resp.HasValue("version", "1.0")
+		resp.HasValue("type", "rich")
+		resp.HasValue("title", "Sweet Child <O> Mine")
+		resp.HasValue("author_name", "Guns N' Roses")
+		resp.HasValue("html", `<a href="http://songs.example.com/api/v1/songs/`+fixedUUID.String()+
+			`/text">Sweet Child &lt;O&gt; Mine - Guns N&#39; Roses</a>`)
+		resp.HasValue("width", oEmbedWidth)
+		resp.HasValue("height", oEmbedHeight)
+	})
+}
+
+func TestSongHandler_FetchSongWithVerses(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text"
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSongIDParamResp.Message)
+	})
+
+	t.Run("invalid granularity", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("granularity", "word").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidGranularityResp.Message)
+	})
+
+	t.Run("invalid verse format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, fixedUUID).
+			WithQuery("verseFormat", "object").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidVerseFormatResp)
+	})
+
+	t.Run("indexed verses across pages", func(t *testing.T) {
+		verses := []string{"Verse 1", "Verse 2", "Verse 3", "Verse 4", "Verse 5"}
+
+		pages := []struct {
+			offset  uint64
+			verses  []string
+			indices []int
+		}{
+			{offset: 0, verses: verses[0:2], indices: []int{0, 1}},
+			{offset: 2, verses: verses[2:4], indices: []int{2, 3}},
+			{offset: 4, verses: verses[4:5], indices: []int{4}},
+		}
+
+		for _, page := range pages {
+			e, songUseCaseMock := setupServer(t)
+
+			songUseCaseMock.
+				On("FetchSongWithVerses", mock.Anything, fixedUUID, entity.Pagination{Offset: page.offset, Limit: 2}, entity.VerseGranularity, false).
+				Once().
+				Return(&entity.SongWithVerses{
+					ID:        fixedUUID,
+					GroupName: "Test Group",
+					Name:      "Test Name",
+					Verses:    page.verses,
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
+				}, &entity.Pagination{Offset: page.offset, Limit: 2, Items: uint64(len(page.verses)), Total: 5}, nil)
+
+			resp := e.GET(path, fixedUUID).
+				WithQuery("offset", page.offset).
+				WithQuery("limit", 2).
+				WithQuery("verseFormat", "indexed").
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object()
+
+			got := resp.Value("song").Object().Value("verses").Array()
+			got.Length().IsEqual(len(page.verses))
+			for i, verse := range page.verses {
+				got.Value(i).Object().IsEqual(indexedVerseSchema{Index: page.indices[i], Text: verse})
+			}
+			resp.Value("pagination").Object().HasValue("offset", page.offset)
+		}
+	})
+
+	t.Run("plain verses by default", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, entity.Pagination{Offset: 2, Limit: 2}, entity.VerseGranularity, false).
+			Once().
+			Return(&entity.SongWithVerses{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Name",
+				Verses:    []string{"Verse 3", "Verse 4"},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, &entity.Pagination{Offset: 2, Limit: 2, Items: 2, Total: 5}, nil)
+
+		e.GET(path, fixedUUID).
+			WithQuery("offset", 2).
+			WithQuery("limit", 2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().
+			Value("song").Object().Value("verses").Array().IsEqual([]string{"Verse 3", "Verse 4"})
+	})
+
+	t.Run("line granularity", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, entity.Pagination{Offset: 2, Limit: 2}, entity.LineGranularity, false).
+			Once().
+			Return(&entity.SongWithVerses{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Name",
+				Verses:    []string{"Line3", "Line4"},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, &entity.Pagination{Offset: 2, Limit: 2, Items: 2, Total: 5}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("granularity", "line").
+			WithQuery("offset", 2).
+			WithQuery("limit", 2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("song").Object().Value("verses").Array().IsEqual([]string{"Line3", "Line4"})
+		resp.Value("pagination").Object().HasValue("items", 2).HasValue("total", 5)
+	})
+
+	t.Run("highlighted", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{HighlightPre: "[[", HighlightPost: "]]"})
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+			Once().
+			Return(&entity.SongWithVerses{
+				ID:     fixedUUID,
+				Verses: []string{"Mama, just killed a man", "Mamma mia & MAMA"},
+			}, &entity.Pagination{Limit: 20, Items: 2, Total: 2}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("q", "mama").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("song").Object().Value("verses").Array().IsEqual([]string{
+			"[[Mama]], just killed a man",
+			"Mamma mia &amp; [[MAMA]]",
+		})
+	})
+
+	t.Run("song without lyrics", func(t *testing.T) {
+		detailless := &entity.SongWithVerses{
+			ID:        fixedUUID,
+			GroupName: "Test Group",
+			Name:      "Test Name",
+			Verses:    []string{},
+			CreatedAt: fixedTime,
+			UpdatedAt: fixedTime,
+		}
+
+		t.Run("empty verses by default", func(t *testing.T) {
+			e, songUseCaseMock := setupServer(t)
+
+			songUseCaseMock.
+				On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+				Once().
+				Return(detailless, &entity.Pagination{Limit: 20}, nil)
+
+			resp := e.GET(path, fixedUUID).
+				Expect().
+				Status(http.StatusOK).
+				JSON().Object()
+
+			resp.Value("song").Object().Value("verses").Array().IsEmpty()
+			resp.Value("pagination").Object().HasValue("total", 0)
+		})
+
+		t.Run("not found when configured", func(t *testing.T) {
+			e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{NoLyricsNotFound: true})
+
+			songUseCaseMock.
+				On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+				Once().
+				Return(detailless, &entity.Pagination{Limit: 20}, nil)
+
+			resp := e.GET(path, fixedUUID).
+				Expect().
+				Status(http.StatusNotFound).
+				JSON().Object()
+
+			resp.HasValue("status", statusError)
+			resp.HasValue("message", noLyricsAvailableResp.Message)
+		})
+
+		t.Run("page past the end is not a missing text", func(t *testing.T) {
+			e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{NoLyricsNotFound: true})
+
+			songUseCaseMock.
+				On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+				Once().
+				Return(detailless, &entity.Pagination{Offset: 10, Limit: 20, Total: 3}, nil)
+
+			e.GET(path, fixedUUID).
+				WithQuery("offset", 10).
+				Expect().
+				Status(http.StatusOK)
+		})
+	})
+
+	t.Run("dedupe verses", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, true).
+			Once().
+			Return(&entity.SongWithVerses{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Name",
+				Verses:    []string{"Verse 1", "Chorus"},
+				Repeats:   []int{1, 3},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, &entity.Pagination{Limit: 20, Items: 2, Total: 2}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("dedupeVerses", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		song := resp.Value("song").Object()
+		song.Value("verses").Array().IsEqual([]string{"Verse 1", "Chorus"})
+		song.Value("repeats").Array().IsEqual([]int{1, 3})
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+			Once().
+			Return(nil, nil, entity.ErrSongNotFound)
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", songNotFoundErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+			Once().
+			Return(&entity.SongWithVerses{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Name",
+				Verses:    []string{"Line1\nLine2\n", "Line3\nLine4\n"},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, &entity.Pagination{
+				Offset: entity.DefaultOffset,
+				Limit:  entity.DefaultLimit,
+				Items:  2,
+				Total:  2,
+			}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		song := resp.Value("song").Object()
+
+		song.HasValue("id", fixedUUID)
+		song.HasValue("groupName", "Test Group")
+		song.HasValue("name", "Test Name")
+		song.Value("verses").Array().Length().IsEqual(2)
+		song.HasValue("created_at", fixedTime)
+		song.HasValue("updated_at", fixedTime)
+
+		resp.Value("pagination").Object().
+			HasValue("offset", entity.DefaultOffset).
+			HasValue("limit", entity.DefaultLimit).
+			HasValue("items", 2).
+			HasValue("total", 2)
+	})
+
+	t.Run("head song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+			Once().
+			Return(nil, nil, entity.ErrSongNotFound)
+
+		r := e.HEAD(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound)
+
+		r.Body().IsEmpty()
+		r.Headers().NotContainsKey("Etag")
+	})
+
+	t.Run("head success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongWithVerses", mock.Anything, fixedUUID, mock.Anything, entity.VerseGranularity, false).
+			Twice().
+			Return(&entity.SongWithVerses{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Name",
+				Verses:    []string{"Line1\nLine2\n", "Line3\nLine4\n"},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, &entity.Pagination{
+				Offset: entity.DefaultOffset,
+				Limit:  entity.DefaultLimit,
+				Items:  2,
+				Total:  2,
+			}, nil)
+
+		get := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK)
+
+		head := e.HEAD(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK)
+
+		head.Body().IsEmpty()
+		head.Header("ETag").NotEmpty().IsEqual(get.Header("ETag").Raw())
+		head.Header("Last-Modified").IsEqual(fixedTime.UTC().Format(http.TimeFormat))
+		head.Header("Content-Length").IsEqual(strconv.Itoa(len(get.Body().Raw())))
+	})
+}
+
+func TestSongHandler_FetchSongsWithVerses(t *testing.T) {
+	const path = "/api/v1/songs/text/batch"
+
+	t.Run("no song ids", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": []string{}}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", noSongIDsResp.Message)
+	})
+
+	t.Run("too many song ids", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		ids := make([]string, maxSongsWithVersesBatchSize+1)
+		for i := range ids {
+			ids[i] = uuid.NewString()
+		}
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": ids}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", tooManySongIDsResp.Message)
+	})
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": []string{fixedUUID.String(), "invalid uuid"}}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", "validation error")
+		resp.Value("details").Array().ContainsAll("ids[1]: invalid uuid")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongsWithVerses", mock.Anything, []uuid.UUID{fixedUUID}, mock.Anything).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": []string{fixedUUID.String()}}).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("partially found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		otherUUID := uuid.New()
+		pagination := entity.Pagination{Offset: 0, Limit: 1}
+
+		songUseCaseMock.
+			On("FetchSongsWithVerses", mock.Anything, []uuid.UUID{fixedUUID, otherUUID}, pagination).
+			Once().
+			Return([]entity.SongVersesPage{
+				{
+					Song: entity.SongWithVerses{
+						ID:        fixedUUID,
+						GroupName: "Test Group",
+						Name:      "Test Song",
+						Verses:    []string{"line1"},
+						CreatedAt: fixedTime,
+						UpdatedAt: fixedTime,
+					},
+					Pagination: entity.Pagination{Offset: 0, Limit: 1, Items: 1, Total: 2},
+				},
+			}, []uuid.UUID{otherUUID}, nil)
+
+		resp := e.POST(path).
+			WithQuery("offset", 0).
+			WithQuery("limit", 1).
+			WithJSON(map[string]any{"ids": []string{fixedUUID.String(), otherUUID.String()}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+		songs.Length().IsEqual(1)
+
+		item := songs.Value(0).Object()
+		item.Value("song").Object().
+			HasValue("id", fixedUUID).
+			HasValue("verses", []string{"line1"})
+		item.Value("pagination").Object().
+			HasValue("items", 1).
+			HasValue("total", 2)
+
+		resp.Value("notFound").Array().IsEqual([]uuid.UUID{otherUUID})
+	})
+}
+
+func TestSongHandler_FetchSimilarSongs(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/similar"
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSongIDParamResp.Message)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSimilarSongs", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(nil, nil, entity.ErrSongNotFound)
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", songNotFoundErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSimilarSongs", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		similarID := uuid.New()
+
+		songUseCaseMock.
+			On("FetchSimilarSongs", mock.Anything, fixedUUID, entity.Pagination{Offset: 0, Limit: 5}).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:        similarID,
+					GroupName: "Test Group",
+					Name:      "Other Song",
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
+				},
+			}, &entity.Pagination{Limit: 5, Items: 1, Total: 1}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("limit", 5).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		songs := resp.Value("songs").Array()
+		songs.Length().IsEqual(1)
+		songs.Value(0).Object().HasValue("id", similarID)
+		resp.Value("pagination").Object().HasValue("total", 1)
+	})
+}
+
+func TestSongHandler_SearchSongVerses(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text/search"
+
+	t.Run("empty query", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, fixedUUID).
+			WithQuery("q", " ").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(emptySearchQueryResp)
+	})
+
+	t.Run("invalid context", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, fixedUUID).
+			WithQuery("q", "chorus").
+			WithQuery("context", entity.MaxVerseMatchContext+1).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidVerseContextResp)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("SearchSongVerses", mock.Anything, fixedUUID, "chorus", 0).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		e.GET(path, fixedUUID).
+			WithQuery("q", "chorus").
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(songNotFoundErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("SearchSongVerses", mock.Anything, fixedUUID, "chorus", 1).
+			Once().
+			Return([]entity.VerseMatch{
+				{Index: 0, Start: 0, Verses: []string{"Chorus", "Verse"}},
+			}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("q", "chorus").
+			WithQuery("context", 1).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("songId", fixedUUID.String())
+		resp.HasValue("query", "chorus")
+		resp.Value("matches").Array().IsEqual([]map[string]any{
+			{"index": 0, "start": 0, "verses": []string{"Chorus", "Verse"}},
+		})
+	})
+
+	t.Run("highlighted", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("SearchSongVerses", mock.Anything, fixedUUID, "chorus", 1).
+			Once().
+			Return([]entity.VerseMatch{
+				{Index: 0, Start: 0, Verses: []string{"Chorus <b>chorus</b>", "Verse"}},
+			}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("q", "chorus").
+			WithQuery("context", 1).
+			WithQuery("highlight", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("matches").Array().IsEqual([]map[string]any{
+			{"index": 0, "start": 0, "verses": []string{"<em>Chorus</em> &lt;b&gt;<em>chorus</em>&lt;/b&gt;", "Verse"}},
+		})
+	})
+}
+
+func TestSongHandler_FetchTextStats(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text/stats"
+
+	t.Run("invalid song ID", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, "invalid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidSongIDParamResp)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchTextStats", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(songNotFoundErrResp)
+	})
+
+	t.Run("no text", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchTextStats", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, entity.ErrNoVerses)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(noLyricsAvailableResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchTextStats", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchTextStats", mock.Anything, fixedUUID).
+			Once().
+			Return(&entity.TextStats{Words: 22, Lines: 5, Verses: 3, UniqueWords: 20}, nil)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{
+			"songId":      fixedUUID,
+			"words":       22,
+			"lines":       5,
+			"verses":      3,
+			"uniqueWords": 20,
+		})
+	})
+}
+
+func TestSongHandler_FetchRandomVerse(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text/random"
+
+	t.Run("invalid song ID", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, "invalid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidSongIDParamResp)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchRandomVerse", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(songNotFoundErrResp)
+	})
+
+	t.Run("no verses", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchRandomVerse", mock.Anything, fixedUUID).
+			Once().
+			Return(nil, fmt.Errorf("usecase.FetchRandomVerse: %w", entity.ErrNoVerses))
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(noLyricsAvailableResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchRandomVerse", mock.Anything, fixedUUID).
+			Once().
+			Return(&entity.Verse{Index: 1, Text: "Chorus"}, nil)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{
+			"songId": fixedUUID.String(),
+			"index":  1,
+			"verse":  "Chorus",
+		})
+	})
+}
+
+func TestSongHandler_FetchSongLines(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text/lines"
+
+	song := &entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song", UpdatedAt: fixedTime}
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidSongIDParamResp)
+	})
+
+	t.Run("invalid blank lines", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, fixedUUID).
+			WithQuery("blankLines", "drop").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidBlankLinesResp)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path, fixedUUID).
+			WithQuery("format", "xml").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidLinesFormatResp)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongLines", mock.Anything, fixedUUID, false).
+			Once().
+			Return(nil, nil, entity.ErrSongNotFound)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(songNotFoundErrResp)
+	})
+
+	t.Run("json skipping blank lines", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongLines", mock.Anything, fixedUUID, false).
+			Once().
+			Return(song, []entity.NumberedLine{
+				{Number: 1, Text: "Line1"},
+				{Number: 2, Text: "Chorus"},
+			}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("id", fixedUUID.String())
+		resp.Value("lines").Array().IsEqual([]map[string]any{
+			{"lineNumber": 1, "text": "Line1"},
+			{"lineNumber": 2, "text": "Chorus"},
+		})
+	})
+
+	t.Run("text numbering blank lines", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("FetchSongLines", mock.Anything, fixedUUID, true).
+			Once().
+			Return(song, []entity.NumberedLine{
+				{Number: 1, Text: "Line1"},
+				{Number: 2, Text: ""},
+				{Number: 3, Text: "Chorus"},
+			}, nil)
+
+		resp := e.GET(path, fixedUUID).
+			WithQuery("blankLines", "number").
+			WithQuery("format", "text").
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Header("Content-Type").IsEqual("text/plain; charset=utf-8")
+		resp.Body().IsEqual("1: Line1\n2:\n3: Chorus\n")
+	})
+
+	t.Run("song without lyrics", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{NoLyricsNotFound: true})
+
+		songUseCaseMock.
+			On("FetchSongLines", mock.Anything, fixedUUID, false).
+			Once().
+			Return(song, []entity.NumberedLine{}, nil)
+
+		e.GET(path, fixedUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(noLyricsAvailableResp)
+	})
+}
+
+func TestSongHandler_CompareSongTexts(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text/diff/{otherSongID}"
+
+	otherUUID := uuid.New()
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path, "invalid uuid", otherUUID).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSongIDParamResp.Message)
+	})
+
+	t.Run("invalid other song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.GET(path, fixedUUID, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidOtherSongIDParamResp.Message)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CompareSongTexts", mock.Anything, fixedUUID, otherUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		resp := e.GET(path, fixedUUID, otherUUID).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", songNotFoundErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CompareSongTexts", mock.Anything, fixedUUID, otherUUID).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.GET(path, fixedUUID, otherUUID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("CompareSongTexts", mock.Anything, fixedUUID, otherUUID).
+			Once().
+			Return([]entity.LineDiff{
+				{Operation: entity.DiffUnchanged, Line: "Line1"},
+				{Operation: entity.DiffRemoved, Line: "Line2"},
+				{Operation: entity.DiffAdded, Line: "Line3"},
+			}, nil)
+
+		resp := e.GET(path, fixedUUID, otherUUID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("songId", fixedUUID)
+		resp.HasValue("otherSongId", otherUUID)
+
+		lines := resp.Value("lines").Array()
+		lines.Length().IsEqual(3)
+		lines.Value(0).Object().HasValue("operation", "unchanged").HasValue("line", "Line1")
+		lines.Value(1).Object().HasValue("operation", "removed").HasValue("line", "Line2")
+		lines.Value(2).Object().HasValue("operation", "added").HasValue("line", "Line3")
+	})
+}
+
+func TestSongHandler_PreviewVerses(t *testing.T) {
+	const path = "/api/v1/text/preview"
+
+	t.Run("empty request body", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", emptyRequestBodyResp.Message)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.Value("details").Array().Length().IsEqual(1)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("PreviewVerses", "Line1\n\nLine2").
+			Once().
+			Return([]string{"Line1", "Line2"})
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{
+				"text": "Line1\n\nLine2",
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("verses").Array().IsEqual([]string{"Line1", "Line2"})
+	})
+}
+
+func TestSongHandler_ValidateReleaseDate(t *testing.T) {
+	const path = "/api/v1/validate/release-date"
+
+	t.Run("empty request body", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", emptyRequestBodyResp.Message)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", "validation error")
+	})
+
+	t.Run("wrong format", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{
+				"releaseDate": "2006-06-19",
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("valid", false)
+		resp.NotContainsKey("releaseDate")
+	})
+
+	t.Run("impossible calendar date", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{
+				"releaseDate": "31.02.2006",
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("valid", false)
+		resp.NotContainsKey("releaseDate")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{
+				"releaseDate": "19.06.2006",
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("valid", true)
+		resp.HasValue("releaseDate", "2006-06-19")
+	})
+}
+
+func TestSongHandler_ModifySong(t *testing.T) {
+	const path = "/api/v1/songs/{songID}"
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{StrictContentType: true})
+
+		resp := e.PATCH(path, fixedUUID).
+			WithHeader("Content-Type", "application/x-www-form-urlencoded").
+			WithBytes([]byte(`{"song":"Test Song"}`)).
+			Expect().
+			Status(http.StatusUnsupportedMediaType).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", unsupportedMediaTypeResp.Message)
+	})
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSongIDParamResp.Message)
+	})
+
+	t.Run("empty request body", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path, fixedUUID).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", emptyRequestBodyResp.Message)
+	})
+
+	t.Run("invalid request body", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON("invalid body").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidRequestBodyResp.Message)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"link": "invalid url",
+			}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.ContainsKey("message")
+		resp.Value("details").Array().Length().IsEqual(1)
+	})
+
+	t.Run("empty update", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", noFieldsToUpdateErrResp.Message)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"text": "New Test Text",
+				"link": "https://new-example.com",
+			}).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", songNotFoundErrResp.Message)
+	})
+
+	t.Run("link already exists", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(nil, fmt.Errorf("usecase.ModifySong: failed to modify song: %w", entity.ErrLinkAlreadyExists))
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"link": "https://new-example.com",
+			}).
+			Expect().
+			Status(http.StatusConflict).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", linkAlreadyExistsResp.Message)
+	})
+
+	t.Run("no fields to update", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(nil, entity.ErrNoFieldsToUpdate)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"text": "New Test Text",
+				"link": "https://new-example.com",
+			}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", noFieldsToUpdateErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"text": "New Test Text",
+				"link": "https://new-example.com",
+			}).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, entity.Song{
+				SongDetail: entity.SongDetail{
+					Text: "New Test Text",
+					Link: "https://new-example.com",
+				},
+			}).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				SongDetail: entity.SongDetail{
+					ReleaseDate: fixedTime,
+					Text:        "New Test Text",
+					Link:        "https://new-example.com",
+				},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"text": "New Test Text",
+				"link": "https://new-example.com",
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("id", fixedUUID)
+		resp.HasValue("groupName", "Test Group")
+		resp.HasValue("name", "Test Song")
+		resp.Value("songDetail").Object().
+			HasValue("releaseDate", fixedTime.Format("02.01.2006")).
+			HasValue("text", "New Test Text").
+			HasValue("link", "https://new-example.com")
+		resp.HasValue("created_at", fixedTime)
+		resp.HasValue("updated_at", fixedTime)
+	})
+
+	t.Run("prefer return representation", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithHeader("Prefer", "return=representation").
+			WithJSON(map[string]any{
+				"text": "New Test Text",
+			}).
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Header("Preference-Applied").IsEmpty()
+		resp.JSON().Object().HasValue("id", fixedUUID)
+	})
+
+	t.Run("prefer return minimal", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithHeader("Prefer", "return=minimal").
+			WithJSON(map[string]any{
+				"text": "New Test Text",
+			}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		resp.Header("Location").IsEqual("/api/v1/songs/" + fixedUUID.String())
+		resp.Header("ETag").NotEmpty()
+		resp.Header("Preference-Applied").IsEqual("return=minimal")
+		resp.Body().IsEmpty()
+	})
+
+	t.Run("hal+json", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(&entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}, nil)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithHeader("Accept", "application/hal+json").
+			WithJSON(map[string]any{"name": "Test Song"}).
+			Expect().
+			Status(http.StatusOK)
+
+		resp.Header("Content-Type").IsEqual("application/hal+json")
+
+		obj := resp.JSON(halContentOpts).Object()
+		obj.HasValue("id", fixedUUID.String())
+		obj.Value("_links").Object().IsEqual(map[string]any{
+			"self":   map[string]any{"href": "/api/v1/songs/" + fixedUUID.String()},
+			"verses": map[string]any{"href": "/api/v1/songs/" + fixedUUID.String() + "/text"},
+		})
+	})
+}
+
+func TestSongHandler_ModifySongReleaseDate(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/release-date"
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path, "invalid uuid").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", invalidSongIDParamResp.Message)
+	})
+
+	t.Run("missing release date", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", "validation error")
+	})
+
+	t.Run("invalid release date", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"releaseDate": "1971-11-08",
+			}).
+			Expect().
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", "validation error")
+		resp.Value("details").Array().ContainsAll("releaseDate: invalid format, must be like '02.01.2006'")
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"releaseDate": "08.11.1971",
+			}).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", songNotFoundErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, mock.Anything).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"releaseDate": "08.11.1971",
+			}).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		releaseDate := time.Date(1971, time.November, 8, 0, 0, 0, 0, time.UTC)
+
+		songUseCaseMock.
+			On("ModifySong", mock.Anything, fixedUUID, entity.Song{
+				SongDetail: entity.SongDetail{
+					ReleaseDate: releaseDate,
+				},
+			}).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				SongDetail: entity.SongDetail{
+					ReleaseDate: releaseDate,
+					Text:        "Test Text",
+					Link:        "https://example.com",
+				},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		resp := e.PATCH(path, fixedUUID).
+			WithJSON(map[string]any{
+				"releaseDate": "08.11.1971",
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("id", fixedUUID)
+		resp.Value("songDetail").Object().
+			HasValue("releaseDate", "08.11.1971").
+			HasValue("text", "Test Text").
+			HasValue("link", "https://example.com")
+	})
+}
+
+func TestSongHandler_ReorderVerses(t *testing.T) {
+	const path = "/api/v1/songs/{songID}/text/order"
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.PUT(path, "invalid-id").
+			WithJSON(map[string]any{"order": []int{1, 0}}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidSongIDParamResp)
+	})
+
+	t.Run("missing order", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PUT(path, fixedUUID).
+			WithJSON(map[string]any{"order": []int{}}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", "validation error")
+	})
+
+	t.Run("invalid permutation", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ReorderVerses", mock.Anything, fixedUUID, []int{0, 0}).
+			Once().
+			Return(nil, fmt.Errorf("usecase.ReorderVerses: %w: index 0 repeated", entity.ErrInvalidVerseOrder))
+
+		e.PUT(path, fixedUUID).
+			WithJSON(map[string]any{"order": []int{0, 0}}).
+			Expect().
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object().IsEqual(invalidVerseOrderResp)
+	})
+
+	t.Run("no verses", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ReorderVerses", mock.Anything, fixedUUID, []int{0}).
+			Once().
+			Return(nil, fmt.Errorf("usecase.ReorderVerses: %w", entity.ErrNoVerses))
+
+		e.PUT(path, fixedUUID).
+			WithJSON(map[string]any{"order": []int{0}}).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(noLyricsAvailableResp)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ReorderVerses", mock.Anything, fixedUUID, []int{1, 0}).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		e.PUT(path, fixedUUID).
+			WithJSON(map[string]any{"order": []int{1, 0}}).
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(songNotFoundErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ReorderVerses", mock.Anything, fixedUUID, []int{1, 0}).
+			Once().
+			Return(&entity.Song{
+				ID:         fixedUUID,
+				GroupName:  "Test Group",
+				Name:       "Test Song",
+				SongDetail: entity.SongDetail{Text: "Chorus\n\nVerse"},
+				CreatedAt:  fixedTime,
+				UpdatedAt:  fixedTime,
+			}, nil)
+
+		e.PUT(path, fixedUUID).
+			WithJSON(map[string]any{"order": []int{1, 0}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().
+			Value("songDetail").Object().HasValue("text", "Chorus\n\nVerse")
+	})
+}
+
+func TestSongHandler_ModifySongsWhere(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	t.Run("unfiltered update not confirmed", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path).
+			WithJSON(map[string]any{"link": "https://example.com"}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", unfilteredUpdateNotConfirmedResp.Message)
+	})
+
+	t.Run("empty filter values are not filters", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path).
+			WithQuery("groupName", "").
+			WithJSON(map[string]any{"link": "https://example.com"}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", unfilteredUpdateNotConfirmedResp.Message)
+	})
+
+	t.Run("empty update", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.PATCH(path).
+			WithQuery("groupName", "Queen").
+			WithJSON(map[string]any{}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", noFieldsToUpdateErrResp.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySongsWhere", mock.Anything, mock.Anything, mock.Anything).
+			Once().
+			Return(int64(0), errors.New("unknown error"))
+
+		resp := e.PATCH(path).
+			WithQuery("groupName", "Queen").
+			WithJSON(map[string]any{"link": "https://example.com"}).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
 
 		resp.HasValue("status", statusError)
 		resp.HasValue("message", serverErrResp.Message)
 	})
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("filtered update", func(t *testing.T) {
 		e, songUseCaseMock := setupServer(t)
 
 		songUseCaseMock.
-			On("ModifySong", mock.Anything, fixedUUID, entity.Song{
-				SongDetail: entity.SongDetail{
-					Text: "New Test Text",
-					Link: "https://new-example.com",
+			On("ModifySongsWhere", mock.Anything,
+				entity.Song{SongDetail: entity.SongDetail{Link: "https://example.com"}},
+				entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"},
+			).
+			Once().
+			Return(int64(3), nil)
+
+		resp := e.PATCH(path).
+			WithQuery("groupName", "Queen").
+			WithJSON(map[string]any{"link": "https://example.com"}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("updated", 3)
+	})
+
+	t.Run("confirmed unfiltered update", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySongsWhere", mock.Anything,
+				entity.Song{SongDetail: entity.SongDetail{Link: "https://example.com"}},
+			).
+			Once().
+			Return(int64(42), nil)
+
+		resp := e.PATCH(path).
+			WithQuery("confirm", true).
+			WithJSON(map[string]any{"link": "https://example.com"}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("updated", 42)
+	})
+}
+
+func TestSongHandler_ModifySongsReleaseDates(t *testing.T) {
+	const path = "/api/v1/songs/release-dates"
+
+	t.Run("empty request body", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", emptyRequestBodyResp.Message)
+	})
+
+	t.Run("no updates", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON([]map[string]any{}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", noReleaseDateUpdatesResp.Message)
+	})
+
+	t.Run("too many updates", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{ImportMaxRows: 1})
+
+		resp := e.POST(path).
+			WithJSON([]map[string]any{
+				{"id": fixedUUID, "releaseDate": "08.11.1971"},
+				{"id": fixedUUID, "releaseDate": "08.11.1971"},
+			}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", tooManyReleaseDateUpdatesResp.Message)
+	})
+
+	t.Run("all invalid", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON([]map[string]any{
+				{"id": "invalid uuid", "releaseDate": "08.11.1971"},
+				{"id": fixedUUID, "releaseDate": "1971-11-08"},
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		results := resp.Value("results").Array()
+		results.Length().IsEqual(2)
+		results.Value(0).Object().
+			HasValue("index", 0).
+			HasValue("status", releaseDateUpdateStatusInvalid).
+			Value("details").Array().ContainsAll("id: invalid uuid")
+		results.Value(1).Object().
+			HasValue("index", 1).
+			HasValue("status", releaseDateUpdateStatusInvalid).
+			Value("details").Array().ContainsAll("releaseDate: invalid format, must be like '02.01.2006'")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("ModifySongs", mock.Anything, mock.Anything).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		resp := e.POST(path).
+			WithJSON([]map[string]any{
+				{"id": fixedUUID, "releaseDate": "08.11.1971"},
+			}).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("mixed valid and invalid", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		otherUUID := uuid.New()
+		releaseDate := time.Date(1971, time.November, 8, 0, 0, 0, 0, time.UTC)
+		otherReleaseDate := time.Date(1975, time.October, 31, 0, 0, 0, 0, time.UTC)
+
+		songUseCaseMock.
+			On("ModifySongs", mock.Anything, []entity.SongUpdate{
+				{
+					SongID: fixedUUID,
+					Song:   entity.Song{SongDetail: entity.SongDetail{ReleaseDate: releaseDate}},
+				},
+				{
+					SongID: otherUUID,
+					Song:   entity.Song{SongDetail: entity.SongDetail{ReleaseDate: otherReleaseDate}},
 				},
 			}).
 			Once().
-			Return(&entity.Song{
-				ID:        fixedUUID,
-				GroupName: "Test Group",
-				Name:      "Test Song",
-				SongDetail: entity.SongDetail{
-					ReleaseDate: fixedTime,
-					Text:        "New Test Text",
-					Link:        "https://new-example.com",
+			Return([]*entity.Song{
+				{
+					ID:         fixedUUID,
+					GroupName:  "Test Group",
+					Name:       "Test Song",
+					SongDetail: entity.SongDetail{ReleaseDate: releaseDate},
+					CreatedAt:  fixedTime,
+					UpdatedAt:  fixedTime,
 				},
-				CreatedAt: fixedTime,
-				UpdatedAt: fixedTime,
+				nil,
 			}, nil)
 
-		resp := e.PATCH(path, fixedUUID).
-			WithJSON(map[string]any{
-				"text": "New Test Text",
-				"link": "https://new-example.com",
+		resp := e.POST(path).
+			WithJSON([]map[string]any{
+				{"id": fixedUUID, "releaseDate": "08.11.1971"},
+				{"id": fixedUUID, "releaseDate": "31.02.1971"},
+				{"id": otherUUID, "releaseDate": "31.10.1975"},
+				{"releaseDate": "31.10.1975"},
 			}).
 			Expect().
 			Status(http.StatusOK).
 			JSON().Object()
 
-		resp.HasValue("id", fixedUUID)
-		resp.HasValue("groupName", "Test Group")
-		resp.HasValue("name", "Test Song")
-		resp.Value("songDetail").Object().
-			HasValue("releaseDate", fixedTime.Format("02.01.2006")).
-			HasValue("text", "New Test Text").
-			HasValue("link", "https://new-example.com")
-		resp.HasValue("created_at", fixedTime)
-		resp.HasValue("updated_at", fixedTime)
+		results := resp.Value("results").Array()
+		results.Length().IsEqual(4)
+
+		updated := results.Value(0).Object()
+		updated.HasValue("index", 0).
+			HasValue("id", fixedUUID).
+			HasValue("status", releaseDateUpdateStatusUpdated).
+			NotContainsKey("details")
+		updated.Value("song").Object().
+			HasValue("id", fixedUUID).
+			Value("songDetail").Object().HasValue("releaseDate", "08.11.1971")
+
+		results.Value(1).Object().
+			HasValue("index", 1).
+			HasValue("status", releaseDateUpdateStatusInvalid).
+			NotContainsKey("song").
+			Value("details").Array().ContainsAll("releaseDate: invalid format, must be like '02.01.2006'")
+
+		results.Value(2).Object().
+			HasValue("index", 2).
+			HasValue("id", otherUUID).
+			HasValue("status", releaseDateUpdateStatusNotFound).
+			NotContainsKey("song")
+
+		results.Value(3).Object().
+			HasValue("index", 3).
+			HasValue("status", releaseDateUpdateStatusInvalid).
+			Value("details").Array().ContainsAll("id: required field")
+	})
+}
+
+func TestSongHandler_OversizedSongID(t *testing.T) {
+	songID := strings.Repeat("f", 8<<10)
+	otherSongID := fixedUUID.String()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   errorResponse
+	}{
+		{name: "export", method: http.MethodGet, path: "/api/v1/songs/" + songID + "/export", want: invalidSongIDParamResp},
+		{name: "text", method: http.MethodGet, path: "/api/v1/songs/" + songID + "/text", want: invalidSongIDParamResp},
+		{name: "remove", method: http.MethodDelete, path: "/api/v1/songs/" + songID, want: invalidSongIDParamResp},
+		{
+			name:   "other song of diff",
+			method: http.MethodGet,
+			path:   "/api/v1/songs/" + otherSongID + "/text/diff/" + songID,
+			want:   invalidOtherSongIDParamResp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := setupServer(t)
+
+			e.Request(tt.method, tt.path).
+				Expect().
+				Status(http.StatusBadRequest).
+				JSON().Object().IsEqual(tt.want)
+		})
+	}
+}
+
+func TestSongHandler_RemoveSongs(t *testing.T) {
+	const path = "/api/v1/songs/batch-delete"
+
+	t.Run("no song ids", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": []string{}}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", noSongIDsResp.Message)
+	})
+
+	t.Run("too many song ids", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		ids := make([]string, maxSongsDeleteBatchSize+1)
+		for i := range ids {
+			ids[i] = uuid.NewString()
+		}
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": ids}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", tooManySongIDsToDeleteResp.Message)
+	})
+
+	t.Run("invalid song id", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": []string{fixedUUID.String(), "invalid uuid"}}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", "validation error")
+		resp.Value("details").Array().ContainsAll("ids[1]: invalid uuid")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("RemoveSongs", mock.Anything, []uuid.UUID{fixedUUID}).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": []string{fixedUUID.String()}}).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+
+	t.Run("partially found", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		otherUUID := uuid.New()
+
+		songUseCaseMock.
+			On("RemoveSongs", mock.Anything, []uuid.UUID{fixedUUID, otherUUID}).
+			Once().
+			Return([]uuid.UUID{fixedUUID}, []uuid.UUID{otherUUID}, nil)
+
+		resp := e.POST(path).
+			WithJSON(map[string]any{"ids": []string{fixedUUID.String(), otherUUID.String()}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.HasValue("deleted", 1)
+		resp.HasValue("notFound", 1)
+		resp.Value("notFoundIds").Array().IsEqual([]string{otherUUID.String()})
 	})
 }
 
@@ -531,4 +5111,148 @@ func TestSongHandler_RemoveSong(t *testing.T) {
 			Expect().
 			Status(http.StatusNoContent)
 	})
+
+	t.Run("multiple removed proceeds by default", func(t *testing.T) {
+		e, songUseCaseMock := setupServer(t)
+
+		songUseCaseMock.
+			On("RemoveSong", mock.Anything, fixedUUID).
+			Once().
+			Return(int64(2), nil)
+
+		e.DELETE(path, fixedUUID).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+
+	t.Run("multiple removed fails when configured", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{FailOnMultipleRemoved: true})
+
+		songUseCaseMock.
+			On("RemoveSong", mock.Anything, fixedUUID).
+			Once().
+			Return(int64(2), nil)
+
+		resp := e.DELETE(path, fixedUUID).
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object()
+
+		resp.HasValue("status", statusError)
+		resp.HasValue("message", serverErrResp.Message)
+	})
+}
+
+func TestSongHandler_StreamSongEvents(t *testing.T) {
+	const path = "/api/v1/songs/events"
+
+	connect := func(t *testing.T, opts *RouterOptions) (*httpMock.MockSongUseCase, func() (*bufio.Reader, context.CancelFunc)) {
+		t.Helper()
+
+		logger := httplog.NewLogger("", httplog.Options{Writer: io.Discard})
+		songUseCaseMock := httpMock.NewMockSongUseCase(t)
+
+		server := httptest.NewServer(NewRouter(logger, songUseCaseMock, opts))
+		t.Cleanup(server.Close)
+
+		return songUseCaseMock, func() (*bufio.Reader, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to connect to event stream: %v", err)
+			}
+			t.Cleanup(func() {
+				resp.Body.Close()
+			})
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+			assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+			return bufio.NewReader(resp.Body), cancel
+		}
+	}
+
+	readMessage := func(t *testing.T, r *bufio.Reader) []string {
+		t.Helper()
+
+		var lines []string
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatalf("Failed to read event stream: %v", err)
+			}
+
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				return lines
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	t.Run("receives events", func(t *testing.T) {
+		songUseCaseMock, dial := connect(t, nil)
+
+		events := make(chan entity.SongEvent, 1)
+		songUseCaseMock.
+			On("SubscribeSongEvents", mock.Anything).
+			Once().
+			Return((<-chan entity.SongEvent)(events))
+
+		r, cancel := dial()
+		defer cancel()
+
+		occurredAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		events <- entity.SongEvent{Type: entity.SongUpdatedEvent, SongID: fixedUUID, OccurredAt: occurredAt}
+
+		assert.Equal(t, []string{
+			"event: updated",
+			`data: {"type":"updated","songId":"` + fixedUUID.String() + `","occurredAt":"2024-01-01T00:00:00Z"}`,
+		}, readMessage(t, r))
+	})
+
+	t.Run("sends heartbeats", func(t *testing.T) {
+		songUseCaseMock, dial := connect(t, &RouterOptions{EventsHeartbeatInterval: 10 * time.Millisecond})
+
+		songUseCaseMock.
+			On("SubscribeSongEvents", mock.Anything).
+			Once().
+			Return((<-chan entity.SongEvent)(make(chan entity.SongEvent)))
+
+		r, cancel := dial()
+		defer cancel()
+
+		assert.Equal(t, []string{": heartbeat"}, readMessage(t, r))
+	})
+
+	t.Run("client disconnect cancels subscription", func(t *testing.T) {
+		songUseCaseMock, dial := connect(t, nil)
+
+		subscribed := make(chan context.Context, 1)
+		songUseCaseMock.
+			On("SubscribeSongEvents", mock.Anything).
+			Once().
+			Run(func(args mock.Arguments) {
+				subscribed <- args.Get(0).(context.Context)
+			}).
+			Return((<-chan entity.SongEvent)(make(chan entity.SongEvent)))
+
+		_, cancel := dial()
+		ctx := <-subscribed
+
+		cancel()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("subscription context was not canceled after the client disconnected")
+		}
+	})
 }
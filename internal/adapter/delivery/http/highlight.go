@@ -0,0 +1,54 @@
+package http
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Markers wrapped around highlighted matches when they are not set in RouterOptions.
+const (
+	defaultHighlightPre  = "<em>"
+	defaultHighlightPost = "</em>"
+)
+
+// highlight returns text with every occurrence of query, ignoring case, wrapped in the pre and post markers.
+// The text is HTML-escaped around the markers, so it can't inject markup when the result is rendered as HTML.
+// An empty query leaves nothing to mark, and the escaped text is returned.
+func highlight(text, query, pre, post string) string {
+	if query == "" {
+		return html.EscapeString(text)
+	}
+
+	re := regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+
+	var b strings.Builder
+	last := 0
+
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		b.WriteString(pre)
+		b.WriteString(html.EscapeString(text[loc[0]:loc[1]]))
+		b.WriteString(post)
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+
+	return b.String()
+}
+
+// highlightVerses returns a copy of verses with the occurrences of query wrapped in the highlight markers
+// configured in the router options, falling back to <em> and </em>.
+func (h *songHandler) highlightVerses(verses []string, query string) []string {
+	pre, post := h.opts.HighlightPre, h.opts.HighlightPost
+	if pre == "" && post == "" {
+		pre, post = defaultHighlightPre, defaultHighlightPost
+	}
+
+	highlighted := make([]string, len(verses))
+	for i, verse := range verses {
+		highlighted[i] = highlight(verse, query, pre, post)
+	}
+
+	return highlighted
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	t.Run("marks every occurrence ignoring case", func(t *testing.T) {
+		assert.Equal(t, "<em>Mama</em>, ooh, <em>MAMA</em>", highlight("Mama, ooh, MAMA", "mama", "<em>", "</em>"))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Equal(t, "Is this the real life?", highlight("Is this the real life?", "fantasy", "<em>", "</em>"))
+	})
+
+	t.Run("escapes text around markers", func(t *testing.T) {
+		assert.Equal(t, "&lt;script&gt;<em>alert</em>&lt;/script&gt;", highlight("<script>alert</script>", "alert", "<em>", "</em>"))
+	})
+
+	t.Run("escapes matched text", func(t *testing.T) {
+		assert.Equal(t, "Rock <em>&amp;</em> Roll", highlight("Rock & Roll", "&", "<em>", "</em>"))
+	})
+
+	t.Run("query with regexp metacharacters", func(t *testing.T) {
+		assert.Equal(t, "Real life<mark>?</mark>", highlight("Real life?", "?", "<mark>", "</mark>"))
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		assert.Equal(t, "Rock &amp; Roll", highlight("Rock & Roll", "", "<em>", "</em>"))
+	})
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// Casings the keys of JSON responses can be rewritten to.
+const (
+	KeyCaseCamel = "camel" // Keys such as created_at become createdAt.
+	KeyCaseSnake = "snake" // Keys such as groupName become group_name.
+)
+
+// jsonKeyCase rewrites the object keys of JSON responses to camelCase or snake_case, whatever the struct tags
+// of the response say. A request picks the casing with the profile parameter of its Accept header, such as
+// Accept: application/json; profile=snake, and falls back to defaultCase. Requests without either are left
+// unchanged. Only the keys naming schema fields are rewritten: neither values nor the keys of data maps,
+// such as facets (see dataMapFields) or responses marked with keepJSONKeys, are changed.
+func jsonKeyCase(defaultCase string) jsonTransformer {
+	return func(w http.ResponseWriter, r *http.Request) jsonTransform {
+		addVary(w.Header(), "Accept")
+
+		convert := keyConverter(requestedKeyCase(r, defaultCase))
+		if convert == nil {
+			return nil
+		}
+
+		return func(resp *jsonResponse) {
+			if resp.keepKeys {
+				return
+			}
+
+			// Bodies that aren't valid JSON, such as the empty body of a HEAD response, are left unchanged.
+			if rewritten, err := rewriteJSONKeys(resp.body, convert); err == nil {
+				resp.body = rewritten
+			}
+		}
+	}
+}
+
+// requestedKeyCase returns the casing named by the profile parameter of the first media range
+// of the Accept header that has one, or defaultCase.
+func requestedKeyCase(r *http.Request, defaultCase string) string {
+	for _, mediaRange := range strings.Split(r.Header.Get("Accept"), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+
+		if profile, ok := params["profile"]; ok {
+			return profile
+		}
+	}
+
+	return defaultCase
+}
+
+// keyConverter returns the function converting keys to the casing, or nil for unknown casings.
+func keyConverter(keyCase string) func(string) string {
+	switch keyCase {
+	case KeyCaseCamel:
+		return camelCase
+	case KeyCaseSnake:
+		return snakeCase
+	default:
+		return nil
+	}
+}
+
+// dataMapFields are the JSON fields of the response schemas holding maps, whose keys are data, such as the names
+// of facet fields, rather than field names. Their values are copied by rewriteJSONKeys as they are.
+var dataMapFields = map[string]bool{
+	"facets": true,
+}
+
+// rewriteJSONKeys converts every object key of the JSON document with convert, except within dataMapFields,
+// keeping the order of the keys and the trailing newline written by the encoder.
+func rewriteJSONKeys(body []byte, convert func(string) string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := rewriteJSONValue(dec, &buf, convert); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+
+	if bytes.HasSuffix(body, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
+
+// rewriteJSONValue copies the next value of the decoder to buf, converting the keys of the objects in it.
+func rewriteJSONValue(dec *json.Decoder, buf *bytes.Buffer, convert func(string) string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return writeJSONToken(buf, tok)
+	}
+
+	switch delim {
+	case '{':
+		buf.WriteByte('{')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			keyTok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := keyTok.(string)
+			if !ok {
+				return errors.New("object key is not a string")
+			}
+
+			if err := writeJSONToken(buf, convert(key)); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+
+			if dataMapFields[key] {
+				var raw json.RawMessage
+				if err := dec.Decode(&raw); err != nil {
+					return err
+				}
+				if err := json.Compact(buf, raw); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := rewriteJSONValue(dec, buf, convert); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case '[':
+		buf.WriteByte('[')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := rewriteJSONValue(dec, buf, convert); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		return errors.New("unexpected closing delimiter")
+	}
+
+	// Consume the closing delimiter.
+	_, err = dec.Token()
+	return err
+}
+
+// writeJSONToken writes a scalar token, encoding it like render.JSON does.
+func writeJSONToken(buf *bytes.Buffer, tok json.Token) error {
+	b, err := json.Marshal(tok)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(b)
+	return nil
+}
+
+// camelCase converts a snake_case key to camelCase, such as created_at to createdAt.
+// Leading underscores, as in the _links of HAL responses, are kept.
+func camelCase(key string) string {
+	trimmed := strings.TrimLeft(key, "_")
+
+	var b strings.Builder
+	b.WriteString(key[:len(key)-len(trimmed)])
+
+	upper := false
+	for _, r := range trimmed {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// snakeCase converts a camelCase key to snake_case, such as groupName to group_name.
+// A run of capitals is a single word, so HTTPServer becomes http_server.
+func snakeCase(key string) string {
+	runes := []rune(key)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' &&
+				(!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
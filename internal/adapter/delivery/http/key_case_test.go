@@ -0,0 +1,122 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCamelCase(t *testing.T) {
+	assert.Equal(t, "createdAt", camelCase("created_at"))
+	assert.Equal(t, "releaseDateAfter", camelCase("release_date_after"))
+	assert.Equal(t, "groupName", camelCase("groupName"))
+	assert.Equal(t, "_links", camelCase("_links"))
+	assert.Equal(t, "_embeddedSongs", camelCase("_embedded_songs"))
+}
+
+func TestSnakeCase(t *testing.T) {
+	assert.Equal(t, "group_name", snakeCase("groupName"))
+	assert.Equal(t, "not_found_ids", snakeCase("notFoundIds"))
+	assert.Equal(t, "created_at", snakeCase("created_at"))
+	assert.Equal(t, "http_server", snakeCase("HTTPServer"))
+	assert.Equal(t, "_links", snakeCase("_links"))
+}
+
+func TestRewriteJSONKeys(t *testing.T) {
+	t.Run("nested objects and arrays", func(t *testing.T) {
+		body, err := rewriteJSONKeys([]byte(`{"songs":[{"groupName":"Queen","songDetail":{"releaseDate":"1975"}}],"total":1.5}`+"\n"), snakeCase)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"songs":[{"group_name":"Queen","song_detail":{"release_date":"1975"}}],"total":1.5}`+"\n", string(body))
+	})
+
+	t.Run("values are kept", func(t *testing.T) {
+		body, err := rewriteJSONKeys([]byte(`{"text":"groupName","list":["created_at",null,true,12345678901234567890]}`), camelCase)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"text":"groupName","list":["created_at",null,true,12345678901234567890]}`, string(body))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := rewriteJSONKeys([]byte(`{"songs":`), snakeCase)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		_, err := rewriteJSONKeys([]byte(`{} {}`), snakeCase)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestJSONKeyCase(t *testing.T) {
+	serve := func(defaultCase, accept, contentType, body string) *httptest.ResponseRecorder {
+		handler := transformJSON(jsonKeyCase(defaultCase))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = w.Write([]byte(body))
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		return w
+	}
+
+	t.Run("keys as declared", func(t *testing.T) {
+		w := serve("", "application/json", "application/json", `{"groupName":"Queen","created_at":"x"}`)
+
+		assert.Equal(t, `{"groupName":"Queen","created_at":"x"}`, w.Body.String())
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	})
+
+	t.Run("default case", func(t *testing.T) {
+		w := serve(KeyCaseCamel, "", "application/json", `{"groupName":"Queen","created_at":"x"}`)
+
+		assert.Equal(t, `{"groupName":"Queen","createdAt":"x"}`, w.Body.String())
+	})
+
+	t.Run("accept profile overrides default case", func(t *testing.T) {
+		w := serve(KeyCaseCamel, "text/html, application/json; profile=snake", "application/json", `{"groupName":"Queen","created_at":"x"}`)
+
+		assert.Equal(t, `{"group_name":"Queen","created_at":"x"}`, w.Body.String())
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		w := serve("", "application/json; profile=kebab", "application/json", `{"groupName":"Queen"}`)
+
+		assert.Equal(t, `{"groupName":"Queen"}`, w.Body.String())
+	})
+
+	t.Run("other media type", func(t *testing.T) {
+		w := serve(KeyCaseSnake, "", "text/csv", "groupName,name\n")
+
+		assert.Equal(t, "groupName,name\n", w.Body.String())
+	})
+
+	t.Run("data map keys kept", func(t *testing.T) {
+		w := serve(KeyCaseSnake, "", "application/json", `{"songs":[],"facets":{"releaseYear":[{"value":"1971","count":2}]}}`)
+
+		assert.Equal(t, `{"songs":[],"facets":{"releaseYear":[{"value":"1971","count":2}]}}`, w.Body.String())
+	})
+
+	t.Run("keys kept when marked", func(t *testing.T) {
+		handler := transformJSON(jsonKeyCase(KeyCaseCamel))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			keepJSONKeys(w)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"HTTP_SERVER_PORT":8080}`))
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, `{"HTTP_SERVER_PORT":8080}`, w.Body.String())
+	})
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+// defaultLibraryStatsInterval is used when a non-positive interval is passed to NewLibraryStatsReporter.
+const defaultLibraryStatsInterval = time.Minute
+
+// LibraryStatsReporter periodically reads counts describing the song library and exposes them as Prometheus gauges,
+// so regressions of data quality, such as a growing number of songs missing details, can be alerted on.
+// It implements prometheus.Collector, so it can be registered in any Prometheus registry.
+type LibraryStatsReporter struct {
+	logger   *slog.Logger
+	stats    func(ctx context.Context) (*entity.LibraryStats, error)
+	interval time.Duration
+
+	songs              prometheus.Gauge
+	groups             prometheus.Gauge
+	songsMissingDetail prometheus.Gauge
+}
+
+// NewLibraryStatsReporter creates a new LibraryStatsReporter that reads the library stats using the provided function,
+// usually the FetchLibraryStats method of the song use case, every interval. A non-positive interval falls back to a minute.
+func NewLibraryStatsReporter(
+	logger *slog.Logger,
+	stats func(ctx context.Context) (*entity.LibraryStats, error),
+	interval time.Duration,
+) *LibraryStatsReporter {
+	if interval <= 0 {
+		interval = defaultLibraryStatsInterval
+	}
+
+	opts := func(name, help string) prometheus.GaugeOpts {
+		return prometheus.GaugeOpts{
+			Namespace: "online_song_library",
+			Subsystem: "library",
+			Name:      name,
+			Help:      help,
+		}
+	}
+
+	return &LibraryStatsReporter{
+		logger:             logger,
+		stats:              stats,
+		interval:           interval,
+		songs:              prometheus.NewGauge(opts("songs", "Number of songs in the library.")),
+		groups:             prometheus.NewGauge(opts("groups", "Number of distinct groups in the library.")),
+		songsMissingDetail: prometheus.NewGauge(opts("songs_missing_detail", "Number of songs without a release date, text, or link.")),
+	}
+}
+
+// Run reports the library stats immediately and then on every tick until the context is done.
+func (s *LibraryStatsReporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.report(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.report(ctx)
+		}
+	}
+}
+
+// report reads the current library stats and updates the gauges.
+// When the stats can't be read, the gauges keep the values of the previous report.
+func (s *LibraryStatsReporter) report(ctx context.Context) {
+	stats, err := s.stats(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Warn("failed to read library stats", slog.Any("err", err))
+		}
+		return
+	}
+
+	s.songs.Set(float64(stats.Songs))
+	s.groups.Set(float64(stats.Groups))
+	s.songsMissingDetail.Set(float64(stats.SongsMissingDetail))
+}
+
+// Describe implements prometheus.Collector.
+func (s *LibraryStatsReporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range s.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (s *LibraryStatsReporter) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range s.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (s *LibraryStatsReporter) collectors() []prometheus.Collector {
+	return []prometheus.Collector{s.songs, s.groups, s.songsMissingDetail}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+func TestLibraryStatsReporter(t *testing.T) {
+	var (
+		stats    = &entity.LibraryStats{Songs: 12, Groups: 4, SongsMissingDetail: 3}
+		statsErr error
+	)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	r := NewLibraryStatsReporter(logger, func(context.Context) (*entity.LibraryStats, error) {
+		return stats, statsErr
+	}, time.Minute)
+
+	t.Run("initial report", func(t *testing.T) {
+		r.report(context.Background())
+
+		assert.Equal(t, 12.0, testutil.ToFloat64(r.songs))
+		assert.Equal(t, 4.0, testutil.ToFloat64(r.groups))
+		assert.Equal(t, 3.0, testutil.ToFloat64(r.songsMissingDetail))
+	})
+
+	t.Run("failed report keeps previous values", func(t *testing.T) {
+		statsErr = errors.New("unknown error")
+		t.Cleanup(func() { statsErr = nil })
+
+		r.report(context.Background())
+
+		assert.Equal(t, 12.0, testutil.ToFloat64(r.songs))
+		assert.Equal(t, 3.0, testutil.ToFloat64(r.songsMissingDetail))
+	})
+
+	t.Run("next report", func(t *testing.T) {
+		stats = &entity.LibraryStats{Songs: 15, Groups: 5, SongsMissingDetail: 1}
+
+		r.report(context.Background())
+
+		assert.Equal(t, 15.0, testutil.ToFloat64(r.songs))
+		assert.Equal(t, 5.0, testutil.ToFloat64(r.groups))
+		assert.Equal(t, 1.0, testutil.ToFloat64(r.songsMissingDetail))
+	})
+
+	t.Run("collects every gauge", func(t *testing.T) {
+		assert.Equal(t, 3, testutil.CollectAndCount(r))
+	})
+}
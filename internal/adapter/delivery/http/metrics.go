@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Histogram buckets (in seconds) used for the route groups when they are not set in RouterOptions.
+// Reads of a single song are expected to be the fastest, while mutations may wait for the music info API.
+var (
+	defaultListBuckets     = []float64{.005, .01, .025, .05, .1, .25, .5, 1}
+	defaultSingleBuckets   = []float64{.001, .0025, .005, .01, .025, .05, .1, .25}
+	defaultMutatingBuckets = []float64{.025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+)
+
+// Route groups used to split request latency into separate histograms.
+const (
+	listRouteGroup     = "list"
+	singleRouteGroup   = "single"
+	mutatingRouteGroup = "mutating"
+)
+
+// metrics holds the Prometheus registry and the per route group latency histograms.
+type metrics struct {
+	registry   *prometheus.Registry
+	histograms map[string]*prometheus.HistogramVec
+}
+
+// newMetrics creates a registry with a request duration histogram for every route group.
+// Empty bucket lists fall back to the defaults.
+func newMetrics(listBuckets, singleBuckets, mutatingBuckets []float64) *metrics {
+	bucketsOrDefault := func(buckets, defaultBuckets []float64) []float64 {
+		if len(buckets) == 0 {
+			return defaultBuckets
+		}
+		return buckets
+	}
+
+	m := &metrics{
+		registry:   prometheus.NewRegistry(),
+		histograms: make(map[string]*prometheus.HistogramVec),
+	}
+
+	groups := map[string][]float64{
+		listRouteGroup:     bucketsOrDefault(listBuckets, defaultListBuckets),
+		singleRouteGroup:   bucketsOrDefault(singleBuckets, defaultSingleBuckets),
+		mutatingRouteGroup: bucketsOrDefault(mutatingBuckets, defaultMutatingBuckets),
+	}
+
+	for group, buckets := range groups {
+		hv := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "online_song_library",
+			Subsystem: "http",
+			Name:      group + "_request_duration_seconds",
+			Help:      "Duration of " + group + " HTTP requests in seconds.",
+			Buckets:   buckets,
+		}, []string{"method", "route", "status"})
+
+		m.registry.MustRegister(hv)
+		m.histograms[group] = hv
+	}
+
+	return m
+}
+
+// routeGroup classifies a request by its method and matched route pattern.
+func routeGroup(method, pattern string) string {
+	switch {
+	case method != http.MethodGet && method != http.MethodHead:
+		return mutatingRouteGroup
+	case strings.Contains(pattern, "{songID}"):
+		return singleRouteGroup
+	default:
+		return listRouteGroup
+	}
+}
+
+// middleware records the duration of every routed request into the histogram of its route group.
+func (m *metrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		pattern := chi.RouteContext(r.Context()).RoutePattern()
+		if pattern == "" {
+			return
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		m.histograms[routeGroup(r.Method, pattern)].
+			WithLabelValues(r.Method, pattern, strconv.Itoa(status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
+
+// handler returns the HTTP handler exposing the collected metrics.
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
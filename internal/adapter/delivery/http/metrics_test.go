@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		pattern string
+		want    string
+	}{
+		{name: "list", method: http.MethodGet, pattern: "/api/v1/songs/", want: listRouteGroup},
+		{name: "single", method: http.MethodGet, pattern: "/api/v1/songs/{songID}/text", want: singleRouteGroup},
+		{name: "add", method: http.MethodPost, pattern: "/api/v1/songs/", want: mutatingRouteGroup},
+		{name: "modify", method: http.MethodPatch, pattern: "/api/v1/songs/{songID}/", want: mutatingRouteGroup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, routeGroup(tt.method, tt.pattern))
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	e, _ := setupServerWithOptions(t, &RouterOptions{
+		MetricsListBuckets:     []float64{0.123, 4.56},
+		MetricsSingleBuckets:   []float64{0.321},
+		MetricsMutatingBuckets: []float64{7.89},
+	})
+
+	e.GET("/api/v1/ping").
+		Expect().
+		Status(http.StatusOK)
+
+	e.GET("/api/v1/songs/{songID}/text", "invalid uuid").
+		Expect().
+		Status(http.StatusBadRequest)
+
+	body := e.GET("/metrics").
+		Expect().
+		Status(http.StatusOK).
+		Body()
+
+	body.Contains(`online_song_library_http_list_request_duration_seconds_bucket{method="GET",route="/api/v1/ping",status="200",le="0.123"}`)
+	body.Contains(`online_song_library_http_list_request_duration_seconds_bucket{method="GET",route="/api/v1/ping",status="200",le="4.56"}`)
+	body.Contains(`online_song_library_http_list_request_duration_seconds_count{method="GET",route="/api/v1/ping",status="200"} 1`)
+	body.Contains(`online_song_library_http_single_request_duration_seconds_bucket{method="GET",route="/api/v1/songs/{songID}/text",status="400",le="0.321"}`)
+	body.Contains(`online_song_library_http_single_request_duration_seconds_count{method="GET",route="/api/v1/songs/{songID}/text",status="400"} 1`)
+	body.NotContains(`online_song_library_http_mutating_request_duration_seconds_count`)
+}
@@ -0,0 +1,621 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/vadimbarashkov/online-song-library/pkg/requestid"
+	"github.com/vadimbarashkov/online-song-library/pkg/servertiming"
+)
+
+// propagateRequestID copies the request ID set by middleware.RequestID into the context with requestid.NewContext,
+// so the layers below, such as the query logging of the repository, can read it without depending on the router.
+func propagateRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := requestid.NewContext(r.Context(), middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requireJSONContentType rejects requests whose Content-Type is not application/json
+// with 415 Unsupported Media Type. Media type parameters such as charset are allowed.
+// When enabled is false, requests are passed through unchanged.
+func requireJSONContentType(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				render.Status(r, http.StatusUnsupportedMediaType)
+				render.JSON(w, r, unsupportedMediaTypeResp)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireUTF8Body rejects requests whose body is not valid UTF-8 with 400 Bad Request, before handlers decode it,
+// so that bodies sent in another encoding, such as latin-1, don't end up as garbled names. The body is read whole
+// and handed on unchanged. When enabled is false, requests are passed through unchanged.
+func requireUTF8Body(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, invalidRequestBodyResp)
+				return
+			}
+
+			if !utf8.Valid(body) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, invalidBodyEncodingResp)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireUserAgent rejects requests with an empty or missing User-Agent header with 400 Bad Request.
+// When enabled is false, requests are passed through unchanged.
+func requireUserAgent(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.TrimSpace(r.UserAgent()) == "" {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, missingUserAgentResp)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// readOnlyMode tells whether the API rejects writes, such as during database maintenance.
+// It can be switched at runtime through the admin endpoints and is safe for concurrent use.
+type readOnlyMode struct {
+	enabled    atomic.Bool
+	retryAfter time.Duration
+}
+
+// newReadOnlyMode creates a readOnlyMode telling clients to retry writes after retryAfter.
+// Non-positive durations fall back to defaultReadOnlyRetryAfter.
+func newReadOnlyMode(enabled bool, retryAfter time.Duration) *readOnlyMode {
+	if retryAfter <= 0 {
+		retryAfter = defaultReadOnlyRetryAfter
+	}
+
+	m := &readOnlyMode{retryAfter: retryAfter}
+	m.enabled.Store(enabled)
+
+	return m
+}
+
+// rejectWrites rejects requests with 503 Service Unavailable and a Retry-After header while the API is read-only.
+// It is meant for routes that modify songs; reads are served normally. Otherwise requests are passed through unchanged.
+func (m *readOnlyMode) rejectWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.enabled.Load() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		retryAfter := int64((m.retryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+
+		render.Status(r, http.StatusServiceUnavailable)
+		render.JSON(w, r, readOnlyModeResp)
+	})
+}
+
+// requireValidDateFormat rejects requests with an unknown dateFormat query parameter with 400 Bad Request.
+func requireValidDateFormat(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := parseDateFormat(r); !ok {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, invalidDateFormatResp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireAdminToken rejects requests that do not carry the admin token as a bearer token in the
+// Authorization header with 401 Unauthorized. Tokens are compared in constant time.
+func requireAdminToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, unauthorizedResp)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// defaultDeadline bounds every request whose context has no deadline with the given timeout,
+// falling back to defaultRequestDeadline when it is not positive. Contexts with a deadline are left unchanged.
+func defaultDeadline(timeout time.Duration) func(http.Handler) http.Handler {
+	if timeout <= 0 {
+		timeout = defaultRequestDeadline
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.Context().Deadline(); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// serverTimingWriter adds the Server-Timing header to a response right before its headers are written.
+type serverTimingWriter struct {
+	http.ResponseWriter
+	timings     *servertiming.Timings
+	start       time.Time
+	wroteHeader bool
+}
+
+func (w *serverTimingWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.timings.Add(servertiming.MetricTotal, time.Since(w.start))
+		w.Header().Set("Server-Timing", w.timings.Header())
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *serverTimingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original writer, so http.ResponseController can flush streamed responses.
+func (w *serverTimingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// serverTiming reports the time spent in the database, in the music info API, and in total until the
+// response is written in a Server-Timing header. When enabled is false, requests are passed through unchanged.
+func serverTiming(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, timings := servertiming.NewContext(r.Context())
+			tw := &serverTimingWriter{ResponseWriter: w, timings: timings, start: time.Now()}
+
+			next.ServeHTTP(tw, r.WithContext(ctx))
+		})
+	}
+}
+
+// jsonResponse is a JSON response held back by jsonTransformWriter for transforms to rewrite.
+type jsonResponse struct {
+	header   http.Header
+	status   int
+	body     []byte
+	keepKeys bool // keepKeys is set by keepJSONKeys for bodies whose object keys are data rather than field names.
+}
+
+// jsonTransform rewrites the status, headers, or body of a JSON response.
+type jsonTransform func(resp *jsonResponse)
+
+// jsonTransformer returns the transform applying to the response to a request, or nil when none applies.
+type jsonTransformer func(w http.ResponseWriter, r *http.Request) jsonTransform
+
+// jsonTransformWriter holds back JSON responses, so they can be rewritten by transforms once the handler returns.
+// Other responses, such as event streams and exported files, are written through as they come.
+type jsonTransformWriter struct {
+	http.ResponseWriter
+	transforms  []jsonTransform
+	keepKeys    bool
+	status      int
+	body        bytes.Buffer
+	buffering   bool
+	wroteHeader bool
+}
+
+func (w *jsonTransformWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if isJSONMediaType(w.Header().Get("Content-Type")) {
+		w.status = status
+		w.buffering = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *jsonTransformWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original writer, so http.ResponseController can flush streamed responses.
+func (w *jsonTransformWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// flush writes the held back JSON response rewritten by the transforms in order,
+// updating its Content-Length when it has one.
+func (w *jsonTransformWriter) flush() {
+	if !w.buffering {
+		return
+	}
+
+	resp := &jsonResponse{header: w.Header(), status: w.status, body: w.body.Bytes(), keepKeys: w.keepKeys}
+	for _, transform := range w.transforms {
+		transform(resp)
+	}
+
+	if w.Header().Get("Content-Length") != "" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(resp.body)))
+	}
+
+	w.ResponseWriter.WriteHeader(resp.status)
+	_, _ = w.ResponseWriter.Write(resp.body)
+}
+
+// keepJSONKeys marks the JSON response written to w as keyed by data, such as the names of environment variables,
+// so jsonKeyCase leaves its keys as they are. It does nothing when the response isn't held back for transforms.
+func keepJSONKeys(w http.ResponseWriter) {
+	for {
+		switch tw := w.(type) {
+		case *jsonTransformWriter:
+			tw.keepKeys = true
+			return
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = tw.Unwrap()
+		default:
+			return
+		}
+	}
+}
+
+// transformJSON holds back the JSON responses of requests any of the transformers has a transform for, and writes
+// them rewritten by those transforms, in the order of the transformers, once the handler returns. Requests without
+// transforms are passed through unchanged.
+func transformJSON(transformers ...jsonTransformer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var transforms []jsonTransform
+			for _, transformer := range transformers {
+				if transform := transformer(w, r); transform != nil {
+					transforms = append(transforms, transform)
+				}
+			}
+
+			if len(transforms) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tw := &jsonTransformWriter{ResponseWriter: w, transforms: transforms}
+
+			next.ServeHTTP(tw, r)
+
+			tw.flush()
+		})
+	}
+}
+
+// isJSONMediaType reports whether a Content-Type is application/json or a JSON based media type, such as HAL+JSON.
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// addVary adds the request header to the Vary header of a response, unless it is listed already.
+func addVary(header http.Header, name string) {
+	for _, value := range header.Values("Vary") {
+		for _, listed := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(listed), name) {
+				return
+			}
+		}
+	}
+
+	header.Add("Vary", name)
+}
+
+// prettyJSON indents the JSON responses of requests with pretty=true in their query with two spaces, to make them
+// readable when inspected by hand. Other requests get compact JSON, as do all requests when enabled is false.
+func prettyJSON(enabled bool) jsonTransformer {
+	return func(_ http.ResponseWriter, r *http.Request) jsonTransform {
+		if !enabled {
+			return nil
+		}
+
+		if pretty, err := strconv.ParseBool(r.URL.Query().Get("pretty")); err != nil || !pretty {
+			return nil
+		}
+
+		return func(resp *jsonResponse) {
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, resp.body, "", "  "); err == nil {
+				resp.body = indented.Bytes()
+			}
+		}
+	}
+}
+
+// deprecationWriter adds the deprecation headers of the matched route to a response right before its headers are written,
+// as the route is only known once the request has been routed.
+type deprecationWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	routes      map[string]time.Time
+	wroteHeader bool
+}
+
+func (w *deprecationWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+
+		if sunset, ok := w.routes[deprecatedRouteKey(w.r)]; ok {
+			w.Header().Set("Deprecation", "true")
+			if !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *deprecationWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original writer, so http.ResponseController can flush streamed responses.
+func (w *deprecationWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// deprecatedRouteKey returns the method and matched route pattern of a request, without a trailing slash,
+// such as "GET /api/v1/songs".
+func deprecatedRouteKey(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return ""
+	}
+
+	pattern := rctx.RoutePattern()
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+
+	return r.Method + " " + pattern
+}
+
+// deprecationHeaders marks the responses of deprecated routes with a Deprecation header and, when the route has
+// a sunset time, a Sunset header (RFC 8594), so clients learn when to migrate. Routes are keyed by their method
+// and route pattern. Without deprecated routes, requests are passed through unchanged.
+func deprecationHeaders(routes map[string]time.Time) func(http.Handler) http.Handler {
+	normalized := make(map[string]time.Time, len(routes))
+	for route, sunset := range routes {
+		if method, pattern, ok := strings.Cut(route, " "); ok {
+			if len(pattern) > 1 {
+				pattern = strings.TrimSuffix(pattern, "/")
+			}
+			route = strings.ToUpper(method) + " " + pattern
+		}
+		normalized[route] = sunset
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(normalized) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(&deprecationWriter{ResponseWriter: w, r: r, routes: normalized}, r)
+		})
+	}
+}
+
+// limitResponseSize replaces JSON responses larger than maxSize bytes with 413 Request Entity Too Large,
+// telling clients to request less data with pagination, so a list of songs with enormous lyrics can't
+// produce an unbounded response. When maxSize is not positive, responses are not limited.
+func limitResponseSize(maxSize int64) jsonTransformer {
+	return func(_ http.ResponseWriter, _ *http.Request) jsonTransform {
+		if maxSize <= 0 {
+			return nil
+		}
+
+		return func(resp *jsonResponse) {
+			if int64(len(resp.body)) <= maxSize {
+				return
+			}
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(responseTooLargeResp); err != nil {
+				return
+			}
+
+			for _, header := range []string{"Content-Length", "ETag", "Last-Modified", "Location"} {
+				resp.header.Del(header)
+			}
+			resp.header.Set("Content-Type", "application/json")
+
+			resp.status = http.StatusRequestEntityTooLarge
+			resp.body = body.Bytes()
+		}
+	}
+}
+
+// bufferedResponseWriter holds back the status and body of a response until the handler returns.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+// entityHeaders buffers the response of a read handler to set its Content-Length and, for successful
+// responses, an ETag computed from the body. The body is dropped for HEAD requests, so the same
+// handler can serve both GET and HEAD with identical headers. Successful responses with a Last-Modified
+// header not later than the If-Modified-Since header of the request are answered with 304 Not Modified.
+func entityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bw := &bufferedResponseWriter{ResponseWriter: w}
+
+		next.ServeHTTP(bw, r)
+
+		if bw.status == 0 {
+			bw.status = http.StatusOK
+		}
+
+		if bw.status == http.StatusOK {
+			w.Header().Set("ETag", entityTag(bw.body.Bytes()))
+
+			if notModifiedSince(r, w.Header().Get("Last-Modified")) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(bw.body.Len()))
+
+		w.WriteHeader(bw.status)
+
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(bw.body.Bytes())
+		}
+	})
+}
+
+// entityTag computes a strong ETag from a response body.
+func entityTag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return strconv.Quote(hex.EncodeToString(sum[:16]))
+}
+
+// prefersMinimalReturn reports whether the request asks for no response body with Prefer: return=minimal,
+// as defined in RFC 7240. Any other or missing return preference means return=representation.
+func prefersMinimalReturn(r *http.Request) bool {
+	for _, header := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(header, ",") {
+			pref, _, _ = strings.Cut(pref, ";")
+			key, value, _ := strings.Cut(pref, "=")
+
+			if strings.EqualFold(strings.TrimSpace(key), "return") {
+				return strings.EqualFold(strings.Trim(strings.TrimSpace(value), `"`), "minimal")
+			}
+		}
+	}
+
+	return false
+}
+
+// acceptsHAL reports whether the Accept header of the request lists the HAL+JSON media type,
+// in which case song representations are rendered with HAL links. Quality values are not weighed.
+func acceptsHAL(r *http.Request) bool {
+	for _, header := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(header, ",") {
+			mediaRange, _, _ = strings.Cut(mediaRange, ";")
+
+			if strings.EqualFold(strings.TrimSpace(mediaRange), halMediaType) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// notModifiedSince reports whether the resource, last modified at the HTTP-date lastModified, hasn't changed
+// since the If-Modified-Since header of the request. Both are HTTP-dates, so they are compared to the second.
+// As required by RFC 9110, If-Modified-Since is ignored in requests that also send If-None-Match.
+func notModifiedSince(r *http.Request, lastModified string) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if lastModified == "" || r.Header.Get("If-None-Match") != "" {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadimbarashkov/online-song-library/pkg/requestid"
+)
+
+func TestPropagateRequestID(t *testing.T) {
+	var reqID string
+
+	handler := middleware.RequestID(propagateRequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		reqID = requestid.FromContext(r.Context())
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "test-request-id")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "test-request-id", reqID)
+}
+
+func TestDefaultDeadline(t *testing.T) {
+	serve := func(timeout time.Duration, r *http.Request) (time.Time, bool) {
+		var (
+			deadline time.Time
+			ok       bool
+		)
+
+		handler := defaultDeadline(timeout)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			deadline, ok = r.Context().Deadline()
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+
+		return deadline, ok
+	}
+
+	t.Run("request without deadline", func(t *testing.T) {
+		start := time.Now()
+
+		deadline, ok := serve(time.Minute, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.True(t, ok)
+		assert.WithinDuration(t, start.Add(time.Minute), deadline, time.Second)
+	})
+
+	t.Run("default timeout", func(t *testing.T) {
+		start := time.Now()
+
+		deadline, ok := serve(0, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.True(t, ok)
+		assert.WithinDuration(t, start.Add(defaultRequestDeadline), deadline, time.Second)
+	})
+
+	t.Run("shorter client deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		expected, _ := ctx.Deadline()
+
+		deadline, ok := serve(time.Minute, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+
+		assert.True(t, ok)
+		assert.Equal(t, expected, deadline)
+	})
+}
+
+func TestRequireUserAgent(t *testing.T) {
+	serve := func(enabled bool, userAgent string) int {
+		handler := requireUserAgent(enabled)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("User-Agent", userAgent)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		return w.Code
+	}
+
+	t.Run("missing user agent", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(true, ""))
+	})
+
+	t.Run("present user agent", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, serve(true, "curl/8.5.0"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, serve(false, ""))
+	})
+}
+
+func TestPrettyJSON(t *testing.T) {
+	serve := func(contentType, body string) string {
+		handler := transformJSON(prettyJSON(true))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = w.Write([]byte(body))
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/?pretty=true", nil)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		return w.Body.String()
+	}
+
+	t.Run("json", func(t *testing.T) {
+		assert.Equal(t, "{\n  \"songs\": [\n    1\n  ]\n}", serve("application/json; charset=utf-8", `{"songs":[1]}`))
+	})
+
+	t.Run("hal json", func(t *testing.T) {
+		assert.Equal(t, "{\n  \"_links\": {}\n}", serve(halMediaType, `{"_links":{}}`))
+	})
+
+	t.Run("other media type", func(t *testing.T) {
+		assert.Equal(t, "group,song\nMuse,Uprising\n", serve("text/csv", "group,song\nMuse,Uprising\n"))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		assert.Equal(t, `{"songs":`, serve("application/json", `{"songs":`))
+	})
+}
+
+func TestTransformJSON(t *testing.T) {
+	serve := func(transformers ...jsonTransformer) *httptest.ResponseRecorder {
+		handler := transformJSON(transformers...)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", "22")
+			_, _ = w.Write([]byte(`{"group_name":"Queen"}`))
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?pretty=true", nil))
+
+		return w
+	}
+
+	t.Run("without transforms", func(t *testing.T) {
+		w := serve(prettyJSON(false), limitResponseSize(0))
+
+		assert.Equal(t, `{"group_name":"Queen"}`, w.Body.String())
+		assert.Equal(t, "22", w.Header().Get("Content-Length"))
+	})
+
+	t.Run("transforms in order", func(t *testing.T) {
+		w := serve(jsonKeyCase(KeyCaseCamel), prettyJSON(true), limitResponseSize(64))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "{\n  \"groupName\": \"Queen\"\n}", w.Body.String())
+		assert.Equal(t, "26", w.Header().Get("Content-Length"))
+	})
+
+	t.Run("size limited after indenting", func(t *testing.T) {
+		w := serve(prettyJSON(true), limitResponseSize(24))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
+
+func TestLimitResponseSize(t *testing.T) {
+	serve := func(contentType, body string) *httptest.ResponseRecorder {
+		handler := transformJSON(limitResponseSize(16))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("ETag", `"abc"`)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(body[:len(body)/2]))
+			_, _ = w.Write([]byte(body[len(body)/2:]))
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		return w
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		w := serve("application/json", `{"songs":[1]}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+		assert.Equal(t, `{"songs":[1]}`, w.Body.String())
+	})
+
+	t.Run("exceeding limit", func(t *testing.T) {
+		w := serve("application/json", `{"songs":[1,2,3,4,5]}`)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+		assert.JSONEq(t, `{"status":"error","message":"`+responseTooLargeResp.Message+`"}`, w.Body.String())
+	})
+
+	t.Run("other media type", func(t *testing.T) {
+		w := serve("text/csv", "group,song\nMuse,Uprising\n")
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "group,song\nMuse,Uprising\n", w.Body.String())
+	})
+}
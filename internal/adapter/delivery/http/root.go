@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+)
+
+// What the root path responds with, see RouterOptions.RootPage.
+const (
+	RootPageLanding = "landing" // A small static page linking to the API documentation.
+	RootPageSwagger = "swagger" // A redirect to the Swagger UI.
+)
+
+// swaggerIndexPath is the path of the Swagger UI.
+const swaggerIndexPath = "/swagger/index.html"
+
+// landingPage is the static page served at the root path with RootPageLanding.
+const landingPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Online Song Library</title>
+</head>
+<body>
+<h1>Online Song Library</h1>
+<p>This is the API of an online song library. Its endpoints are served under <code>/api/v1</code>.</p>
+<ul>
+<li><a href="` + swaggerIndexPath + `">API documentation</a></li>
+<li><a href="/api/v1/ping">Health check</a></li>
+</ul>
+</body>
+</html>
+`
+
+// handleRoot returns the handler of the root path for the page mode, or nil for unknown modes,
+// which leave the root path unrouted.
+func handleRoot(page string) http.HandlerFunc {
+	switch page {
+	case RootPageLanding:
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(landingPage))
+		}
+	case RootPageSwagger:
+		return func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, swaggerIndexPath, http.StatusFound)
+		}
+	default:
+		return nil
+	}
+}
@@ -3,8 +3,10 @@ package http
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
@@ -12,8 +14,10 @@ import (
 	"github.com/go-chi/httplog/v2"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/vadimbarashkov/online-song-library/docs"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/pkg/postgres"
 	"github.com/vadimbarashkov/online-song-library/pkg/validate"
 
 	httpSwagger "github.com/swaggo/http-swagger/v2"
@@ -23,43 +27,244 @@ import (
 // It includes methods for adding, fetching, modifying, and removing songs.
 type songUseCase interface {
 	AddSong(ctx context.Context, song entity.Song) (*entity.Song, error)
+	ImportSongs(ctx context.Context, songs []entity.Song, fetchInfo bool) ([]*entity.Song, error)
 	FetchSongs(
 		ctx context.Context,
 		pagination entity.Pagination,
 		filters ...entity.SongFilter,
 	) ([]*entity.Song, *entity.Pagination, error)
+	CountSongs(ctx context.Context, filters ...entity.SongFilter) (uint64, error)
+	FetchSongsWithBrokenLinks(ctx context.Context, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error)
+	FetchSongFacets(
+		ctx context.Context,
+		fields []entity.FacetField,
+		filters ...entity.SongFilter,
+	) (map[entity.FacetField][]entity.FacetCount, error)
+	FetchIncompleteSongs(
+		ctx context.Context,
+		pagination entity.Pagination,
+		filters ...entity.SongFilter,
+	) ([]*entity.Song, *entity.Pagination, error)
+	FetchRecentSongs(ctx context.Context, limit uint64) ([]*entity.Song, error)
+	FetchRecentlyUpdatedSongs(
+		ctx context.Context,
+		pagination entity.Pagination,
+		filters ...entity.SongFilter,
+	) ([]*entity.Song, *entity.Pagination, error)
+	FetchSong(ctx context.Context, songID uuid.UUID) (*entity.Song, error)
+	FetchSimilarSongs(
+		ctx context.Context,
+		songID uuid.UUID,
+		pagination entity.Pagination,
+	) ([]*entity.Song, *entity.Pagination, error)
+	FetchDecadeStats(ctx context.Context) ([]entity.DecadeCount, error)
+	FetchAlphaIndex(ctx context.Context, field entity.AlphaIndexField) ([]entity.LetterCount, error)
+	FetchGroupFacets(ctx context.Context, groupName string) (*entity.GroupFacets, error)
+	FetchGroupReleaseRange(ctx context.Context, groupName string) (*entity.GroupReleaseRange, error)
+	SuggestNames(ctx context.Context, field entity.SuggestField, prefix string, limit uint64) ([]string, error)
 	FetchSongWithVerses(
 		ctx context.Context,
 		songID uuid.UUID,
 		pagination entity.Pagination,
+		granularity entity.TextGranularity,
+		dedupe bool,
 	) (*entity.SongWithVerses, *entity.Pagination, error)
+	SearchSongVerses(ctx context.Context, songID uuid.UUID, query string, contextVerses int) ([]entity.VerseMatch, error)
+	FetchRandomVerse(ctx context.Context, songID uuid.UUID) (*entity.Verse, error)
+	FetchTextStats(ctx context.Context, songID uuid.UUID) (*entity.TextStats, error)
+	FetchSongsWithVerses(
+		ctx context.Context,
+		songIDs []uuid.UUID,
+		pagination entity.Pagination,
+	) ([]entity.SongVersesPage, []uuid.UUID, error)
+	CompareSongTexts(ctx context.Context, songID, otherSongID uuid.UUID) ([]entity.LineDiff, error)
+	FetchSongLines(ctx context.Context, songID uuid.UUID, numberBlank bool) (*entity.Song, []entity.NumberedLine, error)
+	PreviewVerses(text string) []string
 	ModifySong(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error)
+	ModifySongs(ctx context.Context, updates []entity.SongUpdate) ([]*entity.Song, error)
+	ModifySongsWhere(ctx context.Context, song entity.Song, filters ...entity.SongFilter) (int64, error)
+	ReorderVerses(ctx context.Context, songID uuid.UUID, order []int) (*entity.Song, error)
+	TouchSongs(ctx context.Context, filters ...entity.SongFilter) (int64, error)
 	RemoveSong(ctx context.Context, songID uuid.UUID) (int64, error)
+	RemoveSongs(ctx context.Context, songIDs []uuid.UUID) ([]uuid.UUID, []uuid.UUID, error)
+	RollbackImport(ctx context.Context, importID uuid.UUID) (int64, error)
+	SearchSongs(ctx context.Context, query string, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error)
+	ResplitVerses(ctx context.Context) (int64, error)
+	RecomputeSortNames(ctx context.Context) (int64, error)
+	StartLinkCheck(ctx context.Context, done func(checked int64, err error)) error
+	WalkSongs(ctx context.Context, fn func(song *entity.Song) error, filters ...entity.SongFilter) error
+	SubscribeSongEvents(ctx context.Context) <-chan entity.SongEvent
 }
 
 // RouterOptions holds configuration options for the HTTP router.
 type RouterOptions struct {
 	SwaggerHost string // SwaggerHost is the hostname for serving Swagger documentation.
 	SwaggerPort int    // SwaggerPort is the port number for serving Swagger documentation.
+	StrictJSON  bool   // StrictJSON rejects request bodies containing unknown fields.
+
+	StrictContentType bool // StrictContentType rejects JSON endpoint requests sent without an application/json Content-Type.
+	RequireUTF8Body   bool // RequireUTF8Body rejects JSON endpoint requests whose body is not valid UTF-8.
+
+	// StrictFilters makes song listing respond with 400 when a string filter is sent with an empty value,
+	// such as ?groupName=, instead of ignoring it.
+	StrictFilters bool
+
+	// AllowedFilters lists the song filter query parameters accepted by song listing and bulk updates, such as
+	// groupName. Requests with other filters respond with 400, protecting the database from filters on columns
+	// without an index. An empty list allows every filter.
+	AllowedFilters []string
+
+	// DuplicateFilters is how a song filter parameter sent several times, such as ?name=a&name=b, is handled:
+	// DuplicateFiltersFirst (the default), DuplicateFiltersLast, or DuplicateFiltersAll.
+	DuplicateFilters string
+
+	// PaginationAsStrings serializes the offset, limit, items, and total of paginated responses as JSON strings,
+	// such as "offset": "0", for clients that would lose precision of very large numbers.
+	PaginationAsStrings bool
+
+	// TotalOnRequest makes song listing leave the total out, saving a count query, unless requested
+	// with withTotal=true. Otherwise the total is counted unless requested with withTotal=false.
+	TotalOnRequest bool
+
+	// FailOnMultipleRemoved makes song removal respond with 500 instead of 204 when more than one row was deleted.
+	FailOnMultipleRemoved bool
+
+	// NoLyricsNotFound makes the verses of a song without text respond with 404 instead of an empty verses array.
+	NoLyricsNotFound bool
+
+	// NoLastModified leaves the Last-Modified header out of the verses, lines, and export responses of a song,
+	// so conditional requests with If-Modified-Since always get the full response. ETags are still sent.
+	NoLastModified bool
+
+	ImportMaxFileSize int64 // ImportMaxFileSize is the maximum size in bytes of an uploaded import file.
+	ImportMaxRows     int   // ImportMaxRows is the maximum number of data rows in an uploaded import file.
+
+	// Latency histogram buckets (in seconds) for list reads, single song reads, and mutating requests.
+	MetricsListBuckets     []float64
+	MetricsSingleBuckets   []float64
+	MetricsMutatingBuckets []float64
+
+	MetricsCollectors []prometheus.Collector // MetricsCollectors are additional collectors exposed on the metrics endpoint.
+
+	// CORS settings. Credentials must never be allowed for any origin, so when CORSAllowCredentials is set
+	// wildcard origins, such as https://*, are ignored and only exactly listed origins are allowed.
+	CORSAllowedOrigins   []string
+	CORSAllowCredentials bool
+	CORSMaxAge           time.Duration // CORSMaxAge is how long browsers may cache preflight responses.
+
+	// RequestDeadline is the deadline applied to requests whose context has none, so every handler is bound.
+	// Shorter deadlines set on the request context are kept.
+	RequestDeadline time.Duration
+
+	// EventsHeartbeatInterval is how often a comment is sent on idle song event streams to keep connections alive.
+	EventsHeartbeatInterval time.Duration
+
+	// RequireUserAgent makes API requests without a User-Agent header respond with 400.
+	// The ping, metrics, and Swagger endpoints are exempt.
+	RequireUserAgent bool
+
+	// ServerTiming adds a Server-Timing header with the time spent in the database, in the music info API,
+	// and in total to API responses. It is meant for debugging, as it discloses server internals.
+	ServerTiming bool
+
+	// MaxResponseSize is the maximum size in bytes of JSON responses of the API. Larger responses are replaced
+	// with 413 asking to paginate. Streamed and exported responses are not limited. Zero means no limit.
+	MaxResponseSize int64
+
+	// PrettyJSON makes API requests with pretty=true in their query get indented JSON responses.
+	// It is meant for inspecting responses by hand outside production; responses stay compact without it.
+	PrettyJSON bool
+
+	// JSONKeyCase rewrites the keys of JSON responses to KeyCaseCamel or KeyCaseSnake, unless a request asks
+	// for another casing with the profile parameter of its Accept header. Keys are left as declared when empty.
+	JSONKeyCase string
+
+	// RootPage makes the root path serve a landing page (RootPageLanding) or redirect to the Swagger UI
+	// (RootPageSwagger). The root path responds with 404 like any unknown route when it is empty.
+	RootPage string
+
+	// Markers wrapped around the matches highlighted in verses, <em> and </em> when both are empty.
+	// The verse text around them is HTML-escaped.
+	HighlightPre  string
+	HighlightPost string
+
+	// ReadOnly makes routes that modify songs respond with 503 and a Retry-After header, while reads keep working.
+	// It can be switched at runtime through the admin endpoints. ReadOnlyRetryAfter is the delay sent in the header.
+	ReadOnly           bool
+	ReadOnlyRetryAfter time.Duration
+
+	// DeprecatedRoutes are the routes answered with a Deprecation header, keyed by their method and route pattern,
+	// such as "GET /api/v1/songs/{songID}/text". Routes with a non-zero sunset time also get a Sunset header.
+	DeprecatedRoutes map[string]time.Time
+
+	// AdminToken is the bearer token required by the admin endpoints. They are not mounted when it is empty.
+	AdminToken string
+	// AdminConfig is the sanitized configuration served by the admin config endpoint.
+	// It must never contain secrets, as it is rendered as is.
+	AdminConfig any
+	// MigrationStatus reads the migration state served by the admin migrations endpoint,
+	// which is not mounted when it is nil.
+	MigrationStatus func() (*postgres.MigrationStatus, error)
 }
 
+// Modes of handling a song filter parameter sent several times.
+const (
+	DuplicateFiltersFirst = "first" // Only the first value is used, the others are ignored.
+	DuplicateFiltersLast  = "last"  // Only the last value is used, the others are ignored.
+	DuplicateFiltersAll   = "all"   // Every value adds a filter, so songs must match all of them.
+)
+
+// Import limits used when they are not set in RouterOptions.
+const (
+	defaultImportMaxFileSize int64 = 1 << 20
+	defaultImportMaxRows     int   = 1000
+)
+
+// CORS settings used when they are not set in RouterOptions.
+var (
+	defaultCORSAllowedOrigins = []string{"https://*"}
+	defaultCORSMaxAge         = 24 * time.Hour
+)
+
+// defaultRequestDeadline is used when RequestDeadline is not set in RouterOptions.
+const defaultRequestDeadline = 30 * time.Second
+
+// defaultEventsHeartbeatInterval is used when EventsHeartbeatInterval is not set in RouterOptions.
+const defaultEventsHeartbeatInterval = 15 * time.Second
+
+// defaultReadOnlyRetryAfter is used when ReadOnlyRetryAfter is not set in RouterOptions.
+const defaultReadOnlyRetryAfter = 5 * time.Minute
+
+// maxSongsWithVersesBatchSize is the maximum number of song IDs accepted by a single bulk verses request.
+const maxSongsWithVersesBatchSize = 50
+
+// maxSongsDeleteBatchSize is the maximum number of song IDs accepted by a single batch delete request.
+const maxSongsDeleteBatchSize = 100
+
 // defaultRouterOptions provides default configuration values for the router.
 var defaultRouterOptions = RouterOptions{
-	SwaggerHost: "localhost",
-	SwaggerPort: 8080,
+	SwaggerHost:       "localhost",
+	SwaggerPort:       8080,
+	ImportMaxFileSize: defaultImportMaxFileSize,
+	ImportMaxRows:     defaultImportMaxRows,
 }
 
 // NewRouter initializes a new HTTP router for the application.
-// It sets up middleware for logging, CORS, and error handling, as well as route definitions.
+// It sets up middleware for logging, CORS, error handling, and metrics, as well as route definitions.
+//
+//	@title						Online Song Library API
+//	@description				This is a simple API for managing songs.
+//	@contact.name				Vadim Barashkov
+//	@contatc.email				vadimdominik2005@gmail.com
+//	@license.name				MIT
+//	@license.url				https://opensource.org/license/mit
+//	@version					1.0
+//	@schemes					http https
 //
-//	@title			Online Song Library API
-//	@description	This is a simple API for managing songs.
-//	@contact.name	Vadim Barashkov
-//	@contatc.email	vadimdominik2005@gmail.com
-//	@license.name	MIT
-//	@license.url	https://opensource.org/license/mit
-//	@version		1.0
-//	@schemes		http https
+//	@securityDefinitions.apikey	AdminToken
+//	@in							header
+//	@name						Authorization
+//	@description				Admin token sent as "Bearer <token>".
 func NewRouter(logger *httplog.Logger, songUseCase songUseCase, opts *RouterOptions) *chi.Mux {
 	if opts == nil {
 		opts = &defaultRouterOptions
@@ -67,35 +272,126 @@ func NewRouter(logger *httplog.Logger, songUseCase songUseCase, opts *RouterOpti
 
 	r := chi.NewRouter()
 
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"https://*"},
-		AllowedMethods:   []string{"POST", "GET", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Accept"},
-		AllowCredentials: false,
-		MaxAge:           84600,
-	}))
+	r.Use(cors.Handler(corsOptions(opts)))
 	r.Use(middleware.RequestID)
+	r.Use(propagateRequestID)
 	r.Use(middleware.RealIP)
 	r.Use(httplog.RequestLogger(logger))
 	r.Use(middleware.Recoverer)
 
+	r.NotFound(handleNotFound)
+	r.MethodNotAllowed(handleMethodNotAllowed)
+
 	docs.SwaggerInfo.Host = fmt.Sprintf("%s:%d", opts.SwaggerHost, opts.SwaggerPort)
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 
+	if root := handleRoot(opts.RootPage); root != nil {
+		r.Get("/", root)
+	}
+
+	m := newMetrics(opts.MetricsListBuckets, opts.MetricsSingleBuckets, opts.MetricsMutatingBuckets)
+	m.registry.MustRegister(opts.MetricsCollectors...)
+	r.Handle("/metrics", m.handler())
+
+	validate := newValidate()
+	h := newSongHandler(logger.Logger, songUseCase, validate, *opts)
+
+	r.Get("/sitemap.xml", h.fetchSitemap)
+
 	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(m.middleware)
+		// Keys are rewritten before indenting, and the size is limited on the final body.
+		r.Use(transformJSON(
+			jsonKeyCase(opts.JSONKeyCase),
+			prettyJSON(opts.PrettyJSON),
+			limitResponseSize(opts.MaxResponseSize),
+		))
+		r.Use(serverTiming(opts.ServerTiming))
+		r.Use(deprecationHeaders(opts.DeprecatedRoutes))
+
 		r.Get("/ping", handlePing(logger.Logger))
 
-		r.Route("/songs", func(r chi.Router) {
-			validate := newValidate()
-			h := newSongHandler(logger.Logger, songUseCase, validate)
+		jsonBody := chi.Chain(requireJSONContentType(opts.StrictContentType), requireUTF8Body(opts.RequireUTF8Body)).Handler
 
-			r.Post("/", h.addSong)
-			r.Get("/", h.fetchSongs)
+		readOnly := newReadOnlyMode(opts.ReadOnly, opts.ReadOnlyRetryAfter)
+		writable := readOnly.rejectWrites
 
-			r.Route("/{songID}", func(r chi.Router) {
-				r.Get("/text", h.fetchSongWithVerses)
-				r.Patch("/", h.modifySong)
-				r.Delete("/", h.removeSong)
+		r.Group(func(r chi.Router) {
+			r.Use(requireUserAgent(opts.RequireUserAgent))
+
+			// The event stream is kept open for as long as the client listens, so it gets no default deadline.
+			r.Get("/songs/events", h.streamSongEvents)
+			// Exports stream the whole catalog, which can take longer than the default deadline.
+			r.With(requireValidDateFormat).Get("/songs/export", h.exportSongs)
+
+			r.Group(func(r chi.Router) {
+				r.Use(defaultDeadline(opts.RequestDeadline))
+
+				r.With(jsonBody).Post("/text/preview", h.previewVerses)
+				r.With(jsonBody).Post("/validate/release-date", h.validateReleaseDate)
+				r.Get("/stats/decades", h.fetchDecadeStats)
+				r.Get("/suggest", h.suggestNames)
+				r.With(requireValidDateFormat).Get("/search", h.searchSongs)
+				r.Get("/groups/{groupName}/facets", h.fetchGroupFacets)
+				r.With(requireValidDateFormat).Get("/groups/{groupName}/range", h.fetchGroupReleaseRange)
+				r.With(requireValidDateFormat).Get("/groups/{groupName}/years/{year}/songs", h.fetchGroupYearSongs)
+				r.With(jsonBody, writable).Post("/groups/{groupName}/release-date", h.setGroupReleaseDate)
+				r.With(requireValidDateFormat).Get("/imports/{importId}/songs", h.fetchImportSongs)
+				r.With(writable).Delete("/imports/{importId}", h.rollbackImport)
+
+				if opts.AdminToken != "" {
+					r.Route("/admin", func(r chi.Router) {
+						r.Use(requireAdminToken(opts.AdminToken))
+						r.Get("/config", handleConfig(logger.Logger, opts.AdminConfig))
+						if opts.MigrationStatus != nil {
+							r.Get("/migrations", handleMigrations(logger.Logger, opts.MigrationStatus))
+						}
+						r.Get("/read-only", handleReadOnly(logger.Logger, readOnly))
+						r.Put("/read-only", handleSetReadOnly(logger.Logger, readOnly, true))
+						r.Delete("/read-only", handleSetReadOnly(logger.Logger, readOnly, false))
+						r.With(writable).Post("/songs/resplit", h.resplitVerses)
+						r.With(writable).Post("/songs/sort-names", h.recomputeSortNames)
+						r.With(writable).Post("/songs/link-check", h.startLinkCheck)
+						r.With(writable).Post("/songs/touch", h.touchSongs)
+						r.With(requireValidDateFormat).Get("/songs/regex", h.fetchRegexSongs)
+					})
+				}
+
+				r.Route("/songs", func(r chi.Router) {
+					r.Use(requireValidDateFormat)
+
+					r.With(jsonBody, writable).Post("/", h.addSong)
+					r.With(writable).Post("/import", h.importSongs)
+					r.Get("/import/template", h.fetchImportTemplate)
+					r.Get("/", h.fetchSongs)
+					r.With(jsonBody, writable).Patch("/", h.modifySongsWhere)
+					r.Get("/incomplete", h.fetchIncompleteSongs)
+					r.Get("/broken-links", h.fetchSongsWithBrokenLinks)
+					r.Get("/recent", h.fetchRecentSongs)
+					r.Get("/recently-updated", h.fetchRecentlyUpdatedSongs)
+					r.Get("/alpha-index", h.fetchAlphaIndex)
+					r.With(jsonBody, writable).Post("/release-dates", h.modifySongsReleaseDates)
+					r.With(jsonBody).Post("/text/batch", h.fetchSongsWithVerses)
+					r.With(jsonBody, writable).Post("/batch-delete", h.removeSongs)
+
+					r.Route("/{songID}", func(r chi.Router) {
+						r.With(entityHeaders).Get("/text", h.fetchSongWithVerses)
+						r.Get("/text/search", h.searchSongVerses)
+						r.Get("/text/random", h.fetchRandomVerse)
+						r.Get("/text/stats", h.fetchTextStats)
+						r.With(entityHeaders).Get("/text/lines", h.fetchSongLines)
+						r.With(entityHeaders).Head("/text", h.fetchSongWithVerses)
+						r.With(entityHeaders).Get("/export", h.exportSong)
+						r.With(entityHeaders).Head("/export", h.exportSong)
+						r.Get("/text/diff/{otherSongID}", h.compareSongTexts)
+						r.Get("/similar", h.fetchSimilarSongs)
+						r.Get("/oembed", h.fetchSongOEmbed)
+						r.With(jsonBody, writable).Patch("/", h.modifySong)
+						r.With(jsonBody, writable).Patch("/release-date", h.modifySongReleaseDate)
+						r.With(jsonBody, writable).Put("/text/order", h.reorderVerses)
+						r.With(writable).Delete("/", h.removeSong)
+					})
+				})
 			})
 		})
 	})
@@ -103,6 +399,46 @@ func NewRouter(logger *httplog.Logger, songUseCase songUseCase, opts *RouterOpti
 	return r
 }
 
+// corsOptions builds the CORS settings from the router options. Wildcard origins are dropped
+// when credentials are allowed, and a request from an origin that isn't allowed gets no CORS headers.
+func corsOptions(opts *RouterOptions) cors.Options {
+	origins := opts.CORSAllowedOrigins
+	if len(origins) == 0 {
+		origins = defaultCORSAllowedOrigins
+	}
+
+	if opts.CORSAllowCredentials {
+		var exact []string
+		for _, origin := range origins {
+			if !strings.Contains(origin, "*") {
+				exact = append(exact, origin)
+			}
+		}
+		origins = exact
+	}
+
+	maxAge := opts.CORSMaxAge
+	if maxAge <= 0 {
+		maxAge = defaultCORSMaxAge
+	}
+
+	corsOpts := cors.Options{
+		AllowedOrigins:   origins,
+		AllowedMethods:   []string{"POST", "GET", "HEAD", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "Accept", "Prefer"},
+		ExposedHeaders:   []string{"Location", "ETag", "Preference-Applied"},
+		AllowCredentials: opts.CORSAllowCredentials,
+		MaxAge:           int(maxAge.Seconds()),
+	}
+
+	// An empty origin list allows all origins, so no origin must be allowed explicitly.
+	if len(origins) == 0 {
+		corsOpts.AllowOriginFunc = func(*http.Request, string) bool { return false }
+	}
+
+	return corsOpts
+}
+
 // newValidate initializes a new validator for request validation.
 // It registers custom validation rules and sets a tag name function for JSON field mapping.
 func newValidate() *validator.Validate {
@@ -0,0 +1,642 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/pkg/postgres"
+)
+
+func TestNewRouter_NotFound(t *testing.T) {
+	e, _ := setupServer(t)
+
+	resp := e.GET("/api/v1/unknown").
+		Expect().
+		Status(http.StatusNotFound).
+		HasContentType("application/json").
+		JSON().Object()
+
+	resp.HasValue("status", statusError)
+	resp.HasValue("message", routeNotFoundResp.Message)
+}
+
+func TestNewRouter_RootPage(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET("/").
+			Expect().
+			Status(http.StatusNotFound).
+			JSON().Object().IsEqual(routeNotFoundResp)
+	})
+
+	t.Run("landing page", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{RootPage: RootPageLanding})
+
+		e.GET("/").
+			Expect().
+			Status(http.StatusOK).
+			HasContentType("text/html", "utf-8").
+			Body().Contains(`<a href="/swagger/index.html">`)
+	})
+
+	t.Run("swagger redirect", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{RootPage: RootPageSwagger})
+
+		e.GET("/").
+			WithRedirectPolicy(httpexpect.DontFollowRedirects).
+			Expect().
+			Status(http.StatusFound).
+			Header("Location").IsEqual("/swagger/index.html")
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{RootPage: "docs"})
+
+		e.GET("/").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+}
+
+func TestNewRouter_MethodNotAllowed(t *testing.T) {
+	e, _ := setupServer(t)
+
+	resp := e.PUT("/api/v1/songs/{songID}", fixedUUID).
+		Expect().
+		Status(http.StatusMethodNotAllowed).
+		HasContentType("application/json").
+		JSON().Object()
+
+	resp.HasValue("status", statusError)
+	resp.HasValue("message", methodNotAllowedResp.Message)
+}
+
+func TestNewRouter_CORS(t *testing.T) {
+	const path = "/api/v1/songs"
+
+	preflight := func(e *httpexpect.Expect) *httpexpect.Response {
+		return e.OPTIONS(path).
+			WithHeader("Origin", "https://example.com").
+			WithHeader("Access-Control-Request-Method", http.MethodGet).
+			Expect().
+			Status(http.StatusOK)
+	}
+
+	t.Run("default settings", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		resp := preflight(e)
+
+		resp.Header("Access-Control-Allow-Origin").IsEqual("https://example.com")
+		resp.Header("Access-Control-Max-Age").IsEqual("86400")
+		resp.Header("Access-Control-Allow-Credentials").IsEmpty()
+	})
+
+	t.Run("configured max age and credentials", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{
+			CORSAllowedOrigins:   []string{"https://example.com"},
+			CORSAllowCredentials: true,
+			CORSMaxAge:           10 * time.Minute,
+		})
+
+		resp := preflight(e)
+
+		resp.Header("Access-Control-Allow-Origin").IsEqual("https://example.com")
+		resp.Header("Access-Control-Max-Age").IsEqual("600")
+		resp.Header("Access-Control-Allow-Credentials").IsEqual("true")
+	})
+
+	t.Run("wildcard origin with credentials", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{
+			CORSAllowedOrigins:   []string{"https://*"},
+			CORSAllowCredentials: true,
+		})
+
+		resp := preflight(e)
+
+		resp.Header("Access-Control-Allow-Origin").IsEmpty()
+		resp.Header("Access-Control-Allow-Credentials").IsEmpty()
+	})
+}
+
+func TestNewRouter_AdminConfig(t *testing.T) {
+	const path = "/api/v1/admin/config"
+
+	settings := map[string]any{"HTTP_SERVER_PORT": 8080}
+
+	t.Run("disabled without admin token", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret", AdminConfig: settings})
+
+		resp := e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+
+		resp.Header("WWW-Authenticate").IsEqual("Bearer")
+		resp.JSON().Object().IsEqual(unauthorizedResp)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret", AdminConfig: settings})
+
+		e.GET(path).
+			WithHeader("Authorization", "Bearer wrong").
+			Expect().
+			Status(http.StatusUnauthorized).
+			JSON().Object().IsEqual(unauthorizedResp)
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret", AdminConfig: settings})
+
+		e.GET(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(settings)
+	})
+
+	t.Run("keys kept with json key case", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret", AdminConfig: settings, JSONKeyCase: KeyCaseCamel})
+
+		e.GET(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(settings)
+	})
+}
+
+func TestNewRouter_AdminResplit(t *testing.T) {
+	const path = "/api/v1/admin/songs/resplit"
+
+	t.Run("disabled without admin token", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("ResplitVerses", mock.Anything).
+			Once().
+			Return(int64(0), errors.New("unknown error"))
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("ResplitVerses", mock.Anything).
+			Once().
+			Return(int64(3), nil)
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{"updated": 3})
+	})
+}
+
+func TestNewRouter_AdminSortNames(t *testing.T) {
+	const path = "/api/v1/admin/songs/sort-names"
+
+	t.Run("disabled without admin token", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("RecomputeSortNames", mock.Anything).
+			Once().
+			Return(int64(0), errors.New("unknown error"))
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("RecomputeSortNames", mock.Anything).
+			Once().
+			Return(int64(3), nil)
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{"updated": 3})
+	})
+}
+
+func TestNewRouter_AdminLinkCheck(t *testing.T) {
+	const path = "/api/v1/admin/songs/link-check"
+
+	t.Run("disabled without admin token", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("already running", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("StartLinkCheck", mock.Anything, mock.Anything).
+			Once().
+			Return(fmt.Errorf("usecase.StartLinkCheck: %w", entity.ErrLinkCheckRunning))
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusConflict).
+			JSON().Object().IsEqual(linkCheckRunningResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("StartLinkCheck", mock.Anything, mock.Anything).
+			Once().
+			Return(errors.New("unknown error"))
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("StartLinkCheck", mock.Anything, mock.Anything).
+			Once().
+			Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				assert.NoError(t, ctx.Err())
+
+				done := args.Get(1).(func(checked int64, err error))
+				done(2, nil)
+			}).
+			Return(nil)
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusAccepted).
+			JSON().Object().IsEqual(map[string]any{"started": true})
+	})
+}
+
+func TestNewRouter_AdminTouch(t *testing.T) {
+	const path = "/api/v1/admin/songs/touch"
+
+	filter := entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"}
+
+	t.Run("disabled without admin token", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.POST(path).
+			WithQuery("groupName", "Queen").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("no filters", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		e.POST(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(noTouchFiltersResp)
+	})
+
+	t.Run("only empty filters", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		e.POST(path).
+			WithQuery("groupName", "").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(noTouchFiltersResp)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("TouchSongs", mock.Anything, filter).
+			Once().
+			Return(int64(0), errors.New("unknown error"))
+
+		e.POST(path).
+			WithQuery("groupName", "Queen").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("TouchSongs", mock.Anything, filter).
+			Once().
+			Return(int64(3), nil)
+
+		e.POST(path).
+			WithQuery("groupName", "Queen").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{"touched": 3})
+	})
+}
+
+func TestNewRouter_AdminRegexSongs(t *testing.T) {
+	const path = "/api/v1/admin/songs/regex"
+
+	t.Run("disabled without admin token", func(t *testing.T) {
+		e, _ := setupServer(t)
+
+		e.GET(path).
+			WithQuery("regex", "love").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("missing regex", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		e.GET(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(missingLyricsRegexResp)
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		resp := e.GET(path).
+			WithQuery("regex", "(love").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object()
+
+		resp.Value("message").IsEqual("invalid regex")
+		resp.Value("details").Array().Value(0).String().Contains("missing closing )")
+	})
+
+	t.Run("nested repetitions", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		e.GET(path).
+			WithQuery("regex", "(a+)+b").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(invalidLyricsRegexError(errors.New("pattern nests repetitions")))
+	})
+
+	t.Run("regex rejected by the database", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, entity.Pagination{Limit: entity.DefaultLimit},
+				entity.SongFilter{Field: entity.SongTextRegexFilterField, Value: `\p{Greek}`},
+			).
+			Once().
+			Return(nil, nil, fmt.Errorf("failed to fetch songs: %w", entity.ErrInvalidRegex))
+
+		e.GET(path).
+			WithQuery("regex", `\p{Greek}`).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(unsupportedLyricsRegexResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		songUseCaseMock.
+			On("FetchSongs", mock.Anything, entity.Pagination{Limit: entity.DefaultLimit},
+				entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"},
+				entity.SongFilter{Field: entity.SongTextRegexFilterField, Value: `^is this the real life\?`},
+			).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, GroupName: "Queen", Name: "Bohemian Rhapsody", CreatedAt: fixedTime, UpdatedAt: fixedTime},
+			}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+
+		resp := e.GET(path).
+			WithQuery("regex", `^is this the real life\?`).
+			WithQuery("groupName", "Queen").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+
+		resp.Value("songs").Array().Length().IsEqual(1)
+		resp.Value("pagination").Object().Value("total").IsEqual(1)
+	})
+}
+
+func TestNewRouter_RequireUserAgent(t *testing.T) {
+	t.Run("missing user agent", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{RequireUserAgent: true})
+
+		e.GET("/api/v1/songs").
+			WithHeader("User-Agent", "").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().IsEqual(missingUserAgentResp)
+	})
+
+	t.Run("exempt endpoints", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{RequireUserAgent: true})
+
+		for _, path := range []string{"/api/v1/ping", "/metrics"} {
+			e.GET(path).
+				WithHeader("User-Agent", "").
+				Expect().
+				Status(http.StatusOK)
+		}
+	})
+}
+
+func TestNewRouter_ReadOnly(t *testing.T) {
+	assertRejected := func(e *httpexpect.Expect, method, path, retryAfter string) {
+		resp := e.Request(method, path).
+			WithJSON(map[string]any{"ids": []string{fixedUUID.String()}}).
+			Expect().
+			Status(http.StatusServiceUnavailable)
+
+		resp.Header("Retry-After").IsEqual(retryAfter)
+		resp.JSON().Object().IsEqual(readOnlyModeResp)
+	}
+
+	t.Run("writes are rejected", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{ReadOnly: true, ReadOnlyRetryAfter: 90 * time.Second})
+
+		songPath := "/api/v1/songs/" + fixedUUID.String()
+
+		assertRejected(e, http.MethodPost, "/api/v1/songs", "90")
+		assertRejected(e, http.MethodPatch, "/api/v1/songs", "90")
+		assertRejected(e, http.MethodPost, "/api/v1/songs/batch-delete", "90")
+		assertRejected(e, http.MethodPatch, songPath, "90")
+		assertRejected(e, http.MethodPatch, songPath+"/release-date", "90")
+		assertRejected(e, http.MethodDelete, songPath, "90")
+	})
+
+	t.Run("reads are served", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{ReadOnly: true})
+
+		songUseCaseMock.
+			On("FetchAlphaIndex", mock.Anything, entity.AlphaIndexGroupField).
+			Once().
+			Return([]entity.LetterCount{{Letter: "M", Count: 2}}, nil)
+
+		e.GET("/api/v1/songs/alpha-index").
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("switched at runtime", func(t *testing.T) {
+		e, songUseCaseMock := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		e.PUT("/api/v1/admin/read-only").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{"readOnly": true})
+
+		e.GET("/api/v1/admin/read-only").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{"readOnly": true})
+
+		assertRejected(e, http.MethodDelete, "/api/v1/songs/"+fixedUUID.String(), "300")
+
+		e.DELETE("/api/v1/admin/read-only").
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{"readOnly": false})
+
+		songUseCaseMock.
+			On("RemoveSong", mock.Anything, fixedUUID).
+			Once().
+			Return(int64(1), nil)
+
+		e.DELETE("/api/v1/songs/{songID}", fixedUUID).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+}
+
+func TestNewRouter_AdminMigrations(t *testing.T) {
+	const path = "/api/v1/admin/migrations"
+
+	t.Run("disabled without migration status", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{AdminToken: "secret"})
+
+		e.GET(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{
+			AdminToken: "secret",
+			MigrationStatus: func() (*postgres.MigrationStatus, error) {
+				return nil, errors.New("unknown error")
+			},
+		})
+
+		e.GET(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusInternalServerError).
+			JSON().Object().IsEqual(serverErrResp)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e, _ := setupServerWithOptions(t, &RouterOptions{
+			AdminToken: "secret",
+			MigrationStatus: func() (*postgres.MigrationStatus, error) {
+				return &postgres.MigrationStatus{
+					Version: 2,
+					Dirty:   true,
+					Files:   []string{"000001_init.down.sql", "000001_init.up.sql", "000002_add.up.sql"},
+				}, nil
+			},
+		})
+
+		e.GET(path).
+			WithHeader("Authorization", "Bearer secret").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().IsEqual(map[string]any{
+			"version": 2,
+			"dirty":   true,
+			"files":   []string{"000001_init.down.sql", "000001_init.up.sql", "000002_add.up.sql"},
+		})
+	})
+}
@@ -1,14 +1,27 @@
 package http
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"html"
+	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"regexp/syntax"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"golang.org/x/text/unicode/norm"
 )
 
 // songSchema represents the structure of a song entity for API responses.
@@ -17,23 +30,27 @@ import (
 //	@Description	Represents the structure of a song entity for API responses.
 //	@Tags			songs
 type songSchema struct {
-	ID         uuid.UUID        `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	GroupName  string           `json:"groupName" example:"The Beatles"`
-	Name       string           `json:"name" example:"Hey Jude"`
-	SongDetail songDetailSchema `json:"songDetail"`
-	CreatedAt  time.Time        `json:"created_at" example:"2024-10-05T14:48:00Z"`
-	UpdatedAt  time.Time        `json:"updated_at" example:"2024-10-06T09:12:00Z"`
+	ID           uuid.UUID         `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	GroupName    string            `json:"groupName" example:"The Beatles"`
+	Name         string            `json:"name" example:"Hey Jude"`
+	SongDetail   *songDetailSchema `json:"songDetail,omitempty"`
+	OriginalLink string            `json:"originalLink,omitempty" example:"http://Example.com/heyjude?utm_source=newsletter"`
+	DetailSource string            `json:"detailSource,omitempty" enums:"music_info_api,client,backfill,heuristic" example:"music_info_api"`
+	DetailStatus string            `json:"detailStatus,omitempty" enums:"found,not_found,failed" example:"found"`
+	CreatedAt    time.Time         `json:"created_at" example:"2024-10-05T14:48:00Z"`
+	UpdatedAt    time.Time         `json:"updated_at" example:"2024-10-06T09:12:00Z"`
 }
 
 // songDetailSchema represents detailed information about a song.
-// It includes the release date, text, and a link to the song.
+// It includes the release date, text, and a link to the song. Details that are not known are omitted.
 //
-//	@Description	Represents detailed information about a song.
+//	@Description	Represents detailed information about a song. Details that are not known are omitted.
 //	@Tags			songs
 type songDetailSchema struct {
-	ReleaseDate string `json:"releaseDate" example:"02.01.1968"`
-	Text        string `json:"text" example:"Hey Jude, don't make it bad..."`
-	Link        string `json:"link" example:"https://example.com/heyjude"`
+	ReleaseDate string `json:"releaseDate,omitempty" example:"02.01.1968"`
+	Text        string `json:"text,omitempty" example:"Hey Jude, don't make it bad..."`
+	Link        string `json:"link,omitempty" example:"https://example.com/heyjude"`
+	Provider    string `json:"provider,omitempty" example:"music.info.api"`
 }
 
 // songWithVersesSchema is a structure used for responses containing a song and its verses.
@@ -45,10 +62,89 @@ type songWithVersesSchema struct {
 	GroupName string    `json:"groupName" example:"Queen"`
 	Name      string    `json:"name" example:"Bohemian Rhapsody"`
 	Verses    []string  `json:"verses" example:"Is this the real life?,Is this just fantasy?"`
+	Repeats   []int     `json:"repeats,omitempty" example:"1,2"`
 	CreatedAt time.Time `json:"created_at" example:"2024-10-05T14:48:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"2024-10-06T09:12:00Z"`
 }
 
+// indexedVerseSchema represents a single verse of a song text along with its index in the whole text.
+//
+//	@Description	Represents a single verse of a song text along with its index in the whole text.
+//	@Tags			songs
+type indexedVerseSchema struct {
+	Index int    `json:"index" example:"0"`
+	Text  string `json:"text" example:"Is this the real life?"`
+}
+
+// songWithIndexedVersesSchema is a structure used for responses containing a song and its indexed verses.
+//
+//	@Description	Represents a song and its verses along with their indices for API responses.
+//	@Tags			songs
+type songWithIndexedVersesSchema struct {
+	ID        uuid.UUID            `json:"id" example:"123e4567-e89b-12d3-a456-426614174001"`
+	GroupName string               `json:"groupName" example:"Queen"`
+	Name      string               `json:"name" example:"Bohemian Rhapsody"`
+	Verses    []indexedVerseSchema `json:"verses"`
+	Repeats   []int                `json:"repeats,omitempty" example:"1,2"`
+	CreatedAt time.Time            `json:"created_at" example:"2024-10-05T14:48:00Z"`
+	UpdatedAt time.Time            `json:"updated_at" example:"2024-10-06T09:12:00Z"`
+}
+
+// numberedLineSchema represents a single line of a song text along with its line number.
+//
+//	@Description	Represents a single line of a song text along with its line number.
+//	@Tags			songs
+type numberedLineSchema struct {
+	LineNumber int    `json:"lineNumber" example:"1"`
+	Text       string `json:"text" example:"Is this the real life?"`
+}
+
+// verseMatchSchema represents a verse matching a search query along with the verses around it.
+//
+//	@Description	Represents a verse matching a search query along with the verses around it.
+//	@Tags			songs
+type verseMatchSchema struct {
+	Index  int      `json:"index" example:"1"`
+	Start  int      `json:"start" example:"0"`
+	Verses []string `json:"verses" example:"Is this the real life?,Is this just fantasy?"`
+}
+
+// lineDiffSchema represents a single line of a line-level diff between two song texts.
+//
+//	@Description	Represents a single line of a line-level diff between two song texts.
+//	@Tags			songs
+type lineDiffSchema struct {
+	Operation string `json:"operation" enums:"unchanged,added,removed" example:"added"`
+	Line      string `json:"line" example:"Is this just fantasy?"`
+}
+
+// decadeCountSchema represents the number of songs released within a decade.
+//
+//	@Description	Represents the number of songs released within a decade.
+//	@Tags			stats
+type decadeCountSchema struct {
+	Decade int    `json:"decade" example:"1970"`
+	Count  uint64 `json:"count" example:"42"`
+}
+
+// yearCountSchema represents the number of songs released within a year.
+//
+//	@Description	Represents the number of songs released within a year.
+//	@Tags			groups
+type yearCountSchema struct {
+	Year  int    `json:"year" example:"1971"`
+	Count uint64 `json:"count" example:"8"`
+}
+
+// facetCountSchema represents the number of matching songs sharing a value of a facet.
+//
+//	@Description	Represents the number of matching songs sharing a value of a facet.
+//	@Tags			songs
+type facetCountSchema struct {
+	Value string `json:"value" example:"1971"`
+	Count uint64 `json:"count" example:"8"`
+}
+
 // paginationSchema represents pagination metadata for API responses.
 //
 //	@Description	Represents pagination metadata for API responses.
@@ -57,7 +153,36 @@ type paginationSchema struct {
 	Offset uint64 `json:"offset" example:"0"`
 	Limit  uint64 `json:"limit" example:"10"`
 	Items  uint64 `json:"items" example:"2"`
-	Total  uint64 `json:"total" example:"100"`
+	Total  uint64 `json:"total,omitempty" example:"100"`
+
+	asStrings bool // asStrings makes the numbers be serialized as JSON strings.
+	omitTotal bool // omitTotal leaves the total out, for pages fetched without counting it.
+}
+
+// MarshalJSON serializes the pagination numbers as JSON numbers, or as JSON strings when asStrings is set,
+// so that clients parsing numbers as doubles, such as JavaScript, don't lose precision of very large values.
+// The total is left out when omitTotal is set.
+func (p paginationSchema) MarshalJSON() ([]byte, error) {
+	var total *uint64
+	if !p.omitTotal {
+		total = &p.Total
+	}
+
+	if !p.asStrings {
+		return json.Marshal(struct {
+			Offset uint64  `json:"offset"`
+			Limit  uint64  `json:"limit"`
+			Items  uint64  `json:"items"`
+			Total  *uint64 `json:"total,omitempty"`
+		}{p.Offset, p.Limit, p.Items, total})
+	}
+
+	return json.Marshal(struct {
+		Offset uint64  `json:"offset,string"`
+		Limit  uint64  `json:"limit,string"`
+		Items  uint64  `json:"items,string"`
+		Total  *uint64 `json:"total,string,omitempty"`
+	}{p.Offset, p.Limit, p.Items, total})
 }
 
 // addSongRequest defines the expected structure for requests to add a new song.
@@ -65,8 +190,8 @@ type paginationSchema struct {
 //	@Description	Defines the expected structure for requests to add a new song.
 //	@Tags			songs
 type addSongRequest struct {
-	Group string `json:"group" validate:"required" example:"The Rolling Stones"`
-	Song  string `json:"song" validate:"required" example:"Paint It Black"`
+	Group string `json:"group" validate:"required,max=255" example:"The Rolling Stones"`
+	Song  string `json:"song" validate:"required,max=255" example:"Paint It Black"`
 }
 
 // updateSongRequest defines the expected structure for requests to update an existing song.
@@ -74,11 +199,69 @@ type addSongRequest struct {
 //	@Description	Defines the expected structure for requests to update an existing song.
 //	@Tags			songs
 type updateSongRequest struct {
-	GroupName   string `json:"groupName" example:"Led Zeppelin"`
-	Name        string `json:"name" example:"Stairway to Heaven"`
+	GroupName   string `json:"groupName" validate:"omitempty,max=255" example:"Led Zeppelin"`
+	Name        string `json:"name" validate:"omitempty,max=255" example:"Stairway to Heaven"`
 	ReleaseDate string `json:"releaseDate" validate:"omitempty,releaseDate" example:"08.11.1971"`
 	Text        string `json:"text" example:"There's a lady who's sure..."`
-	Link        string `json:"link" validate:"url,omitempty" example:"https://example.com/stairway"`
+	Link        string `json:"link" validate:"omitempty,url" example:"https://example.com/stairway"`
+}
+
+// updateReleaseDateRequest defines the expected structure for requests to update only the release date of a song.
+//
+//	@Description	Defines the expected structure for requests to update only the release date of a song.
+//	@Tags			songs
+type updateReleaseDateRequest struct {
+	ReleaseDate string `json:"releaseDate" validate:"required,releaseDate" example:"08.11.1971"`
+}
+
+// reorderVersesRequest defines the expected structure for requests to reorder the verses of a song.
+//
+//	@Description	Defines the expected structure for requests to reorder the verses of a song.
+//	@Description	The verse at order[i] becomes the i-th verse, so order must hold every verse index exactly once.
+//	@Tags			songs
+type reorderVersesRequest struct {
+	Order []int `json:"order" validate:"required,min=1" example:"1,0,2"`
+}
+
+// releaseDateUpdateRequest defines the expected structure of a single item of a bulk release date update.
+//
+//	@Description	Defines the expected structure of a single item of a bulk release date update.
+//	@Tags			songs
+type releaseDateUpdateRequest struct {
+	ID          string `json:"id" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ReleaseDate string `json:"releaseDate" validate:"required,releaseDate" example:"08.11.1971"`
+}
+
+// songsWithVersesRequest defines the expected structure for requests to fetch the verses of several songs.
+//
+//	@Description	Defines the expected structure for requests to fetch the verses of several songs.
+//	@Tags			songs
+type songsWithVersesRequest struct {
+	IDs []string `json:"ids" validate:"dive,uuid" example:"123e4567-e89b-12d3-a456-426614174000,123e4567-e89b-12d3-a456-426614174001"`
+}
+
+// songsDeleteRequest defines the expected structure for requests to delete several songs.
+//
+//	@Description	Defines the expected structure for requests to delete several songs.
+//	@Tags			songs
+type songsDeleteRequest struct {
+	IDs []string `json:"ids" validate:"dive,uuid" example:"123e4567-e89b-12d3-a456-426614174000,123e4567-e89b-12d3-a456-426614174001"`
+}
+
+// previewVersesRequest defines the expected structure for requests to preview verse splitting of a text.
+//
+//	@Description	Defines the expected structure for requests to preview verse splitting of a text.
+//	@Tags			text
+type previewVersesRequest struct {
+	Text string `json:"text" validate:"required" example:"Is this the real life?\n\nIs this just fantasy?"`
+}
+
+// validateReleaseDateRequest defines the expected structure for requests to validate a release date.
+//
+//	@Description	Defines the expected structure for requests to validate a release date.
+//	@Tags			validate
+type validateReleaseDateRequest struct {
+	ReleaseDate string `json:"releaseDate" validate:"required" example:"19.06.2006"`
 }
 
 // songsResponse represents the structure of the response for fetching multiple songs.
@@ -86,8 +269,229 @@ type updateSongRequest struct {
 //	@Description	Represents the structure of the response for fetching multiple songs.
 //	@Tags			songs
 type songsResponse struct {
-	Songs      []songSchema     `json:"songs"`
-	Pagination paginationSchema `json:"pagination"`
+	Songs      []songSchema                  `json:"songs"`
+	Pagination paginationSchema              `json:"pagination"`
+	Facets     map[string][]facetCountSchema `json:"facets,omitempty"`
+}
+
+// halMediaType is the media type of HAL+JSON representations, negotiated with the Accept header.
+const halMediaType = "application/hal+json"
+
+// halLink is a link of a HAL+JSON representation.
+type halLink struct {
+	Href string `json:"href" example:"/api/v1/songs/123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// halSongLinks are the links of a song in its HAL+JSON representation.
+type halSongLinks struct {
+	Self   halLink `json:"self"`
+	Verses halLink `json:"verses"`
+}
+
+// halSongSchema is the HAL+JSON representation of a song, linking to the song and its verses.
+//
+//	@Description	Represents a song for API responses in HAL+JSON, linking to the song and its verses.
+//	@Tags			songs
+type halSongSchema struct {
+	songSchema
+	Links halSongLinks `json:"_links"`
+}
+
+// halSongsLinks are the links of a page of songs in its HAL+JSON representation.
+// Next and previous pages are linked only when they exist.
+type halSongsLinks struct {
+	Self halLink  `json:"self"`
+	Next *halLink `json:"next,omitempty"`
+	Prev *halLink `json:"prev,omitempty"`
+}
+
+// halSongsEmbedded holds the songs embedded in a page of songs in its HAL+JSON representation.
+type halSongsEmbedded struct {
+	Songs []halSongSchema `json:"songs"`
+}
+
+// halSongsResponse represents the structure of the response for fetching multiple songs in HAL+JSON.
+//
+//	@Description	Represents the structure of the response for fetching multiple songs in HAL+JSON.
+//	@Tags			songs
+type halSongsResponse struct {
+	Links      halSongsLinks                 `json:"_links"`
+	Embedded   halSongsEmbedded              `json:"_embedded"`
+	Pagination paginationSchema              `json:"pagination"`
+	Facets     map[string][]facetCountSchema `json:"facets,omitempty"`
+}
+
+// songPath returns the path of a song, as linked from its representations.
+func songPath(songID uuid.UUID) string {
+	return "/api/v1/songs/" + songID.String()
+}
+
+// halSongLinksFor returns the HAL links of a song to itself and to its verses.
+func halSongLinksFor(songID uuid.UUID) halSongLinks {
+	return halSongLinks{
+		Self:   halLink{Href: songPath(songID)},
+		Verses: halLink{Href: songPath(songID) + "/text"},
+	}
+}
+
+// halPageLinks returns the HAL links of a page of a listing to itself and to the pages around it.
+// The links keep the query of the request, with the offset and limit of the linked page.
+func halPageLinks(r *http.Request, pgn *entity.Pagination) halSongsLinks {
+	pageLink := func(offset uint64) *halLink {
+		query := r.URL.Query()
+		query.Set("offset", strconv.FormatUint(offset, 10))
+		query.Set("limit", strconv.FormatUint(pgn.Limit, 10))
+
+		return &halLink{Href: r.URL.Path + "?" + query.Encode()}
+	}
+
+	links := halSongsLinks{Self: halLink{Href: r.URL.RequestURI()}}
+
+	// Without the total, a full page is assumed to be followed by another one.
+	if pgn.SkipTotal && pgn.Limit > 0 && pgn.Items == pgn.Limit || !pgn.SkipTotal && pgn.Offset+pgn.Limit < pgn.Total {
+		links.Next = pageLink(pgn.Offset + pgn.Limit)
+	}
+	if pgn.Offset > 0 {
+		links.Prev = pageLink(pgn.Offset - min(pgn.Offset, pgn.Limit))
+	}
+
+	return links
+}
+
+// recentSongsResponse represents the structure of the response for fetching the most recently added songs.
+//
+//	@Description	Represents the structure of the response for fetching the most recently added songs.
+//	@Tags			songs
+type recentSongsResponse struct {
+	Songs []songSchema `json:"songs"`
+}
+
+// letterCountSchema represents the number of groups or songs whose names start with a letter.
+//
+//	@Description	Represents the number of groups or songs whose names start with a letter.
+//	@Tags			songs
+type letterCountSchema struct {
+	Letter string `json:"letter" example:"Q"`
+	Count  uint64 `json:"count" example:"3"`
+}
+
+// alphaIndexResponse represents the structure of the response for fetching the alphabetical index of groups or songs.
+//
+//	@Description	Represents the structure of the response for fetching the alphabetical index of groups or songs.
+//	@Tags			songs
+type alphaIndexResponse struct {
+	By      string              `json:"by" enums:"group,song" example:"group"`
+	Letters []letterCountSchema `json:"letters"`
+}
+
+// decadeStatsResponse represents the structure of the response for fetching song counts per decade.
+//
+//	@Description	Represents the structure of the response for fetching song counts per decade.
+//	@Tags			stats
+type decadeStatsResponse struct {
+	Decades []decadeCountSchema `json:"decades"`
+}
+
+// groupFacetsResponse represents the structure of the response for fetching the filterable values of a group.
+//
+//	@Description	Represents the structure of the response for fetching the filterable values of a group.
+//	@Tags			groups
+type groupFacetsResponse struct {
+	GroupName    string            `json:"groupName" example:"Led Zeppelin"`
+	ReleaseYears []yearCountSchema `json:"releaseYears"`
+}
+
+// songReleaseSchema represents a song of a group identified by its name and release date.
+//
+//	@Description	Represents a song of a group identified by its name and release date.
+//	@Tags			groups
+type songReleaseSchema struct {
+	Name        string `json:"name" example:"Love Me Do"`
+	ReleaseDate string `json:"releaseDate" example:"05.10.1962"`
+}
+
+// groupReleaseRangeResponse represents the structure of the response for fetching the release range of a group.
+//
+//	@Description	Represents the earliest and the latest released songs of a group.
+//	@Tags			groups
+type groupReleaseRangeResponse struct {
+	GroupName string            `json:"groupName" example:"The Beatles"`
+	First     songReleaseSchema `json:"first"`
+	Last      songReleaseSchema `json:"last"`
+}
+
+// suggestionsResponse represents the structure of the response for group or song name suggestions.
+//
+//	@Description	Represents the structure of the response for group or song name suggestions.
+//	@Tags			songs
+type suggestionsResponse struct {
+	Suggestions []string `json:"suggestions" example:"Beastie Boys,The Beatles"`
+}
+
+// importRowErrorSchema describes why a row of an imported file was rejected.
+//
+//	@Description	Describes why a row of an imported file was rejected.
+//	@Tags			songs
+type importRowErrorSchema struct {
+	Row     int      `json:"row" example:"3"`
+	Details []string `json:"details" example:"song: required field"`
+}
+
+// importSongsResponse represents the structure of the response for importing songs from a file.
+//
+//	@Description	Represents the structure of the response for importing songs from a file.
+//	@Tags			songs
+type importSongsResponse struct {
+	ImportID uuid.UUID              `json:"importId" example:"123e4567-e89b-12d3-a456-426614174009"`
+	Songs    []songSchema           `json:"songs"`
+	Errors   []importRowErrorSchema `json:"errors"`
+}
+
+// importRolledBackResponse represents the structure of the response for rolling back a batch import.
+//
+//	@Description	Represents the structure of the response for rolling back a batch import.
+//	@Tags			imports
+type importRolledBackResponse struct {
+	ImportID uuid.UUID `json:"importId" example:"123e4567-e89b-12d3-a456-426614174009"`
+	Deleted  int64     `json:"deleted" example:"12"`
+}
+
+// Outcomes of a single item of a bulk release date update.
+const (
+	releaseDateUpdateStatusUpdated  = "updated"
+	releaseDateUpdateStatusNotFound = "notFound"
+	releaseDateUpdateStatusInvalid  = "invalid"
+)
+
+// releaseDateUpdateResultSchema describes the outcome of a single item of a bulk release date update.
+//
+//	@Description	Describes the outcome of a single item of a bulk release date update.
+//	@Tags			songs
+type releaseDateUpdateResultSchema struct {
+	Index   int         `json:"index" example:"0"`
+	ID      string      `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status  string      `json:"status" enums:"updated,notFound,invalid" example:"updated"`
+	Song    *songSchema `json:"song,omitempty"`
+	Details []string    `json:"details,omitempty" example:"releaseDate: invalid format, must be like '02.01.2006'"`
+}
+
+// releaseDatesUpdateResponse represents the structure of the response for updating the release dates of several songs.
+//
+//	@Description	Represents the structure of the response for updating the release dates of several songs.
+//	@Tags			songs
+type releaseDatesUpdateResponse struct {
+	Results []releaseDateUpdateResultSchema `json:"results"`
+}
+
+// songLinesResponse represents the structure of the response for fetching the numbered lines of a song.
+//
+//	@Description	Represents the structure of the response for fetching the numbered lines of a song.
+//	@Tags			songs
+type songLinesResponse struct {
+	ID        uuid.UUID            `json:"id" example:"123e4567-e89b-12d3-a456-426614174001"`
+	GroupName string               `json:"groupName" example:"Queen"`
+	Name      string               `json:"name" example:"Bohemian Rhapsody"`
+	Lines     []numberedLineSchema `json:"lines"`
 }
 
 // songWithVersesResponse represents the structure of the response for fetching a song with its verses.
@@ -99,118 +503,1324 @@ type songWithVersesResponse struct {
 	Pagination paginationSchema     `json:"pagination"`
 }
 
-// parsePagination extracts pagination parameters from the HTTP request query.
-func parsePagination(r *http.Request) entity.Pagination {
-	getUintQueryParam := func(key string, defaultValue uint64) uint64 {
-		param := r.URL.Query().Get(key)
-		if param != "" {
-			value, err := strconv.ParseUint(param, 10, 64)
-			if err == nil {
-				return value
-			}
-		}
+// songWithIndexedVersesResponse represents the structure of the response for fetching a song with its verses
+// along with their indices.
+//
+//	@Description	Represents the structure of the response for fetching a song with its verses along with their indices.
+//	@Tags			songs
+type songWithIndexedVersesResponse struct {
+	Song       songWithIndexedVersesSchema `json:"song"`
+	Pagination paginationSchema            `json:"pagination"`
+}
 
-		return defaultValue
-	}
+// songsWithVersesResponse represents the structure of the response for fetching the verses of several songs.
+//
+//	@Description	Represents the structure of the response for fetching the verses of several songs.
+//	@Tags			songs
+type songsWithVersesResponse struct {
+	Songs    []songWithVersesResponse `json:"songs"`
+	NotFound []uuid.UUID              `json:"notFound" example:"123e4567-e89b-12d3-a456-426614174001"`
+}
 
-	pagination := entity.Pagination{
-		Offset: getUintQueryParam("offset", entity.DefaultOffset),
-		Limit:  getUintQueryParam("limit", entity.DefaultLimit),
-	}
+// songsDeletedResponse represents the structure of the response for deleting several songs.
+//
+//	@Description	Represents the structure of the response for deleting several songs.
+//	@Tags			songs
+type songsDeletedResponse struct {
+	Deleted     int         `json:"deleted" example:"1"`
+	NotFound    int         `json:"notFound" example:"1"`
+	NotFoundIDs []uuid.UUID `json:"notFoundIds" example:"123e4567-e89b-12d3-a456-426614174001"`
+}
 
-	return pagination
+// verseMatchesResponse represents the structure of the response for searching the verses of a song.
+//
+//	@Description	Represents the structure of the response for searching the verses of a song.
+//	@Tags			songs
+type verseMatchesResponse struct {
+	SongID  uuid.UUID          `json:"songId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Query   string             `json:"query" example:"fantasy"`
+	Matches []verseMatchSchema `json:"matches"`
 }
 
-// parseSongFilters extracts song filter criteria from the HTTP request query.
-func parseSongFilters(r *http.Request) []entity.SongFilter {
-	var filters []entity.SongFilter
+// randomVerseResponse represents the structure of the response for fetching a random verse of a song.
+//
+//	@Description	Represents the structure of the response for fetching a random verse of a song.
+//	@Tags			songs
+type randomVerseResponse struct {
+	SongID uuid.UUID `json:"songId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Index  int       `json:"index" example:"1"`
+	Verse  string    `json:"verse" example:"Is this the real life?\nIs this just fantasy?"`
+}
 
-	addStringFilter := func(param string, field entity.SongFilterField) {
-		if param != "" {
-			filters = append(filters, entity.SongFilter{
-				Field: field,
-				Value: param,
-			})
-		}
-	}
+// textStatsResponse represents the structure of the response for fetching the word and line counts of a song text.
+//
+//	@Description	Represents the structure of the response for fetching the word and line counts of a song text.
+//	@Tags			songs
+type textStatsResponse struct {
+	SongID      uuid.UUID `json:"songId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Words       int       `json:"words" example:"120"`
+	Lines       int       `json:"lines" example:"24"`
+	Verses      int       `json:"verses" example:"6"`
+	UniqueWords int       `json:"uniqueWords" example:"64"`
+}
 
-	addIntFilter := func(param string, field entity.SongFilterField) {
-		if param != "" {
-			value, err := strconv.Atoi(param)
-			if err == nil {
-				filters = append(filters, entity.SongFilter{
-					Field: field,
-					Value: value,
-				})
-			}
-		}
-	}
+// oEmbedSchema represents an oEmbed response describing a song, so it can be embedded in other sites.
+//
+//	@Description	Represents an oEmbed response describing a song.
+//	@Tags			songs
+type oEmbedSchema struct {
+	Version    string `json:"version" example:"1.0"`
+	Type       string `json:"type" example:"rich"`
+	Title      string `json:"title" example:"Hey Jude"`
+	AuthorName string `json:"author_name" example:"The Beatles"`
+	HTML       string `json:"html" example:"<a href=\"https://songs.example.com/api/v1/songs/123e4567-e89b-12d3-a456-426614174000/text\">Hey Jude - The Beatles</a>"`
+	Width      int    `json:"width" example:"400"`
+	Height     int    `json:"height" example:"60"`
+}
 
-	addDateFilter := func(param string, field entity.SongFilterField) {
-		if param != "" {
-			value, err := time.Parse("02.01.2006", param)
-			if err == nil {
-				filters = append(filters, entity.SongFilter{
-					Field: field,
-					Value: value,
-				})
-			}
-		}
+// Size of the snippet embedded by oEmbed consumers, in pixels.
+const (
+	oEmbedWidth  = 400
+	oEmbedHeight = 60
+)
+
+// absoluteURL returns the absolute URL of a path and query, built from the host the request was sent to.
+func absoluteURL(r *http.Request, path string, query url.Values) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
 	}
 
-	query := r.URL.Query()
+	return (&url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     path,
+		RawQuery: query.Encode(),
+	}).String()
+}
+
+// songPermalink returns the absolute URL of the text of a song, built from the host the request was sent to.
+func songPermalink(r *http.Request, songID uuid.UUID) string {
+	return absoluteURL(r, "/api/v1/songs/"+songID.String()+"/text", nil)
+}
 
-	addStringFilter(query.Get("groupName"), entity.SongGroupNameFilterField)
-	addStringFilter(query.Get("name"), entity.SongNameFilterField)
-	addIntFilter(query.Get("releaseYear"), entity.SongReleaseYearFilterField)
-	addDateFilter(query.Get("releaseDate"), entity.SongReleaseDateFilterField)
-	addDateFilter(query.Get("releaseDateAfter"), entity.SongReleaseDateAfterFilterField)
-	addDateFilter(query.Get("releaseDateBefore"), entity.SongReleaseDateBeforeFilterField)
-	addStringFilter(query.Get("text"), entity.SongTextFilterField)
+// sitemapMaxURLs is the maximum number of URLs a single sitemap may list, as set by the sitemap protocol.
+const sitemapMaxURLs = 50000
 
-	return filters
+// sitemapNamespace is the XML namespace of sitemaps and sitemap indexes.
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// errSitemapPageFull is returned while walking the catalog once a sitemap page lists its maximum number of URLs.
+var errSitemapPageFull = errors.New("sitemap page full")
+
+// sitemapURL is an entry of a sitemap, listing the permalink of a song and the time it was last modified.
+type sitemapURL struct {
+	XMLName xml.Name `xml:"url"`
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod"`
 }
 
-const statusError = "error"
+// sitemapIndex lists the pages of a sitemap too large for a single page.
+type sitemapIndex struct {
+	XMLName  xml.Name            `xml:"sitemapindex"`
+	Xmlns    string              `xml:"xmlns,attr"`
+	Sitemaps []sitemapIndexEntry `xml:"sitemap"`
+}
 
-// errorResponse represents the structure of error responses from the API.
-//
-//	@Description	Represents the structure of error responses from the API.
-//	@Tags			errors
-type errorResponse struct {
-	Status  string   `json:"status" example:"error"`
-	Message string   `json:"message" example:"invalid request body"`
-	Details []string `json:"details,omitempty" example:"Group name is required,Song name is required"`
+// sitemapIndexEntry is an entry of a sitemap index, linking to one sitemap page.
+type sitemapIndexEntry struct {
+	Loc string `xml:"loc"`
 }
 
-// Predefined error responses for common scenarios
-var (
-	emptyRequestBodyResp = errorResponse{
-		Status:  statusError,
-		Message: "empty request body",
+// sitemapPages returns the number of sitemap pages needed to list count songs. An empty catalog still has one empty page.
+func sitemapPages(count uint64) uint64 {
+	return max(1, (count+sitemapMaxURLs-1)/sitemapMaxURLs)
+}
+
+// parseSitemapPage parses the 1-based sitemap page number. An empty parameter selects the first page.
+func parseSitemapPage(param string) (uint64, error) {
+	if param == "" {
+		return 1, nil
 	}
 
-	invalidRequestBodyResp = errorResponse{
-		Status:  statusError,
-		Message: "invalid request body",
+	page, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if page == 0 {
+		return 0, errors.New("page must be positive")
 	}
 
-	invalidSongIDParamResp = errorResponse{
-		Status:  statusError,
-		Message: "invalid song id param",
+	return page, nil
+}
+
+// songOEmbedHTML builds the snippet embedding a song: a link to its permalink titled with the song and group names.
+func songOEmbedHTML(permalink, groupName, name string) string {
+	return fmt.Sprintf(`<a href="%s">%s - %s</a>`,
+		html.EscapeString(permalink), html.EscapeString(name), html.EscapeString(groupName))
+}
+
+// parseVerseContext parses the number of verses of context returned around every verse matching a search,
+// which defaults to zero.
+func parseVerseContext(param string) (int, bool) {
+	if param == "" {
+		return 0, true
 	}
 
-	songNotFoundErrResp = errorResponse{
-		Status:  statusError,
-		Message: "song not found",
+	value, err := strconv.Atoi(param)
+	if err != nil || value < 0 || value > entity.MaxVerseMatchContext {
+		return 0, false
 	}
 
-	serverErrResp = errorResponse{
+	return value, true
+}
+
+// songTextDiffResponse represents the structure of the response for comparing the texts of two songs.
+//
+//	@Description	Represents the structure of the response for comparing the texts of two songs.
+//	@Tags			songs
+type songTextDiffResponse struct {
+	SongID      uuid.UUID        `json:"songId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	OtherSongID uuid.UUID        `json:"otherSongId" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Lines       []lineDiffSchema `json:"lines"`
+}
+
+// versesPreviewResponse represents the structure of the response for previewing verse splitting of a text.
+//
+//	@Description	Represents the structure of the response for previewing verse splitting of a text.
+//	@Tags			text
+type versesPreviewResponse struct {
+	Verses []string `json:"verses" example:"Is this the real life?,Is this just fantasy?"`
+}
+
+// releaseDateValidationResponse represents the structure of the response for validating a release date.
+//
+//	@Description	Represents the structure of the response for validating a release date.
+//	@Tags			validate
+type releaseDateValidationResponse struct {
+	Valid       bool   `json:"valid" example:"true"`
+	ReleaseDate string `json:"releaseDate,omitempty" example:"2006-06-19"`
+}
+
+// migrationsResponse represents the structure of the response for the state of the database migrations.
+//
+//	@Description	Represents the structure of the response for the state of the database migrations.
+//	@Tags			admin
+type migrationsResponse struct {
+	Version uint     `json:"version" example:"11"`
+	Dirty   bool     `json:"dirty" example:"false"`
+	Files   []string `json:"files" example:"000001_create_tables.down.sql,000001_create_tables.up.sql"`
+}
+
+// readOnlyResponse represents the structure of the response for the read-only mode of the API.
+//
+//	@Description	Represents the structure of the response for the read-only mode of the API.
+//	@Tags			admin
+type readOnlyResponse struct {
+	ReadOnly bool `json:"readOnly" example:"true"`
+}
+
+// resplitVersesResponse represents the structure of the response for recomputing the verse counts of the songs.
+//
+//	@Description	Represents the structure of the response for recomputing the verse counts of the songs.
+//	@Tags			admin
+type resplitVersesResponse struct {
+	Updated int64 `json:"updated" example:"42"`
+}
+
+// recomputeSortNamesResponse represents the structure of the response for recomputing the sort names of the songs.
+//
+//	@Description	Represents the structure of the response for recomputing the sort names of the songs.
+//	@Tags			admin
+type recomputeSortNamesResponse struct {
+	Updated int64 `json:"updated" example:"42"`
+}
+
+// songsTouchedResponse represents the structure of the response for touching the songs matching filters.
+//
+//	@Description	Represents the structure of the response for touching the songs matching filters.
+//	@Tags			admin
+type songsTouchedResponse struct {
+	Touched int64 `json:"touched" example:"12"`
+}
+
+// songsUpdatedResponse represents the structure of the response for modifying all songs matching filters.
+//
+//	@Description	Represents the structure of the response for modifying all songs matching filters.
+//	@Tags			songs
+type songsUpdatedResponse struct {
+	Updated int64 `json:"updated" example:"12"`
+}
+
+// linkCheckStartedResponse represents the structure of the response for starting a link check.
+//
+//	@Description	Represents the structure of the response for starting a link check.
+//	@Tags			admin
+type linkCheckStartedResponse struct {
+	Started bool `json:"started" example:"true"`
+}
+
+// parsePagination extracts pagination parameters from the HTTP request query.
+func parsePagination(r *http.Request) entity.Pagination {
+	getUintQueryParam := func(key string, defaultValue uint64) uint64 {
+		param := r.URL.Query().Get(key)
+		if param != "" {
+			value, err := strconv.ParseUint(param, 10, 64)
+			if err == nil {
+				return value
+			}
+		}
+
+		return defaultValue
+	}
+
+	pagination := entity.Pagination{
+		Offset: getUintQueryParam("offset", entity.DefaultOffset),
+		Limit:  getUintQueryParam("limit", entity.DefaultLimit),
+	}
+
+	return pagination
+}
+
+// parseWithTotal extracts whether the total number of songs is counted for a song listing from the withTotal
+// query parameter of the HTTP request. Missing or invalid values fall back to defaultValue.
+func parseWithTotal(r *http.Request, defaultValue bool) bool {
+	withTotal, err := strconv.ParseBool(r.URL.Query().Get("withTotal"))
+	if err != nil {
+		return defaultValue
+	}
+
+	return withTotal
+}
+
+// parseRecentLimit extracts the number of recent songs to fetch from the HTTP request query.
+// Missing or invalid values fall back to entity.DefaultRecentLimit and values above entity.MaxRecentLimit are capped.
+func parseRecentLimit(r *http.Request) uint64 {
+	limit, err := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil || limit == 0 {
+		return entity.DefaultRecentLimit
+	}
+
+	return min(limit, entity.MaxRecentLimit)
+}
+
+// exportFileNameReplacer replaces the characters that are not allowed in file names on common file systems.
+var exportFileNameReplacer = strings.NewReplacer(
+	"/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_", "<", "_", ">", "_", "|", "_",
+)
+
+// exportFileName builds the name of the file a song is exported to, such as "Muse - Hysteria.json".
+func exportFileName(groupName, name string) string {
+	return exportFileNameReplacer.Replace(fmt.Sprintf("%s - %s.json", groupName, name))
+}
+
+// pathParam returns the decoded value of a URL path parameter. Parameters are taken from the escaped path
+// when the request path contains escaped characters such as %2F, so they are unescaped here.
+func pathParam(r *http.Request, key string) string {
+	param := chi.URLParam(r, key)
+	if r.URL.RawPath == "" {
+		return param
+	}
+
+	if unescaped, err := url.PathUnescape(param); err == nil {
+		return unescaped
+	}
+	return param
+}
+
+// maxIDParamLength is the length of the longest UUID form accepted by uuid.Parse, "urn:uuid:" followed by 36 characters.
+const maxIDParamLength = 45
+
+// errIDParamTooLong is returned by parseIDParam for values longer than any UUID form.
+var errIDParamTooLong = errors.New("id param too long")
+
+// parseIDParam parses a UUID path parameter. Values longer than any UUID form are rejected before parsing,
+// so that oversized parameters fail fast.
+func parseIDParam(param string) (uuid.UUID, error) {
+	if len(param) > maxIDParamLength {
+		return uuid.Nil, fmt.Errorf("%w: %d characters", errIDParamTooLong, len(param))
+	}
+
+	return uuid.Parse(param)
+}
+
+// parseDetailStatus converts the detailStatus query parameter to an entity.DetailStatus.
+func parseDetailStatus(param string) (entity.DetailStatus, bool) {
+	switch status := entity.DetailStatus(param); status {
+	case entity.DetailStatusFound, entity.DetailStatusNotFound, entity.DetailStatusFailed:
+		return status, true
+	default:
+		return "", false
+	}
+}
+
+// parseSuggestField converts the field query parameter of name suggestions to an entity.SuggestField.
+func parseSuggestField(param string) (entity.SuggestField, bool) {
+	switch param {
+	case "group":
+		return entity.SuggestGroupNameField, true
+	case "song":
+		return entity.SuggestSongNameField, true
+	default:
+		return 0, false
+	}
+}
+
+// parseAlphaIndexField converts the by query parameter of the alphabetical index to an entity.AlphaIndexField.
+// An empty parameter means the index of groups.
+func parseAlphaIndexField(param string) (entity.AlphaIndexField, bool) {
+	switch param {
+	case "", "group":
+		return entity.AlphaIndexGroupField, true
+	case "song":
+		return entity.AlphaIndexSongField, true
+	default:
+		return 0, false
+	}
+}
+
+// parseTextGranularity converts the granularity query parameter of the verses endpoint to an entity.TextGranularity.
+// An empty parameter means verse granularity.
+func parseTextGranularity(param string) (entity.TextGranularity, bool) {
+	switch param {
+	case "", "verse":
+		return entity.VerseGranularity, true
+	case "line":
+		return entity.LineGranularity, true
+	default:
+		return 0, false
+	}
+}
+
+// parseVerseFormat converts the verseFormat query parameter of the verses endpoint to whether verses are returned
+// as objects with their indices. An empty parameter means a plain array of verses.
+func parseVerseFormat(param string) (indexed bool, ok bool) {
+	switch param {
+	case "", "plain":
+		return false, true
+	case "indexed":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// parseBlankLines converts the blankLines query parameter of the numbered lines endpoint to whether blank lines
+// are numbered. An empty parameter means blank lines are skipped.
+func parseBlankLines(param string) (numberBlank bool, ok bool) {
+	switch param {
+	case "", "skip":
+		return false, true
+	case "number":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// writeNumberedLines writes the lines as plain text, one "<number>: <text>" line for each of them.
+func writeNumberedLines(w io.Writer, lines []entity.NumberedLine) error {
+	for _, line := range lines {
+		var err error
+		if line.Text == "" {
+			_, err = fmt.Fprintf(w, "%d:\n", line.Number)
+		} else {
+			_, err = fmt.Fprintf(w, "%d: %s\n", line.Number, line.Text)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseFacetField converts a value of the facets query parameter of song listing to an entity.FacetField.
+func parseFacetField(param string) (entity.FacetField, bool) {
+	switch param {
+	case "releaseYear":
+		return entity.FacetReleaseYearField, true
+	case "group":
+		return entity.FacetGroupNameField, true
+	default:
+		return 0, false
+	}
+}
+
+// facetFieldName returns the name of the facet field used in the facets query parameter and in responses.
+func facetFieldName(field entity.FacetField) string {
+	switch field {
+	case entity.FacetReleaseYearField:
+		return "releaseYear"
+	case entity.FacetGroupNameField:
+		return "group"
+	default:
+		return ""
+	}
+}
+
+// dateFormatLayouts maps the values of the dateFormat query parameter to the layouts release dates are returned in.
+var dateFormatLayouts = map[string]string{
+	"eu":  "02.01.2006",
+	"iso": time.DateOnly,
+	"us":  "01/02/2006",
+}
+
+// defaultDateFormat is the release date format used when the dateFormat query parameter is not set.
+const defaultDateFormat = "eu"
+
+// parseDateFormat returns the release date layout requested with the dateFormat query parameter,
+// or the layout of defaultDateFormat when it is not set. It reports false if the format is unknown.
+func parseDateFormat(r *http.Request) (string, bool) {
+	format := r.URL.Query().Get("dateFormat")
+	if format == "" {
+		format = defaultDateFormat
+	}
+
+	layout, ok := dateFormatLayouts[format]
+	return layout, ok
+}
+
+// dateLayout returns the release date layout of the request, falling back to the layout of defaultDateFormat.
+// Unknown formats are rejected before reaching handlers by requireValidDateFormat.
+func dateLayout(r *http.Request) string {
+	if layout, ok := parseDateFormat(r); ok {
+		return layout
+	}
+	return dateFormatLayouts[defaultDateFormat]
+}
+
+// parseFacetFields extracts the comma-separated facet fields from the HTTP request query, leaving out duplicates.
+// It reports false if any of the fields is unknown.
+func parseFacetFields(r *http.Request) ([]entity.FacetField, bool) {
+	var fields []entity.FacetField
+
+	seen := make(map[entity.FacetField]bool)
+
+	for _, param := range strings.Split(r.URL.Query().Get("facets"), ",") {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+
+		field, ok := parseFacetField(param)
+		if !ok {
+			return nil, false
+		}
+
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, true
+}
+
+// parseSuggestLimit extracts the number of name suggestions from the HTTP request query.
+// Missing or invalid values fall back to entity.DefaultSuggestLimit and values above entity.MaxSuggestLimit are capped.
+func parseSuggestLimit(r *http.Request) uint64 {
+	limit, err := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil || limit == 0 {
+		return entity.DefaultSuggestLimit
+	}
+
+	return min(limit, entity.MaxSuggestLimit)
+}
+
+// songStringFilterParams lists the query parameters of the string song filters.
+var songStringFilterParams = []string{"groupName", "name", "text"}
+
+// emptySongFilterParams returns the string song filter parameters present in the request query with an empty value.
+func emptySongFilterParams(r *http.Request) []string {
+	var params []string
+
+	query := r.URL.Query()
+
+	for _, param := range songStringFilterParams {
+		if _, ok := query[param]; ok && query.Get(param) == "" {
+			params = append(params, param)
+		}
+	}
+
+	return params
+}
+
+// songFilterParams are the query parameters parsed into song filters by parseSongFilters.
+var songFilterParams = []string{
+	"groupName", "name", "releaseYear", "releaseDate", "releaseDateAfter", "releaseDateBefore",
+	"text", "minTextLen", "maxTextLen", "detailStatus", "hasReleaseDate",
+}
+
+// disallowedSongFilterParams returns the song filter parameters present in the request query that are not
+// in the allowed list. An empty allowed list allows every filter.
+func disallowedSongFilterParams(r *http.Request, allowed []string) []string {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	var params []string
+
+	query := r.URL.Query()
+
+	for _, param := range songFilterParams {
+		if _, ok := query[param]; ok && !slices.Contains(allowed, param) {
+			params = append(params, param)
+		}
+	}
+
+	return params
+}
+
+// parseSongFilters extracts song filter criteria from the HTTP request query.
+// Parameters with an empty value add no filter: no song can have an empty group name, name or text,
+// so ?groupName= is treated the same as omitting the parameter. A parameter sent several times,
+// such as ?name=a&name=b, adds filters for the values selected by the duplicates mode (see DuplicateFiltersFirst).
+// The release year is the exception: a song has a single release year, so repeated years, such as
+// ?releaseYear=1968&releaseYear=1969, are always collected into one filter matching any of them.
+func parseSongFilters(r *http.Request, duplicates string) []entity.SongFilter {
+	return parseSongFilterValues(r.URL.Query(), duplicates)
+}
+
+// ParseSongFilters parses song filters written like the filter parameters of a listing request, such as
+// detailStatus=found&hasReleaseDate=true, for filters set in the configuration. Unlike a request,
+// unknown parameters and values that add no filter are reported as errors, and repeated parameters all add filters.
+func ParseSongFilters(query string) ([]entity.SongFilter, error) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter query: %w", err)
+	}
+
+	var filters []entity.SongFilter
+
+	for _, param := range songFilterParams {
+		paramValues, ok := values[param]
+		if !ok {
+			continue
+		}
+		delete(values, param)
+
+		paramFilters := parseSongFilterValues(url.Values{param: paramValues}, DuplicateFiltersAll)
+		if len(paramFilters) == 0 {
+			return nil, fmt.Errorf("invalid value of filter %s: %q", param, paramValues)
+		}
+
+		filters = append(filters, paramFilters...)
+	}
+
+	if unknown := slices.Sorted(maps.Keys(values)); len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown filter %s", unknown[0])
+	}
+
+	return filters, nil
+}
+
+// parseSongFilterValues extracts song filter criteria from query values (see parseSongFilters).
+func parseSongFilterValues(query url.Values, duplicates string) []entity.SongFilter {
+	var filters []entity.SongFilter
+
+	addStringFilter := func(param string, field entity.SongFilterField) {
+		if param != "" {
+			filters = append(filters, entity.SongFilter{
+				Field: field,
+				Value: param,
+			})
+		}
+	}
+
+	// Names are stored in normalization form C, so searched names are normalized the same way.
+	addNameFilter := func(param string, field entity.SongFilterField) {
+		addStringFilter(norm.NFC.String(param), field)
+	}
+
+	addIntFilter := func(param string, field entity.SongFilterField) {
+		if param != "" {
+			value, err := strconv.Atoi(param)
+			if err == nil {
+				filters = append(filters, entity.SongFilter{
+					Field: field,
+					Value: value,
+				})
+			}
+		}
+	}
+
+	addDetailStatusFilter := func(param string, field entity.SongFilterField) {
+		if value, ok := parseDetailStatus(param); ok {
+			filters = append(filters, entity.SongFilter{
+				Field: field,
+				Value: value,
+			})
+		}
+	}
+
+	addPresenceFilter := func(param string, field entity.SongFilterField) {
+		if value, err := strconv.ParseBool(param); err == nil {
+			filters = append(filters, entity.SongFilter{
+				Field: field,
+				Value: !value,
+			})
+		}
+	}
+
+	addDateFilter := func(param string, field entity.SongFilterField) {
+		if param != "" {
+			value, err := time.Parse("02.01.2006", param)
+			if err == nil {
+				filters = append(filters, entity.SongFilter{
+					Field: field,
+					Value: value,
+				})
+			}
+		}
+	}
+
+	addReleaseYearsFilter := func(params []string) {
+		var years []int
+
+		for _, param := range params {
+			if year, err := strconv.Atoi(param); err == nil && !slices.Contains(years, year) {
+				years = append(years, year)
+			}
+		}
+
+		switch len(years) {
+		case 0:
+		case 1:
+			filters = append(filters, entity.SongFilter{
+				Field: entity.SongReleaseYearFilterField,
+				Value: years[0],
+			})
+		default:
+			filters = append(filters, entity.SongFilter{
+				Field: entity.SongReleaseYearFilterField,
+				Value: years,
+			})
+		}
+	}
+
+	addFilters := func(key string, field entity.SongFilterField, add func(string, entity.SongFilterField)) {
+		for _, param := range selectDuplicateValues(query[key], duplicates) {
+			add(param, field)
+		}
+	}
+
+	addFilters("groupName", entity.SongGroupNameFilterField, addNameFilter)
+	addFilters("name", entity.SongNameFilterField, addNameFilter)
+	addReleaseYearsFilter(query["releaseYear"])
+	addFilters("releaseDate", entity.SongReleaseDateFilterField, addDateFilter)
+	addFilters("releaseDateAfter", entity.SongReleaseDateAfterFilterField, addDateFilter)
+	addFilters("releaseDateBefore", entity.SongReleaseDateBeforeFilterField, addDateFilter)
+	addFilters("text", entity.SongTextFilterField, addStringFilter)
+	addFilters("minTextLen", entity.SongMinTextLenFilterField, addIntFilter)
+	addFilters("maxTextLen", entity.SongMaxTextLenFilterField, addIntFilter)
+	addFilters("detailStatus", entity.SongDetailStatusFilterField, addDetailStatusFilter)
+	addFilters("hasReleaseDate", entity.SongReleaseDateMissingFilterField, addPresenceFilter)
+
+	return filters
+}
+
+// maxLyricsRegexLength is the maximum length of a pattern accepted by validateLyricsRegex.
+const maxLyricsRegexLength = 256
+
+// validateLyricsRegex checks that a pattern for the regex lyrics filter compiles and is cheap enough to run
+// against the text of every song. Patterns nesting repetitions, such as (a+)+, are rejected, since
+// they make the database backtrack through every way of splitting a match. Backreferences,
+// which Postgres would run exponentially, don't compile with Go's syntax.
+func validateLyricsRegex(pattern string) error {
+	if len(pattern) > maxLyricsRegexLength {
+		return fmt.Errorf("pattern is longer than %d characters", maxLyricsRegexLength)
+	}
+
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return err
+	}
+
+	if hasNestedRepeat(re, false) {
+		return errors.New("pattern nests repetitions")
+	}
+
+	return nil
+}
+
+// hasNestedRepeat reports whether a repetition of the expression contains another repetition.
+func hasNestedRepeat(re *syntax.Regexp, inRepeat bool) bool {
+	isRepeat := false
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus:
+		isRepeat = true
+	case syntax.OpRepeat:
+		isRepeat = re.Max != re.Min || re.Max == -1
+	}
+
+	if isRepeat && inRepeat {
+		return true
+	}
+
+	for _, sub := range re.Sub {
+		if hasNestedRepeat(sub, inRepeat || isRepeat) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectDuplicateValues returns the values of a query parameter that are used according to the duplicates mode.
+// Unknown modes are handled as DuplicateFiltersFirst.
+func selectDuplicateValues(values []string, duplicates string) []string {
+	if len(values) <= 1 {
+		return values
+	}
+
+	switch duplicates {
+	case DuplicateFiltersAll:
+		return values
+	case DuplicateFiltersLast:
+		return values[len(values)-1:]
+	default:
+		return values[:1]
+	}
+}
+
+// parseMissingDetailFilters extracts missing song detail filter criteria from the HTTP request query.
+func parseMissingDetailFilters(r *http.Request) []entity.SongFilter {
+	var filters []entity.SongFilter
+
+	addBoolFilter := func(param string, field entity.SongFilterField) {
+		if param != "" {
+			value, err := strconv.ParseBool(param)
+			if err == nil && value {
+				filters = append(filters, entity.SongFilter{
+					Field: field,
+					Value: value,
+				})
+			}
+		}
+	}
+
+	query := r.URL.Query()
+
+	addBoolFilter(query.Get("missingReleaseDate"), entity.SongReleaseDateMissingFilterField)
+	addBoolFilter(query.Get("missingText"), entity.SongTextMissingFilterField)
+	addBoolFilter(query.Get("missingLink"), entity.SongLinkMissingFilterField)
+
+	return filters
+}
+
+// errTooManyRows is returned by parseSongsCSV when the file exceeds the allowed number of data rows.
+var errTooManyRows = errors.New("too many rows")
+
+// csvSongRow is a row of an imported CSV file converted to an add song request.
+type csvSongRow struct {
+	line int
+	req  addSongRequest
+}
+
+// Columns of an imported CSV file, matched case-insensitively against its header row.
+const (
+	csvGroupColumn = "group"
+	csvSongColumn  = "song"
+)
+
+// csvTemplateExample is the example data row of the CSV import template, in the order of its header row.
+var csvTemplateExample = []string{"Muse", "Supermassive Black Hole"}
+
+// writeSongsCSVTemplate writes a CSV file with the header row expected by parseSongsCSV and an example row.
+func writeSongsCSVTemplate(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.WriteAll([][]string{{csvGroupColumn, csvSongColumn}, csvTemplateExample}); err != nil {
+		return fmt.Errorf("failed to write csv template: %w", err)
+	}
+
+	return nil
+}
+
+// Names of the members of a songs export archive.
+const (
+	exportCSVMember  = "songs.csv"
+	exportJSONMember = "songs.json"
+)
+
+// exportFormatZIP is the archive format of songs exports, holding the songs both as CSV and as JSON.
+const exportFormatZIP = "zip"
+
+// csvExportHeader is the header row of the CSV member of a songs export. It starts with the columns
+// read by imports, so an exported file can be imported again.
+var csvExportHeader = []string{
+	csvGroupColumn, csvSongColumn, "id", "release_date", "text", "link", "created_at", "updated_at",
+}
+
+// songCSVRecord returns the row of a song in the CSV member of a songs export, in the order of csvExportHeader.
+func songCSVRecord(song songSchema) []string {
+	var detail songDetailSchema
+	if song.SongDetail != nil {
+		detail = *song.SongDetail
+	}
+
+	return []string{
+		song.GroupName,
+		song.Name,
+		song.ID.String(),
+		detail.ReleaseDate,
+		detail.Text,
+		detail.Link,
+		song.CreatedAt.UTC().Format(time.RFC3339),
+		song.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// parseSongsCSV reads add song requests from a CSV file whose header row contains "group" and "song" columns.
+// Malformed rows are reported as row errors instead of failing the whole file.
+func parseSongsCSV(r io.Reader, maxRows int) ([]csvSongRow, []importRowErrorSchema, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	groupIdx, songIdx := -1, -1
+	for i, col := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff"))) {
+		case csvGroupColumn:
+			groupIdx = i
+		case csvSongColumn:
+			songIdx = i
+		}
+	}
+
+	if groupIdx == -1 || songIdx == -1 {
+		return nil, nil, errors.New("header must contain group and song columns")
+	}
+
+	var (
+		rows    []csvSongRow
+		rowErrs []importRowErrorSchema
+	)
+
+	for count := 1; ; count++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if count > maxRows {
+			return nil, nil, errTooManyRows
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrs = append(rowErrs, importRowErrorSchema{
+					Row:     parseErr.Line,
+					Details: []string{parseErr.Err.Error()},
+				})
+				continue
+			}
+
+			return nil, nil, fmt.Errorf("failed to read row: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(record) != len(header) {
+			rowErrs = append(rowErrs, importRowErrorSchema{
+				Row:     line,
+				Details: []string{fmt.Sprintf("expected %d fields, got %d", len(header), len(record))},
+			})
+			continue
+		}
+
+		rows = append(rows, csvSongRow{
+			line: line,
+			req: addSongRequest{
+				Group: record[groupIdx],
+				Song:  record[songIdx],
+			},
+		})
+	}
+
+	return rows, rowErrs, nil
+}
+
+const statusError = "error"
+
+// songEventSchema represents the data of a song change event sent on the song event stream.
+//
+//	@Description	Represents the data of a song change event sent on the song event stream.
+//	@Tags			songs
+type songEventSchema struct {
+	Type       string    `json:"type" enums:"created,updated,deleted" example:"updated"`
+	SongID     uuid.UUID `json:"songId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	OccurredAt time.Time `json:"occurredAt" example:"2024-01-01T00:00:00Z"`
+}
+
+// errorResponse represents the structure of error responses from the API.
+//
+//	@Description	Represents the structure of error responses from the API.
+//	@Tags			errors
+type errorResponse struct {
+	Status  string   `json:"status" example:"error"`
+	Message string   `json:"message" example:"invalid request body"`
+	Details []string `json:"details,omitempty" example:"Group name is required,Song name is required"`
+}
+
+// Predefined error responses for common scenarios
+var (
+	emptyRequestBodyResp = errorResponse{
+		Status:  statusError,
+		Message: "empty request body",
+	}
+
+	invalidRequestBodyResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid request body",
+	}
+
+	unsupportedMediaTypeResp = errorResponse{
+		Status:  statusError,
+		Message: "content type must be application/json",
+	}
+
+	invalidBodyEncodingResp = errorResponse{
+		Status:  statusError,
+		Message: "request body must be valid UTF-8",
+	}
+
+	invalidSongIDParamResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid song id param",
+	}
+
+	noFieldsToUpdateErrResp = errorResponse{
+		Status:  statusError,
+		Message: "no updatable fields provided",
+	}
+
+	unsupportedImportFormatResp = errorResponse{
+		Status:  statusError,
+		Message: "unsupported import format",
+	}
+
+	invalidImportFileResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid import file",
+	}
+
+	importFileTooLargeResp = errorResponse{
+		Status:  statusError,
+		Message: "import file too large",
+	}
+
+	tooManyImportRowsResp = errorResponse{
+		Status:  statusError,
+		Message: "too many rows in import file",
+	}
+
+	unfilteredUpdateNotConfirmedResp = errorResponse{
+		Status:  statusError,
+		Message: "no filters provided, set confirm=true to modify all songs",
+	}
+	noTouchFiltersResp = errorResponse{
+		Status:  statusError,
+		Message: "no filters provided, touching all songs is not allowed",
+	}
+
+	missingLyricsRegexResp = errorResponse{
+		Status:  statusError,
+		Message: "regex is required",
+	}
+	unsupportedLyricsRegexResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid regex",
+		Details: []string{"pattern is not supported by the database"},
+	}
+
+	noReleaseDateUpdatesResp = errorResponse{
+		Status:  statusError,
+		Message: "no release date updates provided",
+	}
+
+	tooManyReleaseDateUpdatesResp = errorResponse{
+		Status:  statusError,
+		Message: "too many release date updates",
+	}
+
+	noSongIDsResp = errorResponse{
+		Status:  statusError,
+		Message: "no song ids provided",
+	}
+
+	tooManySongIDsResp = errorResponse{
+		Status:  statusError,
+		Message: fmt.Sprintf("too many song ids, must be at most %d", maxSongsWithVersesBatchSize),
+	}
+
+	tooManySongIDsToDeleteResp = errorResponse{
+		Status:  statusError,
+		Message: fmt.Sprintf("too many song ids, must be at most %d", maxSongsDeleteBatchSize),
+	}
+
+	invalidOtherSongIDParamResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid other song id param",
+	}
+
+	offsetOutOfRangeResp = errorResponse{
+		Status:  statusError,
+		Message: "offset out of range",
+		Details: []string{"offset is far beyond the total number of songs, request an offset below the total reported in pagination"},
+	}
+
+	linkAlreadyExistsResp = errorResponse{
+		Status:  statusError,
+		Message: "link already belongs to another song",
+	}
+
+	songAlreadyExistsResp = errorResponse{
+		Status:  statusError,
+		Message: "song of the group with the name already exists",
+	}
+
+	groupSongLimitExceededResp = errorResponse{
+		Status:  statusError,
+		Message: "group song limit exceeded",
+	}
+
+	tooManyVersesResp = errorResponse{
+		Status:  statusError,
+		Message: "song text has too many verses",
+	}
+
+	invalidDateFormatResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid date format, must be eu, iso or us",
+	}
+
+	invalidFacetFieldResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid facet, must be releaseYear or group",
+	}
+
+	invalidSuggestFieldResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid suggest field, must be group or song",
+	}
+
+	invalidAlphaIndexFieldResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid alpha index field, must be group or song",
+	}
+
+	invalidGranularityResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid granularity, must be verse or line",
+	}
+
+	emptySearchQueryResp = errorResponse{
+		Status:  statusError,
+		Message: "search query must not be empty",
+	}
+
+	invalidVerseContextResp = errorResponse{
+		Status:  statusError,
+		Message: fmt.Sprintf("invalid context, must be a number of verses from 0 to %d", entity.MaxVerseMatchContext),
+	}
+
+	missingSearchQueryResp = errorResponse{
+		Status:  statusError,
+		Message: "missing search query",
+	}
+
+	suggestQueryTooShortResp = errorResponse{
+		Status:  statusError,
+		Message: fmt.Sprintf("suggest query must be at least %d characters long", entity.MinSuggestQueryLength),
+	}
+
+	responseTooLargeResp = errorResponse{
+		Status:  statusError,
+		Message: "response too large, use limit and offset to request fewer items",
+	}
+
+	readOnlyModeResp = errorResponse{
+		Status:  statusError,
+		Message: "api is in read-only mode, try again later",
+	}
+
+	missingUserAgentResp = errorResponse{
+		Status:  statusError,
+		Message: "missing User-Agent header",
+	}
+
+	unauthorizedResp = errorResponse{
+		Status:  statusError,
+		Message: "unauthorized",
+	}
+
+	linkCheckRunningResp = errorResponse{
+		Status:  statusError,
+		Message: "link check already running",
+	}
+
+	routeNotFoundResp = errorResponse{
+		Status:  statusError,
+		Message: "route not found",
+	}
+
+	methodNotAllowedResp = errorResponse{
+		Status:  statusError,
+		Message: "method not allowed",
+	}
+
+	groupNotFoundErrResp = errorResponse{
+		Status:  statusError,
+		Message: "group not found",
+	}
+
+	invalidExportFormatResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid format, must be zip",
+	}
+
+	invalidYearResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid year, must be a number",
+	}
+
+	groupYearSongsNotFoundResp = errorResponse{
+		Status:  statusError,
+		Message: "no songs of the group released in the year",
+	}
+
+	invalidImportIDParamResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid import id param",
+	}
+
+	importNotFoundResp = errorResponse{
+		Status:  statusError,
+		Message: "import not found",
+	}
+
+	invalidSitemapPageResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid sitemap page, must be a positive number",
+	}
+
+	sitemapPageNotFoundResp = errorResponse{
+		Status:  statusError,
+		Message: "sitemap page not found",
+	}
+
+	invalidBlankLinesResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid blankLines, must be skip or number",
+	}
+
+	invalidVerseFormatResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid verseFormat, must be plain or indexed",
+	}
+
+	invalidLinesFormatResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid format, must be json or text",
+	}
+
+	noLyricsAvailableResp = errorResponse{
+		Status:  statusError,
+		Message: "no lyrics available",
+	}
+
+	invalidVerseOrderResp = errorResponse{
+		Status:  statusError,
+		Message: "invalid verse order, must hold every verse index exactly once",
+	}
+
+	songNotFoundErrResp = errorResponse{
+		Status:  statusError,
+		Message: "song not found",
+	}
+
+	serverErrResp = errorResponse{
 		Status:  statusError,
 		Message: "server error occurred",
 	}
+
+	serviceUnavailableResp = errorResponse{
+		Status:  statusError,
+		Message: "service temporarily unavailable",
+	}
 )
 
+// noValidImportRowsError creates an errorResponse listing the errors of the rejected rows of an imported file.
+func noValidImportRowsError(rowErrs []importRowErrorSchema) errorResponse {
+	var details []string
+
+	for _, rowErr := range rowErrs {
+		for _, detail := range rowErr.Details {
+			details = append(details, fmt.Sprintf("row %d: %s", rowErr.Row, detail))
+		}
+	}
+
+	return errorResponse{
+		Status:  statusError,
+		Message: "no valid rows to import",
+		Details: details,
+	}
+}
+
+// emptyFilterValuesError creates an errorResponse listing the filter parameters sent with an empty value.
+func emptyFilterValuesError(params []string) errorResponse {
+	details := make([]string, 0, len(params))
+	for _, param := range params {
+		details = append(details, fmt.Sprintf("%s: empty value", param))
+	}
+
+	return errorResponse{
+		Status:  statusError,
+		Message: "empty filter values",
+		Details: details,
+	}
+}
+
+// invalidLyricsRegexError creates an errorResponse with the reason the lyrics regex was rejected.
+func invalidLyricsRegexError(err error) errorResponse {
+	return errorResponse{
+		Status:  statusError,
+		Message: "invalid regex",
+		Details: []string{err.Error()},
+	}
+}
+
+// disallowedFiltersError creates an errorResponse listing the filter parameters that are not allowed.
+func disallowedFiltersError(params []string) errorResponse {
+	details := make([]string, 0, len(params))
+	for _, param := range params {
+		details = append(details, fmt.Sprintf("%s: filter not allowed", param))
+	}
+
+	return errorResponse{
+		Status:  statusError,
+		Message: "filters not allowed",
+		Details: details,
+	}
+}
+
+// unknownFieldErrorResp creates an errorResponse naming the unknown field found in the request body.
+func unknownFieldErrorResp(err *unknownFieldError) errorResponse {
+	return errorResponse{
+		Status:  statusError,
+		Message: "unknown field in request body",
+		Details: []string{err.field},
+	}
+}
+
 // messageForValidateTag returns a user-friendly message for validation errors based on the tag.
 func messageForValidateTag(tag string) string {
 	switch tag {
@@ -220,6 +1830,10 @@ func messageForValidateTag(tag string) string {
 		return "invalid format, must be like '02.01.2006'"
 	case "url":
 		return "invalid url"
+	case "uuid":
+		return "invalid uuid"
+	case "max":
+		return "too long"
 	default:
 		return "invalid value"
 	}
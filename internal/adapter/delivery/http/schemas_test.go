@@ -1,11 +1,17 @@
 package http
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
 )
@@ -52,6 +58,73 @@ func TestParsePagination(t *testing.T) {
 	}
 }
 
+func TestPathParam(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "plain",
+			path: "/groups/Queen/facets",
+			want: "Queen",
+		},
+		{
+			name: "escaped space",
+			path: "/groups/Led%20Zeppelin/facets",
+			want: "Led Zeppelin",
+		},
+		{
+			name: "escaped slash",
+			path: "/groups/AC%2FDC/facets",
+			want: "AC/DC",
+		},
+		{
+			name: "escaped percent sign",
+			path: "/groups/100%25/facets",
+			want: "100%",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+
+			r := chi.NewRouter()
+			r.Get("/groups/{groupName}/facets", func(w http.ResponseWriter, r *http.Request) {
+				got = pathParam(r, "groupName")
+			})
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseIDParam(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		id, err := parseIDParam("urn:uuid:123e4567-e89b-12d3-a456-426614174000")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"), id)
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		id, err := parseIDParam(strings.Repeat("a", maxIDParamLength+1))
+
+		assert.ErrorIs(t, err, errIDParamTooLong)
+		assert.Equal(t, uuid.Nil, id)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := parseIDParam("not-a-uuid")
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, errIDParamTooLong)
+	})
+}
+
 func TestParseSongFilters(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -84,6 +157,77 @@ func TestParseSongFilters(t *testing.T) {
 				{Field: entity.SongTextFilterField, Value: "Test Text"},
 			},
 		},
+		{
+			name: "multiple release years",
+			values: url.Values{
+				"releaseYear": []string{"1968", "1969", "invalid", "1968"},
+			},
+			expectedFilters: []entity.SongFilter{
+				{Field: entity.SongReleaseYearFilterField, Value: []int{1968, 1969}},
+			},
+		},
+		{
+			name: "text length filters",
+			values: url.Values{
+				"minTextLen": []string{"10"},
+				"maxTextLen": []string{"500"},
+			},
+			expectedFilters: []entity.SongFilter{
+				{Field: entity.SongMinTextLenFilterField, Value: 10},
+				{Field: entity.SongMaxTextLenFilterField, Value: 500},
+			},
+		},
+		{
+			name: "detail status filter",
+			values: url.Values{
+				"detailStatus": []string{"not_found"},
+			},
+			expectedFilters: []entity.SongFilter{
+				{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusNotFound},
+			},
+		},
+		{
+			name: "invalid detail status filter",
+			values: url.Values{
+				"detailStatus": []string{"missing"},
+			},
+			expectedFilters: []entity.SongFilter{},
+		},
+		{
+			name: "detail status without release date filters",
+			values: url.Values{
+				"detailStatus":   []string{"failed"},
+				"hasReleaseDate": []string{"false"},
+			},
+			expectedFilters: []entity.SongFilter{
+				{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusFailed},
+				{Field: entity.SongReleaseDateMissingFilterField, Value: true},
+			},
+		},
+		{
+			name: "has release date filter",
+			values: url.Values{
+				"hasReleaseDate": []string{"true"},
+			},
+			expectedFilters: []entity.SongFilter{
+				{Field: entity.SongReleaseDateMissingFilterField, Value: false},
+			},
+		},
+		{
+			name: "invalid has release date filter",
+			values: url.Values{
+				"hasReleaseDate": []string{"maybe"},
+			},
+			expectedFilters: []entity.SongFilter{},
+		},
+		{
+			name: "invalid text length filters",
+			values: url.Values{
+				"minTextLen": []string{"short"},
+				"maxTextLen": []string{"long"},
+			},
+			expectedFilters: []entity.SongFilter{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -94,7 +238,7 @@ func TestParseSongFilters(t *testing.T) {
 				},
 			}
 
-			filters := parseSongFilters(req)
+			filters := parseSongFilters(req, DuplicateFiltersFirst)
 
 			assert.Len(t, filters, len(tt.expectedFilters))
 
@@ -107,7 +251,333 @@ func TestParseSongFilters(t *testing.T) {
 	}
 }
 
+func TestParseSongFilters_Duplicates(t *testing.T) {
+	const query = "name=first&releaseYear=1971&name=second&name=last"
+
+	tests := []struct {
+		duplicates string
+		names      []string
+	}{
+		{duplicates: "", names: []string{"first"}},
+		{duplicates: DuplicateFiltersFirst, names: []string{"first"}},
+		{duplicates: DuplicateFiltersLast, names: []string{"last"}},
+		{duplicates: DuplicateFiltersAll, names: []string{"first", "second", "last"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.duplicates, func(t *testing.T) {
+			req := &http.Request{
+				URL: &url.URL{
+					RawQuery: query,
+				},
+			}
+
+			var expected []entity.SongFilter
+			for _, name := range tt.names {
+				expected = append(expected, entity.SongFilter{Field: entity.SongNameFilterField, Value: name})
+			}
+			expected = append(expected, entity.SongFilter{Field: entity.SongReleaseYearFilterField, Value: 1971})
+
+			assert.Equal(t, expected, parseSongFilters(req, tt.duplicates))
+		})
+	}
+}
+
+func TestParseSongFilters_Query(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		filters, err := ParseSongFilters("detailStatus=found&hasReleaseDate=true&groupName=Queen&groupName=Beatles")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.SongFilter{
+			{Field: entity.SongGroupNameFilterField, Value: "Queen"},
+			{Field: entity.SongGroupNameFilterField, Value: "Beatles"},
+			{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusFound},
+			{Field: entity.SongReleaseDateMissingFilterField, Value: false},
+		}, filters)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		filters, err := ParseSongFilters("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, filters)
+	})
+
+	t.Run("unknown filter", func(t *testing.T) {
+		filters, err := ParseSongFilters("detailStatus=found&published=true")
+
+		assert.EqualError(t, err, "unknown filter published")
+		assert.Nil(t, filters)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		filters, err := ParseSongFilters("detailStatus=published")
+
+		assert.EqualError(t, err, `invalid value of filter detailStatus: ["published"]`)
+		assert.Nil(t, filters)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		filters, err := ParseSongFilters("groupName=%zz")
+
+		assert.ErrorContains(t, err, "invalid filter query")
+		assert.Nil(t, filters)
+	})
+}
+
+func TestValidateLyricsRegex(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		err     string
+	}{
+		{name: "plain pattern", pattern: `^(love|heart)\s+me`},
+		{name: "fixed repetition of a repetition", pattern: `(la+){3}`},
+		{name: "does not compile", pattern: `[a-`, err: "missing closing ]"},
+		{name: "backreference", pattern: `(a)\1`, err: "invalid escape sequence"},
+		{name: "nested plus", pattern: `(a+)+$`, err: "pattern nests repetitions"},
+		{name: "nested star in range", pattern: `(x*y){2,}`, err: "pattern nests repetitions"},
+		{name: "too long", pattern: strings.Repeat("a", maxLyricsRegexLength+1), err: "pattern is longer than 256 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLyricsRegex(tt.pattern)
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestEmptySongFilterParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		params []string
+	}{
+		{
+			name:   "no filters",
+			query:  "",
+			params: nil,
+		},
+		{
+			name:   "non-empty filters",
+			query:  "groupName=Muse&name=Uprising&text=rise",
+			params: nil,
+		},
+		{
+			name:   "empty string filters",
+			query:  "groupName=&name=Uprising&text",
+			params: []string{"groupName", "text"},
+		},
+		{
+			name:   "empty non-string filters",
+			query:  "releaseYear=&minTextLen=",
+			params: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{
+				URL: &url.URL{
+					RawQuery: tt.query,
+				},
+			}
+
+			assert.Equal(t, tt.params, emptySongFilterParams(req))
+		})
+	}
+}
+
+func TestDisallowedSongFilterParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		allowed []string
+		params  []string
+	}{
+		{
+			name:    "no allowlist",
+			query:   "groupName=Muse&text=rise",
+			allowed: nil,
+			params:  nil,
+		},
+		{
+			name:    "allowed filters",
+			query:   "groupName=Muse&releaseYear=2009&limit=5",
+			allowed: []string{"groupName", "releaseYear"},
+			params:  nil,
+		},
+		{
+			name:    "disallowed filters",
+			query:   "groupName=Muse&text=rise&hasReleaseDate=false&detailStatus=",
+			allowed: []string{"groupName"},
+			params:  []string{"text", "detailStatus", "hasReleaseDate"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{
+				URL: &url.URL{
+					RawQuery: tt.query,
+				},
+			}
+
+			assert.Equal(t, tt.params, disallowedSongFilterParams(req, tt.allowed))
+		})
+	}
+}
+
+func TestParseMissingDetailFilters(t *testing.T) {
+	tests := []struct {
+		name            string
+		values          url.Values
+		expectedFilters []entity.SongFilter
+	}{
+		{
+			name:            "no filters",
+			values:          url.Values{},
+			expectedFilters: []entity.SongFilter{},
+		},
+		{
+			name: "multiple filters",
+			values: url.Values{
+				"missingReleaseDate": []string{"true"},
+				"missingText":        []string{"false"},
+				"missingLink":        []string{"1"},
+			},
+			expectedFilters: []entity.SongFilter{
+				{Field: entity.SongReleaseDateMissingFilterField, Value: true},
+				{Field: entity.SongLinkMissingFilterField, Value: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{
+				URL: &url.URL{
+					RawQuery: tt.values.Encode(),
+				},
+			}
+
+			filters := parseMissingDetailFilters(req)
+
+			assert.Equal(t, len(tt.expectedFilters), len(filters))
+
+			for i, filter := range filters {
+				assert.Equal(t, tt.expectedFilters[i].Field, filter.Field)
+				assert.Equal(t, tt.expectedFilters[i].Value, filter.Value)
+			}
+		})
+	}
+}
+
+func TestParseSongsCSV(t *testing.T) {
+	t.Run("missing header columns", func(t *testing.T) {
+		rows, rowErrs, err := parseSongsCSV(strings.NewReader("group,title\nTest Group,Test Song\n"), 10)
+
+		assert.Error(t, err)
+		assert.Nil(t, rows)
+		assert.Nil(t, rowErrs)
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		rows, rowErrs, err := parseSongsCSV(strings.NewReader("group,song\nA,B\nC,D\n"), 1)
+
+		assert.ErrorIs(t, err, errTooManyRows)
+		assert.Nil(t, rows)
+		assert.Nil(t, rowErrs)
+	})
+
+	t.Run("success with malformed row", func(t *testing.T) {
+		data := "\ufeffSong,Group\nTest Song 1,Test Group\nmalformed\n\"Test, Song 2\",Test Group\n"
+
+		rows, rowErrs, err := parseSongsCSV(strings.NewReader(data), 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []csvSongRow{
+			{line: 2, req: addSongRequest{Group: "Test Group", Song: "Test Song 1"}},
+			{line: 4, req: addSongRequest{Group: "Test Group", Song: "Test, Song 2"}},
+		}, rows)
+		assert.Len(t, rowErrs, 1)
+		assert.Equal(t, 3, rowErrs[0].Row)
+	})
+}
+
+func TestWriteSongsCSVTemplate(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := writeSongsCSVTemplate(&buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "group,song\nMuse,Supermassive Black Hole\n", buf.String())
+
+	rows, rowErrs, err := parseSongsCSV(&buf, 10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrs)
+	assert.Equal(t, []csvSongRow{
+		{line: 2, req: addSongRequest{Group: "Muse", Song: "Supermassive Black Hole"}},
+	}, rows)
+}
+
 func parseDate(dateStr string) time.Time {
 	date, _ := time.Parse("02.01.2006", dateStr)
 	return date
 }
+
+func TestSongHandler_EntityToSongSchema(t *testing.T) {
+	h := &songHandler{}
+	releaseDate := time.Date(1968, time.August, 26, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		detail     entity.SongDetail
+		wantDetail string
+	}{
+		{
+			name: "detail present",
+			detail: entity.SongDetail{
+				ReleaseDate: releaseDate,
+				Text:        "Hey Jude, don't make it bad",
+				Link:        "https://example.com",
+			},
+			wantDetail: `"songDetail":{"releaseDate":"26.08.1968","text":"Hey Jude, don't make it bad","link":"https://example.com"}`,
+		},
+		{
+			name:       "detail partially present",
+			detail:     entity.SongDetail{Text: "Hey Jude, don't make it bad"},
+			wantDetail: `"songDetail":{"text":"Hey Jude, don't make it bad"}`,
+		},
+		{
+			name:   "detail absent",
+			detail: entity.SongDetail{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(h.entityToSongSchema(&entity.Song{
+				GroupName:  "The Beatles",
+				Name:       "Hey Jude",
+				SongDetail: tt.detail,
+			}, dateFormatLayouts[defaultDateFormat]))
+
+			assert.NoError(t, err)
+
+			if tt.wantDetail == "" {
+				assert.NotContains(t, string(data), "songDetail")
+				return
+			}
+
+			assert.Contains(t, string(data), tt.wantDetail)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+// PostgreSQL error codes reported when the server can't serve the connection, such as during a shutdown or startup.
+// Every code of the connection exception class (08) means the same.
+const (
+	connectionExceptionClass = "08"
+	adminShutdownCode        = "57P01"
+	crashShutdownCode        = "57P02"
+	cannotConnectNowCode     = "57P03"
+)
+
+// uniqueViolationCode is the PostgreSQL error code of a unique constraint violation.
+const uniqueViolationCode = "23505"
+
+// invalidRegexCode is the PostgreSQL error code of a regular expression the server fails to compile.
+const invalidRegexCode = "2201B"
+
+// uniqueLinkIndex is the name of the partial unique index on the link of songs built by CreateUniqueLinkIndex.
+const uniqueLinkIndex = "songs_link_unique_idx"
+
+// isConnectionError reports whether err comes from a failure to reach the database rather than from a query.
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case adminShutdownCode, crashShutdownCode, cannotConnectNowCode:
+			return true
+		default:
+			return strings.HasPrefix(pgErr.Code, connectionExceptionClass)
+		}
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isUniqueLinkViolation reports whether err comes from giving a song the link of another song,
+// which the unique link index built by CreateUniqueLinkIndex rejects.
+func isUniqueLinkViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == uniqueLinkIndex
+}
+
+// isInvalidRegex reports whether err comes from a regular expression the server can't compile,
+// such as one using syntax Go accepts but PostgreSQL doesn't.
+func isInvalidRegex(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == invalidRegexCode
+}
+
+// classifyError marks connection-level failures with entity.ErrStorageUnavailable, so callers can tell
+// an unreachable database from a failed query, and rejected regular expressions with entity.ErrInvalidRegex.
+// Other errors are returned unchanged.
+func classifyError(err error) error {
+	if err == nil || errors.Is(err, entity.ErrStorageUnavailable) || errors.Is(err, entity.ErrInvalidRegex) {
+		return err
+	}
+
+	if isInvalidRegex(err) {
+		return fmt.Errorf("%w: %w", entity.ErrInvalidRegex, err)
+	}
+
+	if !isConnectionError(err) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", entity.ErrStorageUnavailable, err)
+}
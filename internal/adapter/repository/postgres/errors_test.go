@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "bad connection", err: driver.ErrBadConn, expected: true},
+		{name: "connection done", err: fmt.Errorf("query: %w", sql.ErrConnDone), expected: true},
+		{name: "network error", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, expected: true},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, expected: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: adminShutdownCode}, expected: true},
+		{name: "cannot connect now", err: &pgconn.PgError{Code: cannotConnectNowCode}, expected: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, expected: false},
+		{name: "no rows", err: sql.ErrNoRows, expected: false},
+		{name: "unknown error", err: errors.New("unknown error"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isConnectionError(tt.err))
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		assert.NoError(t, classifyError(nil))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		err := errors.New("unknown error")
+
+		assert.Equal(t, err, classifyError(err))
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		err := classifyError(driver.ErrBadConn)
+
+		assert.ErrorIs(t, err, entity.ErrStorageUnavailable)
+		assert.ErrorIs(t, err, driver.ErrBadConn)
+	})
+
+	t.Run("unique link violation", func(t *testing.T) {
+		pgErr := &pgconn.PgError{Code: uniqueViolationCode, ConstraintName: uniqueLinkIndex}
+
+		assert.Equal(t, pgErr, classifyError(pgErr))
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		pgErr := &pgconn.PgError{Code: invalidRegexCode}
+
+		err := classifyError(pgErr)
+
+		assert.ErrorIs(t, err, entity.ErrInvalidRegex)
+		assert.ErrorIs(t, err, pgErr)
+		assert.False(t, errors.Is(err, entity.ErrStorageUnavailable))
+	})
+}
+
+func TestSongRepository_ClassifyError(t *testing.T) {
+	t.Run("unique link violation", func(t *testing.T) {
+		repo, _ := initSongRepository(t, WithUniqueLinkErrors())
+		pgErr := &pgconn.PgError{Code: uniqueViolationCode, ConstraintName: uniqueLinkIndex}
+
+		err := repo.classifyError(pgErr)
+
+		assert.ErrorIs(t, err, entity.ErrLinkAlreadyExists)
+		assert.ErrorIs(t, err, pgErr)
+	})
+
+	t.Run("unique link violation without unique link errors", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+		pgErr := &pgconn.PgError{Code: uniqueViolationCode, ConstraintName: uniqueLinkIndex}
+
+		assert.Equal(t, pgErr, repo.classifyError(pgErr))
+	})
+
+	t.Run("other unique violation", func(t *testing.T) {
+		repo, _ := initSongRepository(t, WithUniqueLinkErrors())
+		pgErr := &pgconn.PgError{Code: uniqueViolationCode, ConstraintName: "songs_pkey"}
+
+		assert.Equal(t, pgErr, repo.classifyError(pgErr))
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		repo, _ := initSongRepository(t, WithUniqueLinkErrors())
+
+		assert.ErrorIs(t, repo.classifyError(driver.ErrBadConn), entity.ErrStorageUnavailable)
+	})
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgreSQL error codes of transaction failures that are safe to retry.
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+// Retry settings for transactions aborted by serialization failures or deadlocks.
+// The backoff grows linearly with every attempt.
+const (
+	txMaxAttempts  = 3
+	txRetryBackoff = 10 * time.Millisecond
+)
+
+// isRetryableTxError reports whether the transaction failed with an error that may succeed when re-run.
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == serializationFailureCode || pgErr.Code == deadlockDetectedCode
+}
+
+// withRetryTx runs fn in a transaction started with the provided options. When the transaction fails with a
+// serialization or deadlock error, it is rolled back and fn is re-run, up to txMaxAttempts times in total.
+// Other errors are returned immediately, so fn must be safe to run more than once.
+func (r *SongRepository) withRetryTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *sqlx.Tx) error) error {
+	const op = "adapter.repository.postgres.SongRepository.withRetryTx"
+
+	var err error
+
+	for attempt := 1; attempt <= txMaxAttempts; attempt++ {
+		err = r.runTx(ctx, opts, fn)
+		if err == nil {
+			return nil
+		}
+
+		if !isRetryableTxError(err) {
+			return fmt.Errorf("%s: %w", op, r.classifyError(err))
+		}
+
+		if attempt == txMaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-time.After(time.Duration(attempt) * txRetryBackoff):
+		}
+	}
+
+	return fmt.Errorf("%s: transaction failed after %d attempts: %w", op, txMaxAttempts, err)
+}
+
+// runTx runs fn in a single transaction, committing it on success and rolling it back on failure.
+func (r *SongRepository) runTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSongRepository_WithRetryTx(t *testing.T) {
+	countSongs := func(ctx context.Context, calls *int) func(tx *sqlx.Tx) error {
+		return func(tx *sqlx.Tx) error {
+			*calls++
+
+			var count int
+			return tx.GetContext(ctx, &count, "SELECT COUNT(*) FROM songs")
+		}
+	}
+
+	t.Run("retries serialization failure", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+			WillReturnError(&pgconn.PgError{Code: serializationFailureCode})
+		mock.ExpectRollback()
+
+		mock.ExpectBegin()
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectCommit()
+
+		var calls int
+
+		err := repo.withRetryTx(
+			context.Background(),
+			&sql.TxOptions{Isolation: sql.LevelSerializable},
+			countSongs(context.Background(), &calls),
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("retries deadlock on commit", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectCommit().WillReturnError(&pgconn.PgError{Code: deadlockDetectedCode})
+
+		mock.ExpectBegin()
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectCommit()
+
+		var calls int
+
+		err := repo.withRetryTx(context.Background(), nil, countSongs(context.Background(), &calls))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+			WillReturnError(errors.New("unknown error"))
+		mock.ExpectRollback()
+
+		var calls int
+
+		err := repo.withRetryTx(context.Background(), nil, countSongs(context.Background(), &calls))
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "unknown error")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		for range txMaxAttempts {
+			mock.ExpectBegin()
+			mock.
+				ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+				WillReturnError(&pgconn.PgError{Code: serializationFailureCode})
+			mock.ExpectRollback()
+		}
+
+		var calls int
+
+		err := repo.withRetryTx(context.Background(), nil, countSongs(context.Background(), &calls))
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "transaction failed after 3 attempts")
+		assert.True(t, isRetryableTxError(err))
+		assert.Equal(t, txMaxAttempts, calls)
+	})
+}
@@ -5,11 +5,17 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/pkg/requestid"
+	"github.com/vadimbarashkov/online-song-library/pkg/servertiming"
 
 	sq "github.com/Masterminds/squirrel"
 )
@@ -17,27 +23,232 @@ import (
 // songRow represents a row in the 'songs' table of the database.
 // This struct is used internally within the repository to map SQL query results.
 type songRow struct {
-	ID          uuid.UUID      `db:"id"`
-	GroupName   string         `db:"group_name"`
-	Name        string         `db:"name"`
-	ReleaseDate sql.NullTime   `db:"release_date"`
-	Text        sql.NullString `db:"text"`
-	Link        sql.NullString `db:"link"`
-	CreatedAt   time.Time      `db:"created_at"`
-	UpdatedAt   time.Time      `db:"updated_at"`
+	ID              uuid.UUID      `db:"id"`
+	GroupName       string         `db:"group_name"`
+	Name            string         `db:"name"`
+	SortName        sql.NullString `db:"sort_name"`
+	ReleaseDate     sql.NullTime   `db:"release_date"`
+	Text            sql.NullString `db:"text"`
+	Link            sql.NullString `db:"link"`
+	OriginalLink    sql.NullString `db:"original_link"`
+	DetailSource    sql.NullString `db:"detail_source"`
+	DetailStatus    sql.NullString `db:"detail_status"`
+	Provider        sql.NullString `db:"detail_provider"`
+	DetailFetchedAt sql.NullTime   `db:"detail_fetched_at"`
+	ImportID        uuid.NullUUID  `db:"import_id"`
+	CreatedAt       time.Time      `db:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at"`
+}
+
+// songColumns lists the columns of the 'songs' table mapped by songRow. Queries select and return
+// exactly these columns instead of *, so that columns added by later migrations don't break scanning.
+var songColumns = []string{
+	"id",
+	"group_name",
+	"name",
+	"sort_name",
+	"release_date",
+	"text",
+	"link",
+	"original_link",
+	"detail_source",
+	"detail_status",
+	"detail_provider",
+	"detail_fetched_at",
+	"import_id",
+	"created_at",
+	"updated_at",
+}
+
+// returningSongColumns is the RETURNING clause of statements returning whole song rows.
+var returningSongColumns = "RETURNING " + strings.Join(songColumns, ", ")
+
+// libraryStatsRow represents the row of the library stats aggregation.
+type libraryStatsRow struct {
+	Songs         uint64 `db:"songs"`
+	GroupCount    uint64 `db:"group_count"`
+	MissingDetail uint64 `db:"missing_detail"`
+}
+
+// decadeCountRow represents a row of the songs per decade aggregation.
+type decadeCountRow struct {
+	Decade int    `db:"decade"`
+	Count  uint64 `db:"count"`
+}
+
+// yearCountRow represents a row of the songs per release year aggregation.
+// Year is null for the songs without a release date.
+type yearCountRow struct {
+	Year  sql.NullInt64 `db:"year"`
+	Count uint64        `db:"count"`
+}
+
+// releaseRangeRow represents the earliest and latest release dates of the songs of a group with the names
+// of the songs released on them. All fields are null when the group has no songs with a release date.
+type releaseRangeRow struct {
+	FirstDate sql.NullTime   `db:"first_date"`
+	FirstName sql.NullString `db:"first_name"`
+	LastDate  sql.NullTime   `db:"last_date"`
+	LastName  sql.NullString `db:"last_name"`
+}
+
+// letterCountRow represents a row of the alphabetical index aggregation.
+type letterCountRow struct {
+	Letter string `db:"letter"`
+	Count  uint64 `db:"count"`
+}
+
+// facetCountRow represents a row of the songs per facet value aggregation.
+type facetCountRow struct {
+	Value string `db:"value"`
+	Count uint64 `db:"count"`
 }
 
 // SongRepository provides methods for interacting with the 'songs' table in the database.
 // It abstracts the details of SQL operations (insert, update, delete, etc.) and provides
 // a clean interface for managing song records.
 type SongRepository struct {
-	db *sqlx.DB
+	db             *sqlx.DB
+	analyticsDB    *sqlx.DB
+	newID          func() uuid.UUID
+	queryLogger    *slog.Logger
+	logQueryArgs   bool
+	defaultFilters []entity.SongFilter
+	collation      string
+	uniqueLinks    bool
+}
+
+// Option represents a functional option for configuring the SongRepository.
+type Option func(*SongRepository)
+
+// WithIDGenerator sets the function generating the IDs of saved songs that have none, uuid.New by default.
+func WithIDGenerator(newID func() uuid.UUID) Option {
+	return func(r *SongRepository) {
+		r.newID = newID
+	}
+}
+
+// WithDefaultFilters sets filters added to every query listing, counting, or aggregating songs, such as GetAll,
+// Count and CountByDecade, and to the bulk updates and deletions, such as UpdateWhere and DeleteWhere.
+// They are combined with the filters of the query with AND, so those can only narrow the results further
+// and songs outside of the default filters can't be modified in bulk either.
+func WithDefaultFilters(filters ...entity.SongFilter) Option {
+	return func(r *SongRepository) {
+		r.defaultFilters = filters
+	}
+}
+
+// WithCollation sets the collation group and song names are sorted by, such as "und-x-icu" for
+// Unicode-aware ordering of names with emoji and CJK characters. The column collation is used by default.
+func WithCollation(collation string) Option {
+	return func(r *SongRepository) {
+		r.collation = collation
+	}
+}
+
+// WithUniqueLinkErrors makes saving or updating a song with the link of another song fail with
+// entity.ErrLinkAlreadyExists once the unique link index is built (see CreateUniqueLinkIndex).
+func WithUniqueLinkErrors() Option {
+	return func(r *SongRepository) {
+		r.uniqueLinks = true
+	}
+}
+
+// WithAnalyticsDB routes the heavy aggregate queries counting songs by decade and by first letter to
+// a separate analytics database, such as a read replica, so that their scans don't compete with the
+// transactional traffic of the primary database. They run against the primary database by default.
+func WithAnalyticsDB(db *sqlx.DB) Option {
+	return func(r *SongRepository) {
+		r.analyticsDB = db
+	}
+}
+
+// WithQueryLogger enables debug-level logging of every SQL statement executed by the repository.
+// Query arguments are logged only when logArgs is true, otherwise just their number is logged,
+// so that song data does not end up in the logs.
+func WithQueryLogger(logger *slog.Logger, logArgs bool) Option {
+	return func(r *SongRepository) {
+		r.queryLogger = logger
+		r.logQueryArgs = logArgs
+	}
 }
 
 // NewSongRepository creates a new instance of SongRepository and accepts a sqlx.DB object.
 // This repository can be used to interact with the 'songs' table.
-func NewSongRepository(db *sqlx.DB) *SongRepository {
-	return &SongRepository{db: db}
+func NewSongRepository(db *sqlx.DB, opts ...Option) *SongRepository {
+	r := &SongRepository{
+		db:    db,
+		newID: uuid.New,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// statsDB returns the database aggregate queries run against: the analytics database when set, the primary one otherwise.
+func (r *SongRepository) statsDB() *sqlx.DB {
+	if r.analyticsDB != nil {
+		return r.analyticsDB
+	}
+	return r.db
+}
+
+// classifyError classifies err like the package-level classifyError and, with WithUniqueLinkErrors,
+// marks duplicate links with entity.ErrLinkAlreadyExists.
+func (r *SongRepository) classifyError(err error) error {
+	if r.uniqueLinks && isUniqueLinkViolation(err) && !errors.Is(err, entity.ErrLinkAlreadyExists) {
+		return fmt.Errorf("%w: %w", entity.ErrLinkAlreadyExists, err)
+	}
+
+	return classifyError(err)
+}
+
+// logQuery logs the SQL statement about to be executed by the operation, if query logging is enabled.
+// The request ID is attached when the context carries one (see requestid.NewContext).
+func (r *SongRepository) logQuery(ctx context.Context, op, query string, args []any) {
+	if r.queryLogger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("op", op),
+		slog.String("query", query),
+	}
+
+	if reqID := requestid.FromContext(ctx); reqID != "" {
+		attrs = append(attrs, slog.String("reqID", reqID))
+	}
+
+	if r.logQueryArgs {
+		attrs = append(attrs, slog.Any("args", args))
+	} else {
+		attrs = append(attrs, slog.Int("argsCount", len(args)))
+	}
+
+	r.queryLogger.LogAttrs(ctx, slog.LevelDebug, "executing sql query", attrs...)
+}
+
+// toDate returns the calendar date of t as midnight UTC. Release dates are stored in a DATE column, so they
+// are normalized to keep the year, month and day of t regardless of its location, which keeps
+// EXTRACT(YEAR FROM release_date) consistent with the year the date was parsed with.
+func toDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// songID returns the ID of a song to be saved, keeping a provided ID and generating a new one otherwise.
+func (r *SongRepository) songID(song entity.Song) uuid.UUID {
+	if song.ID != uuid.Nil {
+		return song.ID
+	}
+
+	return r.newID()
 }
 
 // entityToRow converts an entity.Song object to a songRow. This helper function is used
@@ -47,8 +258,12 @@ func (r *SongRepository) entityToRow(song entity.Song) songRow {
 		ID:        song.ID,
 		GroupName: song.GroupName,
 		Name:      song.Name,
+		SortName: sql.NullString{
+			String: song.SortName,
+			Valid:  song.SortName != "",
+		},
 		ReleaseDate: sql.NullTime{
-			Time:  song.SongDetail.ReleaseDate,
+			Time:  toDate(song.SongDetail.ReleaseDate),
 			Valid: !song.SongDetail.ReleaseDate.IsZero(),
 		},
 		Text: sql.NullString{
@@ -59,6 +274,26 @@ func (r *SongRepository) entityToRow(song entity.Song) songRow {
 			String: song.SongDetail.Link,
 			Valid:  song.SongDetail.Link != "",
 		},
+		OriginalLink: sql.NullString{
+			String: song.OriginalLink,
+			Valid:  song.OriginalLink != "",
+		},
+		DetailSource: sql.NullString{
+			String: string(song.DetailSource),
+			Valid:  song.DetailSource != "",
+		},
+		DetailStatus: sql.NullString{
+			String: string(song.DetailStatus),
+			Valid:  song.DetailStatus != "",
+		},
+		Provider: sql.NullString{
+			String: song.SongDetail.Provider,
+			Valid:  song.SongDetail.Provider != "",
+		},
+		ImportID: uuid.NullUUID{
+			UUID:  song.ImportID,
+			Valid: song.ImportID != uuid.Nil,
+		},
 		CreatedAt: song.CreatedAt,
 		UpdatedAt: song.UpdatedAt,
 	}
@@ -75,8 +310,11 @@ func (r *SongRepository) entityToMap(song entity.Song) map[string]any {
 	if song.Name != "" {
 		clauses["name"] = song.Name
 	}
+	if song.SortName != "" {
+		clauses["sort_name"] = song.SortName
+	}
 	if !song.SongDetail.ReleaseDate.IsZero() {
-		clauses["release_date"] = song.SongDetail.ReleaseDate
+		clauses["release_date"] = toDate(song.SongDetail.ReleaseDate)
 	}
 	if song.SongDetail.Text != "" {
 		clauses["text"] = song.SongDetail.Text
@@ -84,6 +322,19 @@ func (r *SongRepository) entityToMap(song entity.Song) map[string]any {
 	if song.SongDetail.Link != "" {
 		clauses["link"] = song.SongDetail.Link
 	}
+	if song.OriginalLink != "" {
+		clauses["original_link"] = song.OriginalLink
+	}
+	if song.DetailSource != "" {
+		clauses["detail_source"] = song.DetailSource
+	}
+	if song.DetailStatus != "" {
+		clauses["detail_status"] = song.DetailStatus
+		clauses["detail_fetched_at"] = detailFetchedAt(song)
+	}
+	if song.SongDetail.Provider != "" {
+		clauses["detail_provider"] = song.SongDetail.Provider
+	}
 
 	return clauses
 }
@@ -95,13 +346,20 @@ func (r *SongRepository) rowToEntity(row songRow) *entity.Song {
 		ID:        row.ID,
 		GroupName: row.GroupName,
 		Name:      row.Name,
+		SortName:  row.SortName.String,
 		SongDetail: entity.SongDetail{
 			ReleaseDate: row.ReleaseDate.Time,
 			Text:        row.Text.String,
 			Link:        row.Link.String,
+			Provider:    row.Provider.String,
 		},
-		CreatedAt: row.CreatedAt,
-		UpdatedAt: row.UpdatedAt,
+		OriginalLink:    row.OriginalLink.String,
+		DetailSource:    entity.DetailSource(row.DetailSource.String),
+		DetailStatus:    entity.DetailStatus(row.DetailStatus.String),
+		DetailFetchedAt: row.DetailFetchedAt.Time,
+		ImportID:        row.ImportID.UUID,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
 	}
 }
 
@@ -117,10 +375,30 @@ func (r *SongRepository) rowsToEntities(rows []songRow) []*entity.Song {
 	return songs
 }
 
-// applySongFilters adds SQL WHERE conditions to the query builder (squirrel.SelectBuilder)
-// based on the provided SongFilter. It allows filtering results by group name, song title,
-// release year/date, and text content.
-func (r *SongRepository) applySongFilters(sb sq.SelectBuilder, filters ...entity.SongFilter) sq.SelectBuilder {
+// sortBy returns the ORDER BY expression sorting the column in ascending order by the configured collation
+// (see WithCollation). The collation name is quoted as an identifier.
+func (r *SongRepository) sortBy(column string) string {
+	if r.collation == "" {
+		return column + " ASC"
+	}
+
+	return fmt.Sprintf("%s COLLATE %s ASC", column, quoteIdentifier(r.collation))
+}
+
+// quoteIdentifier quotes the name as an SQL identifier, doubling the double quotes it contains.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// songFilterConditions builds the SQL WHERE conditions matching the provided SongFilter. It allows filtering
+// results by group name (exactly or partially), song title, release year/date, text content and length, missing song details,
+// and whether the song was ever edited.
+// Filters are always combined with AND, so several filters on the same field must all match.
+// A missing release date filter set to false matches the songs that have a release date instead.
+// A release year filter holding a slice of years matches songs released in any of them.
+func (r *SongRepository) songFilterConditions(filters ...entity.SongFilter) []sq.Sqlizer {
+	var conds []sq.Sqlizer
+
 	for _, filter := range filters {
 		field := filter.Field
 		value := filter.Value
@@ -128,68 +406,356 @@ func (r *SongRepository) applySongFilters(sb sq.SelectBuilder, filters ...entity
 		switch field {
 		case entity.SongGroupNameFilterField:
 			if val, ok := value.(string); ok {
-				sb = sb.Where("group_name ILIKE ?", fmt.Sprint("%", val, "%"))
+				conds = append(conds, sq.Expr("group_name ILIKE ?", fmt.Sprint("%", val, "%")))
+			}
+		case entity.SongGroupFilterField:
+			if val, ok := value.(string); ok {
+				conds = append(conds, sq.Eq{"group_name": val})
+			}
+		case entity.SongImportFilterField:
+			if val, ok := value.(uuid.UUID); ok {
+				conds = append(conds, sq.Eq{"import_id": val})
 			}
 		case entity.SongNameFilterField:
 			if val, ok := value.(string); ok {
-				sb = sb.Where("name ILIKE ?", fmt.Sprint("%", val, "%"))
+				conds = append(conds, sq.Expr("name ILIKE ?", fmt.Sprint("%", val, "%")))
 			}
 		case entity.SongReleaseYearFilterField:
-			if val, ok := value.(int); ok {
-				sb = sb.Where("EXTRACT(YEAR FROM release_date) = ?", val)
+			switch val := value.(type) {
+			case int:
+				conds = append(conds, sq.Expr("EXTRACT(YEAR FROM release_date) = ?", val))
+			case []int:
+				if len(val) > 0 {
+					conds = append(conds, sq.Eq{"EXTRACT(YEAR FROM release_date)": val})
+				}
 			}
 		case entity.SongReleaseDateFilterField:
 			if val, ok := value.(time.Time); ok {
-				sb = sb.Where(sq.Eq{"release_date": val})
+				conds = append(conds, sq.Eq{"release_date": toDate(val)})
 			}
 		case entity.SongReleaseDateAfterFilterField:
 			if val, ok := value.(time.Time); ok {
-				sb = sb.Where("release_date > ?", val)
+				conds = append(conds, sq.Expr("release_date > ?", toDate(val)))
 			}
 		case entity.SongReleaseDateBeforeFilterField:
 			if val, ok := value.(time.Time); ok {
-				sb = sb.Where("release_date < ?", val)
+				conds = append(conds, sq.Expr("release_date < ?", toDate(val)))
 			}
 		case entity.SongTextFilterField:
 			if val, ok := value.(string); ok {
-				sb = sb.Where("text ILIKE ?", val)
+				conds = append(conds, sq.Expr("text ILIKE ?", val))
+			}
+		case entity.SongReleaseDateMissingFilterField:
+			if val, ok := value.(bool); ok {
+				if val {
+					conds = append(conds, sq.Eq{"release_date": nil})
+				} else {
+					conds = append(conds, sq.NotEq{"release_date": nil})
+				}
+			}
+		case entity.SongTextMissingFilterField:
+			if val, ok := value.(bool); ok && val {
+				conds = append(conds, sq.Eq{"text": nil})
+			}
+		case entity.SongLinkMissingFilterField:
+			if val, ok := value.(bool); ok && val {
+				conds = append(conds, sq.Eq{"link": nil})
+			}
+		case entity.SongMinTextLenFilterField:
+			if val, ok := value.(int); ok {
+				conds = append(conds, sq.Expr("length(text) >= ?", val))
+			}
+		case entity.SongMaxTextLenFilterField:
+			if val, ok := value.(int); ok {
+				conds = append(conds, sq.Expr("length(text) <= ?", val))
+			}
+		case entity.SongDetailStatusFilterField:
+			if val, ok := value.(entity.DetailStatus); ok {
+				conds = append(conds, sq.Eq{"detail_status": val})
+			}
+		case entity.SongEditedFilterField:
+			if val, ok := value.(bool); ok && val {
+				conds = append(conds, sq.Expr("updated_at > created_at"))
+			}
+		case entity.SongTextRegexFilterField:
+			if val, ok := value.(string); ok {
+				conds = append(conds, sq.Expr("text ~* ?", val))
 			}
 		}
 	}
 
+	return conds
+}
+
+// scopedFilterConditions builds the SQL WHERE conditions of the default filters (see WithDefaultFilters)
+// followed by those of the provided SongFilter (see songFilterConditions).
+func (r *SongRepository) scopedFilterConditions(filters ...entity.SongFilter) []sq.Sqlizer {
+	return r.songFilterConditions(append(slices.Clone(r.defaultFilters), filters...)...)
+}
+
+// applySongFilters adds the conditions of the default filters (see WithDefaultFilters) and the provided
+// SongFilter (see songFilterConditions) to the query builder (squirrel.SelectBuilder).
+func (r *SongRepository) applySongFilters(sb sq.SelectBuilder, filters ...entity.SongFilter) sq.SelectBuilder {
+	for _, cond := range r.scopedFilterConditions(filters...) {
+		sb = sb.Where(cond)
+	}
+
 	return sb
 }
 
+// detailFetchedAt returns the SQL value of the detail_fetched_at column of a saved song: the current time
+// when the song comes with the outcome of a music info API lookup, NULL otherwise.
+func detailFetchedAt(song entity.Song) sq.Sqlizer {
+	if song.DetailStatus == "" {
+		return sq.Expr("NULL")
+	}
+	return sq.Expr("CURRENT_TIMESTAMP")
+}
+
+// buildInsertQuery builds the statement inserting the songs into the 'songs' table and returning the saved rows.
+// It returns an error if any song misses required fields.
+func (r *SongRepository) buildInsertQuery(songs []entity.Song) (string, []any, error) {
+	ib := sq.
+		Insert("songs").Columns("id", "group_name", "name", "sort_name", "release_date", "text", "link", "original_link", "detail_source", "detail_status", "detail_provider", "detail_fetched_at", "import_id").
+		Suffix(returningSongColumns).
+		PlaceholderFormat(sq.Dollar)
+
+	for _, song := range songs {
+		row := r.entityToRow(song)
+		if row.GroupName == "" || row.Name == "" {
+			return "", nil, errors.New("missing required fields for saving song")
+		}
+
+		ib = ib.Values(r.songID(song), row.GroupName, row.Name, row.SortName, row.ReleaseDate, row.Text, row.Link, row.OriginalLink, row.DetailSource, row.DetailStatus, row.Provider, detailFetchedAt(song), row.ImportID)
+	}
+
+	query, args, err := ib.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build sql query: %w", err)
+	}
+
+	return query, args, nil
+}
+
+// CheckCollation checks that the database knows the configured collation (see WithCollation),
+// so that a misspelled one fails at startup rather than every sorted query.
+func (r *SongRepository) CheckCollation(ctx context.Context) error {
+	const op = "adapter.repository.postgres.SongRepository.CheckCollation"
+
+	if r.collation == "" {
+		return nil
+	}
+
+	query := "SELECT 'a' COLLATE " + quoteIdentifier(r.collation)
+
+	r.logQuery(ctx, op, query, nil)
+
+	if _, err := r.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s: unknown collation %q: %w", op, r.collation, r.classifyError(err))
+	}
+
+	return nil
+}
+
+// CreateUniqueLinkIndex builds the unique index on the link of songs unless it exists, so every link maps to
+// at most one song. Songs without a link are not constrained. It returns an error if the index can't be built,
+// such as when existing songs already share a link, which are left as they are.
+func (r *SongRepository) CreateUniqueLinkIndex(ctx context.Context) error {
+	const op = "adapter.repository.postgres.SongRepository.CreateUniqueLinkIndex"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	query := "CREATE UNIQUE INDEX IF NOT EXISTS " + uniqueLinkIndex + " ON songs (link) WHERE link IS NOT NULL"
+
+	r.logQuery(ctx, op, query, nil)
+
+	if _, err := r.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s: failed to create unique link index: %w", op, r.classifyError(err))
+	}
+
+	return nil
+}
+
 // Save inserts a new song record into the 'songs' table.
 // It returns the saved song entity if successful or an error if any required fields are missing or if the operation fails.
 func (r *SongRepository) Save(ctx context.Context, song entity.Song) (*entity.Song, error) {
 	const op = "adapter.repository.postgres.SongRepository.Save"
 
-	row := r.entityToRow(song)
-	if row.GroupName == "" || row.Name == "" {
-		return nil, fmt.Errorf("%s: missing required fields for saving song", op)
-	}
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
 
-	query, args, err := sq.
-		Insert("songs").Columns("group_name", "name", "release_date", "text", "link").
-		Values(row.GroupName, row.Name, row.ReleaseDate, row.Text, row.Link).
-		Suffix("RETURNING *").
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
+	query, args, err := r.buildInsertQuery([]entity.Song{song})
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	var savedRow songRow
 
+	r.logQuery(ctx, op, query, args)
+
 	if err := r.db.GetContext(ctx, &savedRow, query, args...); err != nil {
-		return nil, fmt.Errorf("%s: failed to insert row into 'songs' table: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to insert row into 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return r.rowToEntity(savedRow), nil
+}
+
+// SaveBatch inserts multiple song records into the 'songs' table using a single statement.
+// It returns the saved song entities if successful or an error if any song misses required fields or if the operation fails.
+func (r *SongRepository) SaveBatch(ctx context.Context, songs []entity.Song) ([]*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.SaveBatch"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if len(songs) == 0 {
+		return nil, fmt.Errorf("%s: no songs provided for saving", op)
+	}
+
+	query, args, err := r.buildInsertQuery(songs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var savedRows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &savedRows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to insert rows into 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return r.rowsToEntities(savedRows), nil
+}
+
+// lockGroupQuery takes a transaction-level advisory lock keyed by a group name, so that concurrent inserts
+// into the same group wait for each other between counting the songs of the group and inserting.
+const lockGroupQuery = "SELECT pg_advisory_xact_lock(hashtext($1))"
+
+// checkGroupLimit locks the groups of the songs in tx and reports an entity.ErrGroupSongLimitExceeded error
+// when saving the songs would make any of the groups have more than limit songs.
+// Groups are locked in alphabetical order, so that concurrent batches don't deadlock.
+func (r *SongRepository) checkGroupLimit(ctx context.Context, tx *sqlx.Tx, op string, songs []entity.Song, limit uint64) error {
+	added := make(map[string]uint64)
+	for _, song := range songs {
+		added[song.GroupName]++
+	}
+
+	groupNames := make([]string, 0, len(added))
+	for groupName := range added {
+		groupNames = append(groupNames, groupName)
+	}
+	sort.Strings(groupNames)
+
+	for _, groupName := range groupNames {
+		r.logQuery(ctx, op, lockGroupQuery, []any{groupName})
+
+		if _, err := tx.ExecContext(ctx, lockGroupQuery, groupName); err != nil {
+			return fmt.Errorf("failed to lock group: %w", err)
+		}
+
+		query, args, err := sq.
+			Select("COUNT(*)").
+			From("songs").
+			Where(sq.Eq{"group_name": groupName}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build sql query: %w", err)
+		}
+
+		var count uint64
+
+		r.logQuery(ctx, op, query, args)
+
+		if err := tx.GetContext(ctx, &count, query, args...); err != nil {
+			return fmt.Errorf("failed to count songs of group: %w", err)
+		}
+
+		if count+added[groupName] > limit {
+			return fmt.Errorf("%w: group %q has %d songs, limit is %d", entity.ErrGroupSongLimitExceeded, groupName, count, limit)
+		}
+	}
+
+	return nil
+}
+
+// SaveWithinGroupLimit inserts a new song record into the 'songs' table unless its group already has limit songs,
+// in which case it returns an entity.ErrGroupSongLimitExceeded error. The songs are counted in the inserting transaction.
+// It returns the saved song entity if successful or an error if any required fields are missing or if the operation fails.
+func (r *SongRepository) SaveWithinGroupLimit(ctx context.Context, song entity.Song, limit uint64) (*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.SaveWithinGroupLimit"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	query, args, err := r.buildInsertQuery([]entity.Song{song})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var savedRow songRow
+
+	err = r.withRetryTx(ctx, nil, func(tx *sqlx.Tx) error {
+		if err := r.checkGroupLimit(ctx, tx, op, []entity.Song{song}, limit); err != nil {
+			return err
+		}
+
+		r.logQuery(ctx, op, query, args)
+
+		if err := tx.GetContext(ctx, &savedRow, query, args...); err != nil {
+			return fmt.Errorf("failed to insert row into 'songs' table: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return r.rowToEntity(savedRow), nil
 }
 
-// GetAll retrieves all song records that match the provided filter conditions and pagination settings.
+// SaveBatchWithinGroupLimit inserts multiple song records into the 'songs' table unless that would make any of their
+// groups have more than limit songs, in which case nothing is saved and an entity.ErrGroupSongLimitExceeded error is returned.
+// It returns the saved song entities if successful or an error if any song misses required fields or if the operation fails.
+func (r *SongRepository) SaveBatchWithinGroupLimit(ctx context.Context, songs []entity.Song, limit uint64) ([]*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.SaveBatchWithinGroupLimit"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if len(songs) == 0 {
+		return nil, fmt.Errorf("%s: no songs provided for saving", op)
+	}
+
+	query, args, err := r.buildInsertQuery(songs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var savedRows []songRow
+
+	err = r.withRetryTx(ctx, nil, func(tx *sqlx.Tx) error {
+		if err := r.checkGroupLimit(ctx, tx, op, songs, limit); err != nil {
+			return err
+		}
+
+		savedRows = nil
+
+		r.logQuery(ctx, op, query, args)
+
+		if err := tx.SelectContext(ctx, &savedRows, query, args...); err != nil {
+			return fmt.Errorf("failed to insert rows into 'songs' table: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return r.rowsToEntities(savedRows), nil
+}
+
+// GetAll retrieves all song records that match the provided filter conditions and pagination settings,
+// ordered alphabetically by the sort name of the group and the song title.
 // It returns a slice of song entities along with updated pagination information, or an error if the operation fails.
 func (r *SongRepository) GetAll(
 	ctx context.Context,
@@ -198,12 +764,15 @@ func (r *SongRepository) GetAll(
 ) ([]*entity.Song, *entity.Pagination, error) {
 	const op = "adapter.repository.postgres.SongRepository.GetAll"
 
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
 	if pagination.IsEmpty() {
 		pagination.SetDefault()
 	}
 
 	sb := sq.
-		Select("*").From("songs").
+		Select(songColumns...).From("songs").
+		OrderBy(r.sortBy("sort_name"), r.sortBy("name")).
 		Limit(pagination.Limit).
 		Offset(pagination.Offset).
 		PlaceholderFormat(sq.Dollar)
@@ -217,97 +786,1238 @@ func (r *SongRepository) GetAll(
 
 	var rows []songRow
 
+	r.logQuery(ctx, op, query, args)
+
 	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
-		return nil, nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, err)
+		return nil, nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	pagination.Items = uint64(len(rows))
+
+	if pagination.SkipTotal {
+		return r.rowsToEntities(rows), &pagination, nil
 	}
 
-	query, args, err = sq.
+	sb = sq.
 		Select("COUNT(*)").From("songs").
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err = r.applySongFilters(sb, filters...).ToSql()
 	if err != nil {
 		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
 	}
 
 	var totalCount uint64
 
+	r.logQuery(ctx, op, query, args)
+
 	if err := r.db.GetContext(ctx, &totalCount, query, args...); err != nil {
-		return nil, nil, fmt.Errorf("%s: failed to get total count of rows from 'songs' table: %w", op, err)
+		return nil, nil, fmt.Errorf("%s: failed to get total count of rows from 'songs' table: %w", op, r.classifyError(err))
 	}
 
-	pagination.Items = uint64(len(rows))
 	pagination.Total = totalCount
 
 	return r.rowsToEntities(rows), &pagination, nil
 }
 
-// GetByID retrieves a song by its ID from the 'songs' table.
-// It returns the corresponding entity.Song object or an error if the song is not found.
-func (r *SongRepository) GetByID(ctx context.Context, songID uuid.UUID) (*entity.Song, error) {
-	const op = "adapter.repository.postgres.SongRepository.GetByID"
+// Count counts the song records that match the provided filter conditions.
+// It returns the number of matching songs or an error if the operation fails.
+func (r *SongRepository) Count(ctx context.Context, filters ...entity.SongFilter) (uint64, error) {
+	const op = "adapter.repository.postgres.SongRepository.Count"
 
-	query, args, err := sq.
-		Select("*").From("songs").
-		Where(sq.Eq{"id": songID}).
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	sb := sq.
+		Select("COUNT(*)").From("songs").
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := r.applySongFilters(sb, filters...).ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+		return 0, fmt.Errorf("%s: failed to build sql query: %w", op, err)
 	}
 
-	var row songRow
+	var count uint64
 
-	if err := r.db.GetContext(ctx, &row, query, args...); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("%s: %w", op, entity.ErrSongNotFound)
-		}
+	r.logQuery(ctx, op, query, args)
 
-		return nil, fmt.Errorf("%s: failed to get row from 'songs' table: %w", op, err)
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("%s: failed to count rows from 'songs' table: %w", op, r.classifyError(err))
 	}
 
-	return r.rowToEntity(row), nil
+	return count, nil
 }
 
-// Update modifies an existing song record in the 'songs' table based on its ID.
-// It returns the updated song entity or an error if the update operation fails or if the song does not exist.
-func (r *SongRepository) Update(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error) {
-	const op = "adapter.repository.postgres.SongRepository.Update"
+// ExistsByName reports whether a song of the group with the name exists, comparing both names exactly.
+// Default filters (see WithDefaultFilters) are not applied, so that songs hidden from listings are found too.
+func (r *SongRepository) ExistsByName(ctx context.Context, groupName, name string) (bool, error) {
+	const op = "adapter.repository.postgres.SongRepository.ExistsByName"
 
-	clauses := r.entityToMap(song)
-	if len(clauses) == 0 {
-		return nil, fmt.Errorf("%s: no fields provided for update", op)
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	query, args, err := sq.
+		Select("1").From("songs").
+		Where(sq.Eq{"group_name": groupName, "name": name}).
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to build sql query: %w", op, err)
 	}
 
-	ub := sq.
+	var exists bool
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.GetContext(ctx, &exists, query, args...); err != nil {
+		return false, fmt.Errorf("%s: failed to check row in 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return exists, nil
+}
+
+// GetIncomplete retrieves song records that lack some of their details, ordered from the oldest to the newest.
+// Without filters it matches songs missing any of release date, text, or link; missing-detail filters narrow
+// the result to songs lacking all of the requested details.
+// It returns a slice of song entities along with updated pagination information, or an error if the operation fails.
+func (r *SongRepository) GetIncomplete(
+	ctx context.Context,
+	pagination entity.Pagination,
+	filters ...entity.SongFilter,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetIncomplete"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if pagination.IsEmpty() {
+		pagination.SetDefault()
+	}
+
+	where := func(sb sq.SelectBuilder) sq.SelectBuilder {
+		if len(filters) == 0 {
+			sb = sb.Where(sq.Or{
+				sq.Eq{"release_date": nil},
+				sq.Eq{"text": nil},
+				sq.Eq{"link": nil},
+			})
+		}
+
+		return r.applySongFilters(sb, filters...)
+	}
+
+	query, args, err := where(sq.Select(songColumns...).From("songs")).
+		OrderBy("created_at ASC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	query, args, err = where(sq.Select("COUNT(*)").From("songs")).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var totalCount uint64
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.GetContext(ctx, &totalCount, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get total count of rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	pagination.Items = uint64(len(rows))
+	pagination.Total = totalCount
+
+	return r.rowsToEntities(rows), &pagination, nil
+}
+
+// GetRecentlyUpdated retrieves song records that match the provided filter conditions and pagination settings,
+// most recently updated first. Songs that were never edited have the update time equal to the creation time.
+// It returns a slice of song entities along with updated pagination information, or an error if the operation fails.
+func (r *SongRepository) GetRecentlyUpdated(
+	ctx context.Context,
+	pagination entity.Pagination,
+	filters ...entity.SongFilter,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetRecentlyUpdated"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if pagination.IsEmpty() {
+		pagination.SetDefault()
+	}
+
+	query, args, err := r.applySongFilters(sq.Select(songColumns...).From("songs"), filters...).
+		OrderBy("updated_at DESC", "id ASC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	query, args, err = r.applySongFilters(sq.Select("COUNT(*)").From("songs"), filters...).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var totalCount uint64
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.GetContext(ctx, &totalCount, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get total count of rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	pagination.Items = uint64(len(rows))
+	pagination.Total = totalCount
+
+	return r.rowsToEntities(rows), &pagination, nil
+}
+
+// wellFormedLinkPattern is the POSIX regular expression, matched case-insensitively, that stored links must match
+// not to be reported as broken: an http or https URL with a host and no whitespace.
+const wellFormedLinkPattern = `^https?://[^[:space:]/?#]+([/?#][^[:space:]]*)?$`
+
+// failedLinkCheckCondition matches songs whose current link was last checked without a response
+// or with a client or server error status.
+const failedLinkCheckCondition = `EXISTS (SELECT 1 FROM link_checks lc WHERE lc.song_id = songs.id ` +
+	`AND lc.link = songs.link AND (lc.status_code = 0 OR lc.status_code >= 400))`
+
+// GetWithBrokenLinks retrieves song records whose link is broken, ordered from the oldest to the newest. A link
+// is broken when it is not a well-formed http or https URL, or when its last stored check got no response or an
+// error status. Checks of a link the song no longer has are ignored. Songs without a link are not included.
+// It returns a slice of song entities along with updated pagination information, or an error if the operation fails.
+func (r *SongRepository) GetWithBrokenLinks(
+	ctx context.Context,
+	pagination entity.Pagination,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetWithBrokenLinks"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if pagination.IsEmpty() {
+		pagination.SetDefault()
+	}
+
+	brokenLink := sq.And{
+		sq.NotEq{"link": nil},
+		sq.Or{
+			sq.Expr("link !~* ?", wellFormedLinkPattern),
+			sq.Expr(failedLinkCheckCondition),
+		},
+	}
+
+	query, args, err := r.applySongFilters(sq.Select(songColumns...).From("songs").Where(brokenLink)).
+		OrderBy("created_at ASC").
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	query, args, err = r.applySongFilters(sq.Select("COUNT(*)").From("songs").Where(brokenLink)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var totalCount uint64
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.GetContext(ctx, &totalCount, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get total count of rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	pagination.Items = uint64(len(rows))
+	pagination.Total = totalCount
+
+	return r.rowsToEntities(rows), &pagination, nil
+}
+
+// similarSongs adds the conditions matching songs similar to the given one: songs of the same group
+// or with a name similar to its name by trigram similarity. The song itself is excluded.
+func (r *SongRepository) similarSongs(sb sq.SelectBuilder, song entity.Song) sq.SelectBuilder {
+	return sb.
+		Where(sq.NotEq{"id": song.ID}).
+		Where(sq.Or{
+			sq.Eq{"group_name": song.GroupName},
+			sq.Expr("name % ?", song.Name),
+		})
+}
+
+// GetSimilar retrieves songs similar to the given song with pagination. Songs of the same group are ranked first,
+// then songs are ranked by the trigram similarity of their names to the name of the song.
+// It returns a slice of song entities, updated pagination, and an error if the query fails.
+func (r *SongRepository) GetSimilar(
+	ctx context.Context,
+	song entity.Song,
+	pagination entity.Pagination,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetSimilar"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if pagination.IsEmpty() {
+		pagination.SetDefault()
+	}
+
+	query, args, err := r.applySongFilters(r.similarSongs(sq.Select(songColumns...).From("songs"), song)).
+		OrderByClause("group_name = ? DESC", song.GroupName).
+		OrderByClause("similarity(name, ?) DESC", song.Name).
+		OrderBy(r.sortBy("sort_name"), r.sortBy("name")).
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	query, args, err = r.applySongFilters(r.similarSongs(sq.Select("COUNT(*)").From("songs"), song)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var totalCount uint64
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.GetContext(ctx, &totalCount, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get total count of rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	pagination.Items = uint64(len(rows))
+	pagination.Total = totalCount
+
+	return r.rowsToEntities(rows), &pagination, nil
+}
+
+// searchQuery is the full-text query Search builds from free text, parsed like web search engine input:
+// words are ANDed, "quoted phrases" match in order, "or" separates alternatives, and -word excludes a word.
+const searchQuery = "websearch_to_tsquery('simple', ?)"
+
+// Search retrieves the song records whose name, group name, or text match the free-text query, ranked by
+// ts_rank over the search_vector column, which weighs name matches above group name matches, and those
+// above text matches. Songs ranked the same are ordered by group and song name. Default filters (see
+// WithDefaultFilters) apply. It returns a slice of song entities along with updated pagination information,
+// or an error if the operation fails.
+func (r *SongRepository) Search(
+	ctx context.Context,
+	query string,
+	pagination entity.Pagination,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "adapter.repository.postgres.SongRepository.Search"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if pagination.IsEmpty() {
+		pagination.SetDefault()
+	}
+
+	matches := sq.Expr("search_vector @@ "+searchQuery, query)
+
+	sqlQuery, args, err := r.applySongFilters(sq.Select(songColumns...).From("songs").Where(matches)).
+		OrderByClause("ts_rank(search_vector, "+searchQuery+") DESC", query).
+		OrderBy(r.sortBy("sort_name"), r.sortBy("name")).
+		Limit(pagination.Limit).
+		Offset(pagination.Offset).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, sqlQuery, args)
+
+	if err := r.db.SelectContext(ctx, &rows, sqlQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	sqlQuery, args, err = r.applySongFilters(sq.Select("COUNT(*)").From("songs").Where(matches)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var totalCount uint64
+
+	r.logQuery(ctx, op, sqlQuery, args)
+
+	if err := r.db.GetContext(ctx, &totalCount, sqlQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get total count of rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	pagination.Items = uint64(len(rows))
+	pagination.Total = totalCount
+
+	return r.rowsToEntities(rows), &pagination, nil
+}
+
+// GetRecent retrieves the most recently created song records, newest first.
+// The limit falls back to entity.DefaultRecentLimit when it is zero and is capped at entity.MaxRecentLimit.
+func (r *SongRepository) GetRecent(ctx context.Context, limit uint64) ([]*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetRecent"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	switch {
+	case limit == 0:
+		limit = entity.DefaultRecentLimit
+	case limit > entity.MaxRecentLimit:
+		limit = entity.MaxRecentLimit
+	}
+
+	query, args, err := r.applySongFilters(sq.Select(songColumns...).From("songs")).
+		OrderBy("created_at DESC").
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return r.rowsToEntities(rows), nil
+}
+
+// GetStaleDetails retrieves up to limit songs whose details were last looked up in the music info API before
+// staleBefore, or never, for them to be refreshed. Songs never looked up come first, then the longest stale ones.
+func (r *SongRepository) GetStaleDetails(ctx context.Context, staleBefore time.Time, limit uint64) ([]*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetStaleDetails"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	stale := sq.Or{
+		sq.Eq{"detail_fetched_at": nil},
+		sq.Lt{"detail_fetched_at": staleBefore},
+	}
+
+	query, args, err := r.applySongFilters(sq.Select(songColumns...).From("songs").Where(stale)).
+		OrderBy("detail_fetched_at ASC NULLS FIRST", "id ASC").
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return r.rowsToEntities(rows), nil
+}
+
+// GetLibraryStats counts the songs, the distinct groups, and the songs lacking a release date, text, or link
+// in the 'songs' table with a single query.
+func (r *SongRepository) GetLibraryStats(ctx context.Context) (*entity.LibraryStats, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetLibraryStats"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	sb := sq.
+		Select(
+			"COUNT(*) AS songs",
+			"COUNT(DISTINCT group_name) AS group_count",
+			"COUNT(*) FILTER (WHERE release_date IS NULL OR text IS NULL OR link IS NULL) AS missing_detail",
+		).
+		From("songs")
+
+	query, args, err := r.applySongFilters(sb).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var row libraryStatsRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.GetContext(ctx, &row, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to count rows in 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return &entity.LibraryStats{
+		Songs:              row.Songs,
+		Groups:             row.GroupCount,
+		SongsMissingDetail: row.MissingDetail,
+	}, nil
+}
+
+// CountByDecade counts the songs released in each decade, ordered from the earliest decade.
+// Songs without a release date are not counted.
+func (r *SongRepository) CountByDecade(ctx context.Context) ([]entity.DecadeCount, error) {
+	const op = "adapter.repository.postgres.SongRepository.CountByDecade"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	sb := sq.
+		Select("(FLOOR(EXTRACT(YEAR FROM release_date) / 10) * 10)::int AS decade", "COUNT(*) AS count").
+		From("songs").
+		Where(sq.NotEq{"release_date": nil})
+
+	query, args, err := r.applySongFilters(sb).
+		GroupBy("decade").
+		OrderBy("decade ASC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []decadeCountRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.statsDB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to count rows by decade in 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	counts := make([]entity.DecadeCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, entity.DecadeCount{
+			Decade: row.Decade,
+			Count:  row.Count,
+		})
+	}
+
+	return counts, nil
+}
+
+// firstLetterExpr returns the SQL expression of the uppercased first letter of column, or entity.AlphaIndexOther
+// when it doesn't start with a letter. Letters are told apart by having distinct upper and lower case, so
+// letters of any alphabet are indexed under themselves while digits and punctuation are not.
+func firstLetterExpr(column string) string {
+	first := fmt.Sprintf("LEFT(%s, 1)", column)
+	return fmt.Sprintf("CASE WHEN UPPER(%[1]s) <> LOWER(%[1]s) THEN UPPER(%[1]s) ELSE '%[2]s' END", first, entity.AlphaIndexOther)
+}
+
+// CountByFirstLetter counts the groups, by their sort names, or the songs, by their names, per uppercased first letter,
+// ordered by letter with entity.AlphaIndexOther first.
+func (r *SongRepository) CountByFirstLetter(ctx context.Context, field entity.AlphaIndexField) ([]entity.LetterCount, error) {
+	const op = "adapter.repository.postgres.SongRepository.CountByFirstLetter"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	var (
+		letter string
+		count  string
+	)
+
+	switch field {
+	case entity.AlphaIndexGroupField:
+		letter, count = firstLetterExpr("sort_name"), "COUNT(DISTINCT group_name) AS count"
+	case entity.AlphaIndexSongField:
+		letter, count = firstLetterExpr("name"), "COUNT(*) AS count"
+	default:
+		return nil, fmt.Errorf("%s: unknown alpha index field: %d", op, field)
+	}
+
+	// Output columns can't be given a collation, so letters are sorted by their expression, after
+	// entity.AlphaIndexOther whatever the collation orders punctuation by.
+	query, args, err := r.applySongFilters(sq.Select(letter+" AS letter", count).From("songs")).
+		GroupBy("letter").
+		OrderByClause(letter+" = ? DESC", entity.AlphaIndexOther).
+		OrderBy(r.sortBy(letter)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []letterCountRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.statsDB().SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to count rows by first letter in 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	counts := make([]entity.LetterCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, entity.LetterCount{Letter: row.Letter, Count: row.Count})
+	}
+
+	return counts, nil
+}
+
+// likeEscaper escapes the wildcard characters of LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetGroupFacets retrieves the distinct release years of the songs of a group, with the number of songs
+// released in each year, ordered from the earliest year. Songs without a release date are not counted.
+// It returns an error if the group has no songs or if the operation fails.
+func (r *SongRepository) GetGroupFacets(ctx context.Context, groupName string) (*entity.GroupFacets, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetGroupFacets"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	sb := sq.
+		Select("EXTRACT(YEAR FROM release_date)::int AS year", "COUNT(*) AS count").
+		From("songs").
+		Where(sq.Eq{"group_name": groupName})
+
+	query, args, err := r.applySongFilters(sb).
+		GroupBy("year").
+		OrderBy("year ASC NULLS LAST").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []yearCountRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to count rows by year in 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrGroupNotFound)
+	}
+
+	facets := &entity.GroupFacets{
+		GroupName:    groupName,
+		ReleaseYears: make([]entity.YearCount, 0, len(rows)),
+	}
+	for _, row := range rows {
+		if !row.Year.Valid {
+			continue
+		}
+
+		facets.ReleaseYears = append(facets.ReleaseYears, entity.YearCount{
+			Year:  int(row.Year.Int64),
+			Count: row.Count,
+		})
+	}
+
+	return facets, nil
+}
+
+// GetGroupReleaseRange retrieves the earliest and latest release dates of the songs of a group, along with the
+// names of the songs released on them; when several songs share a date, the first by name is taken.
+// Songs without a release date are ignored. It returns an error if the group has no songs with a release date
+// or if the operation fails.
+func (r *SongRepository) GetGroupReleaseRange(ctx context.Context, groupName string) (*entity.GroupReleaseRange, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetGroupReleaseRange"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	bounds := r.applySongFilters(sq.
+		Select("MIN(release_date) AS first_date", "MAX(release_date) AS last_date").
+		From("songs").
+		Where(sq.Eq{"group_name": groupName}))
+
+	songReleasedOn := func(dateColumn string) sq.SelectBuilder {
+		return r.applySongFilters(sq.
+			Select("s.name").
+			From("songs s").
+			Where(sq.Eq{"s.group_name": groupName}).
+			Where("s.release_date = b." + dateColumn)).
+			OrderBy(r.sortBy("s.name")).
+			Limit(1)
+	}
+
+	query, args, err := sq.
+		Select("b.first_date").
+		Column(sq.Alias(songReleasedOn("first_date"), "first_name")).
+		Column("b.last_date").
+		Column(sq.Alias(songReleasedOn("last_date"), "last_name")).
+		FromSelect(bounds, "b").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var row releaseRangeRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.GetContext(ctx, &row, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to get release range from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	if !row.FirstDate.Valid || !row.LastDate.Valid {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrGroupNotFound)
+	}
+
+	return &entity.GroupReleaseRange{
+		GroupName: groupName,
+		First:     entity.SongRelease{Name: row.FirstName.String, ReleaseDate: row.FirstDate.Time},
+		Last:      entity.SongRelease{Name: row.LastName.String, ReleaseDate: row.LastDate.Time},
+	}, nil
+}
+
+// CountFacet counts the songs matching the provided filter conditions per value of the facet field.
+// Release years are ordered from the earliest and songs without a release date are not counted,
+// group names are ordered from the one with the most songs.
+func (r *SongRepository) CountFacet(
+	ctx context.Context,
+	field entity.FacetField,
+	filters ...entity.SongFilter,
+) ([]entity.FacetCount, error) {
+	const op = "adapter.repository.postgres.SongRepository.CountFacet"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	var sb sq.SelectBuilder
+
+	switch field {
+	case entity.FacetReleaseYearField:
+		sb = sq.
+			Select("EXTRACT(YEAR FROM release_date)::int AS value", "COUNT(*) AS count").From("songs").
+			Where(sq.NotEq{"release_date": nil}).
+			GroupBy("value").
+			OrderBy("value ASC")
+	case entity.FacetGroupNameField:
+		sb = sq.
+			Select("group_name AS value", "COUNT(*) AS count").From("songs").
+			GroupBy("group_name").
+			OrderBy("count DESC", r.sortBy("group_name"))
+	default:
+		return nil, fmt.Errorf("%s: unknown facet field: %d", op, field)
+	}
+
+	query, args, err := r.applySongFilters(sb, filters...).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []facetCountRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	counts := make([]entity.FacetCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, entity.FacetCount{Value: row.Value, Count: row.Count})
+	}
+
+	return counts, nil
+}
+
+// Suggest retrieves up to limit distinct group or song names starting with the given prefix, ignoring case.
+// A name equal to the prefix comes first, the others are ordered alphabetically.
+func (r *SongRepository) Suggest(ctx context.Context, field entity.SuggestField, prefix string, limit uint64) ([]string, error) {
+	const op = "adapter.repository.postgres.SongRepository.Suggest"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	column := "group_name"
+	if field == entity.SuggestSongNameField {
+		column = "name"
+	}
+
+	sb := sq.
+		Select(column).From("songs").
+		Where(column+" ILIKE ?", likeEscaper.Replace(prefix)+"%")
+
+	query, args, err := r.applySongFilters(sb).
+		GroupBy(column).
+		OrderByClause("LOWER("+column+") = LOWER(?) DESC", prefix).
+		OrderBy(r.sortBy(column)).
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var names []string
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &names, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to get names from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return names, nil
+}
+
+// GetByID retrieves a song by its ID from the 'songs' table.
+// It returns the corresponding entity.Song object or an error if the song is not found.
+func (r *SongRepository) GetByID(ctx context.Context, songID uuid.UUID) (*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetByID"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	query, args, err := sq.
+		Select(songColumns...).From("songs").
+		Where(sq.Eq{"id": songID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var row songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.GetContext(ctx, &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, entity.ErrSongNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: failed to get row from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return r.rowToEntity(row), nil
+}
+
+// GetByIDs retrieves the songs with the given IDs from the 'songs' table in a single query.
+// Songs that don't exist are left out, and the order of the returned songs is not defined.
+func (r *SongRepository) GetByIDs(ctx context.Context, songIDs []uuid.UUID) ([]*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetByIDs"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if len(songIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sq.
+		Select(songColumns...).From("songs").
+		Where(sq.Eq{"id": songIDs}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return r.rowsToEntities(rows), nil
+}
+
+// buildUpdateQuery builds the statement updating the non-zero fields of a song record and returning the updated row.
+func (r *SongRepository) buildUpdateQuery(songID uuid.UUID, song entity.Song) (string, []any, error) {
+	clauses := r.entityToMap(song)
+	if len(clauses) == 0 {
+		return "", nil, entity.ErrNoFieldsToUpdate
+	}
+
+	query, args, err := sq.
 		Update("songs").
 		SetMap(clauses).
 		Where(sq.Eq{"id": songID}).
-		Suffix("RETURNING *").
-		PlaceholderFormat(sq.Dollar)
+		Suffix(returningSongColumns).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build sql query: %w", err)
+	}
 
-	query, args, err := ub.ToSql()
+	return query, args, nil
+}
+
+// Update modifies an existing song record in the 'songs' table based on its ID.
+// It returns the updated song entity or an error if the update operation fails or if the song does not exist.
+func (r *SongRepository) Update(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.Update"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	query, args, err := r.buildUpdateQuery(songID, song)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	var updatedRow songRow
 
+	r.logQuery(ctx, op, query, args)
+
 	if err := r.db.GetContext(ctx, &updatedRow, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, entity.ErrSongNotFound)
 		}
 
-		return nil, fmt.Errorf("%s: failed to update row from 'songs' table: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to update row from 'songs' table: %w", op, r.classifyError(err))
 	}
 
 	return r.rowToEntity(updatedRow), nil
 }
 
+// UpdateBatch modifies several song records in a single transaction.
+// The returned slice has an entry for every update in the same order, which is nil when the song does not exist.
+// It returns an error if any update has no fields to modify or if the operation fails, in which case nothing is updated.
+func (r *SongRepository) UpdateBatch(ctx context.Context, updates []entity.SongUpdate) ([]*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.UpdateBatch"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("%s: no songs provided for updating", op)
+	}
+
+	var updatedSongs []*entity.Song
+
+	err := r.withRetryTx(ctx, nil, func(tx *sqlx.Tx) error {
+		updatedSongs = make([]*entity.Song, len(updates))
+
+		for i, update := range updates {
+			query, args, err := r.buildUpdateQuery(update.SongID, update.Song)
+			if err != nil {
+				return err
+			}
+
+			var updatedRow songRow
+
+			r.logQuery(ctx, op, query, args)
+
+			if err := tx.GetContext(ctx, &updatedRow, query, args...); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+
+				return fmt.Errorf("failed to update row from 'songs' table: %w", err)
+			}
+
+			updatedSongs[i] = r.rowToEntity(updatedRow)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updatedSongs, nil
+}
+
+// UpdateWhere modifies every song record in the 'songs' table matching the filters with a single statement.
+// Without filters every song matching the default filters is modified. It returns the IDs of the updated records, or an error
+// if the song has no fields to modify or if the update operation fails.
+func (r *SongRepository) UpdateWhere(ctx context.Context, song entity.Song, filters ...entity.SongFilter) ([]uuid.UUID, error) {
+	const op = "adapter.repository.postgres.SongRepository.UpdateWhere"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	clauses := r.entityToMap(song)
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrNoFieldsToUpdate)
+	}
+
+	ub := sq.
+		Update("songs").
+		SetMap(clauses).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar)
+
+	for _, cond := range r.scopedFilterConditions(filters...) {
+		ub = ub.Where(cond)
+	}
+
+	query, args, err := ub.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	r.logQuery(ctx, op, query, args)
+
+	var updatedIDs []uuid.UUID
+
+	if err := r.db.SelectContext(ctx, &updatedIDs, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to update rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return updatedIDs, nil
+}
+
+// TouchWhere sets the update time of every song matching the filters to the current time without changing
+// any other field, and returns the IDs of the touched songs. Filters are required, so that the whole catalog
+// isn't touched by mistake; the default filters don't count, they only narrow the touched songs further.
+func (r *SongRepository) TouchWhere(ctx context.Context, filters ...entity.SongFilter) ([]uuid.UUID, error) {
+	const op = "adapter.repository.postgres.SongRepository.TouchWhere"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if len(r.songFilterConditions(filters...)) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrNoFilters)
+	}
+
+	conds := r.scopedFilterConditions(filters...)
+
+	ub := sq.
+		Update("songs").
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar)
+
+	for _, cond := range conds {
+		ub = ub.Where(cond)
+	}
+
+	query, args, err := ub.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	r.logQuery(ctx, op, query, args)
+
+	var touchedIDs []uuid.UUID
+
+	if err := r.db.SelectContext(ctx, &touchedIDs, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to update rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return touchedIDs, nil
+}
+
+// GetAfter retrieves up to limit song records matching the filters with IDs greater than afterID, ordered by ID,
+// for walking the catalog in batches. Passing uuid.Nil starts from the first song.
+func (r *SongRepository) GetAfter(
+	ctx context.Context,
+	afterID uuid.UUID,
+	limit uint64,
+	filters ...entity.SongFilter,
+) ([]*entity.Song, error) {
+	const op = "adapter.repository.postgres.SongRepository.GetAfter"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	sb := sq.
+		Select(songColumns...).From("songs").
+		Where(sq.Gt{"id": afterID}).
+		OrderBy("id ASC").
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := r.applySongFilters(sb, filters...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var rows []songRow
+
+	r.logQuery(ctx, op, query, args)
+
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to get rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return r.rowsToEntities(rows), nil
+}
+
+// UpdateVerseCounts stores the verse counts of several songs in a single transaction. Rows already holding
+// the same count are left untouched, so that their update timestamp doesn't change.
+// It returns the number of rows changed or an error if the operation fails, in which case nothing is updated.
+func (r *SongRepository) UpdateVerseCounts(ctx context.Context, counts []entity.SongVerseCount) (int64, error) {
+	const op = "adapter.repository.postgres.SongRepository.UpdateVerseCounts"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	var updated int64
+
+	err := r.withRetryTx(ctx, nil, func(tx *sqlx.Tx) error {
+		updated = 0
+
+		for _, count := range counts {
+			query, args, err := sq.
+				Update("songs").
+				Set("verse_count", count.Count).
+				Where(sq.Eq{"id": count.SongID}).
+				Where(sq.Expr("verse_count IS DISTINCT FROM ?", count.Count)).
+				PlaceholderFormat(sq.Dollar).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to build sql query: %w", err)
+			}
+
+			r.logQuery(ctx, op, query, args)
+
+			res, err := tx.ExecContext(ctx, query, args...)
+			if err != nil {
+				return fmt.Errorf("failed to update row from 'songs' table: %w", err)
+			}
+
+			rowsAffected, err := res.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to get number of affected rows: %w", err)
+			}
+
+			updated += rowsAffected
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSortNames stores the sort names of several songs in a single transaction. Rows already holding
+// the same sort name are left untouched, so that their update timestamp doesn't change.
+// It returns the number of rows changed or an error if the operation fails, in which case nothing is updated.
+func (r *SongRepository) UpdateSortNames(ctx context.Context, sortNames []entity.SongSortName) (int64, error) {
+	const op = "adapter.repository.postgres.SongRepository.UpdateSortNames"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	var updated int64
+
+	err := r.withRetryTx(ctx, nil, func(tx *sqlx.Tx) error {
+		updated = 0
+
+		for _, sortName := range sortNames {
+			query, args, err := sq.
+				Update("songs").
+				Set("sort_name", sortName.SortName).
+				Where(sq.Eq{"id": sortName.SongID}).
+				Where(sq.Expr("sort_name IS DISTINCT FROM ?", sortName.SortName)).
+				PlaceholderFormat(sq.Dollar).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to build sql query: %w", err)
+			}
+
+			r.logQuery(ctx, op, query, args)
+
+			res, err := tx.ExecContext(ctx, query, args...)
+			if err != nil {
+				return fmt.Errorf("failed to update row from 'songs' table: %w", err)
+			}
+
+			rowsAffected, err := res.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to get number of affected rows: %w", err)
+			}
+
+			updated += rowsAffected
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// SaveLinkChecks stores the outcomes of checking the links of several songs, replacing earlier checks of the songs.
+// Every check is timestamped by the database. Checks of songs deleted in the meantime are skipped.
+// It returns an error if the operation fails, in which case nothing is stored.
+func (r *SongRepository) SaveLinkChecks(ctx context.Context, checks []entity.LinkCheck) error {
+	const op = "adapter.repository.postgres.SongRepository.SaveLinkChecks"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if len(checks) == 0 {
+		return nil
+	}
+
+	rows := make([]string, 0, len(checks))
+	args := make([]any, 0, 3*len(checks))
+	for _, check := range checks {
+		rows = append(rows, "(?::uuid, ?, ?::integer)")
+		args = append(args, check.SongID, check.Link, check.StatusCode)
+	}
+
+	query, err := sq.Dollar.ReplacePlaceholders(
+		"INSERT INTO link_checks (song_id, link, status_code) " +
+			"SELECT v.song_id, v.link, v.status_code FROM (VALUES " + strings.Join(rows, ", ") + ") AS v (song_id, link, status_code) " +
+			"JOIN songs ON songs.id = v.song_id " +
+			"ON CONFLICT (song_id) DO UPDATE SET link = EXCLUDED.link, status_code = EXCLUDED.status_code, checked_at = CURRENT_TIMESTAMP",
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	r.logQuery(ctx, op, query, args)
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: failed to insert rows into 'link_checks' table: %w", op, r.classifyError(err))
+	}
+
+	return nil
+}
+
 // Delete removes a song record from the 'songs' table based on its ID.
 // It returns an error if the delete operation fails or if the song does not exist.
 func (r *SongRepository) Delete(ctx context.Context, songID uuid.UUID) (int64, error) {
 	const op = "adapter.repository.postgres.SongRepository.Delete"
 
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
 	query, args, err := sq.
 		Delete("songs").
 		Where(sq.Eq{"id": songID}).
@@ -317,14 +2027,16 @@ func (r *SongRepository) Delete(ctx context.Context, songID uuid.UUID) (int64, e
 		return 0, fmt.Errorf("%s: failed to build sql query: %w", op, err)
 	}
 
+	r.logQuery(ctx, op, query, args)
+
 	res, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return 0, fmt.Errorf("%s: failed to delete row from 'songs' table: %w", op, err)
+		return 0, fmt.Errorf("%s: failed to delete row from 'songs' table: %w", op, r.classifyError(err))
 	}
 
 	rowsAffected, err := res.RowsAffected()
 	if err != nil {
-		return 0, fmt.Errorf("%s: failed to get number of affected rows: %w", op, err)
+		return 0, fmt.Errorf("%s: failed to get number of affected rows: %w", op, r.classifyError(err))
 	}
 
 	if rowsAffected == 0 {
@@ -333,3 +2045,84 @@ func (r *SongRepository) Delete(ctx context.Context, songID uuid.UUID) (int64, e
 
 	return rowsAffected, nil
 }
+
+// DeleteBatch deletes the song records with the given IDs from the 'songs' table in a single transaction.
+// It returns the IDs of the deleted songs in no particular order, leaving out songs that don't exist,
+// or an error if the operation fails, in which case nothing is deleted.
+func (r *SongRepository) DeleteBatch(ctx context.Context, songIDs []uuid.UUID) ([]uuid.UUID, error) {
+	const op = "adapter.repository.postgres.SongRepository.DeleteBatch"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if len(songIDs) == 0 {
+		return nil, fmt.Errorf("%s: no songs provided for deleting", op)
+	}
+
+	query, args, err := sq.
+		Delete("songs").
+		Where(sq.Eq{"id": songIDs}).
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	var deletedIDs []uuid.UUID
+
+	err = r.withRetryTx(ctx, nil, func(tx *sqlx.Tx) error {
+		deletedIDs = nil
+
+		r.logQuery(ctx, op, query, args)
+
+		if err := tx.SelectContext(ctx, &deletedIDs, query, args...); err != nil {
+			return fmt.Errorf("failed to delete rows from 'songs' table: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deletedIDs, nil
+}
+
+// DeleteWhere deletes every song matching the filters from the 'songs' table and returns the IDs of the deleted
+// songs in no particular order. Filters are required, so that the whole catalog isn't deleted by mistake;
+// the default filters don't count, they only narrow the deleted songs further.
+func (r *SongRepository) DeleteWhere(ctx context.Context, filters ...entity.SongFilter) ([]uuid.UUID, error) {
+	const op = "adapter.repository.postgres.SongRepository.DeleteWhere"
+
+	defer servertiming.Start(ctx, servertiming.MetricDB)()
+
+	if len(r.songFilterConditions(filters...)) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrNoFilters)
+	}
+
+	conds := r.scopedFilterConditions(filters...)
+
+	db := sq.
+		Delete("songs").
+		Suffix("RETURNING id").
+		PlaceholderFormat(sq.Dollar)
+
+	for _, cond := range conds {
+		db = db.Where(cond)
+	}
+
+	query, args, err := db.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to build sql query: %w", op, err)
+	}
+
+	r.logQuery(ctx, op, query, args)
+
+	var deletedIDs []uuid.UUID
+
+	if err := r.db.SelectContext(ctx, &deletedIDs, query, args...); err != nil {
+		return nil, fmt.Errorf("%s: failed to delete rows from 'songs' table: %w", op, r.classifyError(err))
+	}
+
+	return deletedIDs, nil
+}
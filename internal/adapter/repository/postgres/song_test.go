@@ -1,17 +1,25 @@
 package postgres
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"net"
+	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/pkg/requestid"
 )
 
 var (
@@ -21,7 +29,25 @@ var (
 	fixedTime = time.Now()
 )
 
-func initSongRepository(t testing.TB) (*SongRepository, sqlmock.Sqlmock) {
+// sequentialIDs returns an ID generator producing the IDs 00000000-0000-0000-0000-000000000001, ...002 and so on.
+func sequentialIDs() func() uuid.UUID {
+	var n byte
+
+	return func() uuid.UUID {
+		n++
+		return uuid.UUID{15: n}
+	}
+}
+
+func initSongRepository(t testing.TB, opts ...Option) (*SongRepository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock := initMockDB(t)
+
+	return NewSongRepository(db, opts...), mock
+}
+
+func initMockDB(t testing.TB) (*sqlx.DB, sqlmock.Sqlmock) {
 	t.Helper()
 
 	mockDB, mock, err := sqlmock.New()
@@ -38,7 +64,161 @@ func initSongRepository(t testing.TB) (*SongRepository, sqlmock.Sqlmock) {
 		db.Close()
 	})
 
-	return NewSongRepository(db), mock
+	return db, mock
+}
+
+func TestSongRepository_LogQuery(t *testing.T) {
+	const getByIDQuery = `SELECT (.+) FROM songs WHERE id = \$1`
+
+	expectGetByID := func(mock sqlmock.Sqlmock) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(getByIDQuery).
+			WithArgs(fixedUUID).
+			WillReturnRows(rows)
+	}
+
+	t.Run("debug logging disabled", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+		repo, mock := initSongRepository(t, WithQueryLogger(logger, true))
+		expectGetByID(mock)
+
+		_, err := repo.GetByID(context.Background(), fixedUUID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("redacted args", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		repo, mock := initSongRepository(t, WithQueryLogger(logger, false))
+		expectGetByID(mock)
+
+		ctx := requestid.NewContext(context.Background(), "test-request-id")
+
+		_, err := repo.GetByID(ctx, fixedUUID)
+
+		assert.NoError(t, err)
+
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, "executing sql query", entry["msg"])
+		assert.Equal(t, "adapter.repository.postgres.SongRepository.GetByID", entry["op"])
+		assert.Contains(t, entry["query"], "FROM songs WHERE id = $1")
+		assert.Equal(t, "test-request-id", entry["reqID"])
+		assert.EqualValues(t, 1, entry["argsCount"])
+		assert.NotContains(t, entry, "args")
+		assert.NotContains(t, buf.String(), fixedUUID.String())
+	})
+
+	t.Run("with args", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		repo, mock := initSongRepository(t, WithQueryLogger(logger, true))
+		expectGetByID(mock)
+
+		_, err := repo.GetByID(context.Background(), fixedUUID)
+
+		assert.NoError(t, err)
+
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, []any{fixedUUID.String()}, entry["args"])
+		assert.NotContains(t, entry, "reqID")
+	})
+}
+
+func TestToDate(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "zero time",
+			t:    time.Time{},
+			want: time.Time{},
+		},
+		{
+			name: "utc",
+			t:    time.Date(2020, time.December, 31, 15, 4, 5, 0, time.UTC),
+			want: time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "new year's eve ahead of utc",
+			t:    time.Date(2020, time.December, 31, 23, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60)),
+			want: time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "new year's eve behind utc",
+			t:    time.Date(2020, time.December, 31, 22, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60)),
+			want: time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, toDate(tt.t))
+		})
+	}
+}
+
+func TestSongColumns(t *testing.T) {
+	var tags []string
+
+	rowType := reflect.TypeOf(songRow{})
+	for i := 0; i < rowType.NumField(); i++ {
+		tags = append(tags, rowType.Field(i).Tag.Get("db"))
+	}
+
+	assert.Equal(t, tags, songColumns)
+}
+
+func TestSongRepository_ExplicitColumns(t *testing.T) {
+	const selectList = `id, group_name, name, sort_name, release_date, text, link, original_link, detail_source, detail_status, detail_provider, detail_fetched_at, import_id, created_at, updated_at`
+
+	t.Run("select", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`SELECT ` + selectList + ` FROM songs WHERE id = $1`)).
+			WithArgs(fixedUUID).
+			WillReturnRows(rows)
+
+		song, err := repo.GetByID(context.Background(), fixedUUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, song.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returning", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`UPDATE songs SET name = $1 WHERE id = $2 RETURNING `+selectList)).
+			WithArgs("Test Song", fixedUUID).
+			WillReturnRows(rows)
+
+		song, err := repo.Update(context.Background(), fixedUUID, entity.Song{Name: "Test Song"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, song.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestSongRepository_Save(t *testing.T) {
@@ -57,17 +237,20 @@ func TestSongRepository_Save(t *testing.T) {
 
 		mock.
 			ExpectQuery(`INSERT INTO songs`).
-			WithArgs("Test Group", "Test Song", fixedTime, "Test Text", "https://example.com").
+			WithArgs(sqlmock.AnyArg(), "Test Group", "Test Song", "Test Group", toDate(fixedTime), "Test Text", "https://example.com", nil, "music_info_api", "found", nil, nil).
 			WillReturnError(errors.New("unknown error"))
 
 		song, err := repo.Save(context.Background(), entity.Song{
 			GroupName: "Test Group",
 			Name:      "Test Song",
+			SortName:  "Test Group",
 			SongDetail: entity.SongDetail{
 				ReleaseDate: fixedTime,
 				Text:        "Test Text",
 				Link:        "https://example.com",
 			},
+			DetailSource: entity.DetailSourceMusicInfoAPI,
+			DetailStatus: entity.DetailStatusFound,
 		})
 
 		assert.Error(t, err)
@@ -75,25 +258,70 @@ func TestSongRepository_Save(t *testing.T) {
 		assert.Nil(t, song)
 	})
 
+	t.Run("link already exists", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithUniqueLinkErrors())
+
+		mock.
+			ExpectQuery(`INSERT INTO songs`).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationCode, ConstraintName: uniqueLinkIndex})
+
+		song, err := repo.Save(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+			SongDetail: entity.SongDetail{
+				Link: "https://example.com",
+			},
+		})
+
+		assert.ErrorIs(t, err, entity.ErrLinkAlreadyExists)
+		assert.Nil(t, song)
+	})
+
+	t.Run("link already exists without unique link errors", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		pgErr := &pgconn.PgError{Code: uniqueViolationCode, ConstraintName: uniqueLinkIndex}
+
+		mock.
+			ExpectQuery(`INSERT INTO songs`).
+			WillReturnError(pgErr)
+
+		song, err := repo.Save(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+			SongDetail: entity.SongDetail{
+				Link: "https://example.com",
+			},
+		})
+
+		assert.ErrorIs(t, err, pgErr)
+		assert.NotErrorIs(t, err, entity.ErrLinkAlreadyExists)
+		assert.Nil(t, song)
+	})
+
 	t.Run("success", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
-		rows := sqlmock.NewRows(columns).
-			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+		rows := sqlmock.NewRows(append(columns, "detail_source", "detail_provider")).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime, "music_info_api", "music-info")
 
 		mock.
 			ExpectQuery(`INSERT INTO songs`).
-			WithArgs("Test Group", "Test Song", fixedTime, "Test Text", "https://example.com").
+			WithArgs(sqlmock.AnyArg(), "Test Group", "Test Song", "Test Group", toDate(fixedTime), "Test Text", "https://example.com", nil, "music_info_api", "found", "music-info", nil).
 			WillReturnRows(rows)
 
 		song, err := repo.Save(context.Background(), entity.Song{
 			GroupName: "Test Group",
 			Name:      "Test Song",
+			SortName:  "Test Group",
 			SongDetail: entity.SongDetail{
 				ReleaseDate: fixedTime,
 				Text:        "Test Text",
 				Link:        "https://example.com",
+				Provider:    "music-info",
 			},
+			DetailSource: entity.DetailSourceMusicInfoAPI,
+			DetailStatus: entity.DetailStatusFound,
 		})
 
 		assert.NoError(t, err)
@@ -104,45 +332,388 @@ func TestSongRepository_Save(t *testing.T) {
 		assert.Equal(t, fixedTime, song.SongDetail.ReleaseDate)
 		assert.Equal(t, "Test Text", song.SongDetail.Text)
 		assert.Equal(t, "https://example.com", song.SongDetail.Link)
+		assert.Equal(t, "music-info", song.SongDetail.Provider)
+		assert.Equal(t, entity.DetailSourceMusicInfoAPI, song.DetailSource)
 		assert.Equal(t, fixedTime, song.CreatedAt)
 		assert.Equal(t, fixedTime, song.UpdatedAt)
 	})
+
+	t.Run("emoji and cjk names", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		const (
+			groupName = "🎸 東京事変"
+			name      = "群青日和 🌊"
+		)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, groupName, name, nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`INSERT INTO songs`).
+			WithArgs(sqlmock.AnyArg(), groupName, name, groupName, nil, nil, nil, nil, nil, nil, nil, nil).
+			WillReturnRows(rows)
+
+		song, err := repo.Save(context.Background(), entity.Song{
+			GroupName: groupName,
+			Name:      name,
+			SortName:  groupName,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, groupName, song.GroupName)
+		assert.Equal(t, name, song.Name)
+	})
+
+	t.Run("detail fetched at", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(append(columns, "detail_status", "detail_fetched_at")).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime, "not_found", fixedTime)
+
+		mock.
+			ExpectQuery(`INSERT INTO songs \(.+,detail_fetched_at,import_id\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10,\$11,CURRENT_TIMESTAMP,\$12\) RETURNING`).
+			WithArgs(sqlmock.AnyArg(), "Test Group", "Test Song", nil, nil, nil, nil, nil, nil, "not_found", nil, nil).
+			WillReturnRows(rows)
+
+		song, err := repo.Save(context.Background(), entity.Song{
+			GroupName:    "Test Group",
+			Name:         "Test Song",
+			DetailStatus: entity.DetailStatusNotFound,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DetailStatusNotFound, song.DetailStatus)
+		assert.Equal(t, fixedTime, song.DetailFetchedAt)
+	})
+
+	t.Run("original link", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(append(columns, "original_link")).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, "https://example.com/song", fixedTime, fixedTime, "http://Example.com/song?utm_source=feed")
+
+		mock.
+			ExpectQuery(`INSERT INTO songs \(id,group_name,name,sort_name,release_date,text,link,original_link,`).
+			WithArgs(sqlmock.AnyArg(), "Test Group", "Test Song", nil, nil, nil, "https://example.com/song", "http://Example.com/song?utm_source=feed", nil, nil, nil, nil).
+			WillReturnRows(rows)
+
+		song, err := repo.Save(context.Background(), entity.Song{
+			GroupName:    "Test Group",
+			Name:         "Test Song",
+			SongDetail:   entity.SongDetail{Link: "https://example.com/song"},
+			OriginalLink: "http://Example.com/song?utm_source=feed",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/song", song.SongDetail.Link)
+		assert.Equal(t, "http://Example.com/song?utm_source=feed", song.OriginalLink)
+	})
+
+	t.Run("generated id", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithIDGenerator(sequentialIDs()))
+
+		expectedID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(expectedID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`INSERT INTO songs \(id,`).
+			WithArgs(expectedID, "Test Group", "Test Song", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(rows)
+
+		song, err := repo.Save(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedID, song.ID)
+	})
+
+	t.Run("provided id", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithIDGenerator(func() uuid.UUID {
+			t.Fatal("ID generator must not be called for songs with an ID")
+			return uuid.Nil
+		}))
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`INSERT INTO songs \(id,`).
+			WithArgs(fixedUUID, "Test Group", "Test Song", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(rows)
+
+		song, err := repo.Save(context.Background(), entity.Song{
+			ID:        fixedUUID,
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, song.ID)
+	})
 }
 
-func TestSongRepository_GetAll(t *testing.T) {
+func TestSongRepository_SaveWithinGroupLimit(t *testing.T) {
+	song := entity.Song{GroupName: "Test Group", Name: "Test Song", SortName: "Test Group"}
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtext($1))`)).
+			WithArgs("Test Group").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs WHERE group_name = $1`)).
+			WithArgs("Test Group").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectRollback()
+
+		savedSong, err := repo.SaveWithinGroupLimit(context.Background(), song, 2)
+
+		assert.ErrorIs(t, err, entity.ErrGroupSongLimitExceeded)
+		assert.Nil(t, savedSong)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtext($1))`)).
+			WithArgs("Test Group").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs WHERE group_name = $1`)).
+			WithArgs("Test Group").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.
+			ExpectQuery(`INSERT INTO songs`).
+			WithArgs(sqlmock.AnyArg(), "Test Group", "Test Song", "Test Group", nil, nil, nil, nil, nil, nil, nil, nil).
+			WillReturnRows(rows)
+		mock.ExpectCommit()
+
+		savedSong, err := repo.SaveWithinGroupLimit(context.Background(), song, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, savedSong.ID)
+	})
+}
+
+func TestSongRepository_SaveBatchWithinGroupLimit(t *testing.T) {
+	songs := []entity.Song{
+		{GroupName: "Test Group", Name: "Test Song"},
+		{GroupName: "Other Group", Name: "Other Song"},
+		{GroupName: "Test Group", Name: "Another Song"},
+	}
+
+	t.Run("limit exceeded by batch", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtext($1))`)).
+			WithArgs("Other Group").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs WHERE group_name = $1`)).
+			WithArgs("Other Group").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.
+			ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtext($1))`)).
+			WithArgs("Test Group").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs WHERE group_name = $1`)).
+			WithArgs("Test Group").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		savedSongs, err := repo.SaveBatchWithinGroupLimit(context.Background(), songs, 2)
+
+		assert.ErrorIs(t, err, entity.ErrGroupSongLimitExceeded)
+		assert.ErrorContains(t, err, `group "Test Group" has 1 songs, limit is 2`)
+		assert.Nil(t, savedSongs)
+	})
+}
+
+func TestSongRepository_SaveBatch(t *testing.T) {
+	t.Run("no songs", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		songs, err := repo.SaveBatch(context.Background(), nil)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "no songs provided for saving")
+		assert.Nil(t, songs)
+	})
+
+	t.Run("without not nill fields", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		songs, err := repo.SaveBatch(context.Background(), []entity.Song{
+			{GroupName: "Test Group", Name: "Test Song"},
+			{GroupName: "Test Group"},
+		})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "missing required fields for saving song")
+		assert.Nil(t, songs)
+	})
+
 	t.Run("unknown database error", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
 		mock.
-			ExpectQuery(`SELECT (.+) FROM songs LIMIT 20 OFFSET 0`).
-			WithoutArgs().
+			ExpectQuery(`INSERT INTO songs (.+) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10,\$11,NULL,\$12\),\(\$13,\$14,\$15,\$16,\$17,\$18,\$19,\$20,\$21,\$22,\$23,NULL,\$24\) RETURNING (.+)`).
 			WillReturnError(errors.New("unknown error"))
 
-		songs, pagination, err := repo.GetAll(context.Background(), entity.Pagination{})
+		songs, err := repo.SaveBatch(context.Background(), []entity.Song{
+			{GroupName: "Test Group", Name: "Test Song 1"},
+			{GroupName: "Test Group", Name: "Test Song 2"},
+		})
 
 		assert.Error(t, err)
-		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.ErrorContains(t, err, "failed to insert rows into 'songs' table")
 		assert.Nil(t, songs)
-		assert.Nil(t, pagination)
 	})
 
 	t.Run("success", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
 		rows := sqlmock.NewRows(columns).
-			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+			AddRow(fixedUUID, "Test Group", "Test Song 1", nil, nil, nil, fixedTime, fixedTime).
+			AddRow(uuid.New(), "Test Group", "Test Song 2", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
 
 		mock.
-			ExpectQuery(`SELECT (.+) FROM songs LIMIT 20 OFFSET 0`).
-			WithoutArgs().
+			ExpectQuery(`INSERT INTO songs (.+) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10,\$11,NULL,\$12\),\(\$13,\$14,\$15,\$16,\$17,\$18,\$19,\$20,\$21,\$22,\$23,NULL,\$24\) RETURNING (.+)`).
 			WillReturnRows(rows)
 
-		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+		songs, err := repo.SaveBatch(context.Background(), []entity.Song{
+			{GroupName: "Test Group", Name: "Test Song 1", SortName: "Test Group"},
+			{
+				GroupName: "Test Group",
+				Name:      "Test Song 2",
+				SortName:  "Test Group",
+				SongDetail: entity.SongDetail{
+					ReleaseDate: fixedTime,
+					Text:        "Test Text",
+					Link:        "https://example.com",
+				},
+			},
+		})
 
-		mock.
-			ExpectQuery(`SELECT COUNT\(\*\)`).
-			WithoutArgs().
-			WillReturnRows(rows)
+		assert.NoError(t, err)
+		assert.Len(t, songs, 2)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Equal(t, "Test Song 1", songs[0].Name)
+		assert.Empty(t, songs[0].SongDetail.Text)
+		assert.Equal(t, "Test Song 2", songs[1].Name)
+		assert.Equal(t, "Test Text", songs[1].SongDetail.Text)
+	})
+
+	t.Run("generated ids", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithIDGenerator(sequentialIDs()))
+
+		firstID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		secondID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(firstID, "Test Group", "Test Song 1", nil, nil, nil, fixedTime, fixedTime).
+			AddRow(fixedUUID, "Test Group", "Test Song 2", nil, nil, nil, fixedTime, fixedTime).
+			AddRow(secondID, "Test Group", "Test Song 3", nil, nil, nil, fixedTime, fixedTime)
+
+		anyArg := sqlmock.AnyArg()
+
+		mock.
+			ExpectQuery(`INSERT INTO songs \(id,`).
+			WithArgs(
+				firstID, "Test Group", "Test Song 1", anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg,
+				fixedUUID, "Test Group", "Test Song 2", anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg,
+				secondID, "Test Group", "Test Song 3", anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg,
+			).
+			WillReturnRows(rows)
+
+		songs, err := repo.SaveBatch(context.Background(), []entity.Song{
+			{GroupName: "Test Group", Name: "Test Song 1"},
+			{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song 2"},
+			{GroupName: "Test Group", Name: "Test Song 3"},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 3)
+		assert.Equal(t, firstID, songs[0].ID)
+		assert.Equal(t, fixedUUID, songs[1].ID)
+		assert.Equal(t, secondID, songs[2].ID)
+	})
+
+	t.Run("import id", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		importID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174009")
+
+		rows := sqlmock.NewRows(append(columns, "import_id")).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime, importID)
+
+		anyArg := sqlmock.AnyArg()
+
+		mock.
+			ExpectQuery(`INSERT INTO songs \(.+,import_id\) VALUES`).
+			WithArgs(fixedUUID, "Test Group", "Test Song", anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, importID).
+			WillReturnRows(rows)
+
+		songs, err := repo.SaveBatch(context.Background(), []entity.Song{
+			{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song", ImportID: importID},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, importID, songs[0].ImportID)
+	})
+}
+
+func TestSongRepository_GetAll(t *testing.T) {
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnError(errors.New("unknown error"))
+
+		songs, pagination, err := repo.GetAll(context.Background(), entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithoutArgs().
+			WillReturnRows(rows)
 
 		songs, pagination, err := repo.GetAll(context.Background(), entity.Pagination{})
 
@@ -164,233 +735,2511 @@ func TestSongRepository_GetAll(t *testing.T) {
 		assert.Equal(t, uint64(1), pagination.Total)
 	})
 
-	t.Run("success with non-empty pagination", func(t *testing.T) {
+	t.Run("success with non-empty pagination", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY sort_name ASC, name ASC LIMIT 10 OFFSET 40`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetAll(context.Background(), entity.Pagination{
+			Offset: 40,
+			Limit:  10,
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, songs)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Equal(t, "Test Group", songs[0].GroupName)
+		assert.Equal(t, "Test Song", songs[0].Name)
+		assert.Equal(t, fixedTime, songs[0].SongDetail.ReleaseDate)
+		assert.Equal(t, "Test Text", songs[0].SongDetail.Text)
+		assert.Equal(t, "https://example.com", songs[0].Link)
+		assert.Equal(t, fixedTime, songs[0].CreatedAt)
+		assert.Equal(t, fixedTime, songs[0].UpdatedAt)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(40), pagination.Offset)
+		assert.Equal(t, uint64(10), pagination.Limit)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+
+	t.Run("skip total", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetAll(context.Background(), entity.Pagination{SkipTotal: true})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Zero(t, pagination.Total)
+		assert.True(t, pagination.SkipTotal)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success with not-empty filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE name ILIKE \$1 AND EXTRACT\(YEAR FROM release_date\) = \$2 ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs("%Song%", fixedTime.Year()).
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE name ILIKE \$1 AND EXTRACT\(YEAR FROM release_date\) = \$2`).
+			WithArgs("%Song%", fixedTime.Year()).
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{
+				Field: entity.SongNameFilterField,
+				Value: "Song",
+			},
+			entity.SongFilter{
+				Field: entity.SongReleaseYearFilterField,
+				Value: fixedTime.Year(),
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, songs)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Equal(t, "Test Group", songs[0].GroupName)
+		assert.Equal(t, "Test Song", songs[0].Name)
+		assert.Equal(t, fixedTime, songs[0].SongDetail.ReleaseDate)
+		assert.Equal(t, "Test Text", songs[0].SongDetail.Text)
+		assert.Equal(t, "https://example.com", songs[0].Link)
+		assert.Equal(t, fixedTime, songs[0].CreatedAt)
+		assert.Equal(t, fixedTime, songs[0].UpdatedAt)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, entity.DefaultOffset, pagination.Offset)
+		assert.Equal(t, entity.DefaultLimit, pagination.Limit)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+
+	t.Run("total counts only filtered songs", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE group_name ILIKE \$1 ORDER BY sort_name ASC, name ASC LIMIT 1 OFFSET 0`).
+			WithArgs("%Test Group%").
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(3))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE group_name ILIKE \$1$`).
+			WithArgs("%Test Group%").
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{Limit: 1},
+			entity.SongFilter{
+				Field: entity.SongGroupNameFilterField,
+				Value: "Test Group",
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Equal(t, uint64(3), pagination.Total)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("regex rejected by the database", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE text ~\* \$1`).
+			WithArgs(`(?<=love)`).
+			WillReturnError(&pgconn.PgError{Code: invalidRegexCode})
+
+		songs, pagination, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{
+				Field: entity.SongTextRegexFilterField,
+				Value: `(?<=love)`,
+			},
+		)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrInvalidRegex)
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success with regex filter", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE group_name ILIKE \$1 AND text ~\* \$2 ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs("%Test Group%", `^test\s+text$`).
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithArgs("%Test Group%", `^test\s+text$`).
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{
+				Field: entity.SongGroupNameFilterField,
+				Value: "Test Group",
+			},
+			entity.SongFilter{
+				Field: entity.SongTextRegexFilterField,
+				Value: `^test\s+text$`,
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+
+	t.Run("success with multiple release years", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE EXTRACT\(YEAR FROM release_date\) IN \(\$1,\$2\) ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs(1968, 1969).
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithArgs(1968, 1969).
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{
+				Field: entity.SongReleaseYearFilterField,
+				Value: []int{1968, 1969},
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+
+	t.Run("success with text length filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE length\(text\) >= \$1 AND length\(text\) <= \$2 ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs(5, 100).
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithArgs(5, 100).
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{
+				Field: entity.SongMinTextLenFilterField,
+				Value: 5,
+			},
+			entity.SongFilter{
+				Field: entity.SongMaxTextLenFilterField,
+				Value: 100,
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, "Test Text", songs[0].SongDetail.Text)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+
+	t.Run("detail status filter", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(append(columns, "detail_status")).
+			AddRow(fixedUUID, "Unknown Group", "Unknown Song", nil, nil, nil, fixedTime, fixedTime, "not_found")
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE detail_status = \$1 ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs(entity.DetailStatusNotFound).
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithArgs(entity.DetailStatusNotFound).
+			WillReturnRows(rows)
+
+		songs, _, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{
+				Field: entity.SongDetailStatusFilterField,
+				Value: entity.DetailStatusNotFound,
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, entity.DetailStatusNotFound, songs[0].DetailStatus)
+	})
+
+	t.Run("filters on the same field are combined with and", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE name ILIKE \$1 AND name ILIKE \$2 ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs("%Stairway%", "%Heaven%").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		rows := sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(0))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithArgs("%Stairway%", "%Heaven%").
+			WillReturnRows(rows)
+
+		songs, _, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{Field: entity.SongNameFilterField, Value: "Stairway"},
+			entity.SongFilter{Field: entity.SongNameFilterField, Value: "Heaven"},
+		)
+
+		assert.NoError(t, err)
+		assert.Empty(t, songs)
+	})
+
+	t.Run("release date filters keep the calendar date", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		newYearsEve := time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)
+		// 31.12.2020 23:30 in UTC+3 is already 2021 in UTC, but must still match the stored 2020 date.
+		lateNewYearsEve := time.Date(2020, time.December, 31, 23, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", newYearsEve, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE EXTRACT\(YEAR FROM release_date\) = \$1 AND release_date = \$2 ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs(2020, newYearsEve).
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithArgs(2020, newYearsEve).
+			WillReturnRows(rows)
+
+		songs, _, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{
+				Field: entity.SongReleaseYearFilterField,
+				Value: 2020,
+			},
+			entity.SongFilter{
+				Field: entity.SongReleaseDateFilterField,
+				Value: lateNewYearsEve,
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, 2020, songs[0].SongDetail.ReleaseDate.Year())
+	})
+}
+
+func TestWellFormedLinkPattern(t *testing.T) {
+	wellFormed := regexp.MustCompile("(?i)" + wellFormedLinkPattern)
+
+	tests := []struct {
+		link string
+		want bool
+	}{
+		{link: "https://example.com", want: true},
+		{link: "http://example.com/songs/1?ref=home#lyrics", want: true},
+		{link: "HTTPS://EXAMPLE.COM/", want: true},
+		{link: "", want: false},
+		{link: "example.com", want: false},
+		{link: "ftp://example.com", want: false},
+		{link: "https://", want: false},
+		{link: "https:///path", want: false},
+		{link: "https://example .com", want: false},
+		{link: "https://example.com/a b", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			assert.Equal(t, tt.want, wellFormed.MatchString(tt.link))
+		})
+	}
+}
+
+func TestSongRepository_GetWithBrokenLinks(t *testing.T) {
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE \(link IS NOT NULL AND \(link !~\* \$1 OR EXISTS \(SELECT 1 FROM link_checks lc WHERE lc.song_id = songs.id AND lc.link = songs.link AND \(lc.status_code = 0 OR lc.status_code >= 400\)\)\)\) ORDER BY created_at ASC LIMIT 20 OFFSET 0`).
+			WithArgs(wellFormedLinkPattern).
+			WillReturnError(errors.New("unknown error"))
+
+		songs, pagination, err := repo.GetWithBrokenLinks(context.Background(), entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "example.com/song", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE \(link IS NOT NULL AND \(link !~\* \$1 OR EXISTS \(SELECT 1 FROM link_checks lc WHERE lc.song_id = songs.id AND lc.link = songs.link AND \(lc.status_code = 0 OR lc.status_code >= 400\)\)\)\) ORDER BY created_at ASC LIMIT 20 OFFSET 0`).
+			WithArgs(wellFormedLinkPattern).
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE \(link IS NOT NULL AND \(link !~\* \$1 OR EXISTS \(SELECT 1 FROM link_checks lc WHERE lc.song_id = songs.id AND lc.link = songs.link AND \(lc.status_code = 0 OR lc.status_code >= 400\)\)\)\)`).
+			WithArgs(wellFormedLinkPattern).
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetWithBrokenLinks(context.Background(), entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, "example.com/song", songs[0].SongDetail.Link)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+}
+
+func TestSongRepository_GetIncomplete(t *testing.T) {
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE \(release_date IS NULL OR text IS NULL OR link IS NULL\) ORDER BY created_at ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnError(errors.New("unknown error"))
+
+		songs, pagination, err := repo.GetIncomplete(context.Background(), entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, "Test Text", nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE \(release_date IS NULL OR text IS NULL OR link IS NULL\) ORDER BY created_at ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE \(release_date IS NULL OR text IS NULL OR link IS NULL\)`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetIncomplete(context.Background(), entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.True(t, songs[0].SongDetail.ReleaseDate.IsZero())
+		assert.Equal(t, "Test Text", songs[0].SongDetail.Text)
+		assert.Empty(t, songs[0].SongDetail.Link)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+
+	t.Run("success with missing detail filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, "Test Text", nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE release_date IS NULL AND link IS NULL ORDER BY created_at ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE release_date IS NULL AND link IS NULL`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetIncomplete(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{
+				Field: entity.SongReleaseDateMissingFilterField,
+				Value: true,
+			},
+			entity.SongFilter{
+				Field: entity.SongLinkMissingFilterField,
+				Value: true,
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+}
+
+func TestSongRepository_GetRecentlyUpdated(t *testing.T) {
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY updated_at DESC, id ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnError(errors.New("unknown error"))
+
+		songs, pagination, err := repo.GetRecentlyUpdated(context.Background(), entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		editedID := uuid.New()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(editedID, "Test Group", "Test Song 1", nil, nil, nil, fixedTime, fixedTime.Add(time.Hour)).
+			AddRow(fixedUUID, "Test Group", "Test Song 2", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY updated_at DESC, id ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(2))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetRecentlyUpdated(context.Background(), entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 2)
+		assert.Equal(t, editedID, songs[0].ID)
+		assert.Equal(t, fixedUUID, songs[1].ID)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(2), pagination.Items)
+		assert.Equal(t, uint64(2), pagination.Total)
+	})
+
+	t.Run("success with edited filter", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime.Add(time.Hour))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE group_name ILIKE \$1 AND updated_at > created_at ORDER BY updated_at DESC, id ASC LIMIT 10 OFFSET 10`).
+			WithArgs("%Test%").
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(11))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE group_name ILIKE \$1 AND updated_at > created_at`).
+			WithArgs("%Test%").
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetRecentlyUpdated(
+			context.Background(),
+			entity.Pagination{Offset: 10, Limit: 10},
+			entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Test"},
+			entity.SongFilter{Field: entity.SongEditedFilterField, Value: true},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Equal(t, uint64(11), pagination.Total)
+	})
+
+	t.Run("success with enrichment worklist filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		const where = `WHERE detail_status = \$1 AND release_date IS NULL AND updated_at > created_at`
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, nil, nil, fixedTime, fixedTime.Add(time.Hour))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ` + where + ` ORDER BY updated_at DESC, id ASC LIMIT 20 OFFSET 0`).
+			WithArgs(entity.DetailStatusFailed).
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs ` + where + `$`).
+			WithArgs(entity.DetailStatusFailed).
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetRecentlyUpdated(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusFailed},
+			entity.SongFilter{Field: entity.SongReleaseDateMissingFilterField, Value: true},
+			entity.SongFilter{Field: entity.SongEditedFilterField, Value: true},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+
+	t.Run("success with release date present filter", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE detail_status = \$1 AND release_date IS NOT NULL ORDER BY updated_at DESC, id ASC LIMIT 20 OFFSET 0`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE detail_status = \$1 AND release_date IS NOT NULL$`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(0)))
+
+		songs, pagination, err := repo.GetRecentlyUpdated(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusFound},
+			entity.SongFilter{Field: entity.SongReleaseDateMissingFilterField, Value: false},
+		)
+
+		assert.NoError(t, err)
+		assert.Empty(t, songs)
+		assert.Equal(t, uint64(0), pagination.Total)
+	})
+}
+
+func TestSongRepository_GetSimilar(t *testing.T) {
+	song := entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}
+
+	const similarQuery = `SELECT (.+) FROM songs WHERE id <> \$1 AND \(group_name = \$2 OR name % \$3\) ` +
+		`ORDER BY group_name = \$4 DESC, similarity\(name, \$5\) DESC, sort_name ASC, name ASC LIMIT 20 OFFSET 0`
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(similarQuery).
+			WithArgs(fixedUUID, "Test Group", "Test Song", "Test Group", "Test Song").
+			WillReturnError(errors.New("unknown error"))
+
+		songs, pagination, err := repo.GetSimilar(context.Background(), song, entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		sameGroupID := uuid.New()
+		similarNameID := uuid.New()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(sameGroupID, "Test Group", "Other Song", nil, nil, nil, fixedTime, fixedTime).
+			AddRow(similarNameID, "Other Group", "Test Songs", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(similarQuery).
+			WithArgs(fixedUUID, "Test Group", "Test Song", "Test Group", "Test Song").
+			WillReturnRows(rows)
+
+		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(2))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE id <> \$1 AND \(group_name = \$2 OR name % \$3\)`).
+			WithArgs(fixedUUID, "Test Group", "Test Song").
+			WillReturnRows(rows)
+
+		songs, pagination, err := repo.GetSimilar(context.Background(), song, entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 2)
+		assert.Equal(t, sameGroupID, songs[0].ID)
+		assert.Equal(t, similarNameID, songs[1].ID)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(2), pagination.Items)
+		assert.Equal(t, uint64(2), pagination.Total)
+	})
+}
+
+func TestSongRepository_Search(t *testing.T) {
+	const searchQuery = `SELECT (.+) FROM songs WHERE search_vector @@ websearch_to_tsquery\('simple', \$1\) ` +
+		`ORDER BY ts_rank\(search_vector, websearch_to_tsquery\('simple', \$2\)\) DESC, sort_name ASC, name ASC LIMIT 2 OFFSET 0$`
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(searchQuery).
+			WithArgs("bohemian", "bohemian").
+			WillReturnError(errors.New("unknown error"))
+
+		songs, pagination, err := repo.Search(context.Background(), "bohemian", entity.Pagination{Limit: 2})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("ranked songs", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		nameMatchID := uuid.New()
+		textMatchID := uuid.New()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(nameMatchID, "Queen", "Bohemian Rhapsody", nil, nil, nil, fixedTime, fixedTime).
+			AddRow(textMatchID, "Other Group", "Other Song", nil, "A bohemian life", nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(searchQuery).
+			WithArgs("bohemian", "bohemian").
+			WillReturnRows(rows)
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM songs WHERE search_vector @@ websearch_to_tsquery('simple', $1)`) + `$`).
+			WithArgs("bohemian").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(uint64(3)))
+
+		songs, pagination, err := repo.Search(context.Background(), "bohemian", entity.Pagination{Limit: 2})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 2)
+		assert.Equal(t, nameMatchID, songs[0].ID)
+		assert.Equal(t, textMatchID, songs[1].ID)
+		assert.Equal(t, uint64(2), pagination.Items)
+		assert.Equal(t, uint64(3), pagination.Total)
+	})
+
+	t.Run("default filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(
+			entity.SongFilter{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusFound},
+		))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE search_vector @@ websearch_to_tsquery\('simple', \$1\) AND detail_status = \$2 ORDER BY`).
+			WithArgs("bohemian", "found", "bohemian").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE search_vector @@ websearch_to_tsquery\('simple', \$1\) AND detail_status = \$2$`).
+			WithArgs("bohemian", "found").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(uint64(0)))
+
+		songs, pagination, err := repo.Search(context.Background(), "bohemian", entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, songs)
+		assert.Zero(t, pagination.Total)
+	})
+}
+
+func TestSongRepository_GetRecent(t *testing.T) {
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY created_at DESC LIMIT 10`).
+			WithoutArgs().
+			WillReturnError(errors.New("unknown error"))
+
+		songs, err := repo.GetRecent(context.Background(), 0)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+	})
+
+	t.Run("limit above cap", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY created_at DESC LIMIT 50`).
+			WithoutArgs().
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		songs, err := repo.GetRecent(context.Background(), 1000)
+
+		assert.NoError(t, err)
+		assert.Empty(t, songs)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		newerID := uuid.New()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(newerID, "Test Group", "Test Song 2", nil, nil, nil, fixedTime.Add(time.Hour), fixedTime.Add(time.Hour)).
+			AddRow(fixedUUID, "Test Group", "Test Song 1", nil, nil, nil, fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY created_at DESC LIMIT 2`).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		songs, err := repo.GetRecent(context.Background(), 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 2)
+		assert.Equal(t, newerID, songs[0].ID)
+		assert.Equal(t, fixedUUID, songs[1].ID)
+	})
+}
+
+func TestSongRepository_GetStaleDetails(t *testing.T) {
+	const query = `SELECT (.+) FROM songs WHERE \(detail_fetched_at IS NULL OR detail_fetched_at < \$1\) ` +
+		`ORDER BY detail_fetched_at ASC NULLS FIRST, id ASC LIMIT 50`
+
+	staleBefore := fixedTime.Add(-30 * 24 * time.Hour)
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(query).
+			WithArgs(staleBefore).
+			WillReturnError(errors.New("unknown error"))
+
+		songs, err := repo.GetStaleDetails(context.Background(), staleBefore, 50)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		neverFetchedID := uuid.New()
+		fetchedAt := staleBefore.Add(-time.Hour)
+
+		rows := sqlmock.NewRows(append(columns, "detail_fetched_at")).
+			AddRow(neverFetchedID, "Test Group", "Test Song 1", nil, nil, nil, fixedTime, fixedTime, nil).
+			AddRow(fixedUUID, "Test Group", "Test Song 2", nil, nil, nil, fixedTime, fixedTime, fetchedAt)
+
+		mock.
+			ExpectQuery(query).
+			WithArgs(staleBefore).
+			WillReturnRows(rows)
+
+		songs, err := repo.GetStaleDetails(context.Background(), staleBefore, 50)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 2)
+		assert.Equal(t, neverFetchedID, songs[0].ID)
+		assert.True(t, songs[0].DetailFetchedAt.IsZero())
+		assert.Equal(t, fixedUUID, songs[1].ID)
+		assert.Equal(t, fetchedAt, songs[1].DetailFetchedAt)
+	})
+}
+
+func TestSongRepository_GetLibraryStats(t *testing.T) {
+	const query = `SELECT COUNT\(\*\) AS songs, COUNT\(DISTINCT group_name\) AS group_count, ` +
+		`COUNT\(\*\) FILTER \(WHERE release_date IS NULL OR text IS NULL OR link IS NULL\) AS missing_detail FROM songs`
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(query).
+			WithoutArgs().
+			WillReturnError(errors.New("unknown error"))
+
+		stats, err := repo.GetLibraryStats(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to count rows in 'songs' table")
+		assert.Nil(t, stats)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"songs", "group_count", "missing_detail"}).
+			AddRow(uint64(12), uint64(4), uint64(3))
+
+		mock.
+			ExpectQuery(query).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		stats, err := repo.GetLibraryStats(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.LibraryStats{Songs: 12, Groups: 4, SongsMissingDetail: 3}, stats)
+	})
+}
+
+func TestSongRepository_CountByDecade(t *testing.T) {
+	const query = `SELECT \(FLOOR\(EXTRACT\(YEAR FROM release_date\) / 10\) \* 10\)::int AS decade, COUNT\(\*\) AS count ` +
+		`FROM songs WHERE release_date IS NOT NULL GROUP BY decade ORDER BY decade ASC`
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(query).
+			WithoutArgs().
+			WillReturnError(errors.New("unknown error"))
+
+		counts, err := repo.CountByDecade(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to count rows by decade in 'songs' table")
+		assert.Nil(t, counts)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"decade", "count"}).
+			AddRow(1970, uint64(3)).
+			AddRow(1990, uint64(1))
+
+		mock.
+			ExpectQuery(query).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		counts, err := repo.CountByDecade(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.DecadeCount{
+			{Decade: 1970, Count: 3},
+			{Decade: 1990, Count: 1},
+		}, counts)
+	})
+
+	t.Run("analytics db", func(t *testing.T) {
+		analyticsDB, analyticsMock := initMockDB(t)
+		repo, _ := initSongRepository(t, WithAnalyticsDB(analyticsDB))
+
+		rows := sqlmock.NewRows([]string{"decade", "count"}).AddRow(1970, uint64(3))
+
+		analyticsMock.
+			ExpectQuery(query).
+			WithoutArgs().
+			WillReturnRows(rows)
+
+		counts, err := repo.CountByDecade(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.DecadeCount{{Decade: 1970, Count: 3}}, counts)
+	})
+}
+
+func TestSongRepository_CountByFirstLetter(t *testing.T) {
+	const (
+		groupLetter = `CASE WHEN UPPER\(LEFT\(sort_name, 1\)\) <> LOWER\(LEFT\(sort_name, 1\)\) ` +
+			`THEN UPPER\(LEFT\(sort_name, 1\)\) ELSE '#' END`
+		songLetter = `CASE WHEN UPPER\(LEFT\(name, 1\)\) <> LOWER\(LEFT\(name, 1\)\) ` +
+			`THEN UPPER\(LEFT\(name, 1\)\) ELSE '#' END`
+		groupQuery = `SELECT ` + groupLetter + ` AS letter, COUNT\(DISTINCT group_name\) AS count ` +
+			`FROM songs GROUP BY letter ORDER BY ` + groupLetter + ` = \$1 DESC, ` + groupLetter + ` ASC`
+		songQuery = `SELECT ` + songLetter + ` AS letter, COUNT\(\*\) AS count ` +
+			`FROM songs GROUP BY letter ORDER BY ` + songLetter + ` = \$1 DESC, ` + songLetter + ` ASC`
+	)
+
+	t.Run("unknown field", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		counts, err := repo.CountByFirstLetter(context.Background(), entity.AlphaIndexField(42))
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "unknown alpha index field")
+		assert.Nil(t, counts)
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(groupQuery).
+			WithArgs(entity.AlphaIndexOther).
+			WillReturnError(errors.New("unknown error"))
+
+		counts, err := repo.CountByFirstLetter(context.Background(), entity.AlphaIndexGroupField)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to count rows by first letter in 'songs' table")
+		assert.Nil(t, counts)
+	})
+
+	t.Run("analytics db", func(t *testing.T) {
+		analyticsDB, analyticsMock := initMockDB(t)
+		repo, _ := initSongRepository(t, WithAnalyticsDB(analyticsDB))
+
+		rows := sqlmock.NewRows([]string{"letter", "count"}).AddRow("S", uint64(2))
+
+		analyticsMock.
+			ExpectQuery(songQuery).
+			WithArgs(entity.AlphaIndexOther).
+			WillReturnRows(rows)
+
+		counts, err := repo.CountByFirstLetter(context.Background(), entity.AlphaIndexSongField)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.LetterCount{{Letter: "S", Count: 2}}, counts)
+	})
+
+	t.Run("success by group", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"letter", "count"}).
+			AddRow("#", uint64(1)).
+			AddRow("Q", uint64(2))
+
+		mock.
+			ExpectQuery(groupQuery).
+			WithArgs(entity.AlphaIndexOther).
+			WillReturnRows(rows)
+
+		counts, err := repo.CountByFirstLetter(context.Background(), entity.AlphaIndexGroupField)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.LetterCount{
+			{Letter: entity.AlphaIndexOther, Count: 1},
+			{Letter: "Q", Count: 2},
+		}, counts)
+	})
+
+	t.Run("success by song", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"letter", "count"}).
+			AddRow("B", uint64(5))
+
+		mock.
+			ExpectQuery(songQuery).
+			WithArgs(entity.AlphaIndexOther).
+			WillReturnRows(rows)
+
+		counts, err := repo.CountByFirstLetter(context.Background(), entity.AlphaIndexSongField)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.LetterCount{{Letter: "B", Count: 5}}, counts)
+	})
+}
+
+func TestSongRepository_Count(t *testing.T) {
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+			WithoutArgs().
+			WillReturnError(errors.New("unknown error"))
+
+		count, err := repo.Count(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to count rows from 'songs' table")
+		assert.Zero(t, count)
+	})
+
+	t.Run("success with filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE group_name ILIKE \$1`).
+			WithArgs("%Led%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.Count(
+			context.Background(),
+			entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Led"},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), count)
+	})
+}
+
+func TestSongRepository_ExistsByName(t *testing.T) {
+	const query = `SELECT EXISTS ( SELECT 1 FROM songs WHERE group_name = $1 AND name = $2 )`
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("Test Group", "Test Song").
+			WillReturnError(errors.New("unknown error"))
+
+		exists, err := repo.ExistsByName(context.Background(), "Test Group", "Test Song")
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to check row in 'songs' table")
+		assert.False(t, exists)
+	})
+
+	t.Run("ignores default filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(
+			entity.SongFilter{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusFound},
+		))
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(query)+`$`).
+			WithArgs("Test Group", "Test Song").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		exists, err := repo.ExistsByName(context.Background(), "Test Group", "Test Song")
+
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func TestSongRepository_Collation(t *testing.T) {
+	t.Run("get all", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithCollation("und-x-icu"))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs ORDER BY sort_name COLLATE "und-x-icu" ASC, name COLLATE "und-x-icu" ASC LIMIT 20 OFFSET 0`).
+			WithoutArgs().
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs`).
+			WithoutArgs().
+			WillReturnRows(sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(0)))
+
+		_, _, err := repo.GetAll(context.Background(), entity.Pagination{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("count by first letter", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithCollation("und-x-icu"))
+
+		mock.
+			ExpectQuery(`GROUP BY letter ORDER BY CASE (.+) END = \$1 DESC, CASE (.+) END COLLATE "und-x-icu" ASC$`).
+			WithArgs(entity.AlphaIndexOther).
+			WillReturnRows(sqlmock.NewRows([]string{"letter", "count"}))
+
+		_, err := repo.CountByFirstLetter(context.Background(), entity.AlphaIndexSongField)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("group release range", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithCollation("und-x-icu"))
+
+		mock.
+			ExpectQuery(`s.release_date = b.first_date ORDER BY s.name COLLATE "und-x-icu" ASC LIMIT 1(.+)`+
+				`s.release_date = b.last_date ORDER BY s.name COLLATE "und-x-icu" ASC LIMIT 1`).
+			WithArgs("Test Group", "Test Group", "Test Group").
+			WillReturnRows(sqlmock.NewRows([]string{"first_date", "first_name", "last_date", "last_name"}).
+				AddRow(fixedTime, "Test Song", fixedTime, "Test Song"))
+
+		_, err := repo.GetGroupReleaseRange(context.Background(), "Test Group")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("count facet", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithCollation("und-x-icu"))
+
+		mock.
+			ExpectQuery(`GROUP BY group_name ORDER BY count DESC, group_name COLLATE "und-x-icu" ASC`).
+			WithoutArgs().
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}))
+
+		_, err := repo.CountFacet(context.Background(), entity.FacetGroupNameField)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("quoted name", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithCollation(`x" ASC; DROP TABLE songs; --`))
+
+		mock.
+			ExpectQuery(`SELECT name FROM songs WHERE name ILIKE \$1 GROUP BY name ORDER BY LOWER\(name\) = LOWER\(\$2\) DESC, name COLLATE "x"" ASC; DROP TABLE songs; --" ASC LIMIT 10`).
+			WithArgs("Ro%", "Ro").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}))
+
+		_, err := repo.Suggest(context.Background(), entity.SuggestSongNameField, "Ro", 10)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestSongRepository_CheckCollation(t *testing.T) {
+	t.Run("no collation", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		err := repo.CheckCollation(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown collation", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithCollation("unknown"))
+
+		mock.
+			ExpectExec(regexp.QuoteMeta(`SELECT 'a' COLLATE "unknown"`)).
+			WithoutArgs().
+			WillReturnError(&pgconn.PgError{Code: "42704"})
+
+		err := repo.CheckCollation(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, `unknown collation "unknown"`)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithCollation("und-x-icu"))
+
+		mock.
+			ExpectExec(regexp.QuoteMeta(`SELECT 'a' COLLATE "und-x-icu"`)).
+			WithoutArgs().
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.CheckCollation(context.Background())
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestSongRepository_DefaultFilters(t *testing.T) {
+	defaultFilter := entity.SongFilter{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusFound}
+
+	t.Run("get all without request filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE detail_status = \$1 ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE detail_status = \$1`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(0)))
+
+		songs, pagination, err := repo.GetAll(context.Background(), entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, songs)
+		assert.Zero(t, pagination.Total)
+	})
+
+	t.Run("get all with request filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE detail_status = \$1 AND group_name ILIKE \$2 ORDER BY sort_name ASC, name ASC LIMIT 20 OFFSET 0`).
+			WithArgs(entity.DetailStatusFound, "%Test Group%").
+			WillReturnRows(rows)
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE detail_status = \$1 AND group_name ILIKE \$2`).
+			WithArgs(entity.DetailStatusFound, "%Test Group%").
+			WillReturnRows(sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1)))
+
+		songs, _, err := repo.GetAll(
+			context.Background(),
+			entity.Pagination{},
+			entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Test Group"},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+	})
+
+	t.Run("count can't override defaults", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE detail_status = \$1 AND detail_status = \$2`).
+			WithArgs(entity.DetailStatusFound, entity.DetailStatusFailed).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		count, err := repo.Count(
+			context.Background(),
+			entity.SongFilter{Field: entity.SongDetailStatusFilterField, Value: entity.DetailStatusFailed},
+		)
+
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("get incomplete without request filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE \(release_date IS NULL OR text IS NULL OR link IS NULL\) AND detail_status = \$1 ORDER BY created_at ASC`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE \(release_date IS NULL OR text IS NULL OR link IS NULL\) AND detail_status = \$1`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(0)))
+
+		_, _, err := repo.GetIncomplete(context.Background(), entity.Pagination{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("get with broken links", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE \(link IS NOT NULL AND (.+)\) AND detail_status = \$2 ORDER BY created_at ASC`).
+			WithArgs(wellFormedLinkPattern, entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE \(link IS NOT NULL AND (.+)\) AND detail_status = \$2`).
+			WithArgs(wellFormedLinkPattern, entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(0)))
+
+		_, _, err := repo.GetWithBrokenLinks(context.Background(), entity.Pagination{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("get similar", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		song := entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE id <> \$1 AND \(group_name = \$2 OR name % \$3\) AND detail_status = \$4 ORDER BY`).
+			WithArgs(fixedUUID, "Test Group", "Test Song", entity.DetailStatusFound, "Test Group", "Test Song").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) FROM songs WHERE id <> \$1 AND \(group_name = \$2 OR name % \$3\) AND detail_status = \$4`).
+			WithArgs(fixedUUID, "Test Group", "Test Song", entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(0)))
+
+		_, _, err := repo.GetSimilar(context.Background(), song, entity.Pagination{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("get recent", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE detail_status = \$1 ORDER BY created_at DESC LIMIT 10`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repo.GetRecent(context.Background(), 10)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("get stale details", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE \(detail_fetched_at IS NULL OR detail_fetched_at < \$1\) AND detail_status = \$2 ORDER BY`).
+			WithArgs(fixedTime, entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repo.GetStaleDetails(context.Background(), fixedTime, 10)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("get library stats", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT COUNT\(\*\) AS songs, (.+) FROM songs WHERE detail_status = \$1$`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"songs", "group_count", "missing_detail"}).AddRow(1, 1, 0))
+
+		stats, err := repo.GetLibraryStats(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), stats.Songs)
+	})
+
+	t.Run("count by decade", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`FROM songs WHERE release_date IS NOT NULL AND detail_status = \$1 GROUP BY decade`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"decade", "count"}))
+
+		_, err := repo.CountByDecade(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("count by first letter", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`FROM songs WHERE detail_status = \$1 GROUP BY letter`).
+			WithArgs(entity.DetailStatusFound, entity.AlphaIndexOther).
+			WillReturnRows(sqlmock.NewRows([]string{"letter", "count"}))
+
+		_, err := repo.CountByFirstLetter(context.Background(), entity.AlphaIndexSongField)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("get group facets", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`FROM songs WHERE group_name = \$1 AND detail_status = \$2 GROUP BY year`).
+			WithArgs("Test Group", entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"year", "count"}).AddRow(1971, 1))
+
+		_, err := repo.GetGroupFacets(context.Background(), "Test Group")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("get group release range", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`WHERE s.group_name = \$1 AND s.release_date = b.first_date AND detail_status = \$2 (.+)`+
+				`WHERE s.group_name = \$3 AND s.release_date = b.last_date AND detail_status = \$4 (.+)`+
+				`FROM songs WHERE group_name = \$5 AND detail_status = \$6\) AS b`).
+			WithArgs("Test Group", entity.DetailStatusFound, "Test Group", entity.DetailStatusFound, "Test Group", entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"first_date", "first_name", "last_date", "last_name"}).
+				AddRow(fixedTime, "Test Song", fixedTime, "Test Song"))
+
+		_, err := repo.GetGroupReleaseRange(context.Background(), "Test Group")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("count facet", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT group_name AS value, COUNT\(\*\) AS count FROM songs WHERE detail_status = \$1 GROUP BY group_name`).
+			WithArgs(entity.DetailStatusFound).
+			WillReturnRows(sqlmock.NewRows([]string{"value", "count"}))
+
+		_, err := repo.CountFacet(context.Background(), entity.FacetGroupNameField)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("suggest", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`SELECT name FROM songs WHERE name ILIKE \$1 AND detail_status = \$2 GROUP BY name`).
+			WithArgs("Te%", entity.DetailStatusFound, "Te").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}))
+
+		_, err := repo.Suggest(context.Background(), entity.SuggestSongNameField, "Te", 10)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("update where without request filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`UPDATE songs SET (.+) WHERE detail_status = \$\d+ RETURNING id$`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID))
+
+		updated, err := repo.UpdateWhere(context.Background(), entity.Song{GroupName: "New Group"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID}, updated)
+	})
+
+	t.Run("touch where", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`UPDATE songs SET updated_at = CURRENT_TIMESTAMP WHERE detail_status = \$1 AND group_name = \$2 RETURNING id`).
+			WithArgs(entity.DetailStatusFound, "Test Group").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID))
+
+		touched, err := repo.TouchWhere(
+			context.Background(),
+			entity.SongFilter{Field: entity.SongGroupFilterField, Value: "Test Group"},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID}, touched)
+	})
+
+	t.Run("touch where requires request filters", func(t *testing.T) {
+		repo, _ := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		touched, err := repo.TouchWhere(context.Background())
+
+		assert.ErrorIs(t, err, entity.ErrNoFilters)
+		assert.Zero(t, touched)
+	})
+
+	t.Run("delete where", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		mock.
+			ExpectQuery(`DELETE FROM songs WHERE detail_status = \$1 AND import_id = \$2 RETURNING id`).
+			WithArgs(entity.DetailStatusFound, fixedUUID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID))
+
+		deletedIDs, err := repo.DeleteWhere(
+			context.Background(),
+			entity.SongFilter{Field: entity.SongImportFilterField, Value: fixedUUID},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID}, deletedIDs)
+	})
+
+	t.Run("delete where requires request filters", func(t *testing.T) {
+		repo, _ := initSongRepository(t, WithDefaultFilters(defaultFilter))
+
+		deletedIDs, err := repo.DeleteWhere(context.Background())
+
+		assert.ErrorIs(t, err, entity.ErrNoFilters)
+		assert.Nil(t, deletedIDs)
+	})
+}
+
+func TestSongRepository_CountFacet(t *testing.T) {
+	t.Run("unknown facet field", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		counts, err := repo.CountFacet(context.Background(), entity.FacetField(-1))
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "unknown facet field")
+		assert.Nil(t, counts)
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT group_name AS value, COUNT\(\*\) AS count FROM songs`).
+			WithoutArgs().
+			WillReturnError(errors.New("unknown error"))
+
+		counts, err := repo.CountFacet(context.Background(), entity.FacetGroupNameField)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, counts)
+	})
+
+	t.Run("release year with filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"value", "count"}).
+			AddRow(1971, 2).
+			AddRow(1975, 1)
+
+		mock.
+			ExpectQuery(`SELECT EXTRACT\(YEAR FROM release_date\)::int AS value, COUNT\(\*\) AS count FROM songs ` +
+				`WHERE release_date IS NOT NULL AND group_name ILIKE \$1 GROUP BY value ORDER BY value ASC`).
+			WithArgs("%Led%").
+			WillReturnRows(rows)
+
+		counts, err := repo.CountFacet(
+			context.Background(),
+			entity.FacetReleaseYearField,
+			entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Led"},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.FacetCount{{Value: "1971", Count: 2}, {Value: "1975", Count: 1}}, counts)
+	})
+
+	t.Run("group with filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"value", "count"}).
+			AddRow("Led Zeppelin", 3).
+			AddRow("Deep Purple", 1)
+
+		mock.
+			ExpectQuery(`SELECT group_name AS value, COUNT\(\*\) AS count FROM songs ` +
+				`WHERE EXTRACT\(YEAR FROM release_date\) = \$1 GROUP BY group_name ORDER BY count DESC, group_name ASC`).
+			WithArgs(1971).
+			WillReturnRows(rows)
+
+		counts, err := repo.CountFacet(
+			context.Background(),
+			entity.FacetGroupNameField,
+			entity.SongFilter{Field: entity.SongReleaseYearFilterField, Value: 1971},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.FacetCount{{Value: "Led Zeppelin", Count: 3}, {Value: "Deep Purple", Count: 1}}, counts)
+	})
+}
+
+func TestSongRepository_GetGroupReleaseRange(t *testing.T) {
+	const query = `SELECT b.first_date, \(SELECT s.name FROM songs s WHERE s.group_name = \$1 AND s.release_date = b.first_date ORDER BY s.name ASC LIMIT 1\) AS first_name, ` +
+		`b.last_date, \(SELECT s.name FROM songs s WHERE s.group_name = \$2 AND s.release_date = b.last_date ORDER BY s.name ASC LIMIT 1\) AS last_name ` +
+		`FROM \(SELECT MIN\(release_date\) AS first_date, MAX\(release_date\) AS last_date FROM songs WHERE group_name = \$3\) AS b`
+
+	columns := []string{"first_date", "first_name", "last_date", "last_name"}
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(query).
+			WithArgs("The Beatles", "The Beatles", "The Beatles").
+			WillReturnError(errors.New("unknown error"))
+
+		releaseRange, err := repo.GetGroupReleaseRange(context.Background(), "The Beatles")
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get release range from 'songs' table")
+		assert.Nil(t, releaseRange)
+	})
+
+	t.Run("group without dated songs", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(query).
+			WithArgs("Unknown Group", "Unknown Group", "Unknown Group").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(nil, nil, nil, nil))
+
+		releaseRange, err := repo.GetGroupReleaseRange(context.Background(), "Unknown Group")
+
+		assert.ErrorIs(t, err, entity.ErrGroupNotFound)
+		assert.Nil(t, releaseRange)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		firstDate := time.Date(1962, time.October, 5, 0, 0, 0, 0, time.UTC)
+		lastDate := time.Date(1970, time.May, 8, 0, 0, 0, 0, time.UTC)
+
+		mock.
+			ExpectQuery(query).
+			WithArgs("The Beatles", "The Beatles", "The Beatles").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(firstDate, "Love Me Do", lastDate, "Let It Be"))
+
+		releaseRange, err := repo.GetGroupReleaseRange(context.Background(), "The Beatles")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.GroupReleaseRange{
+			GroupName: "The Beatles",
+			First:     entity.SongRelease{Name: "Love Me Do", ReleaseDate: firstDate},
+			Last:      entity.SongRelease{Name: "Let It Be", ReleaseDate: lastDate},
+		}, releaseRange)
+	})
+}
+
+func TestSongRepository_GetGroupFacets(t *testing.T) {
+	const query = `SELECT EXTRACT\(YEAR FROM release_date\)::int AS year, COUNT\(\*\) AS count ` +
+		`FROM songs WHERE group_name = \$1 GROUP BY year ORDER BY year ASC NULLS LAST`
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(query).
+			WithArgs("Led Zeppelin").
+			WillReturnError(errors.New("unknown error"))
+
+		facets, err := repo.GetGroupFacets(context.Background(), "Led Zeppelin")
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to count rows by year in 'songs' table")
+		assert.Nil(t, facets)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(query).
+			WithArgs("Unknown Group").
+			WillReturnRows(sqlmock.NewRows([]string{"year", "count"}))
+
+		facets, err := repo.GetGroupFacets(context.Background(), "Unknown Group")
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrGroupNotFound)
+		assert.Nil(t, facets)
+	})
+
+	t.Run("group without release dates", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"year", "count"}).
+			AddRow(nil, uint64(2))
+
+		mock.
+			ExpectQuery(query).
+			WithArgs("Led Zeppelin").
+			WillReturnRows(rows)
+
+		facets, err := repo.GetGroupFacets(context.Background(), "Led Zeppelin")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Led Zeppelin", facets.GroupName)
+		assert.Empty(t, facets.ReleaseYears)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"year", "count"}).
+			AddRow(1969, uint64(2)).
+			AddRow(1971, uint64(8)).
+			AddRow(nil, uint64(1))
+
+		mock.
+			ExpectQuery(query).
+			WithArgs("Led Zeppelin").
+			WillReturnRows(rows)
+
+		facets, err := repo.GetGroupFacets(context.Background(), "Led Zeppelin")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.GroupFacets{
+			GroupName: "Led Zeppelin",
+			ReleaseYears: []entity.YearCount{
+				{Year: 1969, Count: 2},
+				{Year: 1971, Count: 8},
+			},
+		}, facets)
+	})
+}
+
+func TestSongRepository_Suggest(t *testing.T) {
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT group_name FROM songs WHERE group_name ILIKE \$1`).
+			WithArgs("bea%", "bea").
+			WillReturnError(errors.New("unknown error"))
+
+		names, err := repo.Suggest(context.Background(), entity.SuggestGroupNameField, "bea", 10)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get names from 'songs' table")
+		assert.Nil(t, names)
+	})
+
+	t.Run("group names", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"group_name"}).
+			AddRow("Beatles").
+			AddRow("Beastie Boys")
+
+		mock.
+			ExpectQuery(`SELECT group_name FROM songs WHERE group_name ILIKE \$1 GROUP BY group_name `+
+				`ORDER BY LOWER\(group_name\) = LOWER\(\$2\) DESC, group_name ASC LIMIT 10`).
+			WithArgs("beatles%", "beatles").
+			WillReturnRows(rows)
+
+		names, err := repo.Suggest(context.Background(), entity.SuggestGroupNameField, "beatles", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Beatles", "Beastie Boys"}, names)
+	})
+
+	t.Run("song names with escaped wildcards", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows([]string{"name"}).AddRow("100% Pure Love")
+
+		mock.
+			ExpectQuery(`SELECT name FROM songs WHERE name ILIKE \$1 GROUP BY name `+
+				`ORDER BY LOWER\(name\) = LOWER\(\$2\) DESC, name ASC LIMIT 5`).
+			WithArgs(`100\%%`, "100%").
+			WillReturnRows(rows)
+
+		names, err := repo.Suggest(context.Background(), entity.SuggestSongNameField, "100%", 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"100% Pure Love"}, names)
+	})
+}
+
+func TestSongRepository_GetByID(t *testing.T) {
+	t.Run("song not found", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs`).
+			WithArgs(fixedUUID).
+			WillReturnError(sql.ErrNoRows)
+
+		song, err := repo.GetByID(context.Background(), fixedUUID)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, song)
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs`).
+			WithArgs(fixedUUID).
+			WillReturnError(errors.New("unknown error"))
+
+		res, err := repo.GetByID(context.Background(), fixedUUID)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get row from 'songs' table")
+		assert.Nil(t, res)
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs`).
+			WithArgs(fixedUUID).
+			WillReturnError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+
+		res, err := repo.GetByID(context.Background(), fixedUUID)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrStorageUnavailable)
+		assert.Nil(t, res)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs`).
+			WithArgs(fixedUUID).
+			WillReturnRows(rows)
+
+		song, err := repo.GetByID(context.Background(), fixedUUID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, song)
+		assert.Equal(t, fixedUUID, song.ID)
+		assert.Equal(t, "Test Group", song.GroupName)
+		assert.Equal(t, "Test Song", song.Name)
+		assert.Equal(t, fixedTime, song.SongDetail.ReleaseDate)
+		assert.Equal(t, "Test Text", song.SongDetail.Text)
+		assert.Equal(t, "https://example.com", song.SongDetail.Link)
+		assert.Equal(t, fixedTime, song.CreatedAt)
+		assert.Equal(t, fixedTime, song.UpdatedAt)
+	})
+}
+
+func TestSongRepository_GetByIDs(t *testing.T) {
+	otherID := uuid.New()
+
+	t.Run("no ids", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		songs, err := repo.GetByIDs(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, songs)
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE id IN \(\$1,\$2\)`).
+			WithArgs(fixedUUID, otherID).
+			WillReturnError(errors.New("unknown error"))
+
+		songs, err := repo.GetByIDs(context.Background(), []uuid.UUID{fixedUUID, otherID})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to get rows from 'songs' table")
+		assert.Nil(t, songs)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`SELECT (.+) FROM songs WHERE id IN \(\$1,\$2\)`).
+			WithArgs(fixedUUID, otherID).
+			WillReturnRows(rows)
+
+		songs, err := repo.GetByIDs(context.Background(), []uuid.UUID{fixedUUID, otherID})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Equal(t, "Test Text", songs[0].SongDetail.Text)
+	})
+}
+
+func TestSongRepository_Update(t *testing.T) {
+	t.Run("empty song", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		song, err := repo.Update(context.Background(), uuid.New(), entity.Song{})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrNoFieldsToUpdate)
+		assert.Nil(t, song)
+	})
+
+	t.Run("song not found", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`UPDATE songs`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), fixedUUID).
+			WillReturnError(sql.ErrNoRows)
+
+		song, err := repo.Update(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{
+				Text: "New Test Text",
+				Link: "https://new-example.com",
+			},
+		})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, song)
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`UPDATE songs`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), fixedUUID).
+			WillReturnError(errors.New("unknown error"))
+
+		res, err := repo.Update(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{
+				Text: "New Test Text",
+				Link: "https://new-example.com",
+			},
+		})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to update row from 'songs' table")
+		assert.Nil(t, res)
+	})
+
+	t.Run("link already exists", func(t *testing.T) {
+		repo, mock := initSongRepository(t, WithUniqueLinkErrors())
+
+		mock.
+			ExpectQuery(`UPDATE songs`).
+			WithArgs(sqlmock.AnyArg(), fixedUUID).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationCode, ConstraintName: uniqueLinkIndex})
+
+		song, err := repo.Update(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{Link: "https://example.com"},
+		})
+
+		assert.ErrorIs(t, err, entity.ErrLinkAlreadyExists)
+		assert.Nil(t, song)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "New Test Text", "https://new-example.com", fixedTime, fixedTime)
+
+		mock.
+			ExpectQuery(`UPDATE songs`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), fixedUUID).
+			WillReturnRows(rows)
+
+		song, err := repo.Update(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{
+				Text: "New Test Text",
+				Link: "https://new-example.com",
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, song)
+		assert.Equal(t, fixedUUID, song.ID)
+		assert.Equal(t, "Test Group", song.GroupName)
+		assert.Equal(t, "Test Song", song.Name)
+		assert.Equal(t, fixedTime, song.SongDetail.ReleaseDate)
+		assert.Equal(t, "New Test Text", song.SongDetail.Text)
+		assert.Equal(t, "https://new-example.com", song.SongDetail.Link)
+		assert.Equal(t, fixedTime, song.CreatedAt)
+		assert.Equal(t, fixedTime, song.UpdatedAt)
+	})
+}
+
+func TestSongRepository_UpdateWhere(t *testing.T) {
+	otherUUID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	t.Run("empty song", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		updated, err := repo.UpdateWhere(context.Background(), entity.Song{})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrNoFieldsToUpdate)
+		assert.Zero(t, updated)
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`UPDATE songs`).
+			WithArgs("https://example.com").
+			WillReturnError(errors.New("unknown error"))
+
+		updated, err := repo.UpdateWhere(context.Background(), entity.Song{
+			SongDetail: entity.SongDetail{Link: "https://example.com"},
+		})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to update rows from 'songs' table")
+		assert.Zero(t, updated)
+	})
+
+	t.Run("filtered update", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`UPDATE songs SET detail_source = $1, link = $2 WHERE group_name ILIKE $3 AND EXTRACT(YEAR FROM release_date) IN ($4,$5) RETURNING id`)).
+			WithArgs(entity.DetailSourceClient, "https://example.com", "%Queen%", 1975, 1976).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID).AddRow(otherUUID))
+
+		updated, err := repo.UpdateWhere(context.Background(), entity.Song{
+			SongDetail:   entity.SongDetail{Link: "https://example.com"},
+			DetailSource: entity.DetailSourceClient,
+		},
+			entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"},
+			entity.SongFilter{Field: entity.SongReleaseYearFilterField, Value: []int{1975, 1976}},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID, otherUUID}, updated)
+	})
+
+	t.Run("release date of group songs missing one", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`UPDATE songs SET detail_source = $1, release_date = $2 WHERE group_name = $3 AND release_date IS NULL RETURNING id`)+`$`).
+			WithArgs(entity.DetailSourceClient, toDate(fixedTime), "Queen").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID).AddRow(otherUUID))
+
+		updated, err := repo.UpdateWhere(context.Background(), entity.Song{
+			SongDetail:   entity.SongDetail{ReleaseDate: fixedTime},
+			DetailSource: entity.DetailSourceClient,
+		},
+			entity.SongFilter{Field: entity.SongGroupFilterField, Value: "Queen"},
+			entity.SongFilter{Field: entity.SongReleaseDateMissingFilterField, Value: true},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID, otherUUID}, updated)
+	})
+
+	t.Run("update without filters", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`UPDATE songs SET link = $1 RETURNING id`) + `$`).
+			WithArgs("https://example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID).AddRow(otherUUID))
+
+		updated, err := repo.UpdateWhere(context.Background(), entity.Song{
+			SongDetail: entity.SongDetail{Link: "https://example.com"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID, otherUUID}, updated)
+	})
+}
+
+func TestSongRepository_TouchWhere(t *testing.T) {
+	otherUUID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	t.Run("no filters", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		touched, err := repo.TouchWhere(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrNoFilters)
+		assert.Zero(t, touched)
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(`UPDATE songs`).
+			WithArgs("%Queen%").
+			WillReturnError(errors.New("unknown error"))
+
+		touched, err := repo.TouchWhere(context.Background(),
+			entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"},
+		)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to update rows from 'songs' table")
+		assert.Zero(t, touched)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectQuery(regexp.QuoteMeta(`UPDATE songs SET updated_at = CURRENT_TIMESTAMP WHERE group_name ILIKE $1 AND EXTRACT(YEAR FROM release_date) IN ($2) RETURNING id`)+`$`).
+			WithArgs("%Queen%", 1975).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID).AddRow(otherUUID))
+
+		touched, err := repo.TouchWhere(context.Background(),
+			entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"},
+			entity.SongFilter{Field: entity.SongReleaseYearFilterField, Value: []int{1975}},
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID, otherUUID}, touched)
+	})
+}
+
+func TestSongRepository_UpdateBatch(t *testing.T) {
+	otherUUID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+	releaseDate := time.Date(1971, time.November, 8, 0, 0, 0, 0, time.UTC)
+
+	updates := []entity.SongUpdate{
+		{
+			SongID: fixedUUID,
+			Song:   entity.Song{SongDetail: entity.SongDetail{ReleaseDate: releaseDate}},
+		},
+		{
+			SongID: otherUUID,
+			Song:   entity.Song{SongDetail: entity.SongDetail{ReleaseDate: releaseDate}},
+		},
+	}
+
+	t.Run("no updates", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		songs, err := repo.UpdateBatch(context.Background(), nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, songs)
+	})
+
+	t.Run("empty update", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		songs, err := repo.UpdateBatch(context.Background(), []entity.SongUpdate{{SongID: fixedUUID}})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrNoFieldsToUpdate)
+		assert.Nil(t, songs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectQuery(`UPDATE songs`).
+			WithArgs(releaseDate, fixedUUID).
+			WillReturnError(errors.New("unknown error"))
+		mock.ExpectRollback()
+
+		songs, err := repo.UpdateBatch(context.Background(), updates)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to update row from 'songs' table")
+		assert.Nil(t, songs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success with missing song", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
 		rows := sqlmock.NewRows(columns).
-			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+			AddRow(fixedUUID, "Test Group", "Test Song", releaseDate, nil, nil, fixedTime, fixedTime)
 
+		mock.ExpectBegin()
 		mock.
-			ExpectQuery(`SELECT (.+) FROM songs LIMIT 10 OFFSET 40`).
-			WithoutArgs().
+			ExpectQuery(`UPDATE songs SET release_date = \$1 WHERE id = \$2 RETURNING (.+)`).
+			WithArgs(releaseDate, fixedUUID).
 			WillReturnRows(rows)
-
-		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
-
 		mock.
-			ExpectQuery(`SELECT COUNT\(\*\)`).
-			WithoutArgs().
-			WillReturnRows(rows)
+			ExpectQuery(`UPDATE songs SET release_date = \$1 WHERE id = \$2 RETURNING (.+)`).
+			WithArgs(releaseDate, otherUUID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectCommit()
 
-		songs, pagination, err := repo.GetAll(context.Background(), entity.Pagination{
-			Offset: 40,
-			Limit:  10,
-		})
+		songs, err := repo.UpdateBatch(context.Background(), updates)
 
 		assert.NoError(t, err)
-		assert.NotNil(t, songs)
-		assert.Len(t, songs, 1)
+		assert.Len(t, songs, 2)
 		assert.Equal(t, fixedUUID, songs[0].ID)
-		assert.Equal(t, "Test Group", songs[0].GroupName)
-		assert.Equal(t, "Test Song", songs[0].Name)
-		assert.Equal(t, fixedTime, songs[0].SongDetail.ReleaseDate)
-		assert.Equal(t, "Test Text", songs[0].SongDetail.Text)
-		assert.Equal(t, "https://example.com", songs[0].Link)
-		assert.Equal(t, fixedTime, songs[0].CreatedAt)
-		assert.Equal(t, fixedTime, songs[0].UpdatedAt)
-		assert.NotNil(t, pagination)
-		assert.Equal(t, uint64(40), pagination.Offset)
-		assert.Equal(t, uint64(10), pagination.Limit)
-		assert.Equal(t, uint64(1), pagination.Items)
-		assert.Equal(t, uint64(1), pagination.Total)
+		assert.Equal(t, releaseDate, songs[0].ReleaseDate)
+		assert.Nil(t, songs[1])
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+}
 
-	t.Run("success with not-empty filters", func(t *testing.T) {
+func TestSongRepository_GetAfter(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
 		rows := sqlmock.NewRows(columns).
-			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
+			AddRow(fixedUUID, "Test Group", "Test Song", nil, "Verse 1\n\nVerse 2", nil, fixedTime, fixedTime)
 
 		mock.
-			ExpectQuery(`SELECT (.+) FROM songs WHERE name ILIKE \$1 AND EXTRACT\(YEAR FROM release_date\) = \$2 LIMIT 20 OFFSET 0`).
-			WithArgs("%Song%", fixedTime.Year()).
+			ExpectQuery(`SELECT (.+) FROM songs WHERE id > \$1 ORDER BY id ASC LIMIT 2`).
+			WithArgs(uuid.Nil).
 			WillReturnRows(rows)
 
-		rows = sqlmock.NewRows([]string{"total_count"}).AddRow(uint64(1))
+		songs, err := repo.GetAfter(context.Background(), uuid.Nil, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Equal(t, "Verse 1\n\nVerse 2", songs[0].SongDetail.Text)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
 
 		mock.
-			ExpectQuery(`SELECT COUNT\(\*\)`).
-			WithoutArgs().
-			WillReturnRows(rows)
+			ExpectQuery(`SELECT (.+) FROM songs WHERE id > \$1 AND group_name ILIKE \$2 ORDER BY id ASC LIMIT 2`).
+			WithArgs(fixedUUID, "%Queen%").
+			WillReturnRows(sqlmock.NewRows(columns))
 
-		songs, pagination, err := repo.GetAll(
-			context.Background(),
-			entity.Pagination{},
-			entity.SongFilter{
-				Field: entity.SongNameFilterField,
-				Value: "Song",
-			},
-			entity.SongFilter{
-				Field: entity.SongReleaseYearFilterField,
-				Value: fixedTime.Year(),
-			},
+		songs, err := repo.GetAfter(context.Background(), fixedUUID, 2,
+			entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"},
 		)
 
 		assert.NoError(t, err)
-		assert.NotNil(t, songs)
-		assert.Len(t, songs, 1)
-		assert.Equal(t, fixedUUID, songs[0].ID)
-		assert.Equal(t, "Test Group", songs[0].GroupName)
-		assert.Equal(t, "Test Song", songs[0].Name)
-		assert.Equal(t, fixedTime, songs[0].SongDetail.ReleaseDate)
-		assert.Equal(t, "Test Text", songs[0].SongDetail.Text)
-		assert.Equal(t, "https://example.com", songs[0].Link)
-		assert.Equal(t, fixedTime, songs[0].CreatedAt)
-		assert.Equal(t, fixedTime, songs[0].UpdatedAt)
-		assert.NotNil(t, pagination)
-		assert.Equal(t, entity.DefaultOffset, pagination.Offset)
-		assert.Equal(t, entity.DefaultLimit, pagination.Limit)
-		assert.Equal(t, uint64(1), pagination.Items)
-		assert.Equal(t, uint64(1), pagination.Total)
+		assert.Empty(t, songs)
 	})
 }
 
-func TestSongRepository_GetByID(t *testing.T) {
-	t.Run("song not found", func(t *testing.T) {
+func TestSongRepository_UpdateVerseCounts(t *testing.T) {
+	otherUUID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	counts := []entity.SongVerseCount{
+		{SongID: fixedUUID, Count: 2},
+		{SongID: otherUUID, Count: 0},
+	}
+
+	t.Run("unknown database error", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
+		mock.ExpectBegin()
 		mock.
-			ExpectQuery(`SELECT (.+) FROM songs`).
-			WithArgs(fixedUUID).
-			WillReturnError(sql.ErrNoRows)
+			ExpectExec(`UPDATE songs SET verse_count`).
+			WithArgs(2, fixedUUID, 2).
+			WillReturnError(errors.New("unknown error"))
+		mock.ExpectRollback()
 
-		song, err := repo.GetByID(context.Background(), fixedUUID)
+		updated, err := repo.UpdateVerseCounts(context.Background(), counts)
 
 		assert.Error(t, err)
-		assert.ErrorIs(t, err, entity.ErrSongNotFound)
-		assert.Nil(t, song)
+		assert.ErrorContains(t, err, "failed to update row from 'songs' table")
+		assert.Zero(t, updated)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectExec(regexp.QuoteMeta(`UPDATE songs SET verse_count = $1 WHERE id = $2 AND verse_count IS DISTINCT FROM $3`)).
+			WithArgs(2, fixedUUID, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.
+			ExpectExec(regexp.QuoteMeta(`UPDATE songs SET verse_count = $1 WHERE id = $2 AND verse_count IS DISTINCT FROM $3`)).
+			WithArgs(0, otherUUID, 0).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		updated, err := repo.UpdateVerseCounts(context.Background(), counts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), updated)
 	})
+}
+
+func TestSongRepository_UpdateSortNames(t *testing.T) {
+	otherUUID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	sortNames := []entity.SongSortName{
+		{SongID: fixedUUID, SortName: "Beatles"},
+		{SongID: otherUUID, SortName: "Queen"},
+	}
 
 	t.Run("unknown database error", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
+		mock.ExpectBegin()
 		mock.
-			ExpectQuery(`SELECT (.+) FROM songs`).
-			WithArgs(fixedUUID).
+			ExpectExec(`UPDATE songs SET sort_name`).
+			WithArgs("Beatles", fixedUUID, "Beatles").
 			WillReturnError(errors.New("unknown error"))
+		mock.ExpectRollback()
 
-		res, err := repo.GetByID(context.Background(), fixedUUID)
+		updated, err := repo.UpdateSortNames(context.Background(), sortNames)
 
 		assert.Error(t, err)
-		assert.ErrorContains(t, err, "failed to get row from 'songs' table")
-		assert.Nil(t, res)
+		assert.ErrorContains(t, err, "failed to update row from 'songs' table")
+		assert.Zero(t, updated)
 	})
 
 	t.Run("success", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
-		rows := sqlmock.NewRows(columns).
-			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "Test Text", "https://example.com", fixedTime, fixedTime)
-
+		mock.ExpectBegin()
 		mock.
-			ExpectQuery(`SELECT (.+) FROM songs`).
-			WithArgs(fixedUUID).
-			WillReturnRows(rows)
+			ExpectExec(regexp.QuoteMeta(`UPDATE songs SET sort_name = $1 WHERE id = $2 AND sort_name IS DISTINCT FROM $3`)).
+			WithArgs("Beatles", fixedUUID, "Beatles").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.
+			ExpectExec(regexp.QuoteMeta(`UPDATE songs SET sort_name = $1 WHERE id = $2 AND sort_name IS DISTINCT FROM $3`)).
+			WithArgs("Queen", otherUUID, "Queen").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
 
-		song, err := repo.GetByID(context.Background(), fixedUUID)
+		updated, err := repo.UpdateSortNames(context.Background(), sortNames)
 
 		assert.NoError(t, err)
-		assert.NotNil(t, song)
-		assert.Equal(t, fixedUUID, song.ID)
-		assert.Equal(t, "Test Group", song.GroupName)
-		assert.Equal(t, "Test Song", song.Name)
-		assert.Equal(t, fixedTime, song.SongDetail.ReleaseDate)
-		assert.Equal(t, "Test Text", song.SongDetail.Text)
-		assert.Equal(t, "https://example.com", song.SongDetail.Link)
-		assert.Equal(t, fixedTime, song.CreatedAt)
-		assert.Equal(t, fixedTime, song.UpdatedAt)
+		assert.Equal(t, int64(1), updated)
 	})
 }
 
-func TestSongRepository_Update(t *testing.T) {
-	t.Run("empty song", func(t *testing.T) {
+func TestSongRepository_DeleteBatch(t *testing.T) {
+	otherUUID := uuid.New()
+
+	t.Run("no songs", func(t *testing.T) {
 		repo, _ := initSongRepository(t)
 
-		song, err := repo.Update(context.Background(), uuid.New(), entity.Song{})
+		deleted, err := repo.DeleteBatch(context.Background(), nil)
 
 		assert.Error(t, err)
-		assert.ErrorContains(t, err, "no fields provided for update")
-		assert.Nil(t, song)
+		assert.ErrorContains(t, err, "no songs provided for deleting")
+		assert.Nil(t, deleted)
 	})
 
-	t.Run("song not found", func(t *testing.T) {
+	t.Run("unknown database error", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
+		mock.ExpectBegin()
 		mock.
-			ExpectQuery(`UPDATE songs`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), fixedUUID).
-			WillReturnError(sql.ErrNoRows)
+			ExpectQuery(`DELETE FROM songs WHERE id IN \(\$1,\$2\) RETURNING id`).
+			WithArgs(fixedUUID, otherUUID).
+			WillReturnError(errors.New("unknown error"))
+		mock.ExpectRollback()
 
-		song, err := repo.Update(context.Background(), fixedUUID, entity.Song{
-			SongDetail: entity.SongDetail{
-				Text: "New Test Text",
-				Link: "https://new-example.com",
-			},
-		})
+		deleted, err := repo.DeleteBatch(context.Background(), []uuid.UUID{fixedUUID, otherUUID})
 
 		assert.Error(t, err)
-		assert.ErrorIs(t, err, entity.ErrSongNotFound)
-		assert.Nil(t, song)
+		assert.ErrorContains(t, err, "failed to delete rows from 'songs' table")
+		assert.Nil(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success with missing song", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.ExpectBegin()
+		mock.
+			ExpectQuery(`DELETE FROM songs WHERE id IN \(\$1,\$2\) RETURNING id`).
+			WithArgs(fixedUUID, otherUUID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID))
+		mock.ExpectCommit()
+
+		deleted, err := repo.DeleteBatch(context.Background(), []uuid.UUID{fixedUUID, otherUUID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID}, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSongRepository_DeleteWhere(t *testing.T) {
+	importID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174009")
+	otherUUID := uuid.New()
+
+	t.Run("no filters", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		deleted, err := repo.DeleteWhere(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrNoFilters)
+		assert.Nil(t, deleted)
 	})
 
 	t.Run("unknown database error", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
 		mock.
-			ExpectQuery(`UPDATE songs`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), fixedUUID).
+			ExpectQuery(`DELETE FROM songs`).
+			WithArgs(importID).
 			WillReturnError(errors.New("unknown error"))
 
-		res, err := repo.Update(context.Background(), fixedUUID, entity.Song{
-			SongDetail: entity.SongDetail{
-				Text: "New Test Text",
-				Link: "https://new-example.com",
-			},
-		})
+		deleted, err := repo.DeleteWhere(context.Background(),
+			entity.SongFilter{Field: entity.SongImportFilterField, Value: importID},
+		)
 
 		assert.Error(t, err)
-		assert.ErrorContains(t, err, "failed to update row from 'songs' table")
-		assert.Nil(t, res)
+		assert.ErrorContains(t, err, "failed to delete rows from 'songs' table")
+		assert.Nil(t, deleted)
 	})
 
 	t.Run("success", func(t *testing.T) {
 		repo, mock := initSongRepository(t)
 
-		rows := sqlmock.NewRows(columns).
-			AddRow(fixedUUID, "Test Group", "Test Song", fixedTime, "New Test Text", "https://new-example.com", fixedTime, fixedTime)
-
 		mock.
-			ExpectQuery(`UPDATE songs`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), fixedUUID).
-			WillReturnRows(rows)
+			ExpectQuery(regexp.QuoteMeta(`DELETE FROM songs WHERE import_id = $1 RETURNING id`) + `$`).
+			WithArgs(importID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fixedUUID).AddRow(otherUUID))
 
-		song, err := repo.Update(context.Background(), fixedUUID, entity.Song{
-			SongDetail: entity.SongDetail{
-				Text: "New Test Text",
-				Link: "https://new-example.com",
-			},
-		})
+		deleted, err := repo.DeleteWhere(context.Background(),
+			entity.SongFilter{Field: entity.SongImportFilterField, Value: importID},
+		)
 
 		assert.NoError(t, err)
-		assert.NotNil(t, song)
-		assert.Equal(t, fixedUUID, song.ID)
-		assert.Equal(t, "Test Group", song.GroupName)
-		assert.Equal(t, "Test Song", song.Name)
-		assert.Equal(t, fixedTime, song.SongDetail.ReleaseDate)
-		assert.Equal(t, "New Test Text", song.SongDetail.Text)
-		assert.Equal(t, "https://new-example.com", song.SongDetail.Link)
-		assert.Equal(t, fixedTime, song.CreatedAt)
-		assert.Equal(t, fixedTime, song.UpdatedAt)
+		assert.Equal(t, []uuid.UUID{fixedUUID, otherUUID}, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
 
@@ -454,3 +3303,79 @@ func TestSongRepository_Delete(t *testing.T) {
 		assert.Equal(t, int64(1), deleted)
 	})
 }
+
+func TestSongRepository_SaveLinkChecks(t *testing.T) {
+	otherUUID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	checks := []entity.LinkCheck{
+		{SongID: fixedUUID, Link: "https://example.com/ok", StatusCode: 200},
+		{SongID: otherUUID, Link: "https://example.com/gone", StatusCode: 0},
+	}
+
+	const query = `INSERT INTO link_checks (song_id, link, status_code) ` +
+		`SELECT v.song_id, v.link, v.status_code FROM (VALUES ($1::uuid, $2, $3::integer), ($4::uuid, $5, $6::integer)) AS v (song_id, link, status_code) ` +
+		`JOIN songs ON songs.id = v.song_id ` +
+		`ON CONFLICT (song_id) DO UPDATE SET link = EXCLUDED.link, status_code = EXCLUDED.status_code, checked_at = CURRENT_TIMESTAMP`
+
+	t.Run("no checks", func(t *testing.T) {
+		repo, _ := initSongRepository(t)
+
+		err := repo.SaveLinkChecks(context.Background(), nil)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown database error", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(fixedUUID, "https://example.com/ok", 200, otherUUID, "https://example.com/gone", 0).
+			WillReturnError(errors.New("unknown error"))
+
+		err := repo.SaveLinkChecks(context.Background(), checks)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to insert rows into 'link_checks' table")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(fixedUUID, "https://example.com/ok", 200, otherUUID, "https://example.com/gone", 0).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.SaveLinkChecks(context.Background(), checks)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestSongRepository_CreateUniqueLinkIndex(t *testing.T) {
+	t.Run("songs already share a link", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectExec(`CREATE UNIQUE INDEX`).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationCode})
+
+		err := repo.CreateUniqueLinkIndex(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to create unique link index")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock := initSongRepository(t)
+
+		mock.
+			ExpectExec(regexp.QuoteMeta(`CREATE UNIQUE INDEX IF NOT EXISTS songs_link_unique_idx ON songs (link) WHERE link IS NOT NULL`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.CreateUniqueLinkIndex(context.Background())
+
+		assert.NoError(t, err)
+	})
+}
@@ -7,8 +7,10 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/httplog/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/vadimbarashkov/online-song-library/internal/adapter/api"
 	"github.com/vadimbarashkov/online-song-library/internal/config"
 	"github.com/vadimbarashkov/online-song-library/internal/usecase"
@@ -27,10 +29,12 @@ import (
 //  2. Runs database migrations based on the provided migration path.
 //  3. Initializes the song repository and the music information API client.
 //  4. Sets up the song use case logic that interacts with the repository and API.
-//  5. Configures the HTTP server with routing and timeout settings.
-//  6. Starts the server in a separate goroutine, handling both TLS and non-TLS modes
+//  5. Starts a background reporter exposing the connection pool stats as metrics,
+//     stopped together with the server.
+//  6. Configures the HTTP server with routing and timeout settings.
+//  7. Starts the server in a separate goroutine, handling both TLS and non-TLS modes
 //     depending on the environment configuration.
-//  7. Waits for the context to be done (indicating shutdown) and gracefully shuts down
+//  8. Waits for the context to be done (indicating shutdown) and gracefully shuts down
 //     the server, ensuring all active connections are completed before exiting.
 func Run(ctx context.Context, cfg *config.Config) error {
 	const op = "app.Run"
@@ -53,29 +57,162 @@ func Run(ctx context.Context, cfg *config.Config) error {
 
 	logger.Info("preparing server")
 
-	songRepo := repo.NewSongRepository(db)
-	musicInfoAPI := api.NewMusicInfoAPI(cfg.MusicInfoAPI, nil)
-	songUseCase := usecase.NewSongUseCase(musicInfoAPI, songRepo)
+	dbStats := postgres.NewStatsReporter(db.Stats, cfg.Postgres.StatsInterval)
+
+	var repoOpts []repo.Option
+	if cfg.Postgres.LogQueries {
+		repoOpts = append(repoOpts, repo.WithQueryLogger(logger.Logger, cfg.Postgres.LogQueryArgs))
+	}
+
+	if cfg.DefaultSongFilters != "" {
+		defaultFilters, err := delivery.ParseSongFilters(cfg.DefaultSongFilters)
+		if err != nil {
+			return fmt.Errorf("%s: invalid default song filters: %w", op, err)
+		}
+
+		repoOpts = append(repoOpts, repo.WithDefaultFilters(defaultFilters...))
+	}
+
+	if cfg.SortCollation != "" {
+		repoOpts = append(repoOpts, repo.WithCollation(cfg.SortCollation))
+	}
+
+	if cfg.Postgres.AnalyticsDSN != "" {
+		logger.Info("connecting to the analytics database")
+
+		analyticsDB, err := postgres.New(ctx, cfg.Postgres.AnalyticsDSN)
+		if err != nil {
+			return fmt.Errorf("%s: failed to connect to analytics database: %w", op, err)
+		}
+		defer analyticsDB.Close()
+
+		repoOpts = append(repoOpts, repo.WithAnalyticsDB(analyticsDB))
+	}
+
+	if cfg.LinkUnique {
+		repoOpts = append(repoOpts, repo.WithUniqueLinkErrors())
+	}
+
+	songRepo := repo.NewSongRepository(db, repoOpts...)
+
+	if err := songRepo.CheckCollation(ctx); err != nil {
+		return fmt.Errorf("%s: invalid sort collation: %w", op, err)
+	}
+
+	if cfg.LinkUnique {
+		if err := songRepo.CreateUniqueLinkIndex(ctx); err != nil {
+			return fmt.Errorf("%s: failed to enforce unique links: %w", op, err)
+		}
+	}
+
+	musicInfoClient, err := api.NewTLSClient(cfg.MusicInfoAPICAFile, cfg.MusicInfoAPIInsecureSkipTLS)
+	if err != nil {
+		return fmt.Errorf("%s: failed to create music info api client: %w", op, err)
+	}
+
+	musicInfoProviders, err := newMusicInfoProviders(cfg, musicInfoClient)
+	if err != nil {
+		return fmt.Errorf("%s: invalid music info api settings: %w", op, err)
+	}
+	musicInfoAPI := api.NewMusicInfoPool(musicInfoProviders...)
+
+	useCaseOpts := []usecase.Option{
+		usecase.WithSortNameArticles(cfg.SortNameArticles...),
+		usecase.WithMaxOffsetOverrun(cfg.MaxOffsetOverrun),
+		usecase.WithMaxSongsPerGroup(cfg.MaxSongsPerGroup),
+		usecase.WithMaxVerses(cfg.TextMaxVerses),
+		usecase.WithMusicInfoTimeoutFallback(cfg.MusicInfoAPITimeoutFallback),
+		usecase.WithDetailStaleAfter(cfg.MusicInfoAPIDetailStaleAfter),
+		usecase.WithLinkChecker(api.NewLinkChecker(nil, cfg.LinkCheckTimeout), cfg.LinkCheckConcurrency),
+	}
+	if cfg.CheckDuplicateSongs {
+		useCaseOpts = append(useCaseOpts, usecase.WithDuplicateCheck())
+	}
+	if cfg.LinkNormalization {
+		useCaseOpts = append(useCaseOpts, usecase.WithLinkNormalization(cfg.LinkTrackingParams, cfg.LinkUpgradeHTTPS))
+	}
+	if cfg.LinkKeepOriginal {
+		useCaseOpts = append(useCaseOpts, usecase.WithOriginalLinks())
+	}
+	if cfg.TextStripControlChars {
+		useCaseOpts = append(useCaseOpts, usecase.WithControlCharStripping())
+	}
+	if cfg.ReleaseYearFromText {
+		useCaseOpts = append(useCaseOpts, usecase.WithReleaseYearFromText())
+	}
+
+	songUseCase := usecase.NewSongUseCase(musicInfoAPI, songRepo, useCaseOpts...)
+
+	// Every filter is allowed in development, so the allowlist doesn't get in the way of exploring the API.
+	var allowedFilters []string
+	if cfg.Env != config.EnvDev {
+		allowedFilters = cfg.HTTPServer.AllowedFilters
+	}
+
+	libraryStats := delivery.NewLibraryStatsReporter(logger.Logger, songUseCase.FetchLibraryStats, cfg.LibraryStatsInterval)
+
+	deprecatedRoutes, err := cfg.HTTPServer.DeprecatedRouteSunsets()
+	if err != nil {
+		return fmt.Errorf("%s: invalid deprecated routes: %w", op, err)
+	}
 
 	r := delivery.NewRouter(logger, songUseCase, &delivery.RouterOptions{
 		SwaggerHost: cfg.HTTPServer.Host,
 		SwaggerPort: cfg.HTTPServer.Port,
-	})
+		StrictJSON:  cfg.HTTPServer.StrictJSON,
 
-	server := &http.Server{
-		Addr:           cfg.HTTPServer.Addr(),
-		Handler:        r,
-		ReadTimeout:    cfg.HTTPServer.ReadTimeout,
-		WriteTimeout:   cfg.HTTPServer.WriteTimeout,
-		IdleTimeout:    cfg.HTTPServer.IdleTimeout,
-		MaxHeaderBytes: cfg.HTTPServer.MaxHeaderBytes,
-		BaseContext: func(_ net.Listener) context.Context {
-			return ctx
+		StrictContentType: cfg.HTTPServer.StrictContentType,
+		RequireUTF8Body:   cfg.HTTPServer.RequireUTF8Body,
+		StrictFilters:     cfg.HTTPServer.StrictFilters,
+		DuplicateFilters:  cfg.HTTPServer.DuplicateFilters,
+		AllowedFilters:    allowedFilters,
+		RequireUserAgent:  cfg.HTTPServer.RequireUserAgent,
+		ServerTiming:      cfg.HTTPServer.ServerTiming,
+		PrettyJSON:        cfg.HTTPServer.PrettyJSON,
+		JSONKeyCase:       cfg.HTTPServer.JSONKeyCase,
+		RootPage:          cfg.HTTPServer.RootPage,
+		MaxResponseSize:   cfg.HTTPServer.MaxResponseSize,
+		HighlightPre:      cfg.HTTPServer.HighlightPre,
+		HighlightPost:     cfg.HTTPServer.HighlightPost,
+
+		PaginationAsStrings:   cfg.HTTPServer.PaginationAsStrings,
+		TotalOnRequest:        cfg.HTTPServer.TotalOnRequest,
+		FailOnMultipleRemoved: cfg.HTTPServer.FailOnMultipleRemoved,
+		NoLyricsNotFound:      cfg.HTTPServer.NoLyricsNotFound,
+		NoLastModified:        cfg.HTTPServer.NoLastModified,
+
+		ImportMaxFileSize: cfg.HTTPServer.ImportMaxFileSize,
+		ImportMaxRows:     cfg.HTTPServer.ImportMaxRows,
+
+		MetricsListBuckets:     cfg.HTTPServer.MetricsBuckets.List,
+		MetricsSingleBuckets:   cfg.HTTPServer.MetricsBuckets.Single,
+		MetricsMutatingBuckets: cfg.HTTPServer.MetricsBuckets.Mutating,
+
+		MetricsCollectors: []prometheus.Collector{dbStats, libraryStats},
+
+		CORSAllowedOrigins:   cfg.HTTPServer.CORSAllowedOrigins,
+		CORSAllowCredentials: cfg.HTTPServer.CORSAllowCredentials,
+		CORSMaxAge:           cfg.HTTPServer.CORSMaxAge,
+
+		RequestDeadline:         cfg.HTTPServer.RequestDeadline,
+		EventsHeartbeatInterval: cfg.HTTPServer.EventsHeartbeatInterval,
+
+		ReadOnly:           cfg.HTTPServer.ReadOnly,
+		ReadOnlyRetryAfter: cfg.HTTPServer.ReadOnlyRetryAfter,
+
+		DeprecatedRoutes: deprecatedRoutes,
+
+		AdminToken:  cfg.HTTPServer.AdminToken,
+		AdminConfig: cfg.Sanitized(),
+		MigrationStatus: func() (*postgres.MigrationStatus, error) {
+			return postgres.ReadMigrationStatus(cfg.MigrationsPath, cfg.Postgres.DSN())
 		},
-	}
+	})
 
 	g, ctx := errgroup.WithContext(ctx)
 
+	server := newServer(ctx, cfg.HTTPServer, r)
+
 	g.Go(func() error {
 		logger.Info("running server", slog.Any("addr", cfg.HTTPServer.Addr()))
 
@@ -95,6 +232,16 @@ func Run(ctx context.Context, cfg *config.Config) error {
 		return nil
 	})
 
+	g.Go(func() error {
+		dbStats.Run(ctx)
+		return nil
+	})
+
+	g.Go(func() error {
+		libraryStats.Run(ctx)
+		return nil
+	})
+
 	g.Go(func() error {
 		<-ctx.Done()
 
@@ -110,6 +257,68 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	return g.Wait()
 }
 
+// newMusicInfoProviders creates a music info API client for every base URL, configured with the settings
+// of the provider at the same position. It returns an error if the required fields or a response mapping
+// name an unknown field, so that a misspelled field fails the startup instead of going unchecked.
+func newMusicInfoProviders(cfg *config.Config, client *http.Client) ([]*api.MusicInfoAPI, error) {
+	if err := api.ValidateFields(cfg.MusicInfoAPIRequiredFields...); err != nil {
+		return nil, fmt.Errorf("required fields: %w", err)
+	}
+
+	providers := make([]*api.MusicInfoAPI, 0, len(cfg.MusicInfoAPI))
+	for i, baseURL := range cfg.MusicInfoAPI {
+		mapping, err := config.ParseResponseMapping(providerSetting(cfg.MusicInfoAPIResponseMappings, i))
+		if err != nil {
+			return nil, err
+		}
+
+		for field := range mapping {
+			if err := api.ValidateFields(field); err != nil {
+				return nil, fmt.Errorf("response mapping of %s: %w", baseURL, err)
+			}
+		}
+
+		providers = append(providers, api.NewMusicInfoAPI(
+			baseURL,
+			client,
+			api.WithRequiredFields(cfg.MusicInfoAPIRequiredFields...),
+			api.WithNetworkRetry(cfg.MusicInfoAPINetworkRetries, cfg.MusicInfoAPINetworkRetryDelay),
+			api.WithMaxBodySize(cfg.MusicInfoAPIMaxBodySize),
+			api.WithQueryParams(providerSetting(cfg.MusicInfoAPIGroupParams, i), providerSetting(cfg.MusicInfoAPISongParams, i)),
+			api.WithResponseMapping(mapping),
+		))
+	}
+
+	return providers, nil
+}
+
+// providerSetting returns the setting of the i-th music info provider from a list of settings given
+// in the order of the providers, or an empty string when the list has no entry for it.
+func providerSetting(settings []string, i int) string {
+	if i < len(settings) {
+		return strings.TrimSpace(settings[i])
+	}
+
+	return ""
+}
+
+// newServer creates the HTTP server serving handler with the timeouts and limits from the settings.
+// Requests are served with ctx as their base context.
+func newServer(ctx context.Context, cfg config.HTTPServer, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              cfg.Addr(),
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+	}
+}
+
 // setupLogger configures the HTTP logger based on the application environment.
 func setupLogger(env string) *httplog.Logger {
 	opt := httplog.Options{
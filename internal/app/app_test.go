@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vadimbarashkov/online-song-library/internal/config"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+func TestNewServer(t *testing.T) {
+	cfg := config.HTTPServer{
+		Port:              8080,
+		ReadTimeout:       5 * time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       time.Minute,
+		MaxHeaderBytes:    1 << 20,
+	}
+	handler := http.NotFoundHandler()
+
+	server := newServer(context.Background(), cfg, handler)
+
+	assert.Equal(t, ":8080", server.Addr)
+	assert.Equal(t, 5*time.Second, server.ReadTimeout)
+	assert.Equal(t, 2*time.Second, server.ReadHeaderTimeout)
+	assert.Equal(t, 10*time.Second, server.WriteTimeout)
+	assert.Equal(t, time.Minute, server.IdleTimeout)
+	assert.Equal(t, 1<<20, server.MaxHeaderBytes)
+	assert.NotNil(t, server.Handler)
+}
+
+func TestNewMusicInfoProviders(t *testing.T) {
+	t.Run("unknown required field", func(t *testing.T) {
+		cfg := &config.Config{
+			MusicInfoAPI:               []string{"https://example.com.api"},
+			MusicInfoAPIRequiredFields: []string{"releaseDate", "lyrics"},
+		}
+
+		providers, err := newMusicInfoProviders(cfg, nil)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, `required fields: unknown song detail field "lyrics"`)
+		assert.Nil(t, providers)
+	})
+
+	t.Run("unknown mapped field", func(t *testing.T) {
+		cfg := &config.Config{
+			MusicInfoAPI:                 []string{"https://example.com.api"},
+			MusicInfoAPIResponseMappings: []string{"lyrics=text"},
+		}
+
+		providers, err := newMusicInfoProviders(cfg, nil)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, `unknown song detail field "lyrics"`)
+		assert.Nil(t, providers)
+	})
+
+	t.Run("mapped provider", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"released":"16.07.2006","lyrics":"Test Text","url":"https://example.com"}`))
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{
+			MusicInfoAPI:                 []string{"https://fallback.example.com.api", server.URL},
+			MusicInfoAPIRequiredFields:   []string{"releaseDate", "text", "link"},
+			MusicInfoAPIResponseMappings: []string{"", "releaseDate=released text=lyrics link=url"},
+		}
+
+		providers, err := newMusicInfoProviders(cfg, server.Client())
+
+		assert.NoError(t, err)
+		assert.Len(t, providers, 2)
+
+		songDetail, err := providers[1].FetchSongInfo(context.Background(), entity.Song{
+			GroupName: "Test Group",
+			Name:      "Test Song",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Test Text", songDetail.Text)
+		assert.Equal(t, "https://example.com", songDetail.Link)
+		assert.Equal(t, 2006, songDetail.ReleaseDate.Year())
+	})
+}
@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
@@ -17,23 +20,98 @@ const (
 
 // Config holds the configuration settings for the application.
 type Config struct {
-	Env            string `env:"ENV" envDefault:"dev"`
-	MigrationsPath string `env:"MIGRATIONS_PATH" envDefault:"migrations"`
-	MusicInfoAPI   string `env:"MUSIC_INFO_API,required"`
-	HTTPServer     `envPrefix:"HTTP_SERVER_"`
-	Postgres       `envPrefix:"POSTGRES_"`
+	Env                           string        `env:"ENV" envDefault:"dev"`
+	MigrationsPath                string        `env:"MIGRATIONS_PATH" envDefault:"migrations"`
+	MusicInfoAPI                  []string      `env:"MUSIC_INFO_API,required" envSeparator:","`
+	MusicInfoAPIRequiredFields    []string      `env:"MUSIC_INFO_API_REQUIRED_FIELDS" envSeparator:"," envDefault:"releaseDate,text,link"`
+	MusicInfoAPINetworkRetries    int           `env:"MUSIC_INFO_API_NETWORK_RETRIES" envDefault:"2"`
+	MusicInfoAPINetworkRetryDelay time.Duration `env:"MUSIC_INFO_API_NETWORK_RETRY_DELAY" envDefault:"200ms"`
+	MusicInfoAPIMaxBodySize       int64         `env:"MUSIC_INFO_API_MAX_BODY_SIZE" envDefault:"1048576"`
+	MusicInfoAPITimeoutFallback   time.Duration `env:"MUSIC_INFO_API_TIMEOUT_FALLBACK" envDefault:"0s"`
+	MusicInfoAPIGroupParams       []string      `env:"MUSIC_INFO_API_GROUP_PARAMS" envSeparator:","`
+	MusicInfoAPISongParams        []string      `env:"MUSIC_INFO_API_SONG_PARAMS" envSeparator:","`
+	MusicInfoAPIResponseMappings  []string      `env:"MUSIC_INFO_API_RESPONSE_MAPPINGS" envSeparator:","`
+	MusicInfoAPIDetailStaleAfter  time.Duration `env:"MUSIC_INFO_API_DETAIL_STALE_AFTER" envDefault:"720h"`
+	MusicInfoAPIRequireHTTPS      bool          `env:"MUSIC_INFO_API_REQUIRE_HTTPS" envDefault:"false"`
+	MusicInfoAPICAFile            string        `env:"MUSIC_INFO_API_CA_FILE"`
+	MusicInfoAPIInsecureSkipTLS   bool          `env:"MUSIC_INFO_API_INSECURE_SKIP_VERIFY" envDefault:"false"`
+	SortNameArticles              []string      `env:"SORT_NAME_ARTICLES" envSeparator:"," envDefault:"The,A,An"`
+	DefaultSongFilters            string        `env:"DEFAULT_SONG_FILTERS"`
+	SortCollation                 string        `env:"SORT_COLLATION"`
+	MaxOffsetOverrun              uint64        `env:"MAX_OFFSET_OVERRUN" envDefault:"0"`
+	MaxSongsPerGroup              uint64        `env:"MAX_SONGS_PER_GROUP" envDefault:"0"`
+	CheckDuplicateSongs           bool          `env:"CHECK_DUPLICATE_SONGS" envDefault:"false"`
+	LinkNormalization             bool          `env:"LINK_NORMALIZATION" envDefault:"false"`
+	LinkTrackingParams            []string      `env:"LINK_TRACKING_PARAMS" envSeparator:"," envDefault:"utm_*,fbclid,gclid"`
+	LinkUpgradeHTTPS              bool          `env:"LINK_UPGRADE_HTTPS" envDefault:"false"`
+	LinkKeepOriginal              bool          `env:"LINK_KEEP_ORIGINAL" envDefault:"false"`
+	LinkUnique                    bool          `env:"LINK_UNIQUE" envDefault:"false"`
+	TextStripControlChars         bool          `env:"TEXT_STRIP_CONTROL_CHARS" envDefault:"false"`
+	TextMaxVerses                 int           `env:"TEXT_MAX_VERSES" envDefault:"0"`
+	ReleaseYearFromText           bool          `env:"RELEASE_YEAR_FROM_TEXT" envDefault:"false"`
+	LinkCheckConcurrency          int           `env:"LINK_CHECK_CONCURRENCY" envDefault:"8"`
+	LinkCheckTimeout              time.Duration `env:"LINK_CHECK_TIMEOUT" envDefault:"5s"`
+	LibraryStatsInterval          time.Duration `env:"LIBRARY_STATS_INTERVAL" envDefault:"1m"`
+	HTTPServer                    `envPrefix:"HTTP_SERVER_"`
+	Postgres                      `envPrefix:"POSTGRES_"`
 }
 
 // HTTPServer contains settings related to the HTTP server.
 type HTTPServer struct {
-	Host           string        `env:"HOST" envDefault:"localhost"`
-	Port           int           `env:"PORT" envDefault:"8080"`
-	ReadTimeout    time.Duration `env:"READ_TIMEOUT" envDefault:"5s"`
-	WriteTimeout   time.Duration `env:"WRITE_TIMEOUT" envDefault:"10s"`
-	IdleTimeout    time.Duration `env:"IDLE_TIMEOUT" envDefault:"1m"`
-	MaxHeaderBytes int           `env:"MAX_HEADER_BYTES" envDefault:"1048576"`
-	CertFile       string        `env:"CERT_FILE"`
-	KeyFile        string        `env:"KEY_FILE"`
+	Host                  string        `env:"HOST" envDefault:"localhost"`
+	Port                  int           `env:"PORT" envDefault:"8080"`
+	ReadTimeout           time.Duration `env:"READ_TIMEOUT" envDefault:"5s"`
+	ReadHeaderTimeout     time.Duration `env:"READ_HEADER_TIMEOUT" envDefault:"2s"`
+	WriteTimeout          time.Duration `env:"WRITE_TIMEOUT" envDefault:"10s"`
+	IdleTimeout           time.Duration `env:"IDLE_TIMEOUT" envDefault:"1m"`
+	MaxHeaderBytes        int           `env:"MAX_HEADER_BYTES" envDefault:"1048576"`
+	CertFile              string        `env:"CERT_FILE"`
+	KeyFile               string        `env:"KEY_FILE"`
+	StrictJSON            bool          `env:"STRICT_JSON" envDefault:"false"`
+	StrictContentType     bool          `env:"STRICT_CONTENT_TYPE" envDefault:"false"`
+	RequireUTF8Body       bool          `env:"REQUIRE_UTF8_BODY" envDefault:"false"`
+	StrictFilters         bool          `env:"STRICT_FILTERS" envDefault:"false"`
+	RequireUserAgent      bool          `env:"REQUIRE_USER_AGENT" envDefault:"false"`
+	ServerTiming          bool          `env:"SERVER_TIMING" envDefault:"false"`
+	PrettyJSON            bool          `env:"PRETTY_JSON" envDefault:"false"`
+	JSONKeyCase           string        `env:"JSON_KEY_CASE"`
+	RootPage              string        `env:"ROOT_PAGE"`
+	MaxResponseSize       int64         `env:"MAX_RESPONSE_SIZE" envDefault:"0"`
+	HighlightPre          string        `env:"HIGHLIGHT_PRE" envDefault:"<em>"`
+	HighlightPost         string        `env:"HIGHLIGHT_POST" envDefault:"</em>"`
+	DuplicateFilters      string        `env:"DUPLICATE_FILTERS" envDefault:"first"`
+	AllowedFilters        []string      `env:"ALLOWED_FILTERS" envSeparator:","`
+	PaginationAsStrings   bool          `env:"PAGINATION_AS_STRINGS" envDefault:"false"`
+	TotalOnRequest        bool          `env:"TOTAL_ON_REQUEST" envDefault:"false"`
+	FailOnMultipleRemoved bool          `env:"FAIL_ON_MULTIPLE_REMOVED" envDefault:"false"`
+	NoLyricsNotFound      bool          `env:"NO_LYRICS_NOT_FOUND" envDefault:"false"`
+	NoLastModified        bool          `env:"NO_LAST_MODIFIED" envDefault:"false"`
+	ImportMaxFileSize     int64         `env:"IMPORT_MAX_FILE_SIZE" envDefault:"1048576"`
+	ImportMaxRows         int           `env:"IMPORT_MAX_ROWS" envDefault:"1000"`
+	MetricsBuckets        `envPrefix:"METRICS_BUCKETS_"`
+
+	CORSAllowedOrigins   []string      `env:"CORS_ALLOWED_ORIGINS" envSeparator:"," envDefault:"https://*"`
+	CORSAllowCredentials bool          `env:"CORS_ALLOW_CREDENTIALS" envDefault:"false"`
+	CORSMaxAge           time.Duration `env:"CORS_MAX_AGE" envDefault:"24h"`
+
+	RequestDeadline         time.Duration `env:"REQUEST_DEADLINE" envDefault:"30s"`
+	EventsHeartbeatInterval time.Duration `env:"EVENTS_HEARTBEAT_INTERVAL" envDefault:"15s"`
+
+	ReadOnly           bool          `env:"READ_ONLY" envDefault:"false"`
+	ReadOnlyRetryAfter time.Duration `env:"READ_ONLY_RETRY_AFTER" envDefault:"5m"`
+
+	// DeprecatedRoutes lists the routes answered with deprecation headers, each as a method and route pattern
+	// optionally followed by the sunset date, such as "GET /api/v1/songs 2026-12-31".
+	DeprecatedRoutes []string `env:"DEPRECATED_ROUTES" envSeparator:","`
+
+	AdminToken string `env:"ADMIN_TOKEN" redact:"true"`
+}
+
+// MetricsBuckets contains the latency histogram buckets (in seconds) for each route group.
+type MetricsBuckets struct {
+	List     []float64 `env:"LIST" envSeparator:"," envDefault:"0.005,0.01,0.025,0.05,0.1,0.25,0.5,1"`
+	Single   []float64 `env:"SINGLE" envSeparator:"," envDefault:"0.001,0.0025,0.005,0.01,0.025,0.05,0.1,0.25"`
+	Mutating []float64 `env:"MUTATING" envSeparator:"," envDefault:"0.025,0.05,0.1,0.25,0.5,1,2.5,5,10"`
 }
 
 // Addr returns the address <host:port> on which the HTTP server will listen.
@@ -41,20 +119,232 @@ func (s *HTTPServer) Addr() string {
 	return fmt.Sprintf(":%d", s.Port)
 }
 
+// DeprecatedRouteSunsets parses DeprecatedRoutes into the sunset times of the routes keyed by their method
+// and route pattern, such as "GET /api/v1/songs". Routes without a sunset date get the zero time.
+// It returns an error if an entry is malformed.
+func (s *HTTPServer) DeprecatedRouteSunsets() (map[string]time.Time, error) {
+	sunsets := make(map[string]time.Time, len(s.DeprecatedRoutes))
+
+	for _, route := range s.DeprecatedRoutes {
+		fields := strings.Fields(route)
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("deprecated route %q must be a method and route pattern optionally followed by a sunset date", route)
+		}
+
+		var sunset time.Time
+		if len(fields) == 3 {
+			var err error
+			if sunset, err = time.Parse(time.DateOnly, fields[2]); err != nil {
+				return nil, fmt.Errorf("deprecated route %q has invalid sunset date: %w", route, err)
+			}
+		}
+
+		sunsets[strings.ToUpper(fields[0])+" "+fields[1]] = sunset
+	}
+
+	return sunsets, nil
+}
+
+// validate checks that the settings are consistent. Deprecated routes must be well formed, and credentials
+// can't be allowed for wildcard CORS origins, since that would allow them for any matching site.
+func (s *HTTPServer) validate() error {
+	if _, err := s.DeprecatedRouteSunsets(); err != nil {
+		return err
+	}
+
+	if !s.CORSAllowCredentials {
+		return nil
+	}
+
+	for _, origin := range s.CORSAllowedOrigins {
+		if strings.Contains(origin, "*") {
+			return fmt.Errorf("wildcard cors origin %q is not allowed with credentials", origin)
+		}
+	}
+
+	return nil
+}
+
+// ParseResponseMapping parses the response mapping of a music info provider, written as space-separated
+// pairs of a song detail field and the key the provider returns it under, such as "releaseDate=released text=lyrics".
+// An empty setting gives no mapping. It returns an error if a pair is malformed.
+func ParseResponseMapping(setting string) (map[string]string, error) {
+	pairs := strings.Fields(setting)
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	mapping := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		field, key, ok := strings.Cut(pair, "=")
+		if !ok || field == "" || key == "" {
+			return nil, fmt.Errorf("response mapping %q must be a field and a key separated by =", pair)
+		}
+
+		mapping[field] = key
+	}
+
+	return mapping, nil
+}
+
+// validate checks the sort collation, which can't contain double quotes since it is quoted as an identifier,
+// and the music info API settings: response mappings must be well formed, base URLs must use https when it is
+// required, and certificate verification may only be skipped in development.
+func (c *Config) validate() error {
+	if strings.Contains(c.SortCollation, `"`) {
+		return fmt.Errorf("sort collation %q must not contain double quotes", c.SortCollation)
+	}
+
+	for _, setting := range c.MusicInfoAPIResponseMappings {
+		if _, err := ParseResponseMapping(setting); err != nil {
+			return err
+		}
+	}
+
+	if c.MusicInfoAPIInsecureSkipTLS && c.Env != EnvDev {
+		return fmt.Errorf("skipping certificate verification is only allowed in %s env", EnvDev)
+	}
+
+	if !c.MusicInfoAPIRequireHTTPS {
+		return nil
+	}
+
+	for _, baseURL := range c.MusicInfoAPI {
+		u, err := url.Parse(baseURL)
+		if err != nil || u.Scheme != "https" {
+			return fmt.Errorf("music info api base url %q must use https", baseURL)
+		}
+	}
+
+	return nil
+}
+
 // Postgres contains settings required to connect to a PostgreSQL database.
 type Postgres struct {
 	User     string `env:"USER,required"`
-	Password string `env:"PASSWORD,required"`
+	Password string `env:"PASSWORD,required" redact:"true"`
 	Host     string `env:"HOST" envDefault:"localhost"`
 	Port     int    `env:"PORT" envDefault:"5432"`
 	DB       string `env:"DB,required"`
 	SSLMode  string `env:"SSLMODE" envDefault:"disable"`
+
+	TimeZone string `env:"TIMEZONE" envDefault:"UTC"`
+
+	StatsInterval time.Duration `env:"STATS_INTERVAL" envDefault:"15s"`
+
+	LogQueries   bool `env:"LOG_QUERIES" envDefault:"false"`
+	LogQueryArgs bool `env:"LOG_QUERY_ARGS" envDefault:"false"`
+
+	AnalyticsDSN string `env:"ANALYTICS_DSN" redact:"true"`
 }
 
 // DSN returns the Data Source Name (DSN) used to connect to the PostgreSQL database.
+// When TimeZone is set, it is used as the session time zone, so that the database evaluates
+// dates and timestamps the same way as the application.
 func (p *Postgres) DSN() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		p.User, p.Password, p.Host, p.Port, p.DB, p.SSLMode)
+
+	if p.TimeZone != "" {
+		dsn += "&timezone=" + url.QueryEscape(p.TimeZone)
+	}
+
+	return dsn
+}
+
+// redactedValue replaces the values of secret settings in Sanitized.
+const redactedValue = "[REDACTED]"
+
+// Sanitized returns the effective settings keyed by their environment variable names, safe to be shown to operators.
+// Settings tagged with redact:"true" are replaced with [REDACTED], passwords in URLs are masked,
+// and durations are formatted like the environment variables, such as 1m30s.
+func (c *Config) Sanitized() map[string]any {
+	settings := make(map[string]any)
+	sanitize(reflect.ValueOf(*c), "", settings)
+
+	return settings
+}
+
+// sanitize adds the settings of the struct value to settings, descending into nested structs with their env prefixes.
+func sanitize(v reflect.Value, prefix string, settings map[string]any) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		if envPrefix, ok := field.Tag.Lookup("envPrefix"); ok {
+			sanitize(value, prefix+envPrefix, settings)
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if name == "" {
+			continue
+		}
+		name = prefix + name
+
+		switch val := value.Interface().(type) {
+		case string:
+			if field.Tag.Get("redact") == "true" && val != "" {
+				settings[name] = redactedValue
+			} else {
+				settings[name] = redactURL(val)
+			}
+		case []string:
+			urls := make([]string, len(val))
+			for i, v := range val {
+				urls[i] = redactURL(v)
+			}
+			settings[name] = urls
+		case time.Duration:
+			settings[name] = val.String()
+		default:
+			settings[name] = val
+		}
+	}
+}
+
+// secretQueryParams are the words marking a query parameter of a URL as a secret, such as api_key or access_token.
+var secretQueryParams = []string{"key", "token", "secret", "password"}
+
+// redactURL masks the password of a URL value and the values of its secret query parameters (see secretQueryParams).
+// Other values are returned unchanged.
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || (u.User == nil && u.RawQuery == "") {
+		return value
+	}
+
+	u.RawQuery = redactQuery(u.RawQuery)
+
+	return u.Redacted()
+}
+
+// redactQuery masks the values of the secret query parameters of a raw query, keeping the order of the parameters.
+func redactQuery(rawQuery string) string {
+	params := strings.Split(rawQuery, "&")
+
+	for i, param := range params {
+		rawName, _, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+
+		name := rawName
+		if unescaped, err := url.QueryUnescape(rawName); err == nil {
+			name = unescaped
+		}
+
+		for _, secret := range secretQueryParams {
+			if strings.Contains(strings.ToLower(name), secret) {
+				params[i] = rawName + "=xxxxx"
+				break
+			}
+		}
+	}
+
+	return strings.Join(params, "&")
 }
 
 // Load reads environment variables from a specified .env file and parses them into the Config struct.
@@ -71,5 +361,13 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("%s: failed to parse Config struct: %w", op, err)
 	}
 
+	if err := cfg.HTTPServer.validate(); err != nil {
+		return nil, fmt.Errorf("%s: invalid http server settings: %w", op, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("%s: invalid music info api settings: %w", op, err)
+	}
+
 	return &cfg, nil
 }
@@ -10,21 +10,119 @@ import (
 // ErrSongNotFound is returned when a requested song is not found in the database.
 var ErrSongNotFound = errors.New("song not found")
 
+// ErrSongInfoNotFound is returned when the music info API does not know the requested song.
+var ErrSongInfoNotFound = errors.New("song info not found")
+
+// ErrGroupNotFound is returned when no song of a requested group is found in the database.
+var ErrGroupNotFound = errors.New("group not found")
+
+// ErrNoFieldsToUpdate is returned when an update is requested without any fields to modify.
+var ErrNoFieldsToUpdate = errors.New("no fields provided for update")
+
+// ErrNoFilters is returned when an operation that must be limited to some songs is requested without filters.
+var ErrNoFilters = errors.New("no filters provided")
+
+// ErrStorageUnavailable is returned when the song storage can't be reached, as opposed to a failed query.
+var ErrStorageUnavailable = errors.New("storage unavailable")
+
+// ErrOffsetOutOfRange is returned when a requested page starts too far beyond the total number of items.
+var ErrOffsetOutOfRange = errors.New("offset out of range")
+
+// ErrLinkCheckRunning is returned when a link check is requested while another one is still running.
+var ErrLinkCheckRunning = errors.New("link check already running")
+
+// ErrGroupSongLimitExceeded is returned when saving songs would make a group have more songs than allowed.
+var ErrGroupSongLimitExceeded = errors.New("group song limit exceeded")
+
+// ErrTooManyVerses is returned when saving a song whose text splits into more verses than allowed.
+var ErrTooManyVerses = errors.New("too many verses")
+
+// ErrLinkAlreadyExists is returned when a song is given a link of another song while links must be unique.
+var ErrLinkAlreadyExists = errors.New("link already exists")
+
+// ErrNoVerses is returned when a verse of a song is requested while the song has no text.
+var ErrNoVerses = errors.New("song has no verses")
+
+// ErrSongAlreadyExists is returned when adding a song while a song of the same group with the same name exists.
+var ErrSongAlreadyExists = errors.New("song already exists")
+
+// ErrImportNotFound is returned when no song added by a requested batch import is found in the database.
+var ErrImportNotFound = errors.New("import not found")
+
+// ErrInvalidVerseOrder is returned when verses are reordered with indices that aren't a permutation of the verses.
+var ErrInvalidVerseOrder = errors.New("invalid verse order")
+
+// ErrInvalidRegex is returned when the database rejects the regular expression of a song text filter.
+var ErrInvalidRegex = errors.New("invalid regular expression")
+
 // Song represents a musical composition with associated details.
 type Song struct {
-	ID         uuid.UUID // Unique identifier for the song
-	GroupName  string    // Name of the musical group or artist
-	Name       string    // Title of the song
-	SongDetail           // Contains additional details about the song
-	CreatedAt  time.Time // Timestamp when the song was created
-	UpdatedAt  time.Time // Timestamp when the song was last updated
+	ID              uuid.UUID    // Unique identifier for the song
+	GroupName       string       // Name of the musical group or artist
+	Name            string       // Title of the song
+	SortName        string       // Group name used for alphabetical ordering, without leading articles
+	SongDetail                   // Contains additional details about the song
+	OriginalLink    string       // Link as it was received before normalization, empty unless originals are kept
+	DetailSource    DetailSource // Origin of the song details, empty when the song has no details
+	DetailStatus    DetailStatus // Outcome of the last music info API lookup, empty when none was made
+	DetailFetchedAt time.Time    // Timestamp of the last music info API lookup, set by the repository, zero when none was made
+	ImportID        uuid.UUID    // Identifier of the batch import that added the song, uuid.Nil when it was added on its own
+	CreatedAt       time.Time    // Timestamp when the song was created
+	UpdatedAt       time.Time    // Timestamp when the song was last updated
 }
 
+// DetailSource identifies where the details of a song came from.
+type DetailSource string
+
+// Possible origins of song details.
+const (
+	DetailSourceMusicInfoAPI DetailSource = "music_info_api" // Fetched from the music info API
+	DetailSourceClient       DetailSource = "client"         // Supplied by an API client
+	DetailSourceBackfill     DetailSource = "backfill"       // Filled in later by a background job
+	DetailSourceHeuristic    DetailSource = "heuristic"      // Fetched from the music info API, with the release date guessed from the text
+)
+
+// DetailStatus describes the outcome of looking up the details of a song in the music info API.
+type DetailStatus string
+
+// Possible outcomes of a music info API lookup.
+const (
+	DetailStatusFound    DetailStatus = "found"     // The details were fetched
+	DetailStatusNotFound DetailStatus = "not_found" // The music info API does not know the song
+	DetailStatusFailed   DetailStatus = "failed"    // The lookup failed and may succeed when retried
+)
+
 // SongDetail holds detailed information about a song.
 type SongDetail struct {
 	ReleaseDate time.Time // Release date of the song
 	Text        string    // Lyrics or text of the song
 	Link        string    // Link to the song (e.g., streaming link)
+	Provider    string    // Name of the music info provider the details were fetched from, empty for other sources
+}
+
+// SongUpdate pairs the ID of a song with the fields to modify, used for updating several songs at once.
+type SongUpdate struct {
+	SongID uuid.UUID // Unique identifier of the song to update
+	Song   Song      // Fields to modify, zero values are left unchanged
+}
+
+// LinkCheck is the outcome of requesting the link of a song.
+type LinkCheck struct {
+	SongID     uuid.UUID // Unique identifier of the song
+	Link       string    // Link that was requested
+	StatusCode int       // HTTP status code of the response, 0 when no response was received
+}
+
+// SongVerseCount pairs the ID of a song with the number of verses of its text.
+type SongVerseCount struct {
+	SongID uuid.UUID // Unique identifier of the song
+	Count  int       // Number of verses, zero for songs without text
+}
+
+// SongSortName pairs the ID of a song with the group name used for its alphabetical ordering.
+type SongSortName struct {
+	SongID   uuid.UUID // Unique identifier of the song
+	SortName string    // Group name without leading articles
 }
 
 // SongWithVerses represents a song with its lyrics broken down into verses.
@@ -32,11 +130,149 @@ type SongWithVerses struct {
 	ID        uuid.UUID // Unique identifier for the song
 	GroupName string    // Name of the musical group or artist
 	Name      string    // Title of the song
-	Verses    []string  // Lyrics of the song, divided into verses
+	Verses    []string  // Lyrics of the song, divided into verses or lines depending on the granularity
+	Repeats   []int     // Times every verse is repeated in a row, set only when repeated verses are collapsed
 	CreatedAt time.Time // Timestamp when the song was created
 	UpdatedAt time.Time // Timestamp when the song was last updated
 }
 
+// VerseMatch represents a verse of a song text matching a search query along with the verses around it.
+type VerseMatch struct {
+	Index  int      // Index of the matching verse in the text
+	Start  int      // Index in the text of the first verse of Verses
+	Verses []string // Matching verse with up to the requested number of verses before and after it
+}
+
+// Verse represents a single verse of a song text.
+type Verse struct {
+	Index int    // Index of the verse in the text
+	Text  string // Content of the verse
+}
+
+// TextStats represents word and line counts of a song text.
+type TextStats struct {
+	Words       int // Number of words in the text
+	Lines       int // Number of non-blank lines in the text
+	Verses      int // Number of verses in the text
+	UniqueWords int // Number of distinct words in the text, ignoring case
+}
+
+// SongVersesPage represents a song with a single page of its verses.
+type SongVersesPage struct {
+	Song       SongWithVerses // Song with the verses of the page
+	Pagination Pagination     // Pagination of the song verses
+}
+
+// SongEventType identifies the kind of change a song event reports.
+type SongEventType string
+
+// Kinds of song changes.
+const (
+	SongCreatedEvent SongEventType = "created" // A song was added, on its own or by an import
+	SongUpdatedEvent SongEventType = "updated" // Fields of a song were modified
+	SongDeletedEvent SongEventType = "deleted" // A song was removed
+)
+
+// SongEvent reports a change of a song.
+type SongEvent struct {
+	Type       SongEventType // Kind of the change
+	SongID     uuid.UUID     // Unique identifier of the changed song
+	OccurredAt time.Time     // Timestamp when the change was made
+}
+
+// DiffOperation defines how a line changed between two texts.
+const (
+	DiffUnchanged DiffOperation = iota
+	DiffAdded
+	DiffRemoved
+)
+
+// DiffOperation represents the type for specifying how a line changed between two texts.
+type DiffOperation int
+
+// LineDiff represents a single line of a line-level diff between two song texts.
+type LineDiff struct {
+	Operation DiffOperation // How the line changed (unchanged, added, or removed)
+	Line      string        // Content of the line
+}
+
+// NumberedLine represents a single line of a song text along with its line number.
+type NumberedLine struct {
+	Number int    // Number of the line, starting at 1
+	Text   string // Content of the line
+}
+
+// LibraryStats represents counts describing the whole song library.
+type LibraryStats struct {
+	Songs              uint64 // Number of songs
+	Groups             uint64 // Number of distinct groups
+	SongsMissingDetail uint64 // Number of songs without a release date, text, or link
+}
+
+// DecadeCount represents the number of songs released within a decade.
+type DecadeCount struct {
+	Decade int    // First year of the decade (e.g., 1970)
+	Count  uint64 // Number of songs released in the decade
+}
+
+// YearCount represents the number of songs released within a year.
+type YearCount struct {
+	Year  int    // Release year
+	Count uint64 // Number of songs released in the year
+}
+
+// SongRelease identifies a song of a group by its name and release date.
+type SongRelease struct {
+	Name        string    // Title of the song
+	ReleaseDate time.Time // Release date of the song
+}
+
+// GroupReleaseRange holds the earliest and the latest released songs of a group.
+type GroupReleaseRange struct {
+	GroupName string      // Name of the musical group or artist
+	First     SongRelease // Song with the earliest release date
+	Last      SongRelease // Song with the latest release date
+}
+
+// GroupFacets holds the distinct filterable values of the songs of a group.
+type GroupFacets struct {
+	GroupName    string      // Name of the musical group or artist
+	ReleaseYears []YearCount // Release years of the group's songs, earliest first
+}
+
+// AlphaIndexField defines the song fields that songs can be indexed by the first letter of.
+const (
+	AlphaIndexGroupField AlphaIndexField = iota // Sort name of the group, counting distinct groups
+	AlphaIndexSongField                         // Name of the song, counting songs
+)
+
+// AlphaIndexField represents the type for specifying the field to index songs by.
+type AlphaIndexField int
+
+// AlphaIndexOther is the letter of an alphabetical index grouping names that don't start with a letter.
+const AlphaIndexOther = "#"
+
+// LetterCount represents the number of groups or songs whose names start with a letter.
+type LetterCount struct {
+	Letter string // Uppercased first letter, or AlphaIndexOther
+	Count  uint64 // Number of groups or songs starting with the letter
+}
+
+// FacetField defines the song fields that matching songs can be counted by.
+const (
+	FacetReleaseYearField FacetField = iota
+	FacetGroupNameField
+)
+
+// FacetField represents the type for specifying the field to count songs by.
+type FacetField int
+
+// FacetCount represents the number of songs sharing a value of a facet field.
+type FacetCount struct {
+	Value string // Value of the facet field, such as a release year or a group name
+	Count uint64 // Number of songs with the value
+}
+
 // SongFilterField defines the various fields that can be used to filter song queries.
 const (
 	SongGroupNameFilterField SongFilterField = iota
@@ -46,6 +282,16 @@ const (
 	SongReleaseDateAfterFilterField
 	SongReleaseDateBeforeFilterField
 	SongTextFilterField
+	SongReleaseDateMissingFilterField
+	SongTextMissingFilterField
+	SongLinkMissingFilterField
+	SongMinTextLenFilterField
+	SongMaxTextLenFilterField
+	SongDetailStatusFilterField
+	SongEditedFilterField
+	SongTextRegexFilterField
+	SongGroupFilterField
+	SongImportFilterField
 )
 
 // SongFilterField represents the type for specifying different song filter fields.
@@ -63,6 +309,40 @@ const (
 	DefaultLimit  uint64 = 20
 )
 
+// Limits for fetching the most recently added songs.
+const (
+	DefaultRecentLimit uint64 = 10
+	MaxRecentLimit     uint64 = 50
+)
+
+// Limits for name suggestions.
+const (
+	DefaultSuggestLimit   uint64 = 10
+	MaxSuggestLimit       uint64 = 25
+	MinSuggestQueryLength int    = 2
+)
+
+// MaxVerseMatchContext is the maximum number of verses returned before and after every verse matching a search.
+const MaxVerseMatchContext = 10
+
+// SuggestField defines the song fields that names can be suggested for.
+const (
+	SuggestGroupNameField SuggestField = iota
+	SuggestSongNameField
+)
+
+// SuggestField represents the type for specifying the field to suggest names for.
+type SuggestField int
+
+// TextGranularity defines the units song text is broken into.
+const (
+	VerseGranularity TextGranularity = iota // Verses separated by blank lines
+	LineGranularity                         // Single non-blank lines
+)
+
+// TextGranularity represents the type for specifying how song text is broken down.
+type TextGranularity int
+
 // Pagination is used to control the pagination of query results by specifying the page number
 // and the number of items per page (limit).
 type Pagination struct {
@@ -70,6 +350,10 @@ type Pagination struct {
 	Limit  uint64 // Maximum number of items per page
 	Items  uint64 // The number of items in the current page
 	Total  uint64 // The total number of items across all pages
+
+	// SkipTotal leaves Total zero instead of counting the items across all pages, saving a query.
+	// It is honored by listing all songs (see FetchSongs).
+	SkipTotal bool
 }
 
 // IsEmpty checks if the pagination values are not set.
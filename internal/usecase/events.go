@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+// songEventBufferSize is the number of events buffered for every subscriber. Events published while
+// the buffer of a subscriber is full are dropped for that subscriber, so a slow client never blocks a mutation.
+const songEventBufferSize = 64
+
+// songEventBroker fans song events out to the subscribers that are currently connected.
+type songEventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan entity.SongEvent]struct{}
+}
+
+// newSongEventBroker creates a songEventBroker without subscribers.
+func newSongEventBroker() *songEventBroker {
+	return &songEventBroker{
+		subscribers: make(map[chan entity.SongEvent]struct{}),
+	}
+}
+
+// subscribe returns a channel receiving the events published from now on.
+// The channel is closed once ctx is done.
+func (b *songEventBroker) subscribe(ctx context.Context) <-chan entity.SongEvent {
+	ch := make(chan entity.SongEvent, songEventBufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+
+	return ch
+}
+
+// publish sends the events to every subscriber without waiting for them.
+func (b *songEventBroker) publish(events ...entity.SongEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		for _, event := range events {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
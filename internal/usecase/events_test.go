@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+func TestSongEventBroker(t *testing.T) {
+	t.Run("publishes to subscribers", func(t *testing.T) {
+		b := newSongEventBroker()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		first := b.subscribe(ctx)
+		second := b.subscribe(ctx)
+
+		event := entity.SongEvent{Type: entity.SongCreatedEvent, SongID: fixedUUID, OccurredAt: fixedTime}
+		b.publish(event)
+
+		assert.Equal(t, event, <-first)
+		assert.Equal(t, event, <-second)
+	})
+
+	t.Run("closes channel when context is done", func(t *testing.T) {
+		b := newSongEventBroker()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := b.subscribe(ctx)
+
+		cancel()
+
+		_, ok := <-events
+		assert.False(t, ok)
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		assert.Empty(t, b.subscribers)
+	})
+
+	t.Run("drops events for full subscribers", func(t *testing.T) {
+		b := newSongEventBroker()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := b.subscribe(ctx)
+
+		for range songEventBufferSize + 1 {
+			b.publish(entity.SongEvent{Type: entity.SongUpdatedEvent, SongID: fixedUUID})
+		}
+
+		assert.Len(t, events, songEventBufferSize)
+	})
+}
+
+func TestSongUseCase_SubscribeSongEvents(t *testing.T) {
+	uc, _, songRepoMock := initSongUseCase(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := uc.SubscribeSongEvents(ctx)
+
+	songRepoMock.
+		On("Update", context.Background(), fixedUUID, entity.Song{Name: "New Song"}).
+		Once().
+		Return(&entity.Song{ID: fixedUUID, Name: "New Song", UpdatedAt: fixedTime}, nil)
+	songRepoMock.
+		On("Delete", context.Background(), fixedUUID).
+		Once().
+		Return(int64(1), nil)
+
+	_, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{Name: "New Song"})
+	assert.NoError(t, err)
+
+	_, err = uc.RemoveSong(context.Background(), fixedUUID)
+	assert.NoError(t, err)
+
+	updated := <-events
+	assert.Equal(t, entity.SongUpdatedEvent, updated.Type)
+	assert.Equal(t, fixedUUID, updated.SongID)
+	assert.Equal(t, fixedTime, updated.OccurredAt)
+
+	deleted := <-events
+	assert.Equal(t, entity.SongDeletedEvent, deleted.Type)
+	assert.Equal(t, fixedUUID, deleted.SongID)
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+// linkChecker defines the interface for requesting song links to find out whether they still lead anywhere.
+type linkChecker interface {
+	CheckLink(ctx context.Context, link string) (int, error)
+}
+
+// defaultLinkCheckConcurrency is the number of links checked at once when it is not set with WithLinkChecker.
+const defaultLinkCheckConcurrency = 8
+
+// linkChecking holds the settings and the state of checking the stored song links.
+type linkChecking struct {
+	checker     linkChecker
+	concurrency int
+	running     atomic.Bool
+}
+
+// WithLinkChecker enables checking the links of all stored songs with StartLinkCheck, requesting up to
+// concurrency links at once. Non-positive concurrency falls back to the default.
+func WithLinkChecker(checker linkChecker, concurrency int) Option {
+	return func(uc *SongUseCase) {
+		uc.linkCheck.checker = checker
+		uc.linkCheck.concurrency = concurrency
+		if concurrency <= 0 {
+			uc.linkCheck.concurrency = defaultLinkCheckConcurrency
+		}
+	}
+}
+
+// StartLinkCheck starts checking the links of all stored songs in the background and returns without waiting
+// for it. The job runs with ctx, so it must outlive the caller's request; done, if set, is called with the number
+// of checked links and the error of the job once it ends. Only one job runs at a time: starting another while one
+// is running fails with entity.ErrLinkCheckRunning.
+func (uc *SongUseCase) StartLinkCheck(ctx context.Context, done func(checked int64, err error)) error {
+	const op = "usecase.StartLinkCheck"
+
+	if uc.linkCheck.checker == nil {
+		return fmt.Errorf("%s: link checker not configured", op)
+	}
+
+	if !uc.linkCheck.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("%s: %w", op, entity.ErrLinkCheckRunning)
+	}
+
+	go func() {
+		defer uc.linkCheck.running.Store(false)
+
+		checked, err := uc.CheckLinks(ctx)
+		if done != nil {
+			done(checked, err)
+		}
+	}()
+
+	return nil
+}
+
+// CheckLinks requests the link of every stored song that has one and stores the outcomes, batch by batch.
+// A link that can't be requested is stored with a zero status code. It returns the number of checked links,
+// counting those of the batches stored before an error, or an error if reading songs or storing the outcomes fails.
+func (uc *SongUseCase) CheckLinks(ctx context.Context) (int64, error) {
+	const op = "usecase.CheckLinks"
+
+	if uc.linkCheck.checker == nil {
+		return 0, fmt.Errorf("%s: link checker not configured", op)
+	}
+
+	var checked int64
+
+	err := uc.walkSongs(ctx, walkSongsBatchSize, func(songs []*entity.Song) error {
+		checks := uc.checkLinks(ctx, songs)
+		if len(checks) == 0 {
+			return nil
+		}
+
+		if err := uc.songRepo.SaveLinkChecks(ctx, checks); err != nil {
+			return fmt.Errorf("failed to store link checks: %w", err)
+		}
+
+		checked += int64(len(checks))
+		return nil
+	})
+	if err != nil {
+		return checked, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checked, nil
+}
+
+// checkLinks requests the links of the songs that have one, up to the configured number at once.
+// The outcomes are returned in the order of the songs.
+func (uc *SongUseCase) checkLinks(ctx context.Context, songs []*entity.Song) []entity.LinkCheck {
+	checks := make([]entity.LinkCheck, 0, len(songs))
+	for _, song := range songs {
+		if song.SongDetail.Link != "" {
+			checks = append(checks, entity.LinkCheck{SongID: song.ID, Link: song.SongDetail.Link})
+		}
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, uc.linkCheck.concurrency)
+	)
+
+	for i := range checks {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(check *entity.LinkCheck) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			// A link that can't be requested keeps the zero status code.
+			check.StatusCode, _ = uc.linkCheck.checker.CheckLink(ctx, check.Link)
+		}(&checks[i])
+	}
+
+	wg.Wait()
+
+	return checks
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/mocks/usecase"
+)
+
+func TestSongUseCase_CheckLinks(t *testing.T) {
+	ids := []uuid.UUID{
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174001"),
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174002"),
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174003"),
+	}
+
+	songs := []*entity.Song{
+		{ID: ids[0], SongDetail: entity.SongDetail{Link: "https://example.com/ok"}},
+		{ID: ids[1]},
+		{ID: ids[2], SongDetail: entity.SongDetail{Link: "https://example.com/unreachable"}},
+	}
+
+	initUseCase := func(t *testing.T) (*SongUseCase, *usecase.MockLinkChecker, *usecase.MockSongRepository) {
+		linkCheckerMock := usecase.NewMockLinkChecker(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(usecase.NewMockMusicInfoAPI(t), songRepoMock, WithLinkChecker(linkCheckerMock, 2))
+
+		return uc, linkCheckerMock, songRepoMock
+	}
+
+	t.Run("link checker not configured", func(t *testing.T) {
+		uc := NewSongUseCase(usecase.NewMockMusicInfoAPI(t), usecase.NewMockSongRepository(t))
+
+		checked, err := uc.CheckLinks(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "link checker not configured")
+		assert.Zero(t, checked)
+	})
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, linkCheckerMock, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(walkSongsBatchSize)).
+			Once().
+			Return(songs, nil)
+		linkCheckerMock.
+			On("CheckLink", context.Background(), mock.Anything).
+			Return(http.StatusOK, nil)
+		songRepoMock.
+			On("SaveLinkChecks", context.Background(), mock.Anything).
+			Once().
+			Return(errors.New("unknown error"))
+
+		checked, err := uc.CheckLinks(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to store link checks")
+		assert.Zero(t, checked)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, linkCheckerMock, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(walkSongsBatchSize)).
+			Once().
+			Return(songs, nil)
+		linkCheckerMock.
+			On("CheckLink", context.Background(), "https://example.com/ok").
+			Once().
+			Return(http.StatusOK, nil)
+		linkCheckerMock.
+			On("CheckLink", context.Background(), "https://example.com/unreachable").
+			Once().
+			Return(0, errors.New("connection refused"))
+		songRepoMock.
+			On("SaveLinkChecks", context.Background(), []entity.LinkCheck{
+				{SongID: ids[0], Link: "https://example.com/ok", StatusCode: http.StatusOK},
+				{SongID: ids[2], Link: "https://example.com/unreachable", StatusCode: 0},
+			}).
+			Once().
+			Return(nil)
+
+		checked, err := uc.CheckLinks(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), checked)
+	})
+}
+
+func TestSongUseCase_StartLinkCheck(t *testing.T) {
+	t.Run("link checker not configured", func(t *testing.T) {
+		uc := NewSongUseCase(usecase.NewMockMusicInfoAPI(t), usecase.NewMockSongRepository(t))
+
+		err := uc.StartLinkCheck(context.Background(), nil)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "link checker not configured")
+	})
+
+	t.Run("runs in background once at a time", func(t *testing.T) {
+		linkCheckerMock := usecase.NewMockLinkChecker(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(usecase.NewMockMusicInfoAPI(t), songRepoMock, WithLinkChecker(linkCheckerMock, 0))
+
+		release := make(chan time.Time)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(walkSongsBatchSize)).
+			Once().
+			WaitUntil(release).
+			Return([]*entity.Song{{ID: fixedUUID, SongDetail: entity.SongDetail{Link: "https://example.com"}}}, nil)
+		linkCheckerMock.
+			On("CheckLink", context.Background(), "https://example.com").
+			Once().
+			Return(http.StatusNotFound, nil)
+		songRepoMock.
+			On("SaveLinkChecks", context.Background(), []entity.LinkCheck{
+				{SongID: fixedUUID, Link: "https://example.com", StatusCode: http.StatusNotFound},
+			}).
+			Once().
+			Return(nil)
+
+		type result struct {
+			checked int64
+			err     error
+		}
+		done := make(chan result, 1)
+
+		err := uc.StartLinkCheck(context.Background(), func(checked int64, err error) {
+			done <- result{checked: checked, err: err}
+		})
+		assert.NoError(t, err)
+
+		err = uc.StartLinkCheck(context.Background(), nil)
+		assert.ErrorIs(t, err, entity.ErrLinkCheckRunning)
+
+		close(release)
+
+		res := <-done
+		assert.NoError(t, res.err)
+		assert.Equal(t, int64(1), res.checked)
+	})
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+// linkNormalization holds the rules applied to song links before they are saved.
+type linkNormalization struct {
+	enabled        bool
+	trackingParams []string
+	upgradeHTTPS   bool
+	keepOriginal   bool
+}
+
+// normalize rewrites a link to its normalized form: the host is lowercased, tracking query parameters are
+// removed, and http is upgraded to https when configured. Links that can't be parsed as absolute URLs
+// are returned unchanged.
+func (n linkNormalization) normalize(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return link
+	}
+
+	u.Host = strings.ToLower(u.Host)
+
+	if n.upgradeHTTPS && strings.EqualFold(u.Scheme, "http") {
+		u.Scheme = "https"
+	}
+
+	if u.RawQuery != "" {
+		var kept []string
+		for _, param := range strings.Split(u.RawQuery, "&") {
+			name, _, _ := strings.Cut(param, "=")
+			if !n.isTrackingParam(name) {
+				kept = append(kept, param)
+			}
+		}
+		u.RawQuery = strings.Join(kept, "&")
+	}
+
+	return u.String()
+}
+
+// isTrackingParam reports whether a query parameter is a tracking parameter. Tracking parameters ending
+// with * match every parameter with the preceding prefix, such as utm_* matching utm_source. Names are
+// compared case-insensitively.
+func (n linkNormalization) isTrackingParam(name string) bool {
+	if unescaped, err := url.QueryUnescape(name); err == nil {
+		name = unescaped
+	}
+
+	for _, param := range n.trackingParams {
+		if prefix, ok := strings.CutSuffix(param, "*"); ok {
+			if len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+				return true
+			}
+			continue
+		}
+
+		if strings.EqualFold(name, param) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apply normalizes the link of a song when normalization is enabled, keeping the link as it was received
+// in OriginalLink when configured. Songs without a link are left unchanged.
+func (n linkNormalization) apply(song entity.Song) entity.Song {
+	if !n.enabled || song.SongDetail.Link == "" {
+		return song
+	}
+
+	if n.keepOriginal {
+		song.OriginalLink = song.SongDetail.Link
+	}
+	song.SongDetail.Link = n.normalize(song.SongDetail.Link)
+
+	return song
+}
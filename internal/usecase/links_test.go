@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/mocks/usecase"
+)
+
+func TestLinkNormalization_Normalize(t *testing.T) {
+	n := linkNormalization{
+		enabled:        true,
+		trackingParams: []string{"utm_*", "fbclid"},
+	}
+
+	t.Run("lowercases host", func(t *testing.T) {
+		assert.Equal(t, "https://example.com/Songs/HeyJude", n.normalize("https://Example.COM/Songs/HeyJude"))
+	})
+
+	t.Run("strips tracking params", func(t *testing.T) {
+		assert.Equal(t, "https://example.com/song?id=1&lang=en", n.normalize("https://example.com/song?utm_source=feed&id=1&FBCLID=abc&lang=en&UTM_Medium=mail"))
+	})
+
+	t.Run("drops query of tracking params only", func(t *testing.T) {
+		assert.Equal(t, "https://example.com/song", n.normalize("https://example.com/song?utm_source=feed"))
+	})
+
+	t.Run("keeps http without upgrade", func(t *testing.T) {
+		assert.Equal(t, "http://example.com/song", n.normalize("http://example.com/song"))
+	})
+
+	t.Run("upgrades http to https", func(t *testing.T) {
+		n := n
+		n.upgradeHTTPS = true
+
+		assert.Equal(t, "https://example.com/song", n.normalize("http://example.com/song"))
+		assert.Equal(t, "ftp://example.com/song", n.normalize("ftp://example.com/song"))
+	})
+
+	t.Run("leaves malformed links unchanged", func(t *testing.T) {
+		assert.Equal(t, "example.com/song?utm_source=feed", n.normalize("example.com/song?utm_source=feed"))
+		assert.Equal(t, "https://exa mple.com/%zz", n.normalize("https://exa mple.com/%zz"))
+	})
+}
+
+func TestSongUseCase_LinkNormalization(t *testing.T) {
+	const (
+		rawLink        = "http://Example.com/song?utm_source=feed&id=7"
+		normalizedLink = "https://example.com/song?id=7"
+	)
+
+	initUseCase := func(t *testing.T, opts ...Option) (*SongUseCase, *usecase.MockMusicInfoAPI, *usecase.MockSongRepository) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, opts...)
+
+		return uc, musicInfoAPIMock, songRepoMock
+	}
+
+	t.Run("add song", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t, WithLinkNormalization([]string{"utm_*"}, true))
+
+		song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Link: rawLink}, nil)
+
+		songRepoMock.
+			On("Save", mock.Anything, entity.Song{
+				GroupName:    "Test Group",
+				Name:         "Test Song",
+				SortName:     "Test Group",
+				SongDetail:   entity.SongDetail{Link: normalizedLink},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				DetailStatus: entity.DetailStatusFound,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("modify song keeping original", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t, WithLinkNormalization([]string{"utm_*"}, true), WithOriginalLinks())
+
+		songRepoMock.
+			On("Update", context.Background(), fixedUUID, entity.Song{
+				SongDetail:   entity.SongDetail{Link: normalizedLink},
+				OriginalLink: rawLink,
+				DetailSource: entity.DetailSourceClient,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{Link: rawLink},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t, WithOriginalLinks())
+
+		songRepoMock.
+			On("Update", context.Background(), fixedUUID, entity.Song{
+				SongDetail:   entity.SongDetail{Link: rawLink},
+				DetailSource: entity.DetailSourceClient,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{Link: rawLink},
+		})
+
+		assert.NoError(t, err)
+	})
+}
@@ -2,11 +2,19 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/text/unicode/norm"
 )
 
 // musicInfoAPI defines the interface for fetching song information from an external Music Info API.
@@ -17,78 +25,1041 @@ type musicInfoAPI interface {
 // songRepository defines the interface for song repository operations.
 type songRepository interface {
 	Save(ctx context.Context, song entity.Song) (*entity.Song, error)
+	SaveBatch(ctx context.Context, songs []entity.Song) ([]*entity.Song, error)
+	SaveWithinGroupLimit(ctx context.Context, song entity.Song, limit uint64) (*entity.Song, error)
+	SaveBatchWithinGroupLimit(ctx context.Context, songs []entity.Song, limit uint64) ([]*entity.Song, error)
 	GetAll(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)
+	Count(ctx context.Context, filters ...entity.SongFilter) (uint64, error)
+	ExistsByName(ctx context.Context, groupName, name string) (bool, error)
+	GetIncomplete(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)
+	GetRecent(ctx context.Context, limit uint64) ([]*entity.Song, error)
+	GetStaleDetails(ctx context.Context, staleBefore time.Time, limit uint64) ([]*entity.Song, error)
+	GetRecentlyUpdated(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)
+	GetWithBrokenLinks(ctx context.Context, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error)
+	GetSimilar(ctx context.Context, song entity.Song, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error)
+	Search(ctx context.Context, query string, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error)
+	CountByDecade(ctx context.Context) ([]entity.DecadeCount, error)
+	GetLibraryStats(ctx context.Context) (*entity.LibraryStats, error)
+	CountByFirstLetter(ctx context.Context, field entity.AlphaIndexField) ([]entity.LetterCount, error)
+	CountFacet(ctx context.Context, field entity.FacetField, filters ...entity.SongFilter) ([]entity.FacetCount, error)
+	GetGroupFacets(ctx context.Context, groupName string) (*entity.GroupFacets, error)
+	GetGroupReleaseRange(ctx context.Context, groupName string) (*entity.GroupReleaseRange, error)
+	Suggest(ctx context.Context, field entity.SuggestField, prefix string, limit uint64) ([]string, error)
 	GetByID(ctx context.Context, songID uuid.UUID) (*entity.Song, error)
+	GetByIDs(ctx context.Context, songIDs []uuid.UUID) ([]*entity.Song, error)
 	Update(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error)
+	UpdateBatch(ctx context.Context, updates []entity.SongUpdate) ([]*entity.Song, error)
+	UpdateWhere(ctx context.Context, song entity.Song, filters ...entity.SongFilter) ([]uuid.UUID, error)
+	TouchWhere(ctx context.Context, filters ...entity.SongFilter) ([]uuid.UUID, error)
+	GetAfter(ctx context.Context, afterID uuid.UUID, limit uint64, filters ...entity.SongFilter) ([]*entity.Song, error)
+	UpdateVerseCounts(ctx context.Context, counts []entity.SongVerseCount) (int64, error)
+	UpdateSortNames(ctx context.Context, sortNames []entity.SongSortName) (int64, error)
+	SaveLinkChecks(ctx context.Context, checks []entity.LinkCheck) error
 	Delete(ctx context.Context, songID uuid.UUID) (int64, error)
+	DeleteBatch(ctx context.Context, songIDs []uuid.UUID) ([]uuid.UUID, error)
+	DeleteWhere(ctx context.Context, filters ...entity.SongFilter) ([]uuid.UUID, error)
 }
 
+// defaultResplitBatchSize is the number of songs read and updated at once by ResplitVerses and RecomputeSortNames.
+const defaultResplitBatchSize = 500
+
+// defaultDetailStaleAfter is how long the details of a song are considered fresh by FetchStaleSongs.
+const defaultDetailStaleAfter = 30 * 24 * time.Hour
+
+// addSongTimeout bounds an addition shared by coalesced AddSong calls, which doesn't end with the call that
+// started it.
+const addSongTimeout = time.Minute
+
+// walkSongsBatchSize is the number of songs read at once by WalkSongs and CheckLinks.
+const walkSongsBatchSize = 1000
+
 // SongUseCase encapsulates the business logic for managing songs.
 type SongUseCase struct {
-	musicInfoApi musicInfoAPI
-	songRepo     songRepository
+	musicInfoApi        musicInfoAPI
+	songRepo            songRepository
+	sortNameArticles    []string
+	maxOffsetOverrun    uint64
+	maxGroupSongs       uint64
+	maxVerses           int
+	musicInfoTimeout    time.Duration
+	resplitBatch        uint64
+	detailStaleAfter    time.Duration
+	links               linkNormalization
+	stripControls       bool
+	releaseYearFromText bool
+	linkCheck           linkChecking
+	events              *songEventBroker
+	random              *rand.Rand
+	randomMu            sync.Mutex
+	newImportID         func() uuid.UUID
+	checkDuplicates     bool
+	adding              singleflight.Group
+}
+
+// Option represents a functional option for configuring the SongUseCase.
+type Option func(*SongUseCase)
+
+// WithSortNameArticles sets the leading articles (e.g. "The", "A", "An") that are stripped
+// from group names when computing their sort names.
+func WithSortNameArticles(articles ...string) Option {
+	return func(uc *SongUseCase) {
+		uc.sortNameArticles = articles
+	}
+}
+
+// WithMaxOffsetOverrun makes paginated song listings fail with entity.ErrOffsetOutOfRange when the requested
+// offset exceeds the total number of songs by more than overrun. Smaller overruns still return an empty page.
+// Zero, the default, disables the check.
+func WithMaxOffsetOverrun(overrun uint64) Option {
+	return func(uc *SongUseCase) {
+		uc.maxOffsetOverrun = overrun
+	}
+}
+
+// WithMaxSongsPerGroup makes adding and importing songs fail with entity.ErrGroupSongLimitExceeded when a group
+// would end up with more than limit songs. Zero, the default, disables the limit.
+func WithMaxSongsPerGroup(limit uint64) Option {
+	return func(uc *SongUseCase) {
+		uc.maxGroupSongs = limit
+	}
+}
+
+// WithMusicInfoTimeoutFallback bounds the music info API lookup of AddSong by timeout. When the lookup times out,
+// the song is still saved without details and marked with entity.DetailStatusFailed, so it is listed among
+// incomplete songs to be backfilled later. Other lookup errors still fail. Zero, the default, disables it.
+func WithMusicInfoTimeoutFallback(timeout time.Duration) Option {
+	return func(uc *SongUseCase) {
+		uc.musicInfoTimeout = timeout
+	}
+}
+
+// WithLinkNormalization makes song links be normalized when songs are added or modified: the host is lowercased
+// and the given tracking query parameters are removed. A parameter ending with *, such as utm_*, matches every
+// parameter with that prefix. When upgradeHTTPS is set, http links are rewritten to https.
+func WithLinkNormalization(trackingParams []string, upgradeHTTPS bool) Option {
+	return func(uc *SongUseCase) {
+		uc.links.enabled = true
+		uc.links.trackingParams = trackingParams
+		uc.links.upgradeHTTPS = upgradeHTTPS
+	}
+}
+
+// WithOriginalLinks makes the link of a song be kept as it was received, before link normalization, in OriginalLink.
+// It has no effect without WithLinkNormalization.
+func WithOriginalLinks() Option {
+	return func(uc *SongUseCase) {
+		uc.links.keepOriginal = true
+	}
+}
+
+// WithDuplicateCheck makes AddSong look for a song of the same group with the same name before fetching details
+// and saving, and fail with entity.ErrSongAlreadyExists when there is one. It costs a query per added song.
+//
+// The check doesn't lock anything: a song added by another instance, or by an import, between the check
+// and the insert is not detected, so duplicates remain possible under concurrent writes. Concurrent AddSong
+// calls for the same song within an instance are coalesced and don't race.
+func WithDuplicateCheck() Option {
+	return func(uc *SongUseCase) {
+		uc.checkDuplicates = true
+	}
+}
+
+// WithResplitBatchSize sets the number of songs read and updated at once by ResplitVerses and RecomputeSortNames.
+// Zero keeps the default.
+func WithResplitBatchSize(size uint64) Option {
+	return func(uc *SongUseCase) {
+		if size > 0 {
+			uc.resplitBatch = size
+		}
+	}
+}
+
+// WithDetailStaleAfter sets how long after a music info API lookup the details of a song are considered stale
+// by FetchStaleSongs. Non-positive durations keep the default of 30 days.
+func WithDetailStaleAfter(d time.Duration) Option {
+	return func(uc *SongUseCase) {
+		if d > 0 {
+			uc.detailStaleAfter = d
+		}
+	}
+}
+
+// WithControlCharStripping makes the control characters be removed from the text of songs when they are added,
+// imported, or modified, such as those pasted along with lyrics copied from PDFs. Line breaks and tabs are kept.
+func WithControlCharStripping() Option {
+	return func(uc *SongUseCase) {
+		uc.stripControls = true
+	}
+}
+
+// WithReleaseYearFromText makes songs added without a release date from the music info API get one from
+// a copyright notice in their text, such as "(c) 1971", set to January 1 of that year. Such songs have
+// entity.DetailSourceHeuristic as their detail source.
+func WithReleaseYearFromText() Option {
+	return func(uc *SongUseCase) {
+		uc.releaseYearFromText = true
+	}
+}
+
+// WithRandomSource makes random picks, such as the verse returned by FetchRandomVerse, be drawn from src
+// instead of the shared random generator, so that they can be reproduced with a seeded source.
+func WithRandomSource(src rand.Source) Option {
+	return func(uc *SongUseCase) {
+		if src != nil {
+			uc.random = rand.New(src)
+		}
+	}
+}
+
+// WithImportIDGenerator sets the function generating the IDs that ImportSongs tags imported songs with,
+// uuid.New by default.
+func WithImportIDGenerator(newID func() uuid.UUID) Option {
+	return func(uc *SongUseCase) {
+		if newID != nil {
+			uc.newImportID = newID
+		}
+	}
+}
+
+// NewSongUseCase creates a new instance of SongUseCase with the provided musicInfoAPI and songRepository implementations
+// and applies the provided configuration options.
+func NewSongUseCase(musicInfoAPI musicInfoAPI, songRepo songRepository, opts ...Option) *SongUseCase {
+	uc := &SongUseCase{
+		musicInfoApi:     musicInfoAPI,
+		songRepo:         songRepo,
+		resplitBatch:     defaultResplitBatchSize,
+		detailStaleAfter: defaultDetailStaleAfter,
+		events:           newSongEventBroker(),
+		newImportID:      uuid.New,
+	}
+
+	for _, opt := range opts {
+		opt(uc)
+	}
+
+	return uc
+}
+
+// sortName computes the name used for alphabetical ordering of a group by stripping the first matching
+// leading article, compared ignoring case, with all the whitespace following it, like migration 000002 does.
+// The display name is left untouched.
+func (uc *SongUseCase) sortName(groupName string) string {
+	for _, article := range uc.sortNameArticles {
+		if len(groupName) <= len(article) || !strings.EqualFold(groupName[:len(article)], article) {
+			continue
+		}
+
+		rest := groupName[len(article):]
+		if r, _ := utf8.DecodeRuneInString(rest); !unicode.IsSpace(r) {
+			continue
+		}
+
+		if rest = strings.TrimLeftFunc(rest, unicode.IsSpace); rest != "" {
+			return rest
+		}
+	}
+
+	return groupName
+}
+
+// SubscribeSongEvents returns a channel receiving an event for every song added, modified, or removed
+// from now on. The channel is closed once ctx is done. Events are dropped for subscribers that fall behind.
+func (uc *SongUseCase) SubscribeSongEvents(ctx context.Context) <-chan entity.SongEvent {
+	return uc.events.subscribe(ctx)
+}
+
+// publishSongEvents reports a change of the songs to the event subscribers.
+func (uc *SongUseCase) publishSongEvents(eventType entity.SongEventType, songs ...*entity.Song) {
+	events := make([]entity.SongEvent, 0, len(songs))
+
+	for _, song := range songs {
+		if song == nil {
+			continue
+		}
+
+		occurredAt := song.UpdatedAt
+		if occurredAt.IsZero() {
+			occurredAt = time.Now()
+		}
+
+		events = append(events, entity.SongEvent{
+			Type:       eventType,
+			SongID:     song.ID,
+			OccurredAt: occurredAt,
+		})
+	}
+
+	uc.events.publish(events...)
+}
+
+// publishSongIDEvents reports a change of the songs with the given IDs to the event subscribers, for changes
+// made by a single statement that doesn't return the songs themselves.
+func (uc *SongUseCase) publishSongIDEvents(eventType entity.SongEventType, songIDs []uuid.UUID) {
+	events := make([]entity.SongEvent, 0, len(songIDs))
+	now := time.Now()
+
+	for _, songID := range songIDs {
+		events = append(events, entity.SongEvent{
+			Type:       eventType,
+			SongID:     songID,
+			OccurredAt: now,
+		})
+	}
+
+	uc.events.publish(events...)
+}
+
+// checkOffset reports an entity.ErrOffsetOutOfRange error when the offset of a fetched page exceeds
+// the total by more than the allowed overrun.
+func (uc *SongUseCase) checkOffset(pagination *entity.Pagination) error {
+	if uc.maxOffsetOverrun == 0 || pagination.SkipTotal || pagination.Offset <= pagination.Total+uc.maxOffsetOverrun {
+		return nil
+	}
+
+	return fmt.Errorf("%w: offset %d, total %d", entity.ErrOffsetOutOfRange, pagination.Offset, pagination.Total)
+}
+
+// WithMaxVerses makes adding and importing songs fail with entity.ErrTooManyVerses when the text of a song
+// splits into more than limit verses, as malformed lyrics with every line separated by a blank one do.
+// Zero, the default, disables the limit.
+func WithMaxVerses(limit int) Option {
+	return func(uc *SongUseCase) {
+		uc.maxVerses = limit
+	}
+}
+
+// AddSong creates a new song by fetching its details from the music info API and saving it to the repository.
+// When the timeout fallback is enabled, a song whose lookup times out is saved without details.
+// It returns the saved song or an error if the process fails.
+//
+// Concurrent calls adding the same song, with group and song names compared ignoring case and surrounding
+// spaces, are coalesced: the song is fetched and saved once and every caller gets the same result. The shared
+// addition keeps the values of the first call's context but not its cancellation, so that the other callers
+// aren't failed by it; it is bounded by addSongTimeout instead. Every caller still returns as soon as its own
+// context is done. Group and song names are normalized first (see normalizeNames).
+func (uc *SongUseCase) AddSong(ctx context.Context, song entity.Song) (*entity.Song, error) {
+	const op = "usecase.AddSong"
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	song = normalizeNames(song)
+
+	added := uc.adding.DoChan(addSongKey(song), func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), addSongTimeout)
+		defer cancel()
+
+		return uc.addSong(ctx, song)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s: %w", op, ctx.Err())
+	case res := <-added:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		return res.Val.(*entity.Song), nil
+	}
+}
+
+// normalizeNames converts the group and song names to Unicode normalization form C, so that a name typed
+// with combining characters is stored, and searched for, like the same name typed with precomposed ones.
+// Names without combining characters, such as emoji and CJK names, are unchanged.
+func normalizeNames(song entity.Song) entity.Song {
+	song.GroupName = norm.NFC.String(song.GroupName)
+	song.Name = norm.NFC.String(song.Name)
+
+	return song
+}
+
+// addSongKey returns the key coalescing concurrent additions of a song.
+func addSongKey(song entity.Song) string {
+	normalize := func(name string) string {
+		return strings.ToLower(strings.TrimSpace(name))
+	}
+
+	return normalize(song.GroupName) + "\x00" + normalize(song.Name)
+}
+
+// addSong fetches the details of a song and saves it, for AddSong.
+func (uc *SongUseCase) addSong(ctx context.Context, song entity.Song) (*entity.Song, error) {
+	const op = "usecase.AddSong"
+
+	if uc.checkDuplicates {
+		exists, err := uc.songRepo.ExistsByName(ctx, song.GroupName, song.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to check for existing song: %w", op, err)
+		}
+		if exists {
+			return nil, fmt.Errorf("%s: %w", op, entity.ErrSongAlreadyExists)
+		}
+	}
+
+	songDetail, err := uc.fetchSongInfo(ctx, song)
+	switch {
+	case err == nil:
+		song.SongDetail = *songDetail
+		song.DetailSource = entity.DetailSourceMusicInfoAPI
+		song.DetailStatus = entity.DetailStatusFound
+		song = uc.guessReleaseDate(song)
+	case uc.isMusicInfoTimeout(ctx, err):
+		song.DetailStatus = entity.DetailStatusFailed
+	default:
+		return nil, fmt.Errorf("%s: failed to fetch song detail from music info api: %w", op, err)
+	}
+
+	song.SortName = uc.sortName(song.GroupName)
+	song = uc.links.apply(song)
+	song = uc.sanitizeText(song)
+
+	if err := uc.checkVerseCount(song); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	savedSong, err := uc.saveSong(ctx, song)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to add song: %w", op, err)
+	}
+
+	uc.publishSongEvents(entity.SongCreatedEvent, savedSong)
+
+	return savedSong, nil
+}
+
+// saveSong saves a new song, enforcing the limit of songs per group when it is set.
+func (uc *SongUseCase) saveSong(ctx context.Context, song entity.Song) (*entity.Song, error) {
+	if uc.maxGroupSongs == 0 {
+		return uc.songRepo.Save(ctx, song)
+	}
+
+	return uc.songRepo.SaveWithinGroupLimit(ctx, song, uc.maxGroupSongs)
+}
+
+// saveSongs saves new songs in a single batch, enforcing the limit of songs per group when it is set.
+func (uc *SongUseCase) saveSongs(ctx context.Context, songs []entity.Song) ([]*entity.Song, error) {
+	if uc.maxGroupSongs == 0 {
+		return uc.songRepo.SaveBatch(ctx, songs)
+	}
+
+	return uc.songRepo.SaveBatchWithinGroupLimit(ctx, songs, uc.maxGroupSongs)
+}
+
+// fetchSongInfo fetches the details of a song from the music info API, bounding the lookup
+// by the timeout of the timeout fallback when it is enabled.
+func (uc *SongUseCase) fetchSongInfo(ctx context.Context, song entity.Song) (*entity.SongDetail, error) {
+	if uc.musicInfoTimeout <= 0 {
+		return uc.musicInfoApi.FetchSongInfo(ctx, song)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.musicInfoTimeout)
+	defer cancel()
+
+	return uc.musicInfoApi.FetchSongInfo(ctx, song)
+}
+
+// isMusicInfoTimeout reports whether the timeout fallback is enabled and the music info API lookup failed
+// because it timed out, while the request itself can still go on to save the song.
+func (uc *SongUseCase) isMusicInfoTimeout(ctx context.Context, err error) bool {
+	return uc.musicInfoTimeout > 0 && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil
+}
+
+// ImportSongs adds multiple songs to the repository in a single batch, all tagged with a new import ID
+// so that the import can be listed or rolled back (see RollbackImport) later. When fetchInfo is set, song details
+// are fetched from the music info API on a best-effort basis: songs whose details can't be fetched are saved without them.
+// It returns the saved songs or an error if the process fails.
+func (uc *SongUseCase) ImportSongs(ctx context.Context, songs []entity.Song, fetchInfo bool) ([]*entity.Song, error) {
+	const op = "usecase.ImportSongs"
+
+	importID := uc.newImportID()
+
+	for i := range songs {
+		songs[i] = normalizeNames(songs[i])
+
+		if fetchInfo {
+			songDetail, err := uc.musicInfoApi.FetchSongInfo(ctx, songs[i])
+			switch {
+			case err == nil:
+				songs[i].SongDetail = *songDetail
+				songs[i].DetailSource = entity.DetailSourceMusicInfoAPI
+				songs[i].DetailStatus = entity.DetailStatusFound
+			case errors.Is(err, entity.ErrSongInfoNotFound):
+				songs[i].DetailStatus = entity.DetailStatusNotFound
+			default:
+				songs[i].DetailStatus = entity.DetailStatusFailed
+			}
+		}
+
+		songs[i].ImportID = importID
+		songs[i].SortName = uc.sortName(songs[i].GroupName)
+		songs[i] = uc.sanitizeText(songs[i])
+
+		if err := uc.checkVerseCount(songs[i]); err != nil {
+			return nil, fmt.Errorf("%s: song %d: %w", op, i, err)
+		}
+	}
+
+	savedSongs, err := uc.saveSongs(ctx, songs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to import songs: %w", op, err)
+	}
+
+	uc.publishSongEvents(entity.SongCreatedEvent, savedSongs...)
+
+	return savedSongs, nil
+}
+
+// FetchSongs retrieves all songs from the repository that match the provided filter and pagination parameters.
+// It returns a slice of songs or an error if the retrieval fails or the offset is out of range (see WithMaxOffsetOverrun).
+func (uc *SongUseCase) FetchSongs(
+	ctx context.Context,
+	pagination entity.Pagination,
+	filters ...entity.SongFilter,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "usecase.FetchSongs"
+
+	songs, pgn, err := uc.songRepo.GetAll(ctx, pagination, filters...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to fetch songs: %w", op, err)
+	}
+
+	if err := uc.checkOffset(pgn); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, pgn, nil
+}
+
+// CountSongs counts the songs in the repository that match the provided filters without fetching them.
+// It returns the number of matching songs or an error if the count fails.
+func (uc *SongUseCase) CountSongs(ctx context.Context, filters ...entity.SongFilter) (uint64, error) {
+	const op = "usecase.CountSongs"
+
+	count, err := uc.songRepo.Count(ctx, filters...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to count songs: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// FetchSongFacets counts the songs matching the provided filters per value of every requested facet field.
+// It returns the counts by facet field or an error if any of the counts fails.
+func (uc *SongUseCase) FetchSongFacets(
+	ctx context.Context,
+	fields []entity.FacetField,
+	filters ...entity.SongFilter,
+) (map[entity.FacetField][]entity.FacetCount, error) {
+	const op = "usecase.FetchSongFacets"
+
+	facets := make(map[entity.FacetField][]entity.FacetCount, len(fields))
+
+	for _, field := range fields {
+		counts, err := uc.songRepo.CountFacet(ctx, field, filters...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to count songs by facet: %w", op, err)
+		}
+
+		facets[field] = counts
+	}
+
+	return facets, nil
+}
+
+// FetchIncompleteSongs retrieves songs that lack some of their details, such as release date, text, or link.
+// It returns a slice of songs, oldest first, or an error if the retrieval fails.
+func (uc *SongUseCase) FetchIncompleteSongs(
+	ctx context.Context,
+	pagination entity.Pagination,
+	filters ...entity.SongFilter,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "usecase.FetchIncompleteSongs"
+
+	songs, pgn, err := uc.songRepo.GetIncomplete(ctx, pagination, filters...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to fetch incomplete songs: %w", op, err)
+	}
+
+	if err := uc.checkOffset(pgn); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, pgn, nil
+}
+
+// FetchRecentSongs retrieves up to limit of the most recently added songs, newest first.
+// It returns a slice of songs or an error if the retrieval fails.
+func (uc *SongUseCase) FetchRecentSongs(ctx context.Context, limit uint64) ([]*entity.Song, error) {
+	const op = "usecase.FetchRecentSongs"
+
+	songs, err := uc.songRepo.GetRecent(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch recent songs: %w", op, err)
+	}
+
+	return songs, nil
+}
+
+// FetchRecentlyUpdatedSongs retrieves songs that match the provided filter and pagination parameters,
+// most recently updated first. It returns a slice of songs or an error if the retrieval fails.
+func (uc *SongUseCase) FetchRecentlyUpdatedSongs(
+	ctx context.Context,
+	pagination entity.Pagination,
+	filters ...entity.SongFilter,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "usecase.FetchRecentlyUpdatedSongs"
+
+	songs, pgn, err := uc.songRepo.GetRecentlyUpdated(ctx, pagination, filters...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to fetch recently updated songs: %w", op, err)
+	}
+
+	if err := uc.checkOffset(pgn); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, pgn, nil
+}
+
+// FetchStaleSongs retrieves up to limit songs whose details are stale (see WithDetailStaleAfter) or were never
+// looked up in the music info API, so that refreshing them can start with the ones most in need of it.
+// It returns the songs or an error if the retrieval fails.
+func (uc *SongUseCase) FetchStaleSongs(ctx context.Context, limit uint64) ([]*entity.Song, error) {
+	const op = "usecase.FetchStaleSongs"
+
+	songs, err := uc.songRepo.GetStaleDetails(ctx, time.Now().Add(-uc.detailStaleAfter), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch songs with stale details: %w", op, err)
+	}
+
+	return songs, nil
+}
+
+// FetchLibraryStats counts the songs, the groups, and the songs lacking some of their details in the library.
+// It returns the counts or an error if the retrieval fails.
+func (uc *SongUseCase) FetchLibraryStats(ctx context.Context) (*entity.LibraryStats, error) {
+	const op = "usecase.FetchLibraryStats"
+
+	stats, err := uc.songRepo.GetLibraryStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch library stats: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+// FetchDecadeStats counts the songs released in each decade, ordered from the earliest decade.
+// It returns the counts or an error if the retrieval fails.
+func (uc *SongUseCase) FetchDecadeStats(ctx context.Context) ([]entity.DecadeCount, error) {
+	const op = "usecase.FetchDecadeStats"
+
+	counts, err := uc.songRepo.CountByDecade(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch decade stats: %w", op, err)
+	}
+
+	return counts, nil
+}
+
+// FetchAlphaIndex counts the groups or the songs per uppercased first letter of their names, ordered by letter.
+// Names that don't start with a letter are counted under entity.AlphaIndexOther.
+// It returns the counts or an error if the retrieval fails.
+func (uc *SongUseCase) FetchAlphaIndex(ctx context.Context, field entity.AlphaIndexField) ([]entity.LetterCount, error) {
+	const op = "usecase.FetchAlphaIndex"
+
+	counts, err := uc.songRepo.CountByFirstLetter(ctx, field)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch alpha index: %w", op, err)
+	}
+
+	return counts, nil
+}
+
+// FetchGroupReleaseRange retrieves the earliest and latest released songs of a group.
+// It returns the range or an error if the group has no songs with a release date or if the retrieval fails.
+func (uc *SongUseCase) FetchGroupReleaseRange(ctx context.Context, groupName string) (*entity.GroupReleaseRange, error) {
+	const op = "usecase.FetchGroupReleaseRange"
+
+	releaseRange, err := uc.songRepo.GetGroupReleaseRange(ctx, groupName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch group release range: %w", op, err)
+	}
+
+	return releaseRange, nil
+}
+
+// FetchGroupFacets retrieves the distinct release years of the songs of a group, with their song counts.
+// It returns the facets or an error if the group has no songs or if the retrieval fails.
+func (uc *SongUseCase) FetchGroupFacets(ctx context.Context, groupName string) (*entity.GroupFacets, error) {
+	const op = "usecase.FetchGroupFacets"
+
+	facets, err := uc.songRepo.GetGroupFacets(ctx, groupName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch group facets: %w", op, err)
+	}
+
+	return facets, nil
+}
+
+// SuggestNames retrieves up to limit distinct group or song names starting with the given prefix.
+// It returns the names or an error if the retrieval fails.
+func (uc *SongUseCase) SuggestNames(ctx context.Context, field entity.SuggestField, prefix string, limit uint64) ([]string, error) {
+	const op = "usecase.SuggestNames"
+
+	names, err := uc.songRepo.Suggest(ctx, field, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to suggest names: %w", op, err)
+	}
+
+	return names, nil
+}
+
+// FetchSong retrieves a specific song by its ID.
+// It returns the song or an error if the retrieval fails.
+func (uc *SongUseCase) FetchSong(ctx context.Context, songID uuid.UUID) (*entity.Song, error) {
+	const op = "usecase.FetchSong"
+
+	song, err := uc.songRepo.GetByID(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	return song, nil
+}
+
+// FetchSongsWithBrokenLinks retrieves songs whose link is set but is not a well-formed http or https URL,
+// or whose link failed its last stored check.
+// It returns a slice of songs, oldest first, or an error if the retrieval fails.
+func (uc *SongUseCase) FetchSongsWithBrokenLinks(
+	ctx context.Context,
+	pagination entity.Pagination,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "usecase.FetchSongsWithBrokenLinks"
+
+	songs, pgn, err := uc.songRepo.GetWithBrokenLinks(ctx, pagination)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to fetch songs with broken links: %w", op, err)
+	}
+
+	if err := uc.checkOffset(pgn); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, pgn, nil
+}
+
+// FetchSimilarSongs retrieves songs similar to the song with the given ID, ranked by similarity: songs of the same
+// group first, then songs with similar names. It returns a slice of songs without the song itself,
+// or an error if the song does not exist or the retrieval fails.
+func (uc *SongUseCase) FetchSimilarSongs(
+	ctx context.Context,
+	songID uuid.UUID,
+	pagination entity.Pagination,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "usecase.FetchSimilarSongs"
+
+	song, err := uc.songRepo.GetByID(ctx, songID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	songs, pgn, err := uc.songRepo.GetSimilar(ctx, *song, pagination)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to fetch similar songs: %w", op, err)
+	}
+
+	return songs, pgn, nil
+}
+
+// SearchSongs retrieves the songs whose name, group name, or text match the free-text query, the most relevant
+// first, with name matches weighing most and text matches least. It returns a slice of songs or an error
+// if the retrieval fails or the offset is out of range (see WithMaxOffsetOverrun).
+func (uc *SongUseCase) SearchSongs(
+	ctx context.Context,
+	query string,
+	pagination entity.Pagination,
+) ([]*entity.Song, *entity.Pagination, error) {
+	const op = "usecase.SearchSongs"
+
+	songs, pgn, err := uc.songRepo.Search(ctx, norm.NFC.String(query), pagination)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to search songs: %w", op, err)
+	}
+
+	if err := uc.checkOffset(pgn); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, pgn, nil
+}
+
+// FetchSongWithVerses retrieves the text of a specific song by its ID, breaking it into verses or lines
+// depending on granularity and applying pagination if specified. With dedupe, runs of identical verses are
+// collapsed into one before paginating, and the number of repeats of every verse is reported. Stored text is not changed.
+// It returns the song with verses or an error if the retrieval fails.
+func (uc *SongUseCase) FetchSongWithVerses(
+	ctx context.Context,
+	songID uuid.UUID,
+	pagination entity.Pagination,
+	granularity entity.TextGranularity,
+	dedupe bool,
+) (*entity.SongWithVerses, *entity.Pagination, error) {
+	const op = "usecase.FetchSongText"
+
+	song, err := uc.songRepo.GetByID(ctx, songID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	songWithVerses, pgn := paginateVerses(song, pagination, granularity, dedupe)
+
+	return songWithVerses, pgn, nil
+}
+
+// FetchSongLines retrieves a specific song by its ID along with the lines of its text numbered from 1.
+// Blank lines separating verses are skipped and not counted, as with line granularity, unless numberBlank is set,
+// in which case they are returned with their own numbers so that numbers match the stored text.
+// It returns the song and its numbered lines or an error if the retrieval fails.
+func (uc *SongUseCase) FetchSongLines(
+	ctx context.Context,
+	songID uuid.UUID,
+	numberBlank bool,
+) (*entity.Song, []entity.NumberedLine, error) {
+	const op = "usecase.FetchSongLines"
+
+	song, err := uc.songRepo.GetByID(ctx, songID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	return song, numberLines(song.SongDetail.Text, numberBlank), nil
 }
 
-// NewSongUseCase creates a new instance of SongUseCase with the provided musicInfoAPI and songRepository implementations.
-func NewSongUseCase(musicInfoAPI musicInfoAPI, songRepo songRepository) *SongUseCase {
-	return &SongUseCase{
-		musicInfoApi: musicInfoAPI,
-		songRepo:     songRepo,
+// numberLines breaks song text into lines numbered from 1, skipping blank lines unless numberBlank is set.
+func numberLines(text string, numberBlank bool) []entity.NumberedLine {
+	lines := splitVerseLines(text)
+	if numberBlank {
+		lines = splitLines(text)
+	}
+
+	numbered := make([]entity.NumberedLine, 0, len(lines))
+	for i, line := range lines {
+		numbered = append(numbered, entity.NumberedLine{Number: i + 1, Text: line})
 	}
+
+	return numbered
 }
 
-// AddSong creates a new song by fetching its details from the music info API and saving it to the repository.
-// It returns the saved song or an error if the process fails.
-func (uc *SongUseCase) AddSong(ctx context.Context, song entity.Song) (*entity.Song, error) {
-	const op = "usecase.AddSong"
+// SearchSongVerses finds the verses of a song containing query, ignoring case. Every match comes with up to
+// contextVerses verses before and after it, fewer when the match is near the start or the end of the text.
+// It returns the matches in text order or an error if the song can't be fetched.
+func (uc *SongUseCase) SearchSongVerses(
+	ctx context.Context,
+	songID uuid.UUID,
+	query string,
+	contextVerses int,
+) ([]entity.VerseMatch, error) {
+	const op = "usecase.SearchSongVerses"
 
-	songDetail, err := uc.musicInfoApi.FetchSongInfo(ctx, song)
+	song, err := uc.songRepo.GetByID(ctx, songID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to fetch song detail from music info api: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	return matchVerses(splitVerses(song.SongDetail.Text), query, contextVerses), nil
+}
+
+// matchVerses returns the verses containing query, ignoring case, each with up to contextVerses verses around it.
+func matchVerses(verses []string, query string, contextVerses int) []entity.VerseMatch {
+	query = strings.ToLower(query)
+
+	var matches []entity.VerseMatch
+
+	for i, verse := range verses {
+		if query == "" || !strings.Contains(strings.ToLower(verse), query) {
+			continue
+		}
+
+		start := max(i-contextVerses, 0)
+		end := min(i+contextVerses+1, len(verses))
+
+		matches = append(matches, entity.VerseMatch{
+			Index:  i,
+			Start:  start,
+			Verses: verses[start:end],
+		})
 	}
 
-	song.SongDetail = *songDetail
+	return matches
+}
+
+// FetchRandomVerse retrieves a specific song by its ID and picks one of its verses at random.
+// It returns the picked verse, entity.ErrNoVerses if the song has no text, or an error if the retrieval fails.
+func (uc *SongUseCase) FetchRandomVerse(ctx context.Context, songID uuid.UUID) (*entity.Verse, error) {
+	const op = "usecase.FetchRandomVerse"
 
-	savedSong, err := uc.songRepo.Save(ctx, song)
+	song, err := uc.songRepo.GetByID(ctx, songID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to add song: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
 	}
 
-	return savedSong, nil
+	verses := splitVerses(song.SongDetail.Text)
+	if len(verses) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrNoVerses)
+	}
+
+	index := uc.randomIntN(len(verses))
+
+	return &entity.Verse{Index: index, Text: verses[index]}, nil
 }
 
-// FetchSongs retrieves all songs from the repository that match the provided filter and pagination parameters.
-// It returns a slice of songs or an error if the retrieval fails.
-func (uc *SongUseCase) FetchSongs(
+// FetchTextStats retrieves a specific song by its ID and counts the words, lines, and verses of its text.
+// It returns the counts, entity.ErrNoVerses if the song has no text, or an error if the retrieval fails.
+func (uc *SongUseCase) FetchTextStats(ctx context.Context, songID uuid.UUID) (*entity.TextStats, error) {
+	const op = "usecase.FetchTextStats"
+
+	song, err := uc.songRepo.GetByID(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	if song.SongDetail.Text == "" {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrNoVerses)
+	}
+
+	return textStats(song.SongDetail.Text), nil
+}
+
+// textStats counts the words, non-blank lines, and verses of song text. Unique words are compared ignoring case.
+func textStats(text string) *entity.TextStats {
+	lines := splitVerseLines(text)
+	stats := &entity.TextStats{
+		Lines:  len(lines),
+		Verses: countVerses(text),
+	}
+
+	unique := make(map[string]struct{})
+	for _, line := range lines {
+		for _, word := range splitWords(line) {
+			stats.Words++
+			unique[strings.ToLower(word)] = struct{}{}
+		}
+	}
+	stats.UniqueWords = len(unique)
+
+	return stats
+}
+
+// splitWords breaks a line into words: runs of letters, marks, and digits, which may be joined by apostrophes
+// as in "don't". Punctuation, symbols such as emoji, and spaces separate words. Scripts written without spaces,
+// such as Chinese, are not segmented further, so a run of their characters is a single word.
+func splitWords(line string) []string {
+	isWordRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+	}
+	isApostrophe := func(r rune) bool {
+		return r == '\'' || r == '’'
+	}
+
+	runes := []rune(line)
+
+	var words []string
+	start := -1
+	for i, r := range runes {
+		inWord := isWordRune(r) ||
+			(start >= 0 && isApostrophe(r) && i+1 < len(runes) && isWordRune(runes[i+1]))
+
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			words = append(words, string(runes[start:i]))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+// randomIntN returns a random number in [0, n) drawn from the source set with WithRandomSource,
+// or from the shared random generator when there is none.
+func (uc *SongUseCase) randomIntN(n int) int {
+	if uc.random == nil {
+		return rand.IntN(n)
+	}
+
+	uc.randomMu.Lock()
+	defer uc.randomMu.Unlock()
+
+	return uc.random.IntN(n)
+}
+
+// FetchSongsWithVerses retrieves several songs by their IDs, breaking the text of each into verses and applying
+// the same pagination to every song. Songs are returned in the order of their IDs and duplicate IDs are returned once.
+// It returns the found songs with verses, the IDs of songs that don't exist, or an error if the retrieval fails.
+func (uc *SongUseCase) FetchSongsWithVerses(
 	ctx context.Context,
+	songIDs []uuid.UUID,
 	pagination entity.Pagination,
-	filters ...entity.SongFilter,
-) ([]*entity.Song, *entity.Pagination, error) {
-	const op = "usecase.FetchSongs"
+) ([]entity.SongVersesPage, []uuid.UUID, error) {
+	const op = "usecase.FetchSongsWithVerses"
 
-	songs, pgn, err := uc.songRepo.GetAll(ctx, pagination, filters...)
+	songs, err := uc.songRepo.GetByIDs(ctx, songIDs)
 	if err != nil {
 		return nil, nil, fmt.Errorf("%s: failed to fetch songs: %w", op, err)
 	}
 
-	return songs, pgn, nil
+	songsByID := make(map[uuid.UUID]*entity.Song, len(songs))
+	for _, song := range songs {
+		songsByID[song.ID] = song
+	}
+
+	var (
+		pages    []entity.SongVersesPage
+		notFound []uuid.UUID
+	)
+
+	seen := make(map[uuid.UUID]bool, len(songIDs))
+
+	for _, songID := range songIDs {
+		if seen[songID] {
+			continue
+		}
+		seen[songID] = true
+
+		song, ok := songsByID[songID]
+		if !ok {
+			notFound = append(notFound, songID)
+			continue
+		}
+
+		songWithVerses, pgn := paginateVerses(song, pagination, entity.VerseGranularity, false)
+		pages = append(pages, entity.SongVersesPage{Song: *songWithVerses, Pagination: *pgn})
+	}
+
+	return pages, notFound, nil
 }
 
-// FetchSongWithVerses retrieves the text of a specific song by its ID, breaking it into verses and applying pagination if specified.
-// It returns the song with verses or an error if the retrieval fails.
-func (uc *SongUseCase) FetchSongWithVerses(
-	ctx context.Context,
-	songID uuid.UUID,
+// paginateVerses breaks the song text into verses or lines and returns the page of them selected by pagination.
+// With dedupe, repeated verses are collapsed before paginating. Empty pagination falls back to the defaults.
+func paginateVerses(
+	song *entity.Song,
 	pagination entity.Pagination,
-) (*entity.SongWithVerses, *entity.Pagination, error) {
-	const op = "usecase.FetchSongText"
+	granularity entity.TextGranularity,
+	dedupe bool,
+) (*entity.SongWithVerses, *entity.Pagination) {
+	verses := splitText(song.SongDetail.Text, granularity)
 
-	song, err := uc.songRepo.GetByID(ctx, songID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	var repeats []int
+	if dedupe {
+		verses, repeats = collapseVerses(verses)
 	}
 
-	verses := strings.Split(song.SongDetail.Text, "\n\n")
 	versesCount := uint64(len(verses))
 
 	if pagination.IsEmpty() {
@@ -108,14 +1079,265 @@ func (uc *SongUseCase) FetchSongWithVerses(
 	pagination.Items = uint64(len(verses[offset:limit]))
 	pagination.Total = versesCount
 
-	return &entity.SongWithVerses{
+	songWithVerses := &entity.SongWithVerses{
 		ID:        song.ID,
 		GroupName: song.GroupName,
 		Name:      song.Name,
 		Verses:    verses[offset:limit],
 		CreatedAt: song.CreatedAt,
 		UpdatedAt: song.UpdatedAt,
-	}, &pagination, nil
+	}
+	if dedupe {
+		songWithVerses.Repeats = repeats[offset:limit]
+	}
+
+	return songWithVerses, &pagination
+}
+
+// collapseVerses merges every run of identical consecutive verses into a single verse.
+// It returns the remaining verses along with the number of times each of them was repeated in a row.
+func collapseVerses(verses []string) ([]string, []int) {
+	collapsed := make([]string, 0, len(verses))
+	repeats := make([]int, 0, len(verses))
+
+	for _, verse := range verses {
+		if n := len(collapsed); n > 0 && collapsed[n-1] == verse {
+			repeats[n-1]++
+			continue
+		}
+
+		collapsed = append(collapsed, verse)
+		repeats = append(repeats, 1)
+	}
+
+	return collapsed, repeats
+}
+
+// CompareSongTexts computes a line-level diff between the texts of two songs identified by their IDs.
+// Lines only present in the first song are reported as removed, lines only present in the other song as added.
+// It returns the diff or an error if either song can't be retrieved.
+func (uc *SongUseCase) CompareSongTexts(ctx context.Context, songID, otherSongID uuid.UUID) ([]entity.LineDiff, error) {
+	const op = "usecase.CompareSongTexts"
+
+	song, err := uc.songRepo.GetByID(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	otherSong, err := uc.songRepo.GetByID(ctx, otherSongID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch other song: %w", op, err)
+	}
+
+	return diffLines(splitLines(song.SongDetail.Text), splitLines(otherSong.SongDetail.Text)), nil
+}
+
+// splitLines breaks text into lines. An empty text has no lines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
+
+// diffLines computes a line-level diff between a and b based on their longest common subsequence.
+func diffLines(a, b []string) []entity.LineDiff {
+	// lcs[i][j] holds the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]entity.LineDiff, 0, max(len(a), len(b)))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, entity.LineDiff{Operation: entity.DiffUnchanged, Line: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, entity.LineDiff{Operation: entity.DiffRemoved, Line: a[i]})
+			i++
+		default:
+			diff = append(diff, entity.LineDiff{Operation: entity.DiffAdded, Line: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		diff = append(diff, entity.LineDiff{Operation: entity.DiffRemoved, Line: a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		diff = append(diff, entity.LineDiff{Operation: entity.DiffAdded, Line: b[j]})
+	}
+
+	return diff
+}
+
+// PreviewVerses splits the provided text into verses exactly as FetchSongWithVerses does for stored songs,
+// without persisting anything.
+func (uc *SongUseCase) PreviewVerses(text string) []string {
+	return splitVerses(text)
+}
+
+// splitText breaks song text into the units of the given granularity.
+func splitText(text string, granularity entity.TextGranularity) []string {
+	if granularity == entity.LineGranularity {
+		return splitVerseLines(text)
+	}
+	return splitVerses(text)
+}
+
+// splitVerses breaks song text into verses separated by blank lines. An empty text has no verses.
+func splitVerses(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n\n")
+}
+
+// splitVerseLines breaks song text into single lines, dropping the blank lines that separate verses.
+func splitVerseLines(text string) []string {
+	var lines []string
+	for _, line := range splitLines(text) {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// countVerses returns the number of verses of song text, zero when there is no text.
+func countVerses(text string) int {
+	return len(splitVerses(text))
+}
+
+// ResplitVerses splits the text of every song into verses again and stores the verse counts, walking
+// the catalog in batches of the configured size (see WithResplitBatchSize). Every batch is stored in its own
+// transaction, so the counts of the batches stored before a failure are kept.
+// It returns the number of songs whose count changed or an error if the process fails.
+func (uc *SongUseCase) ResplitVerses(ctx context.Context) (int64, error) {
+	const op = "usecase.ResplitVerses"
+
+	var updated int64
+
+	err := uc.walkSongs(ctx, uc.resplitBatch, func(songs []*entity.Song) error {
+		counts := make([]entity.SongVerseCount, 0, len(songs))
+		for _, song := range songs {
+			counts = append(counts, entity.SongVerseCount{SongID: song.ID, Count: countVerses(song.SongDetail.Text)})
+		}
+
+		n, err := uc.songRepo.UpdateVerseCounts(ctx, counts)
+		if err != nil {
+			return fmt.Errorf("failed to store verse counts: %w", err)
+		}
+
+		updated += n
+		return nil
+	})
+	if err != nil {
+		return updated, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// RecomputeSortNames strips the configured leading articles (see WithSortNameArticles) from the group name
+// of every song again and stores the sort names, so that songs saved under other articles sort like new ones.
+// Songs are walked in batches of the resplit batch size (see WithResplitBatchSize), every batch in its own
+// transaction. It returns the number of songs whose sort name changed or an error if the process fails.
+func (uc *SongUseCase) RecomputeSortNames(ctx context.Context) (int64, error) {
+	const op = "usecase.RecomputeSortNames"
+
+	var updated int64
+
+	err := uc.walkSongs(ctx, uc.resplitBatch, func(songs []*entity.Song) error {
+		sortNames := make([]entity.SongSortName, 0, len(songs))
+		for _, song := range songs {
+			sortNames = append(sortNames, entity.SongSortName{SongID: song.ID, SortName: uc.sortName(song.GroupName)})
+		}
+
+		n, err := uc.songRepo.UpdateSortNames(ctx, sortNames)
+		if err != nil {
+			return fmt.Errorf("failed to store sort names: %w", err)
+		}
+
+		updated += n
+		return nil
+	})
+	if err != nil {
+		return updated, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// WalkSongs calls fn for every song in the repository matching the filters, in the order of their IDs. Songs are
+// read in batches, so the whole catalog is never held in memory. Walking stops at the first error returned by fn,
+// which is returned wrapped.
+func (uc *SongUseCase) WalkSongs(ctx context.Context, fn func(song *entity.Song) error, filters ...entity.SongFilter) error {
+	const op = "usecase.WalkSongs"
+
+	err := uc.walkSongs(ctx, walkSongsBatchSize, func(songs []*entity.Song) error {
+		for _, song := range songs {
+			if err := fn(song); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, filters...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// walkSongs reads all songs matching the filters in batches of batchSize, ordered by ID, and calls fn with
+// every batch. It stops at the first error, either of reading a batch or returned by fn.
+func (uc *SongUseCase) walkSongs(
+	ctx context.Context,
+	batchSize uint64,
+	fn func(songs []*entity.Song) error,
+	filters ...entity.SongFilter,
+) error {
+	var afterID uuid.UUID
+
+	for {
+		songs, err := uc.songRepo.GetAfter(ctx, afterID, batchSize, filters...)
+		if err != nil {
+			return fmt.Errorf("failed to fetch songs: %w", err)
+		}
+
+		if len(songs) == 0 {
+			return nil
+		}
+
+		if err := fn(songs); err != nil {
+			return err
+		}
+
+		if uint64(len(songs)) < batchSize {
+			return nil
+		}
+
+		afterID = songs[len(songs)-1].ID
+	}
 }
 
 // ModifySong updates an existing song in the repository based on the provided song ID and new song data.
@@ -123,14 +1345,147 @@ func (uc *SongUseCase) FetchSongWithVerses(
 func (uc *SongUseCase) ModifySong(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error) {
 	const op = "usecase.ModifySong"
 
-	updatedSong, err := uc.songRepo.Update(ctx, songID, song)
+	updatedSong, err := uc.songRepo.Update(ctx, songID, uc.prepareUpdate(song))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to modify song: %w", op, err)
+	}
+
+	uc.publishSongEvents(entity.SongUpdatedEvent, updatedSong)
+
+	return updatedSong, nil
+}
+
+// ReorderVerses rearranges the verses of a song in the repository, so that the verse at order[i] comes i-th.
+// Verses aren't stored on their own but split from the text at blank lines, so the text is rewritten with
+// the verses in the new order. It returns entity.ErrNoVerses if the song has no text, and
+// entity.ErrInvalidVerseOrder unless order holds every verse index exactly once.
+func (uc *SongUseCase) ReorderVerses(ctx context.Context, songID uuid.UUID, order []int) (*entity.Song, error) {
+	const op = "usecase.ReorderVerses"
+
+	song, err := uc.songRepo.GetByID(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to fetch song: %w", op, err)
+	}
+
+	verses := splitVerses(song.SongDetail.Text)
+	if len(verses) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, entity.ErrNoVerses)
+	}
+
+	reordered, err := permuteVerses(verses, order)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updatedSong, err := uc.songRepo.Update(ctx, songID, uc.prepareUpdate(entity.Song{
+		SongDetail: entity.SongDetail{Text: strings.Join(reordered, "\n\n")},
+	}))
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to modify song: %w", op, err)
 	}
 
+	uc.publishSongEvents(entity.SongUpdatedEvent, updatedSong)
+
 	return updatedSong, nil
 }
 
+// permuteVerses returns the verses in the given order, or entity.ErrInvalidVerseOrder
+// unless order is a permutation of the verse indices.
+func permuteVerses(verses []string, order []int) ([]string, error) {
+	if len(order) != len(verses) {
+		return nil, fmt.Errorf("%w: %d indices for %d verses", entity.ErrInvalidVerseOrder, len(order), len(verses))
+	}
+
+	seen := make([]bool, len(verses))
+	reordered := make([]string, 0, len(verses))
+
+	for _, index := range order {
+		if index < 0 || index >= len(verses) {
+			return nil, fmt.Errorf("%w: index %d out of range", entity.ErrInvalidVerseOrder, index)
+		}
+		if seen[index] {
+			return nil, fmt.Errorf("%w: index %d repeated", entity.ErrInvalidVerseOrder, index)
+		}
+		seen[index] = true
+
+		reordered = append(reordered, verses[index])
+	}
+
+	return reordered, nil
+}
+
+// ModifySongs updates several songs in the repository at once, either all of them or none.
+// The returned slice has an entry for every update in the same order, which is nil when the song does not exist.
+func (uc *SongUseCase) ModifySongs(ctx context.Context, updates []entity.SongUpdate) ([]*entity.Song, error) {
+	const op = "usecase.ModifySongs"
+
+	prepared := make([]entity.SongUpdate, 0, len(updates))
+	for _, update := range updates {
+		prepared = append(prepared, entity.SongUpdate{
+			SongID: update.SongID,
+			Song:   uc.prepareUpdate(update.Song),
+		})
+	}
+
+	updatedSongs, err := uc.songRepo.UpdateBatch(ctx, prepared)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to modify songs: %w", op, err)
+	}
+
+	uc.publishSongEvents(entity.SongUpdatedEvent, updatedSongs...)
+
+	return updatedSongs, nil
+}
+
+// ModifySongsWhere updates every song matching the filters in the repository with the same fields.
+// Without filters every song is updated. It returns the number of updated songs.
+func (uc *SongUseCase) ModifySongsWhere(ctx context.Context, song entity.Song, filters ...entity.SongFilter) (int64, error) {
+	const op = "usecase.ModifySongsWhere"
+
+	updatedIDs, err := uc.songRepo.UpdateWhere(ctx, uc.prepareUpdate(song), filters...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to modify songs: %w", op, err)
+	}
+
+	uc.publishSongIDEvents(entity.SongUpdatedEvent, updatedIDs)
+
+	return int64(len(updatedIDs)), nil
+}
+
+// TouchSongs sets the update time of every song matching the filters to the current time, so that they are
+// picked up again by anything following recently updated songs. It returns the number of touched songs,
+// or entity.ErrNoFilters when no filters are provided.
+func (uc *SongUseCase) TouchSongs(ctx context.Context, filters ...entity.SongFilter) (int64, error) {
+	const op = "usecase.TouchSongs"
+
+	if len(filters) == 0 {
+		return 0, fmt.Errorf("%s: %w", op, entity.ErrNoFilters)
+	}
+
+	touchedIDs, err := uc.songRepo.TouchWhere(ctx, filters...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to touch songs: %w", op, err)
+	}
+
+	uc.publishSongIDEvents(entity.SongUpdatedEvent, touchedIDs)
+
+	return int64(len(touchedIDs)), nil
+}
+
+// prepareUpdate fills in the fields derived from the modified fields of a song.
+func (uc *SongUseCase) prepareUpdate(song entity.Song) entity.Song {
+	song = normalizeNames(song)
+
+	if song.GroupName != "" {
+		song.SortName = uc.sortName(song.GroupName)
+	}
+	if song.SongDetail != (entity.SongDetail{}) {
+		song.DetailSource = entity.DetailSourceClient
+	}
+
+	return uc.sanitizeText(uc.links.apply(song))
+}
+
 // RemoveSong deletes a song from the repository based on its ID.
 // It returns the number of deleted records or an error if the deletion fails.
 func (uc *SongUseCase) RemoveSong(ctx context.Context, songID uuid.UUID) (int64, error) {
@@ -141,5 +1496,91 @@ func (uc *SongUseCase) RemoveSong(ctx context.Context, songID uuid.UUID) (int64,
 		return 0, fmt.Errorf("%s: failed to remove song: %w", op, err)
 	}
 
+	if deleted > 0 {
+		uc.events.publish(entity.SongEvent{
+			Type:       entity.SongDeletedEvent,
+			SongID:     songID,
+			OccurredAt: time.Now(),
+		})
+	}
+
 	return deleted, nil
 }
+
+// RollbackImport deletes every song added by the batch import with the given ID, including songs modified
+// since. It returns the number of deleted songs, or entity.ErrImportNotFound when the import added no songs
+// that still exist.
+func (uc *SongUseCase) RollbackImport(ctx context.Context, importID uuid.UUID) (int64, error) {
+	const op = "usecase.RollbackImport"
+
+	deletedIDs, err := uc.songRepo.DeleteWhere(ctx, entity.SongFilter{Field: entity.SongImportFilterField, Value: importID})
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to remove imported songs: %w", op, err)
+	}
+
+	if len(deletedIDs) == 0 {
+		return 0, fmt.Errorf("%s: %w", op, entity.ErrImportNotFound)
+	}
+
+	now := time.Now()
+
+	for _, songID := range deletedIDs {
+		uc.events.publish(entity.SongEvent{
+			Type:       entity.SongDeletedEvent,
+			SongID:     songID,
+			OccurredAt: now,
+		})
+	}
+
+	return int64(len(deletedIDs)), nil
+}
+
+// RemoveSongs deletes several songs from the repository by their IDs in a single transaction.
+// Duplicate IDs are deleted once. It returns the IDs of the deleted songs and of the songs that don't exist,
+// both in the order of the given IDs, or an error if the deletion fails, in which case nothing is deleted.
+func (uc *SongUseCase) RemoveSongs(ctx context.Context, songIDs []uuid.UUID) ([]uuid.UUID, []uuid.UUID, error) {
+	const op = "usecase.RemoveSongs"
+
+	uniqueIDs := make([]uuid.UUID, 0, len(songIDs))
+	seen := make(map[uuid.UUID]bool, len(songIDs))
+
+	for _, songID := range songIDs {
+		if seen[songID] {
+			continue
+		}
+		seen[songID] = true
+
+		uniqueIDs = append(uniqueIDs, songID)
+	}
+
+	deletedIDs, err := uc.songRepo.DeleteBatch(ctx, uniqueIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to remove songs: %w", op, err)
+	}
+
+	isDeleted := make(map[uuid.UUID]bool, len(deletedIDs))
+	for _, songID := range deletedIDs {
+		isDeleted[songID] = true
+	}
+
+	var deleted, notFound []uuid.UUID
+
+	now := time.Now()
+
+	for _, songID := range uniqueIDs {
+		if !isDeleted[songID] {
+			notFound = append(notFound, songID)
+			continue
+		}
+
+		deleted = append(deleted, songID)
+
+		uc.events.publish(entity.SongEvent{
+			Type:       entity.SongDeletedEvent,
+			SongID:     songID,
+			OccurredAt: now,
+		})
+	}
+
+	return deleted, notFound, nil
+}
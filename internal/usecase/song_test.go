@@ -3,11 +3,15 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/vadimbarashkov/online-song-library/internal/entity"
 	"github.com/vadimbarashkov/online-song-library/mocks/usecase"
 )
@@ -27,12 +31,46 @@ func initSongUseCase(t testing.TB) (*SongUseCase, *usecase.MockMusicInfoAPI, *us
 	return uc, musicInfoAPIMock, songRepoMock
 }
 
+func TestSongUseCase_SortName(t *testing.T) {
+	uc := NewSongUseCase(nil, nil, WithSortNameArticles("The", "A", "An"))
+
+	tests := []struct {
+		name      string
+		groupName string
+		want      string
+	}{
+		{name: "leading the", groupName: "The Beatles", want: "Beatles"},
+		{name: "leading a", groupName: "A Tribe Called Quest", want: "Tribe Called Quest"},
+		{name: "leading an", groupName: "An Horse", want: "Horse"},
+		{name: "case insensitive", groupName: "the Who", want: "Who"},
+		{name: "no article", groupName: "Queen", want: "Queen"},
+		{name: "article without space", groupName: "Theatre of Tragedy", want: "Theatre of Tragedy"},
+		{name: "article only", groupName: "The", want: "The"},
+		{name: "only first article stripped", groupName: "The A Team", want: "A Team"},
+		{name: "tab after article", groupName: "The\tBand", want: "Band"},
+		{name: "repeated spaces after article", groupName: "The  Band", want: "Band"},
+		{name: "article followed by whitespace only", groupName: "The \t", want: "The \t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, uc.sortName(tt.groupName))
+		})
+	}
+
+	t.Run("no articles configured", func(t *testing.T) {
+		uc := NewSongUseCase(nil, nil)
+
+		assert.Equal(t, "The Beatles", uc.sortName("The Beatles"))
+	})
+}
+
 func TestSongUseCase_AddSong(t *testing.T) {
 	t.Run("music info api error", func(t *testing.T) {
 		uc, musicInfoAPIMock, _ := initSongUseCase(t)
 
 		musicInfoAPIMock.
-			On("FetchSongInfo", context.Background(), entity.Song{
+			On("FetchSongInfo", mock.Anything, entity.Song{
 				GroupName: "Test Group",
 				Name:      "Test Song",
 			}).
@@ -53,7 +91,7 @@ func TestSongUseCase_AddSong(t *testing.T) {
 		uc, musicInfoAPIMock, songRepoMock := initSongUseCase(t)
 
 		musicInfoAPIMock.
-			On("FetchSongInfo", context.Background(), entity.Song{
+			On("FetchSongInfo", mock.Anything, entity.Song{
 				GroupName: "Test Group",
 				Name:      "Test Song",
 			}).
@@ -65,14 +103,17 @@ func TestSongUseCase_AddSong(t *testing.T) {
 			}, nil)
 
 		songRepoMock.
-			On("Save", context.Background(), entity.Song{
+			On("Save", mock.Anything, entity.Song{
 				GroupName: "Test Group",
 				Name:      "Test Song",
+				SortName:  "Test Group",
 				SongDetail: entity.SongDetail{
 					ReleaseDate: fixedTime,
 					Text:        "Test Text",
 					Link:        "https://example.com",
 				},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				DetailStatus: entity.DetailStatusFound,
 			}).
 			Once().
 			Return(nil, errors.New("unknown error"))
@@ -91,7 +132,7 @@ func TestSongUseCase_AddSong(t *testing.T) {
 		uc, musicInfoAPIMock, songRepoMock := initSongUseCase(t)
 
 		musicInfoAPIMock.
-			On("FetchSongInfo", context.Background(), entity.Song{
+			On("FetchSongInfo", mock.Anything, entity.Song{
 				GroupName: "Test Group",
 				Name:      "Test Song",
 			}).
@@ -103,14 +144,17 @@ func TestSongUseCase_AddSong(t *testing.T) {
 			}, nil)
 
 		songRepoMock.
-			On("Save", context.Background(), entity.Song{
+			On("Save", mock.Anything, entity.Song{
 				GroupName: "Test Group",
 				Name:      "Test Song",
+				SortName:  "Test Group",
 				SongDetail: entity.SongDetail{
 					ReleaseDate: fixedTime,
 					Text:        "Test Text",
 					Link:        "https://example.com",
 				},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				DetailStatus: entity.DetailStatusFound,
 			}).
 			Once().
 			Return(&entity.Song{
@@ -144,6 +188,456 @@ func TestSongUseCase_AddSong(t *testing.T) {
 	})
 }
 
+func TestSongUseCase_AddSong_DuplicateCheck(t *testing.T) {
+	song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+
+	initUseCase := func(t *testing.T, opts ...Option) (*SongUseCase, *usecase.MockMusicInfoAPI, *usecase.MockSongRepository) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, opts...)
+
+		return uc, musicInfoAPIMock, songRepoMock
+	}
+
+	t.Run("existing song", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t, WithDuplicateCheck())
+
+		songRepoMock.
+			On("ExistsByName", mock.Anything, "Test Group", "Test Song").
+			Once().
+			Return(true, nil)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.ErrorIs(t, err, entity.ErrSongAlreadyExists)
+		assert.Nil(t, savedSong)
+	})
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t, WithDuplicateCheck())
+
+		songRepoMock.
+			On("ExistsByName", mock.Anything, "Test Group", "Test Song").
+			Once().
+			Return(false, errors.New("unknown error"))
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to check for existing song")
+		assert.Nil(t, savedSong)
+	})
+
+	t.Run("new song", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t, WithDuplicateCheck())
+
+		songRepoMock.
+			On("ExistsByName", mock.Anything, "Test Group", "Test Song").
+			Once().
+			Return(false, nil)
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{}, nil)
+		songRepoMock.
+			On("Save", mock.Anything, mock.Anything).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, savedSong.ID)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{}, nil)
+		songRepoMock.
+			On("Save", mock.Anything, mock.Anything).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, savedSong.ID)
+		songRepoMock.AssertNotCalled(t, "ExistsByName", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSongUseCase_AddSong_TimeoutFallback(t *testing.T) {
+	song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+
+	initUseCase := func(t *testing.T) (*SongUseCase, *usecase.MockMusicInfoAPI, *usecase.MockSongRepository) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, WithMusicInfoTimeoutFallback(10*time.Millisecond))
+
+		return uc, musicInfoAPIMock, songRepoMock
+	}
+
+	t.Run("timeout saves song without detail", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			}).
+			Return(nil, fmt.Errorf("failed to fetch song info: %w", context.DeadlineExceeded))
+
+		songRepoMock.
+			On("Save", mock.Anything, entity.Song{
+				GroupName:    "Test Group",
+				Name:         "Test Song",
+				SortName:     "Test Group",
+				DetailStatus: entity.DetailStatusFailed,
+			}).
+			Once().
+			Return(&entity.Song{
+				ID:           fixedUUID,
+				GroupName:    "Test Group",
+				Name:         "Test Song",
+				DetailStatus: entity.DetailStatusFailed,
+				CreatedAt:    fixedTime,
+				UpdatedAt:    fixedTime,
+			}, nil)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, savedSong.ID)
+		assert.Equal(t, entity.DetailStatusFailed, savedSong.DetailStatus)
+	})
+
+	t.Run("hard failure", func(t *testing.T) {
+		uc, musicInfoAPIMock, _ := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(nil, errors.New("unexpected status code: 500"))
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch song detail from music info api")
+		assert.Nil(t, savedSong)
+	})
+
+	t.Run("request deadline exceeded", func(t *testing.T) {
+		uc, _, _ := initUseCase(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		<-ctx.Done()
+
+		savedSong, err := uc.AddSong(ctx, song)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, savedSong)
+	})
+
+	t.Run("timeout fails when disabled", func(t *testing.T) {
+		uc, musicInfoAPIMock, _ := initSongUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(nil, context.DeadlineExceeded)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, savedSong)
+	})
+}
+
+func TestNormalizeNames(t *testing.T) {
+	t.Run("combining characters", func(t *testing.T) {
+		song := normalizeNames(entity.Song{GroupName: "Beyonce\u0301", Name: "Cafe\u0301"})
+
+		assert.Equal(t, "Beyonc\u00e9", song.GroupName)
+		assert.Equal(t, "Caf\u00e9", song.Name)
+	})
+
+	t.Run("emoji and cjk names unchanged", func(t *testing.T) {
+		song := entity.Song{GroupName: "🎸 東京事変", Name: "群青日和 👩‍🎤"}
+
+		assert.Equal(t, song, normalizeNames(song))
+	})
+}
+
+func TestSongUseCase_AddSong_Coalescing(t *testing.T) {
+	const callers = 5
+
+	uc, musicInfoAPIMock, songRepoMock := initSongUseCase(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	musicInfoAPIMock.
+		On("FetchSongInfo", mock.Anything, entity.Song{GroupName: "Test Group", Name: "Test Song"}).
+		Once().
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(&entity.SongDetail{Text: "Test Text"}, nil)
+
+	songRepoMock.
+		On("Save", mock.Anything, mock.Anything).
+		Once().
+		Return(&entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}, nil)
+
+	songs := make([]*entity.Song, callers)
+	errs := make([]error, callers)
+
+	var wg sync.WaitGroup
+	add := func(i int, song entity.Song) {
+		defer wg.Done()
+		songs[i], errs[i] = uc.AddSong(context.Background(), song)
+	}
+
+	wg.Add(1)
+	go add(0, entity.Song{GroupName: "Test Group", Name: "Test Song"})
+	<-started
+
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go add(i, entity.Song{GroupName: " test group", Name: "TEST SONG "})
+	}
+
+	// Give the other callers time to join the addition in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range callers {
+		assert.NoError(t, errs[i])
+		assert.Same(t, songs[0], songs[i])
+	}
+	assert.Equal(t, fixedUUID, songs[0].ID)
+}
+
+func TestSongUseCase_AddSong_CoalescedCancel(t *testing.T) {
+	uc, musicInfoAPIMock, songRepoMock := initSongUseCase(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	musicInfoAPIMock.
+		On("FetchSongInfo", mock.Anything, entity.Song{GroupName: "Test Group", Name: "Test Song"}).
+		Once().
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(&entity.SongDetail{Text: "Test Text"}, nil)
+
+	songRepoMock.
+		On("Save", mock.Anything, mock.Anything).
+		Once().
+		Return(&entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := uc.AddSong(ctx, entity.Song{GroupName: "Test Group", Name: "Test Song"})
+		firstErr <- err
+	}()
+	<-started
+
+	secondSong := make(chan *entity.Song, 1)
+	secondErr := make(chan error, 1)
+	go func() {
+		song, err := uc.AddSong(context.Background(), entity.Song{GroupName: "test group", Name: "test song"})
+		secondSong <- song
+		secondErr <- err
+	}()
+
+	// Give the second caller time to join the addition in flight before the first one goes away.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+
+	song := <-secondSong
+	assert.NoError(t, <-secondErr)
+	assert.Equal(t, fixedUUID, song.ID)
+}
+
+func TestSongUseCase_AddSong_GroupLimit(t *testing.T) {
+	song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+	savingSong := entity.Song{
+		GroupName:    "Test Group",
+		Name:         "Test Song",
+		SortName:     "Test Group",
+		SongDetail:   entity.SongDetail{Text: "Test Text"},
+		DetailSource: entity.DetailSourceMusicInfoAPI,
+		DetailStatus: entity.DetailStatusFound,
+	}
+
+	initUseCase := func(t *testing.T) (*SongUseCase, *usecase.MockMusicInfoAPI, *usecase.MockSongRepository) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, WithMaxSongsPerGroup(2), WithImportIDGenerator(func() uuid.UUID { return fixedUUID }))
+
+		return uc, musicInfoAPIMock, songRepoMock
+	}
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Text: "Test Text"}, nil)
+
+		songRepoMock.
+			On("SaveWithinGroupLimit", mock.Anything, savingSong, uint64(2)).
+			Once().
+			Return(nil, entity.ErrGroupSongLimitExceeded)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.ErrorIs(t, err, entity.ErrGroupSongLimitExceeded)
+		assert.Nil(t, savedSong)
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Text: "Test Text"}, nil)
+
+		songRepoMock.
+			On("SaveWithinGroupLimit", mock.Anything, savingSong, uint64(2)).
+			Once().
+			Return(&entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}, nil)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, savedSong.ID)
+	})
+
+	t.Run("import limit exceeded", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("SaveBatchWithinGroupLimit", context.Background(), []entity.Song{
+				{GroupName: "Test Group", Name: "Test Song", SortName: "Test Group", ImportID: fixedUUID},
+			}, uint64(2)).
+			Once().
+			Return(nil, entity.ErrGroupSongLimitExceeded)
+
+		savedSongs, err := uc.ImportSongs(context.Background(), []entity.Song{song}, false)
+
+		assert.ErrorIs(t, err, entity.ErrGroupSongLimitExceeded)
+		assert.Nil(t, savedSongs)
+	})
+}
+
+func TestSongUseCase_ImportSongs(t *testing.T) {
+	importID := uuid.New()
+
+	initUseCase := func(t *testing.T) (*SongUseCase, *usecase.MockMusicInfoAPI, *usecase.MockSongRepository) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, WithImportIDGenerator(func() uuid.UUID { return importID }))
+
+		return uc, musicInfoAPIMock, songRepoMock
+	}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("SaveBatch", context.Background(), []entity.Song{
+				{GroupName: "Test Group", Name: "Test Song", SortName: "Test Group", ImportID: importID},
+			}).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		songs, err := uc.ImportSongs(context.Background(), []entity.Song{
+			{GroupName: "Test Group", Name: "Test Song"},
+		}, false)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to import songs")
+		assert.Nil(t, songs)
+	})
+
+	t.Run("success with best-effort music info", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", context.Background(), entity.Song{GroupName: "Test Group", Name: "Test Song 1"}).
+			Once().
+			Return(&entity.SongDetail{
+				ReleaseDate: fixedTime,
+				Text:        "Test Text",
+				Link:        "https://example.com",
+			}, nil)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", context.Background(), entity.Song{GroupName: "Test Group", Name: "Test Song 2"}).
+			Once().
+			Return(nil, errors.New("api error"))
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", context.Background(), entity.Song{GroupName: "Test Group", Name: "Test Song 3"}).
+			Once().
+			Return(nil, fmt.Errorf("api: %w", entity.ErrSongInfoNotFound))
+
+		songRepoMock.
+			On("SaveBatch", context.Background(), []entity.Song{
+				{
+					GroupName: "Test Group",
+					Name:      "Test Song 1",
+					SortName:  "Test Group",
+					SongDetail: entity.SongDetail{
+						ReleaseDate: fixedTime,
+						Text:        "Test Text",
+						Link:        "https://example.com",
+					},
+					DetailSource: entity.DetailSourceMusicInfoAPI,
+					DetailStatus: entity.DetailStatusFound,
+					ImportID:     importID,
+				},
+				{GroupName: "Test Group", Name: "Test Song 2", SortName: "Test Group", DetailStatus: entity.DetailStatusFailed, ImportID: importID},
+				{GroupName: "Test Group", Name: "Test Song 3", SortName: "Test Group", DetailStatus: entity.DetailStatusNotFound, ImportID: importID},
+			}).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song 1"},
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song 2"},
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song 3"},
+			}, nil)
+
+		songs, err := uc.ImportSongs(context.Background(), []entity.Song{
+			{GroupName: "Test Group", Name: "Test Song 1"},
+			{GroupName: "Test Group", Name: "Test Song 2"},
+			{GroupName: "Test Group", Name: "Test Song 3"},
+		}, true)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 3)
+	})
+}
+
 func TestSongUseCase_FetchSongs(t *testing.T) {
 	t.Run("song repository error", func(t *testing.T) {
 		uc, _, songRepoMock := initSongUseCase(t)
@@ -206,129 +700,1943 @@ func TestSongUseCase_FetchSongs(t *testing.T) {
 		assert.Equal(t, uint64(1), pagination.Items)
 		assert.Equal(t, uint64(1), pagination.Total)
 	})
-}
 
-func TestSongUseCase_FetchSongWithVerses(t *testing.T) {
-	t.Run("song repository error", func(t *testing.T) {
-		uc, _, songRepoMock := initSongUseCase(t)
+	t.Run("offset out of range", func(t *testing.T) {
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(nil, songRepoMock, WithMaxOffsetOverrun(100))
 
 		songRepoMock.
-			On("GetByID", context.Background(), fixedUUID).
+			On("GetAll", context.Background(), entity.Pagination{Offset: 1000}).
 			Once().
-			Return(nil, errors.New("unknown error"))
+			Return([]*entity.Song{}, &entity.Pagination{Offset: 1000, Limit: entity.DefaultLimit, Total: 50}, nil)
 
-		song, pagination, err := uc.FetchSongWithVerses(context.Background(), fixedUUID, entity.Pagination{})
+		songs, pagination, err := uc.FetchSongs(context.Background(), entity.Pagination{Offset: 1000})
 
 		assert.Error(t, err)
-		assert.ErrorContains(t, err, "failed to fetch song")
-		assert.Nil(t, song)
+		assert.ErrorIs(t, err, entity.ErrOffsetOutOfRange)
+		assert.Nil(t, songs)
 		assert.Nil(t, pagination)
 	})
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("offset within allowed overrun", func(t *testing.T) {
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(nil, songRepoMock, WithMaxOffsetOverrun(100))
+
+		songRepoMock.
+			On("GetAll", context.Background(), entity.Pagination{Offset: 150}).
+			Once().
+			Return([]*entity.Song{}, &entity.Pagination{Offset: 150, Limit: entity.DefaultLimit, Total: 50}, nil)
+
+		songs, pagination, err := uc.FetchSongs(context.Background(), entity.Pagination{Offset: 150})
+
+		assert.NoError(t, err)
+		assert.Empty(t, songs)
+		assert.NotNil(t, pagination)
+	})
+
+	t.Run("offset check disabled", func(t *testing.T) {
 		uc, _, songRepoMock := initSongUseCase(t)
 
 		songRepoMock.
-			On("GetByID", context.Background(), fixedUUID).
+			On("GetAll", context.Background(), entity.Pagination{Offset: 1000}).
 			Once().
-			Return(&entity.Song{
-				ID:        fixedUUID,
-				GroupName: "Test Group",
-				Name:      "Test Song",
-				SongDetail: entity.SongDetail{
-					ReleaseDate: fixedTime,
-					Text:        "line1\nline2\n\nline4\nline4\n",
-					Link:        "https://example.com",
-				},
-				CreatedAt: fixedTime,
-				UpdatedAt: fixedTime,
-			}, nil)
+			Return([]*entity.Song{}, &entity.Pagination{Offset: 1000, Limit: entity.DefaultLimit, Total: 50}, nil)
 
-		song, pagination, err := uc.FetchSongWithVerses(context.Background(), fixedUUID, entity.Pagination{})
+		songs, pagination, err := uc.FetchSongs(context.Background(), entity.Pagination{Offset: 1000})
 
 		assert.NoError(t, err)
-		assert.NotNil(t, song)
-		assert.Equal(t, fixedUUID, song.ID)
-		assert.Equal(t, "Test Group", song.GroupName)
-		assert.Equal(t, "Test Song", song.Name)
-		assert.Len(t, song.Verses, 2)
-		assert.Equal(t, fixedTime, song.CreatedAt)
-		assert.Equal(t, fixedTime, song.UpdatedAt)
+		assert.Empty(t, songs)
 		assert.NotNil(t, pagination)
-		assert.Equal(t, entity.DefaultOffset, pagination.Offset)
-		assert.Equal(t, entity.DefaultLimit, pagination.Limit)
-		assert.Equal(t, uint64(2), pagination.Items)
-		assert.Equal(t, uint64(2), pagination.Total)
 	})
 }
 
-func TestSongUseCase_ModifySong(t *testing.T) {
+func TestSongUseCase_FetchRecentlyUpdatedSongs(t *testing.T) {
 	t.Run("song repository error", func(t *testing.T) {
 		uc, _, songRepoMock := initSongUseCase(t)
 
 		songRepoMock.
-			On("Update", context.Background(), fixedUUID, entity.Song{
-				SongDetail: entity.SongDetail{
-					Text: "New Test Text",
-					Link: "https://new-example.com",
-				},
-			}).
+			On("GetRecentlyUpdated", context.Background(), entity.Pagination{}).
 			Once().
-			Return(nil, errors.New("unknown error"))
+			Return(nil, nil, errors.New("unknown error"))
 
-		song, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
-			SongDetail: entity.SongDetail{
-				Text: "New Test Text",
-				Link: "https://new-example.com",
-			},
-		})
+		songs, pagination, err := uc.FetchRecentlyUpdatedSongs(context.Background(), entity.Pagination{})
 
 		assert.Error(t, err)
-		assert.ErrorContains(t, err, "failed to modify song")
-		assert.Nil(t, song)
+		assert.ErrorContains(t, err, "failed to fetch recently updated songs")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
 	})
 
 	t.Run("success", func(t *testing.T) {
 		uc, _, songRepoMock := initSongUseCase(t)
 
+		filter := entity.SongFilter{Field: entity.SongEditedFilterField, Value: true}
+
 		songRepoMock.
-			On("Update", context.Background(), fixedUUID, entity.Song{
-				SongDetail: entity.SongDetail{
-					Text: "New Test Text",
-					Link: "https://new-example.com",
-				},
+			On("GetRecentlyUpdated", context.Background(), entity.Pagination{}, filter).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, CreatedAt: fixedTime, UpdatedAt: fixedTime.Add(time.Hour)},
+			}, &entity.Pagination{
+				Offset: entity.DefaultOffset,
+				Limit:  entity.DefaultLimit,
+				Items:  1,
+				Total:  1,
+			}, nil)
+
+		songs, pagination, err := uc.FetchRecentlyUpdatedSongs(context.Background(), entity.Pagination{}, filter)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+}
+
+func TestSongUseCase_FetchSongsWithBrokenLinks(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetWithBrokenLinks", context.Background(), entity.Pagination{}).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		songs, pagination, err := uc.FetchSongsWithBrokenLinks(context.Background(), entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch songs with broken links")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetWithBrokenLinks", context.Background(), entity.Pagination{}).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song", SongDetail: entity.SongDetail{Link: "not a link"}},
+			}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+
+		songs, pagination, err := uc.FetchSongsWithBrokenLinks(context.Background(), entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+}
+
+func TestSongUseCase_FetchIncompleteSongs(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetIncomplete", context.Background(), entity.Pagination{}).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		songs, pagination, err := uc.FetchIncompleteSongs(context.Background(), entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch incomplete songs")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetIncomplete", context.Background(), entity.Pagination{}, entity.SongFilter{
+				Field: entity.SongLinkMissingFilterField,
+				Value: true,
 			}).
 			Once().
-			Return(&entity.Song{
-				ID:        fixedUUID,
-				GroupName: "Test Group",
-				Name:      "Test Song",
-				SongDetail: entity.SongDetail{
-					ReleaseDate: fixedTime,
-					Text:        "New Test Text",
-					Link:        "https://new-example.com",
+			Return([]*entity.Song{
+				{
+					ID:        fixedUUID,
+					GroupName: "Test Group",
+					Name:      "Test Song",
+					SongDetail: entity.SongDetail{
+						ReleaseDate: fixedTime,
+						Text:        "Test Text",
+					},
+					CreatedAt: fixedTime,
+					UpdatedAt: fixedTime,
 				},
-				CreatedAt: fixedTime,
-				UpdatedAt: fixedTime,
+			}, &entity.Pagination{
+				Offset: entity.DefaultOffset,
+				Limit:  entity.DefaultLimit,
+				Items:  1,
+				Total:  1,
 			}, nil)
 
-		song, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
-			SongDetail: entity.SongDetail{
-				Text: "New Test Text",
-				Link: "https://new-example.com",
-			},
+		songs, pagination, err := uc.FetchIncompleteSongs(context.Background(), entity.Pagination{}, entity.SongFilter{
+			Field: entity.SongLinkMissingFilterField,
+			Value: true,
 		})
 
 		assert.NoError(t, err)
-		assert.NotNil(t, song)
-		assert.Equal(t, fixedUUID, song.ID)
-		assert.Equal(t, "Test Group", song.GroupName)
-		assert.Equal(t, "Test Song", song.Name)
-		assert.Equal(t, fixedTime, song.SongDetail.ReleaseDate)
-		assert.Equal(t, "New Test Text", song.SongDetail.Text)
-		assert.Equal(t, "https://new-example.com", song.SongDetail.Link)
-		assert.Equal(t, fixedTime, song.CreatedAt)
-		assert.Equal(t, fixedTime, song.UpdatedAt)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Empty(t, songs[0].Link)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Items)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+}
+
+func TestSongUseCase_FetchRecentSongs(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetRecent", context.Background(), uint64(10)).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		songs, err := uc.FetchRecentSongs(context.Background(), 10)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch recent songs")
+		assert.Nil(t, songs)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetRecent", context.Background(), uint64(10)).
+			Once().
+			Return([]*entity.Song{
+				{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"},
+			}, nil)
+
+		songs, err := uc.FetchRecentSongs(context.Background(), 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+	})
+}
+
+func TestSongUseCase_FetchLibraryStats(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetLibraryStats", context.Background()).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		stats, err := uc.FetchLibraryStats(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch library stats")
+		assert.Nil(t, stats)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetLibraryStats", context.Background()).
+			Once().
+			Return(&entity.LibraryStats{Songs: 12, Groups: 4, SongsMissingDetail: 3}, nil)
+
+		stats, err := uc.FetchLibraryStats(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.LibraryStats{Songs: 12, Groups: 4, SongsMissingDetail: 3}, stats)
+	})
+}
+
+func TestSongUseCase_FetchDecadeStats(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("CountByDecade", context.Background()).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		counts, err := uc.FetchDecadeStats(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch decade stats")
+		assert.Nil(t, counts)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("CountByDecade", context.Background()).
+			Once().
+			Return([]entity.DecadeCount{{Decade: 1970, Count: 3}}, nil)
+
+		counts, err := uc.FetchDecadeStats(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.DecadeCount{{Decade: 1970, Count: 3}}, counts)
+	})
+}
+
+func TestSongUseCase_FetchStaleSongs(t *testing.T) {
+	staleBefore := func(staleAfter time.Duration) any {
+		return mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= staleAfter && time.Since(before) < staleAfter+time.Minute
+		})
+	}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetStaleDetails", context.Background(), staleBefore(defaultDetailStaleAfter), uint64(10)).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		songs, err := uc.FetchStaleSongs(context.Background(), 10)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch songs with stale details")
+		assert.Nil(t, songs)
+	})
+
+	t.Run("configured threshold", func(t *testing.T) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, WithDetailStaleAfter(24*time.Hour))
+
+		songRepoMock.
+			On("GetStaleDetails", context.Background(), staleBefore(24*time.Hour), uint64(10)).
+			Once().
+			Return([]*entity.Song{{ID: fixedUUID}}, nil)
+
+		songs, err := uc.FetchStaleSongs(context.Background(), 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+	})
+}
+
+func TestSongUseCase_FetchAlphaIndex(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("CountByFirstLetter", context.Background(), entity.AlphaIndexSongField).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		counts, err := uc.FetchAlphaIndex(context.Background(), entity.AlphaIndexSongField)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch alpha index")
+		assert.Nil(t, counts)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("CountByFirstLetter", context.Background(), entity.AlphaIndexGroupField).
+			Once().
+			Return([]entity.LetterCount{{Letter: "Q", Count: 2}}, nil)
+
+		counts, err := uc.FetchAlphaIndex(context.Background(), entity.AlphaIndexGroupField)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.LetterCount{{Letter: "Q", Count: 2}}, counts)
+	})
+}
+
+func TestSongUseCase_CountSongs(t *testing.T) {
+	filter := entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Led"}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("Count", context.Background(), filter).
+			Once().
+			Return(uint64(0), errors.New("unknown error"))
+
+		count, err := uc.CountSongs(context.Background(), filter)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to count songs")
+		assert.Zero(t, count)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("Count", context.Background(), filter).
+			Once().
+			Return(uint64(3), nil)
+
+		count, err := uc.CountSongs(context.Background(), filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), count)
+	})
+}
+
+func TestSongUseCase_FetchSongFacets(t *testing.T) {
+	filter := entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Led"}
+	fields := []entity.FacetField{entity.FacetReleaseYearField, entity.FacetGroupNameField}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("CountFacet", context.Background(), entity.FacetReleaseYearField, filter).
+			Once().
+			Return([]entity.FacetCount{{Value: "1971", Count: 2}}, nil)
+
+		songRepoMock.
+			On("CountFacet", context.Background(), entity.FacetGroupNameField, filter).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		facets, err := uc.FetchSongFacets(context.Background(), fields, filter)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to count songs by facet")
+		assert.Nil(t, facets)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("CountFacet", context.Background(), entity.FacetReleaseYearField, filter).
+			Once().
+			Return([]entity.FacetCount{{Value: "1971", Count: 2}}, nil)
+
+		songRepoMock.
+			On("CountFacet", context.Background(), entity.FacetGroupNameField, filter).
+			Once().
+			Return([]entity.FacetCount{{Value: "Led Zeppelin", Count: 2}}, nil)
+
+		facets, err := uc.FetchSongFacets(context.Background(), fields, filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[entity.FacetField][]entity.FacetCount{
+			entity.FacetReleaseYearField: {{Value: "1971", Count: 2}},
+			entity.FacetGroupNameField:   {{Value: "Led Zeppelin", Count: 2}},
+		}, facets)
+	})
+}
+
+func TestSongUseCase_FetchGroupReleaseRange(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetGroupReleaseRange", context.Background(), "Unknown Group").
+			Once().
+			Return(nil, entity.ErrGroupNotFound)
+
+		releaseRange, err := uc.FetchGroupReleaseRange(context.Background(), "Unknown Group")
+
+		assert.ErrorIs(t, err, entity.ErrGroupNotFound)
+		assert.ErrorContains(t, err, "failed to fetch group release range")
+		assert.Nil(t, releaseRange)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		expected := &entity.GroupReleaseRange{
+			GroupName: "The Beatles",
+			First:     entity.SongRelease{Name: "Love Me Do", ReleaseDate: fixedTime},
+			Last:      entity.SongRelease{Name: "Let It Be", ReleaseDate: fixedTime},
+		}
+
+		songRepoMock.
+			On("GetGroupReleaseRange", context.Background(), "The Beatles").
+			Once().
+			Return(expected, nil)
+
+		releaseRange, err := uc.FetchGroupReleaseRange(context.Background(), "The Beatles")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, releaseRange)
+	})
+}
+
+func TestSongUseCase_FetchGroupFacets(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetGroupFacets", context.Background(), "Unknown Group").
+			Once().
+			Return(nil, entity.ErrGroupNotFound)
+
+		facets, err := uc.FetchGroupFacets(context.Background(), "Unknown Group")
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrGroupNotFound)
+		assert.Nil(t, facets)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		expected := &entity.GroupFacets{
+			GroupName:    "Led Zeppelin",
+			ReleaseYears: []entity.YearCount{{Year: 1971, Count: 8}},
+		}
+
+		songRepoMock.
+			On("GetGroupFacets", context.Background(), "Led Zeppelin").
+			Once().
+			Return(expected, nil)
+
+		facets, err := uc.FetchGroupFacets(context.Background(), "Led Zeppelin")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, facets)
+	})
+}
+
+func TestSongUseCase_SuggestNames(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("Suggest", context.Background(), entity.SuggestGroupNameField, "bea", uint64(10)).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		names, err := uc.SuggestNames(context.Background(), entity.SuggestGroupNameField, "bea", 10)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to suggest names")
+		assert.Nil(t, names)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("Suggest", context.Background(), entity.SuggestSongNameField, "hey", uint64(10)).
+			Once().
+			Return([]string{"Hey Jude"}, nil)
+
+		names, err := uc.SuggestNames(context.Background(), entity.SuggestSongNameField, "hey", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Hey Jude"}, names)
+	})
+}
+
+func TestSongUseCase_FetchSong(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		song, err := uc.FetchSong(context.Background(), fixedUUID)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, song)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}, nil)
+
+		song, err := uc.FetchSong(context.Background(), fixedUUID)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, song)
+		assert.Equal(t, fixedUUID, song.ID)
+	})
+}
+
+func TestSongUseCase_SearchSongs(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("Search", context.Background(), "bohemian", entity.Pagination{}).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		songs, pagination, err := uc.SearchSongs(context.Background(), "bohemian", entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to search songs")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("normalized query", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("Search", context.Background(), "Beyonc\u00e9", entity.Pagination{}).
+			Once().
+			Return([]*entity.Song{{ID: fixedUUID}}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+
+		songs, pagination, err := uc.SearchSongs(context.Background(), "Beyonce\u0301", entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+}
+
+func TestSongUseCase_FetchSimilarSongs(t *testing.T) {
+	t.Run("song not found", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		songs, pagination, err := uc.FetchSimilarSongs(context.Background(), fixedUUID, entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		song := &entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(song, nil)
+		songRepoMock.
+			On("GetSimilar", context.Background(), *song, entity.Pagination{}).
+			Once().
+			Return(nil, nil, errors.New("unknown error"))
+
+		songs, pagination, err := uc.FetchSimilarSongs(context.Background(), fixedUUID, entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch similar songs")
+		assert.Nil(t, songs)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		song := &entity.Song{ID: fixedUUID, GroupName: "Test Group", Name: "Test Song"}
+		similarID := uuid.New()
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(song, nil)
+		songRepoMock.
+			On("GetSimilar", context.Background(), *song, entity.Pagination{}).
+			Once().
+			Return([]*entity.Song{
+				{ID: similarID, GroupName: "Test Group", Name: "Other Song"},
+			}, &entity.Pagination{Limit: entity.DefaultLimit, Items: 1, Total: 1}, nil)
+
+		songs, pagination, err := uc.FetchSimilarSongs(context.Background(), fixedUUID, entity.Pagination{})
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, similarID, songs[0].ID)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, uint64(1), pagination.Total)
+	})
+}
+
+func TestSongUseCase_FetchSongWithVerses(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		song, pagination, err := uc.FetchSongWithVerses(context.Background(), fixedUUID, entity.Pagination{}, entity.VerseGranularity, false)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch song")
+		assert.Nil(t, song)
+		assert.Nil(t, pagination)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				SongDetail: entity.SongDetail{
+					ReleaseDate: fixedTime,
+					Text:        "line1\nline2\n\nline4\nline4\n",
+					Link:        "https://example.com",
+				},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		song, pagination, err := uc.FetchSongWithVerses(context.Background(), fixedUUID, entity.Pagination{}, entity.VerseGranularity, false)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, song)
+		assert.Equal(t, fixedUUID, song.ID)
+		assert.Equal(t, "Test Group", song.GroupName)
+		assert.Equal(t, "Test Song", song.Name)
+		assert.Len(t, song.Verses, 2)
+		assert.Equal(t, fixedTime, song.CreatedAt)
+		assert.Equal(t, fixedTime, song.UpdatedAt)
+		assert.NotNil(t, pagination)
+		assert.Equal(t, entity.DefaultOffset, pagination.Offset)
+		assert.Equal(t, entity.DefaultLimit, pagination.Limit)
+		assert.Equal(t, uint64(2), pagination.Items)
+		assert.Equal(t, uint64(2), pagination.Total)
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		song, pagination, err := uc.FetchSongWithVerses(context.Background(), fixedUUID, entity.Pagination{}, entity.VerseGranularity, false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, song.Verses)
+		assert.Equal(t, uint64(0), pagination.Items)
+		assert.Equal(t, uint64(0), pagination.Total)
+	})
+
+	t.Run("line granularity", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{
+				ID:         fixedUUID,
+				GroupName:  "Test Group",
+				Name:       "Test Song",
+				SongDetail: entity.SongDetail{Text: "line1\nline2\n\nline3\nline4\n\nline5\n"},
+			}, nil)
+
+		song, pagination, err := uc.FetchSongWithVerses(
+			context.Background(),
+			fixedUUID,
+			entity.Pagination{Offset: 1, Limit: 3},
+			entity.LineGranularity,
+			false,
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"line2", "line3", "line4"}, song.Verses)
+		assert.Equal(t, uint64(1), pagination.Offset)
+		assert.Equal(t, uint64(3), pagination.Limit)
+		assert.Equal(t, uint64(3), pagination.Items)
+		assert.Equal(t, uint64(5), pagination.Total)
+	})
+
+	t.Run("dedupe repeated chorus", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{
+				ID:         fixedUUID,
+				SongDetail: entity.SongDetail{Text: "Verse 1\n\nChorus\n\nChorus\n\nChorus\n\nVerse 2\n\nChorus\n\nChorus"},
+			}, nil)
+
+		song, pagination, err := uc.FetchSongWithVerses(
+			context.Background(),
+			fixedUUID,
+			entity.Pagination{Offset: 1, Limit: 3},
+			entity.VerseGranularity,
+			true,
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Chorus", "Verse 2", "Chorus"}, song.Verses)
+		assert.Equal(t, []int{3, 1, 2}, song.Repeats)
+		assert.Equal(t, uint64(3), pagination.Items)
+		assert.Equal(t, uint64(4), pagination.Total)
+	})
+}
+
+func TestCollapseVerses(t *testing.T) {
+	verses, repeats := collapseVerses([]string{"a", "a", "b", "a", "c", "c", "c"})
+
+	assert.Equal(t, []string{"a", "b", "a", "c"}, verses)
+	assert.Equal(t, []int{2, 1, 1, 3}, repeats)
+
+	verses, repeats = collapseVerses(nil)
+
+	assert.Empty(t, verses)
+	assert.Empty(t, repeats)
+}
+
+func TestSongUseCase_FetchSongsWithVerses(t *testing.T) {
+	otherUUID := uuid.New()
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByIDs", context.Background(), []uuid.UUID{fixedUUID}).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		pages, notFound, err := uc.FetchSongsWithVerses(context.Background(), []uuid.UUID{fixedUUID}, entity.Pagination{})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch songs")
+		assert.Nil(t, pages)
+		assert.Nil(t, notFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songIDs := []uuid.UUID{otherUUID, fixedUUID, fixedUUID}
+
+		songRepoMock.
+			On("GetByIDs", context.Background(), songIDs).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:         fixedUUID,
+					GroupName:  "Test Group",
+					Name:       "Test Song",
+					SongDetail: entity.SongDetail{Text: "line1\n\nline3\n\nline5"},
+				},
+			}, nil)
+
+		pages, notFound, err := uc.FetchSongsWithVerses(context.Background(), songIDs, entity.Pagination{Offset: 1, Limit: 1})
+
+		assert.NoError(t, err)
+		assert.Len(t, pages, 1)
+		assert.Equal(t, fixedUUID, pages[0].Song.ID)
+		assert.Equal(t, []string{"line3"}, pages[0].Song.Verses)
+		assert.Equal(t, uint64(1), pages[0].Pagination.Items)
+		assert.Equal(t, uint64(3), pages[0].Pagination.Total)
+		assert.Equal(t, []uuid.UUID{otherUUID}, notFound)
+	})
+}
+
+func TestSongUseCase_CompareSongTexts(t *testing.T) {
+	otherUUID := uuid.New()
+
+	t.Run("other song not found", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID, SongDetail: entity.SongDetail{Text: "Line1"}}, nil)
+
+		songRepoMock.
+			On("GetByID", context.Background(), otherUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		diff, err := uc.CompareSongTexts(context.Background(), fixedUUID, otherUUID)
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, diff)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID, SongDetail: entity.SongDetail{Text: "Line1\nLine2"}}, nil)
+
+		songRepoMock.
+			On("GetByID", context.Background(), otherUUID).
+			Once().
+			Return(&entity.Song{ID: otherUUID, SongDetail: entity.SongDetail{Text: "Line1\nLine3"}}, nil)
+
+		diff, err := uc.CompareSongTexts(context.Background(), fixedUUID, otherUUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.LineDiff{
+			{Operation: entity.DiffUnchanged, Line: "Line1"},
+			{Operation: entity.DiffRemoved, Line: "Line2"},
+			{Operation: entity.DiffAdded, Line: "Line3"},
+		}, diff)
+	})
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want []entity.LineDiff
+	}{
+		{
+			name: "identical lyrics",
+			a:    []string{"Line1", "Line2"},
+			b:    []string{"Line1", "Line2"},
+			want: []entity.LineDiff{
+				{Operation: entity.DiffUnchanged, Line: "Line1"},
+				{Operation: entity.DiffUnchanged, Line: "Line2"},
+			},
+		},
+		{
+			name: "completely different lyrics",
+			a:    []string{"Line1", "Line2"},
+			b:    []string{"Line3"},
+			want: []entity.LineDiff{
+				{Operation: entity.DiffRemoved, Line: "Line1"},
+				{Operation: entity.DiffRemoved, Line: "Line2"},
+				{Operation: entity.DiffAdded, Line: "Line3"},
+			},
+		},
+		{
+			name: "partially overlapping lyrics",
+			a:    []string{"Intro", "Chorus", "Verse1", "Chorus"},
+			b:    []string{"Chorus", "Verse2", "Chorus", "Outro"},
+			want: []entity.LineDiff{
+				{Operation: entity.DiffRemoved, Line: "Intro"},
+				{Operation: entity.DiffUnchanged, Line: "Chorus"},
+				{Operation: entity.DiffRemoved, Line: "Verse1"},
+				{Operation: entity.DiffAdded, Line: "Verse2"},
+				{Operation: entity.DiffUnchanged, Line: "Chorus"},
+				{Operation: entity.DiffAdded, Line: "Outro"},
+			},
+		},
+		{
+			name: "empty lyrics",
+			want: []entity.LineDiff{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffLines(tt.a, tt.b))
+		})
+	}
+}
+
+func TestMatchVerses(t *testing.T) {
+	verses := []string{"Intro", "Verse one", "Chorus", "Verse two", "Outro chorus"}
+
+	tests := []struct {
+		name          string
+		query         string
+		contextVerses int
+		want          []entity.VerseMatch
+	}{
+		{
+			name:          "match in the middle",
+			query:         "verse two",
+			contextVerses: 1,
+			want: []entity.VerseMatch{
+				{Index: 3, Start: 2, Verses: []string{"Chorus", "Verse two", "Outro chorus"}},
+			},
+		},
+		{
+			name:          "match at the first verse",
+			query:         "intro",
+			contextVerses: 2,
+			want: []entity.VerseMatch{
+				{Index: 0, Start: 0, Verses: []string{"Intro", "Verse one", "Chorus"}},
+			},
+		},
+		{
+			name:          "match at the last verse",
+			query:         "outro",
+			contextVerses: 2,
+			want: []entity.VerseMatch{
+				{Index: 4, Start: 2, Verses: []string{"Chorus", "Verse two", "Outro chorus"}},
+			},
+		},
+		{
+			name:          "several matches without context",
+			query:         "CHORUS",
+			contextVerses: 0,
+			want: []entity.VerseMatch{
+				{Index: 2, Start: 2, Verses: []string{"Chorus"}},
+				{Index: 4, Start: 4, Verses: []string{"Outro chorus"}},
+			},
+		},
+		{
+			name:          "context wider than the text",
+			query:         "chorus",
+			contextVerses: 10,
+			want: []entity.VerseMatch{
+				{Index: 2, Start: 0, Verses: verses},
+				{Index: 4, Start: 0, Verses: verses},
+			},
+		},
+		{
+			name:  "no match",
+			query: "bridge",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchVerses(verses, tt.query, tt.contextVerses))
+		})
+	}
+}
+
+func TestSongUseCase_SearchSongVerses(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		matches, err := uc.SearchSongVerses(context.Background(), fixedUUID, "chorus", 1)
+
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, matches)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{
+				ID:         fixedUUID,
+				SongDetail: entity.SongDetail{Text: "Line1\nLine2\n\nChorus\n\nLine3"},
+			}, nil)
+
+		matches, err := uc.SearchSongVerses(context.Background(), fixedUUID, "chorus", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.VerseMatch{
+			{Index: 1, Start: 0, Verses: []string{"Line1\nLine2", "Chorus", "Line3"}},
+		}, matches)
+	})
+}
+
+func TestSongUseCase_FetchTextStats(t *testing.T) {
+	const text = "Is this the real life?\nIs this just fantasy?\n\n" +
+		"Caught in a landslide,\nNo escape from reality\n\n" +
+		"Don’t stop me now — 東京 🎸"
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		stats, err := uc.FetchTextStats(context.Background(), fixedUUID)
+
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, stats)
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		stats, err := uc.FetchTextStats(context.Background(), fixedUUID)
+
+		assert.ErrorIs(t, err, entity.ErrNoVerses)
+		assert.Nil(t, stats)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID, SongDetail: entity.SongDetail{Text: text}}, nil)
+
+		stats, err := uc.FetchTextStats(context.Background(), fixedUUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.TextStats{Words: 22, Lines: 5, Verses: 3, UniqueWords: 20}, stats)
+	})
+}
+
+func TestSplitWords(t *testing.T) {
+	assert.Equal(t, []string{"Don't", "stop", "rock'n'roll"}, splitWords("Don't stop... rock'n'roll'"))
+	assert.Equal(t, []string{"Beyoncé", "99", "東京事変"}, splitWords("«Beyoncé» 99 🎸 東京事変!"))
+	assert.Empty(t, splitWords(" ✨ — ✨ "))
+}
+
+func TestSongUseCase_FetchRandomVerse(t *testing.T) {
+	const text = "Line1\nLine2\n\nChorus\n\nLine3"
+
+	verses := []string{"Line1\nLine2", "Chorus", "Line3"}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		verse, err := uc.FetchRandomVerse(context.Background(), fixedUUID)
+
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, verse)
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		verse, err := uc.FetchRandomVerse(context.Background(), fixedUUID)
+
+		assert.ErrorIs(t, err, entity.ErrNoVerses)
+		assert.Nil(t, verse)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, WithRandomSource(rand.NewPCG(1, 2)))
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Times(10).
+			Return(&entity.Song{ID: fixedUUID, SongDetail: entity.SongDetail{Text: text}}, nil)
+
+		for range 10 {
+			verse, err := uc.FetchRandomVerse(context.Background(), fixedUUID)
+
+			assert.NoError(t, err)
+			if assert.NotNil(t, verse) && assert.Less(t, verse.Index, len(verses)) {
+				assert.Equal(t, verses[verse.Index], verse.Text)
+			}
+		}
+	})
+}
+
+func TestSongUseCase_ReorderVerses(t *testing.T) {
+	const text = "Line1\nLine2\n\nChorus\n\nLine3"
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		song, err := uc.ReorderVerses(context.Background(), fixedUUID, []int{0})
+
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, song)
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		song, err := uc.ReorderVerses(context.Background(), fixedUUID, []int{0})
+
+		assert.ErrorIs(t, err, entity.ErrNoVerses)
+		assert.Nil(t, song)
+	})
+
+	for name, order := range map[string][]int{
+		"too few indices":    {1, 0},
+		"too many indices":   {1, 0, 2, 3},
+		"repeated index":     {0, 0, 2},
+		"index out of range": {0, 1, 3},
+		"negative index":     {-1, 0, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			uc, _, songRepoMock := initSongUseCase(t)
+
+			songRepoMock.
+				On("GetByID", context.Background(), fixedUUID).
+				Once().
+				Return(&entity.Song{ID: fixedUUID, SongDetail: entity.SongDetail{Text: text}}, nil)
+
+			song, err := uc.ReorderVerses(context.Background(), fixedUUID, order)
+
+			assert.ErrorIs(t, err, entity.ErrInvalidVerseOrder)
+			assert.Nil(t, song)
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{ID: fixedUUID, SongDetail: entity.SongDetail{Text: text}}, nil)
+
+		songRepoMock.
+			On("Update", context.Background(), fixedUUID, entity.Song{
+				SongDetail:   entity.SongDetail{Text: "Chorus\n\nLine3\n\nLine1\nLine2"},
+				DetailSource: entity.DetailSourceClient,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID, SongDetail: entity.SongDetail{Text: "Chorus\n\nLine3\n\nLine1\nLine2"}}, nil)
+
+		song, err := uc.ReorderVerses(context.Background(), fixedUUID, []int{1, 2, 0})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Chorus\n\nLine3\n\nLine1\nLine2", song.SongDetail.Text)
+	})
+}
+
+func TestNumberLines(t *testing.T) {
+	const text = "Line1\nLine2\n\nLine3\n\n\nLine4"
+
+	t.Run("skip blank lines", func(t *testing.T) {
+		assert.Equal(t, []entity.NumberedLine{
+			{Number: 1, Text: "Line1"},
+			{Number: 2, Text: "Line2"},
+			{Number: 3, Text: "Line3"},
+			{Number: 4, Text: "Line4"},
+		}, numberLines(text, false))
+	})
+
+	t.Run("number blank lines", func(t *testing.T) {
+		assert.Equal(t, []entity.NumberedLine{
+			{Number: 1, Text: "Line1"},
+			{Number: 2, Text: "Line2"},
+			{Number: 3, Text: ""},
+			{Number: 4, Text: "Line3"},
+			{Number: 5, Text: ""},
+			{Number: 6, Text: ""},
+			{Number: 7, Text: "Line4"},
+		}, numberLines(text, true))
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		assert.Empty(t, numberLines("", false))
+		assert.Empty(t, numberLines("", true))
+	})
+}
+
+func TestSongUseCase_FetchSongLines(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(nil, entity.ErrSongNotFound)
+
+		song, lines, err := uc.FetchSongLines(context.Background(), fixedUUID, false)
+
+		assert.ErrorIs(t, err, entity.ErrSongNotFound)
+		assert.Nil(t, song)
+		assert.Nil(t, lines)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{
+				ID:         fixedUUID,
+				SongDetail: entity.SongDetail{Text: "Line1\n\nChorus"},
+			}, nil)
+
+		song, lines, err := uc.FetchSongLines(context.Background(), fixedUUID, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, song.ID)
+		assert.Equal(t, []entity.NumberedLine{
+			{Number: 1, Text: "Line1"},
+			{Number: 2, Text: ""},
+			{Number: 3, Text: "Chorus"},
+		}, lines)
+	})
+}
+
+func TestSongUseCase_PreviewVerses(t *testing.T) {
+	const text = "Line1\nLine2\n\nLine3\nLine4\n\nLine5"
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, _ := initSongUseCase(t)
+
+		verses := uc.PreviewVerses(text)
+
+		assert.Equal(t, []string{"Line1\nLine2", "Line3\nLine4", "Line5"}, verses)
+	})
+
+	t.Run("matches stored song verses", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("GetByID", context.Background(), fixedUUID).
+			Once().
+			Return(&entity.Song{
+				ID:         fixedUUID,
+				GroupName:  "Test Group",
+				Name:       "Test Song",
+				SongDetail: entity.SongDetail{Text: text},
+			}, nil)
+
+		song, _, err := uc.FetchSongWithVerses(context.Background(), fixedUUID, entity.Pagination{}, entity.VerseGranularity, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, song.Verses, uc.PreviewVerses(text))
+	})
+}
+
+func TestSongUseCase_ModifySong(t *testing.T) {
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("Update", context.Background(), fixedUUID, entity.Song{
+				SongDetail: entity.SongDetail{
+					Text: "New Test Text",
+					Link: "https://new-example.com",
+				},
+				DetailSource: entity.DetailSourceClient,
+			}).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		song, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{
+				Text: "New Test Text",
+				Link: "https://new-example.com",
+			},
+		})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to modify song")
+		assert.Nil(t, song)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("Update", context.Background(), fixedUUID, entity.Song{
+				SongDetail: entity.SongDetail{
+					Text: "New Test Text",
+					Link: "https://new-example.com",
+				},
+				DetailSource: entity.DetailSourceClient,
+			}).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				SongDetail: entity.SongDetail{
+					ReleaseDate: fixedTime,
+					Text:        "New Test Text",
+					Link:        "https://new-example.com",
+				},
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		song, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{
+				Text: "New Test Text",
+				Link: "https://new-example.com",
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, song)
+		assert.Equal(t, fixedUUID, song.ID)
+		assert.Equal(t, "Test Group", song.GroupName)
+		assert.Equal(t, "Test Song", song.Name)
+		assert.Equal(t, fixedTime, song.SongDetail.ReleaseDate)
+		assert.Equal(t, "New Test Text", song.SongDetail.Text)
+		assert.Equal(t, "https://new-example.com", song.SongDetail.Link)
+		assert.Equal(t, fixedTime, song.CreatedAt)
+		assert.Equal(t, fixedTime, song.UpdatedAt)
+	})
+
+	t.Run("success with sort name", func(t *testing.T) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, WithSortNameArticles("The"))
+
+		songRepoMock.
+			On("Update", context.Background(), fixedUUID, entity.Song{
+				GroupName: "The Beatles",
+				SortName:  "Beatles",
+			}).
+			Once().
+			Return(&entity.Song{
+				ID:        fixedUUID,
+				GroupName: "The Beatles",
+				Name:      "Test Song",
+				SortName:  "Beatles",
+				CreatedAt: fixedTime,
+				UpdatedAt: fixedTime,
+			}, nil)
+
+		song, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
+			GroupName: "The Beatles",
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, song)
+		assert.Equal(t, "The Beatles", song.GroupName)
+		assert.Equal(t, "Beatles", song.SortName)
+	})
+}
+
+func TestSongUseCase_ModifySongs(t *testing.T) {
+	otherUUID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174001")
+
+	updates := []entity.SongUpdate{
+		{SongID: fixedUUID, Song: entity.Song{SongDetail: entity.SongDetail{ReleaseDate: fixedTime}}},
+		{SongID: otherUUID, Song: entity.Song{SongDetail: entity.SongDetail{ReleaseDate: fixedTime}}},
+	}
+
+	expectedUpdates := []entity.SongUpdate{
+		{
+			SongID: fixedUUID,
+			Song: entity.Song{
+				SongDetail:   entity.SongDetail{ReleaseDate: fixedTime},
+				DetailSource: entity.DetailSourceClient,
+			},
+		},
+		{
+			SongID: otherUUID,
+			Song: entity.Song{
+				SongDetail:   entity.SongDetail{ReleaseDate: fixedTime},
+				DetailSource: entity.DetailSourceClient,
+			},
+		},
+	}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("UpdateBatch", context.Background(), expectedUpdates).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		songs, err := uc.ModifySongs(context.Background(), updates)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to modify songs")
+		assert.Nil(t, songs)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("UpdateBatch", context.Background(), expectedUpdates).
+			Once().
+			Return([]*entity.Song{
+				{
+					ID:           fixedUUID,
+					GroupName:    "Test Group",
+					Name:         "Test Song",
+					SongDetail:   entity.SongDetail{ReleaseDate: fixedTime},
+					DetailSource: entity.DetailSourceClient,
+					CreatedAt:    fixedTime,
+					UpdatedAt:    fixedTime,
+				},
+				nil,
+			}, nil)
+
+		songs, err := uc.ModifySongs(context.Background(), updates)
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 2)
+		assert.Equal(t, fixedUUID, songs[0].ID)
+		assert.Nil(t, songs[1])
+	})
+}
+
+func TestSongUseCase_ModifySongsWhere(t *testing.T) {
+	otherUUID := uuid.New()
+	filter := entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"}
+
+	expectedSong := entity.Song{
+		SongDetail:   entity.SongDetail{Link: "https://example.com"},
+		DetailSource: entity.DetailSourceClient,
+	}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("UpdateWhere", context.Background(), expectedSong, filter).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		updated, err := uc.ModifySongsWhere(context.Background(), entity.Song{
+			SongDetail: entity.SongDetail{Link: "https://example.com"},
+		}, filter)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to modify songs")
+		assert.Zero(t, updated)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := uc.SubscribeSongEvents(ctx)
+
+		songRepoMock.
+			On("UpdateWhere", context.Background(), expectedSong, filter).
+			Once().
+			Return([]uuid.UUID{fixedUUID, otherUUID}, nil)
+
+		updated, err := uc.ModifySongsWhere(context.Background(), entity.Song{
+			SongDetail: entity.SongDetail{Link: "https://example.com"},
+		}, filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+
+		for _, songID := range []uuid.UUID{fixedUUID, otherUUID} {
+			event := <-events
+			assert.Equal(t, entity.SongUpdatedEvent, event.Type)
+			assert.Equal(t, songID, event.SongID)
+		}
+	})
+}
+
+func TestSongUseCase_TouchSongs(t *testing.T) {
+	filter := entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"}
+
+	t.Run("no filters", func(t *testing.T) {
+		uc, _, _ := initSongUseCase(t)
+
+		touched, err := uc.TouchSongs(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrNoFilters)
+		assert.Zero(t, touched)
+	})
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("TouchWhere", context.Background(), filter).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		touched, err := uc.TouchSongs(context.Background(), filter)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to touch songs")
+		assert.Zero(t, touched)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := uc.SubscribeSongEvents(ctx)
+
+		songRepoMock.
+			On("TouchWhere", context.Background(), filter).
+			Once().
+			Return([]uuid.UUID{fixedUUID}, nil)
+
+		touched, err := uc.TouchSongs(context.Background(), filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), touched)
+
+		event := <-events
+		assert.Equal(t, entity.SongUpdatedEvent, event.Type)
+		assert.Equal(t, fixedUUID, event.SongID)
+	})
+}
+
+func TestSongUseCase_ResplitVerses(t *testing.T) {
+	ids := []uuid.UUID{
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174001"),
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174002"),
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174003"),
+	}
+
+	initUseCase := func(t *testing.T) (*SongUseCase, *usecase.MockSongRepository) {
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(usecase.NewMockMusicInfoAPI(t), songRepoMock, WithResplitBatchSize(2))
+
+		return uc, songRepoMock
+	}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(2)).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		updated, err := uc.ResplitVerses(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch songs")
+		assert.Zero(t, updated)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(2)).
+			Once().
+			Return([]*entity.Song{
+				{ID: ids[0], SongDetail: entity.SongDetail{Text: "Verse 1\n\nVerse 2\n\nVerse 3"}},
+				{ID: ids[1]},
+			}, nil)
+		songRepoMock.
+			On("UpdateVerseCounts", context.Background(), []entity.SongVerseCount{
+				{SongID: ids[0], Count: 3},
+				{SongID: ids[1], Count: 0},
+			}).
+			Once().
+			Return(int64(2), nil)
+		songRepoMock.
+			On("GetAfter", context.Background(), ids[1], uint64(2)).
+			Once().
+			Return([]*entity.Song{
+				{ID: ids[2], SongDetail: entity.SongDetail{Text: "Line 1\nLine 2"}},
+			}, nil)
+		songRepoMock.
+			On("UpdateVerseCounts", context.Background(), []entity.SongVerseCount{
+				{SongID: ids[2], Count: 1},
+			}).
+			Once().
+			Return(int64(0), nil)
+
+		updated, err := uc.ResplitVerses(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+	})
+}
+
+func TestSongUseCase_RecomputeSortNames(t *testing.T) {
+	ids := []uuid.UUID{
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174001"),
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174002"),
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174003"),
+	}
+
+	initUseCase := func(t *testing.T) (*SongUseCase, *usecase.MockSongRepository) {
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(
+			usecase.NewMockMusicInfoAPI(t),
+			songRepoMock,
+			WithResplitBatchSize(2),
+			WithSortNameArticles("The", "Die"),
+		)
+
+		return uc, songRepoMock
+	}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(2)).
+			Once().
+			Return([]*entity.Song{{ID: ids[0], GroupName: "The Beatles"}}, nil)
+		songRepoMock.
+			On("UpdateSortNames", context.Background(), []entity.SongSortName{{SongID: ids[0], SortName: "Beatles"}}).
+			Once().
+			Return(int64(0), errors.New("unknown error"))
+
+		updated, err := uc.RecomputeSortNames(context.Background())
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to store sort names")
+		assert.Zero(t, updated)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(2)).
+			Once().
+			Return([]*entity.Song{
+				{ID: ids[0], GroupName: "The Beatles"},
+				{ID: ids[1], GroupName: "Die Toten Hosen"},
+			}, nil)
+		songRepoMock.
+			On("UpdateSortNames", context.Background(), []entity.SongSortName{
+				{SongID: ids[0], SortName: "Beatles"},
+				{SongID: ids[1], SortName: "Toten Hosen"},
+			}).
+			Once().
+			Return(int64(1), nil)
+		songRepoMock.
+			On("GetAfter", context.Background(), ids[1], uint64(2)).
+			Once().
+			Return([]*entity.Song{
+				{ID: ids[2], GroupName: "A Tribe Called Quest"},
+			}, nil)
+		songRepoMock.
+			On("UpdateSortNames", context.Background(), []entity.SongSortName{
+				{SongID: ids[2], SortName: "A Tribe Called Quest"},
+			}).
+			Once().
+			Return(int64(1), nil)
+
+		updated, err := uc.RecomputeSortNames(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+	})
+}
+
+func TestSongUseCase_WalkSongs(t *testing.T) {
+	ids := []uuid.UUID{
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174001"),
+		uuid.MustParse("123e4567-e89b-12d3-a456-426614174002"),
+	}
+
+	initUseCase := func(t *testing.T) (*SongUseCase, *usecase.MockSongRepository) {
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(usecase.NewMockMusicInfoAPI(t), songRepoMock)
+
+		return uc, songRepoMock
+	}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(walkSongsBatchSize)).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		err := uc.WalkSongs(context.Background(), func(song *entity.Song) error {
+			t.Fatal("fn must not be called without songs")
+			return nil
+		})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to fetch songs")
+	})
+
+	t.Run("fn error stops walking", func(t *testing.T) {
+		uc, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(walkSongsBatchSize)).
+			Once().
+			Return([]*entity.Song{{ID: ids[0]}, {ID: ids[1]}}, nil)
+
+		stopErr := errors.New("stop")
+		var walked []uuid.UUID
+
+		err := uc.WalkSongs(context.Background(), func(song *entity.Song) error {
+			walked = append(walked, song.ID)
+			return stopErr
+		})
+
+		assert.ErrorIs(t, err, stopErr)
+		assert.Equal(t, ids[:1], walked)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(walkSongsBatchSize)).
+			Once().
+			Return([]*entity.Song{{ID: ids[0]}, {ID: ids[1]}}, nil)
+
+		var walked []uuid.UUID
+
+		err := uc.WalkSongs(context.Background(), func(song *entity.Song) error {
+			walked = append(walked, song.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ids, walked)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		uc, songRepoMock := initUseCase(t)
+
+		filter := entity.SongFilter{Field: entity.SongGroupNameFilterField, Value: "Queen"}
+
+		songRepoMock.
+			On("GetAfter", context.Background(), uuid.Nil, uint64(walkSongsBatchSize), filter).
+			Once().
+			Return([]*entity.Song{{ID: ids[0]}}, nil)
+
+		var walked []uuid.UUID
+
+		err := uc.WalkSongs(context.Background(), func(song *entity.Song) error {
+			walked = append(walked, song.ID)
+			return nil
+		}, filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ids[:1], walked)
+	})
+}
+
+func TestSongUseCase_RollbackImport(t *testing.T) {
+	importID := uuid.New()
+	otherUUID := uuid.New()
+	filter := entity.SongFilter{Field: entity.SongImportFilterField, Value: importID}
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("DeleteWhere", context.Background(), filter).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		deleted, err := uc.RollbackImport(context.Background(), importID)
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to remove imported songs")
+		assert.Zero(t, deleted)
+	})
+
+	t.Run("import not found", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("DeleteWhere", context.Background(), filter).
+			Once().
+			Return(nil, nil)
+
+		deleted, err := uc.RollbackImport(context.Background(), importID)
+
+		assert.ErrorIs(t, err, entity.ErrImportNotFound)
+		assert.Zero(t, deleted)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := uc.SubscribeSongEvents(ctx)
+
+		songRepoMock.
+			On("DeleteWhere", context.Background(), filter).
+			Once().
+			Return([]uuid.UUID{fixedUUID, otherUUID}, nil)
+
+		deleted, err := uc.RollbackImport(context.Background(), importID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		for _, songID := range []uuid.UUID{fixedUUID, otherUUID} {
+			event := <-events
+			assert.Equal(t, entity.SongDeletedEvent, event.Type)
+			assert.Equal(t, songID, event.SongID)
+		}
+	})
+}
+
+func TestSongUseCase_RemoveSongs(t *testing.T) {
+	otherUUID := uuid.New()
+
+	t.Run("song repository error", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("DeleteBatch", context.Background(), []uuid.UUID{fixedUUID}).
+			Once().
+			Return(nil, errors.New("unknown error"))
+
+		deleted, notFound, err := uc.RemoveSongs(context.Background(), []uuid.UUID{fixedUUID})
+
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed to remove songs")
+		assert.Nil(t, deleted)
+		assert.Nil(t, notFound)
+	})
+
+	t.Run("partially found with duplicates", func(t *testing.T) {
+		uc, _, songRepoMock := initSongUseCase(t)
+
+		songRepoMock.
+			On("DeleteBatch", context.Background(), []uuid.UUID{otherUUID, fixedUUID}).
+			Once().
+			Return([]uuid.UUID{fixedUUID}, nil)
+
+		deleted, notFound, err := uc.RemoveSongs(context.Background(), []uuid.UUID{otherUUID, fixedUUID, otherUUID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{fixedUUID}, deleted)
+		assert.Equal(t, []uuid.UUID{otherUUID}, notFound)
 	})
 }
 
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+)
+
+// stripControlChars removes the control characters from song text, except the line feeds, carriage returns,
+// and tabs that lay the lyrics out.
+func stripControlChars(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// sanitizeText strips the control characters from the text of a song when enabled by WithControlCharStripping.
+func (uc *SongUseCase) sanitizeText(song entity.Song) entity.Song {
+	if uc.stripControls && song.SongDetail.Text != "" {
+		song.SongDetail.Text = stripControlChars(song.SongDetail.Text)
+	}
+	return song
+}
+
+// checkVerseCount returns entity.ErrTooManyVerses when the text of a song has more verses than allowed by WithMaxVerses.
+func (uc *SongUseCase) checkVerseCount(song entity.Song) error {
+	if uc.maxVerses <= 0 {
+		return nil
+	}
+
+	if verses := countVerses(song.SongDetail.Text); verses > uc.maxVerses {
+		return fmt.Errorf("%d verses, at most %d allowed: %w", verses, uc.maxVerses, entity.ErrTooManyVerses)
+	}
+
+	return nil
+}
+
+// copyrightYearPattern matches a copyright notice followed by a year, such as "(c) 1971", "© 1971" or "Copyright 1971".
+var copyrightYearPattern = regexp.MustCompile(`(?i)(?:\(c\)|©|copyright)\s*((?:19|20)\d{2})\b`)
+
+// extractCopyrightYear returns the year of the first copyright notice found in song text.
+// Years in the future are not taken for release years.
+func extractCopyrightYear(text string) (int, bool) {
+	match := copyrightYearPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, false
+	}
+
+	year, err := strconv.Atoi(match[1])
+	if err != nil || year > time.Now().Year() {
+		return 0, false
+	}
+
+	return year, true
+}
+
+// guessReleaseDate sets the release date of a song missing one to January 1 of the copyright year found in its text,
+// when enabled by WithReleaseYearFromText. The details of the song are then marked as derived by the heuristic.
+func (uc *SongUseCase) guessReleaseDate(song entity.Song) entity.Song {
+	if !uc.releaseYearFromText || !song.SongDetail.ReleaseDate.IsZero() {
+		return song
+	}
+
+	year, ok := extractCopyrightYear(song.SongDetail.Text)
+	if !ok {
+		return song
+	}
+
+	song.SongDetail.ReleaseDate = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	song.DetailSource = entity.DetailSourceHeuristic
+
+	return song
+}
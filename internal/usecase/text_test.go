@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vadimbarashkov/online-song-library/internal/entity"
+	"github.com/vadimbarashkov/online-song-library/mocks/usecase"
+)
+
+func TestStripControlChars(t *testing.T) {
+	assert.Equal(t, "Line1\r\nLine2\n\n\tLine3", stripControlChars("Li\x00ne1\r\nLine2\x0b\n\n\tLine3\x7f"))
+	assert.Equal(t, "Привет, мир", stripControlChars("Привет,\u0085 мир\x1b"))
+}
+
+func TestSongUseCase_ControlCharStripping(t *testing.T) {
+	const (
+		rawText       = "Verse\x0c one\x00\nLine\x1f two\n\n\tVerse two\x08"
+		sanitizedText = "Verse one\nLine two\n\n\tVerse two"
+	)
+
+	initUseCase := func(t *testing.T, opts ...Option) (*SongUseCase, *usecase.MockMusicInfoAPI, *usecase.MockSongRepository) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, opts...)
+
+		return uc, musicInfoAPIMock, songRepoMock
+	}
+
+	t.Run("add song", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t, WithControlCharStripping())
+
+		song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Text: rawText}, nil)
+
+		songRepoMock.
+			On("Save", mock.Anything, entity.Song{
+				GroupName:    "Test Group",
+				Name:         "Test Song",
+				SortName:     "Test Group",
+				SongDetail:   entity.SongDetail{Text: sanitizedText},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				DetailStatus: entity.DetailStatusFound,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("modify song", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t, WithControlCharStripping())
+
+		songRepoMock.
+			On("Update", context.Background(), fixedUUID, entity.Song{
+				SongDetail:   entity.SongDetail{Text: sanitizedText},
+				DetailSource: entity.DetailSourceClient,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{Text: rawText},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("Update", context.Background(), fixedUUID, entity.Song{
+				SongDetail:   entity.SongDetail{Text: rawText},
+				DetailSource: entity.DetailSourceClient,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.ModifySong(context.Background(), fixedUUID, entity.Song{
+			SongDetail: entity.SongDetail{Text: rawText},
+		})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestSongUseCase_MaxVerses(t *testing.T) {
+	const (
+		normalText    = "Line1\nLine2\n\nLine3\nLine4"
+		overLimitText = "Line1\n\nLine2\n\nLine3\n\nLine4"
+	)
+
+	song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+
+	initUseCase := func(t *testing.T) (*SongUseCase, *usecase.MockMusicInfoAPI, *usecase.MockSongRepository) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, WithMaxVerses(3), WithImportIDGenerator(func() uuid.UUID { return fixedUUID }))
+
+		return uc, musicInfoAPIMock, songRepoMock
+	}
+
+	t.Run("add over limit", func(t *testing.T) {
+		uc, musicInfoAPIMock, _ := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Text: overLimitText}, nil)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.ErrorIs(t, err, entity.ErrTooManyVerses)
+		assert.Nil(t, savedSong)
+	})
+
+	t.Run("add within limit", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Text: normalText}, nil)
+
+		songRepoMock.
+			On("Save", mock.Anything, entity.Song{
+				GroupName:    "Test Group",
+				Name:         "Test Song",
+				SortName:     "Test Group",
+				SongDetail:   entity.SongDetail{Text: normalText},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				DetailStatus: entity.DetailStatusFound,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		savedSong, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fixedUUID, savedSong.ID)
+	})
+
+	t.Run("import over limit", func(t *testing.T) {
+		uc, _, _ := initUseCase(t)
+
+		savedSongs, err := uc.ImportSongs(context.Background(), []entity.Song{
+			{GroupName: "Test Group", Name: "Test Song", SongDetail: entity.SongDetail{Text: normalText}},
+			{GroupName: "Test Group", Name: "Other Song", SongDetail: entity.SongDetail{Text: overLimitText}},
+		}, false)
+
+		assert.ErrorIs(t, err, entity.ErrTooManyVerses)
+		assert.ErrorContains(t, err, "song 1")
+		assert.Nil(t, savedSongs)
+	})
+
+	t.Run("import within limit", func(t *testing.T) {
+		uc, _, songRepoMock := initUseCase(t)
+
+		songRepoMock.
+			On("SaveBatch", context.Background(), []entity.Song{
+				{GroupName: "Test Group", Name: "Test Song", SortName: "Test Group", SongDetail: entity.SongDetail{Text: normalText}, ImportID: fixedUUID},
+			}).
+			Once().
+			Return([]*entity.Song{{ID: fixedUUID}}, nil)
+
+		savedSongs, err := uc.ImportSongs(context.Background(), []entity.Song{
+			{GroupName: "Test Group", Name: "Test Song", SongDetail: entity.SongDetail{Text: normalText}},
+		}, false)
+
+		assert.NoError(t, err)
+		assert.Len(t, savedSongs, 1)
+	})
+}
+
+func TestExtractCopyrightYear(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		year int
+		ok   bool
+	}{
+		{name: "parenthesized c", text: "Verse one\n\n(c) 1971 Atlantic Records", year: 1971, ok: true},
+		{name: "copyright sign", text: "©1975 EMI", year: 1975, ok: true},
+		{name: "copyright word", text: "Copyright 2004, all rights reserved", year: 2004, ok: true},
+		{name: "first notice wins", text: "(C) 1968\n(c) 1969", year: 1968, ok: true},
+		{name: "year without notice", text: "Summer of 1969", ok: false},
+		{name: "future year", text: "(c) 2099", ok: false},
+		{name: "no year", text: "Is this the real life?", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			year, ok := extractCopyrightYear(tt.text)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.year, year)
+		})
+	}
+}
+
+func TestSongUseCase_ReleaseYearFromText(t *testing.T) {
+	song := entity.Song{GroupName: "Test Group", Name: "Test Song"}
+
+	initUseCase := func(t *testing.T, opts ...Option) (*SongUseCase, *usecase.MockMusicInfoAPI, *usecase.MockSongRepository) {
+		musicInfoAPIMock := usecase.NewMockMusicInfoAPI(t)
+		songRepoMock := usecase.NewMockSongRepository(t)
+		uc := NewSongUseCase(musicInfoAPIMock, songRepoMock, opts...)
+
+		return uc, musicInfoAPIMock, songRepoMock
+	}
+
+	t.Run("text with year", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t, WithReleaseYearFromText())
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Text: "Verse\n\n(c) 1971"}, nil)
+
+		songRepoMock.
+			On("Save", mock.Anything, entity.Song{
+				GroupName: "Test Group",
+				Name:      "Test Song",
+				SortName:  "Test Group",
+				SongDetail: entity.SongDetail{
+					ReleaseDate: time.Date(1971, time.January, 1, 0, 0, 0, 0, time.UTC),
+					Text:        "Verse\n\n(c) 1971",
+				},
+				DetailSource: entity.DetailSourceHeuristic,
+				DetailStatus: entity.DetailStatusFound,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("text without year", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t, WithReleaseYearFromText())
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Text: "Verse"}, nil)
+
+		songRepoMock.
+			On("Save", mock.Anything, entity.Song{
+				GroupName:    "Test Group",
+				Name:         "Test Song",
+				SortName:     "Test Group",
+				SongDetail:   entity.SongDetail{Text: "Verse"},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				DetailStatus: entity.DetailStatusFound,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("upstream release date kept", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t, WithReleaseYearFromText())
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{ReleaseDate: fixedTime, Text: "(c) 1971"}, nil)
+
+		songRepoMock.
+			On("Save", mock.Anything, entity.Song{
+				GroupName:    "Test Group",
+				Name:         "Test Song",
+				SortName:     "Test Group",
+				SongDetail:   entity.SongDetail{ReleaseDate: fixedTime, Text: "(c) 1971"},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				DetailStatus: entity.DetailStatusFound,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		uc, musicInfoAPIMock, songRepoMock := initUseCase(t)
+
+		musicInfoAPIMock.
+			On("FetchSongInfo", mock.Anything, song).
+			Once().
+			Return(&entity.SongDetail{Text: "(c) 1971"}, nil)
+
+		songRepoMock.
+			On("Save", mock.Anything, entity.Song{
+				GroupName:    "Test Group",
+				Name:         "Test Song",
+				SortName:     "Test Group",
+				SongDetail:   entity.SongDetail{Text: "(c) 1971"},
+				DetailSource: entity.DetailSourceMusicInfoAPI,
+				DetailStatus: entity.DetailStatusFound,
+			}).
+			Once().
+			Return(&entity.Song{ID: fixedUUID}, nil)
+
+		_, err := uc.AddSong(context.Background(), song)
+
+		assert.NoError(t, err)
+	})
+}
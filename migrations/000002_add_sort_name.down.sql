@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS songs_sort_name_idx;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS sort_name;
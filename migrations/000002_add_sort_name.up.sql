@@ -0,0 +1,5 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS sort_name VARCHAR(255);
+
+UPDATE songs SET sort_name = regexp_replace(group_name, '^(the|a|an)\s+', '', 'i');
+
+CREATE INDEX IF NOT EXISTS songs_sort_name_idx ON songs(sort_name);
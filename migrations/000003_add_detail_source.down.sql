@@ -0,0 +1,3 @@
+ALTER TABLE songs DROP COLUMN IF EXISTS detail_source;
+
+DROP TYPE IF EXISTS song_detail_source;
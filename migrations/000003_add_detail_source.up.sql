@@ -0,0 +1,6 @@
+CREATE TYPE song_detail_source AS ENUM ('music_info_api', 'client', 'backfill');
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS detail_source song_detail_source;
+
+UPDATE songs SET detail_source = 'music_info_api'
+WHERE release_date IS NOT NULL OR text IS NOT NULL OR link IS NOT NULL;
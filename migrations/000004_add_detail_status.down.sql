@@ -0,0 +1,3 @@
+ALTER TABLE songs DROP COLUMN IF EXISTS detail_status;
+
+DROP TYPE IF EXISTS song_detail_status;
@@ -0,0 +1,6 @@
+CREATE TYPE song_detail_status AS ENUM ('found', 'not_found', 'failed');
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS detail_status song_detail_status;
+
+UPDATE songs SET detail_status = 'found'
+WHERE detail_source = 'music_info_api';
@@ -0,0 +1,3 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS songs_name_trgm_idx ON songs USING GIN (name gin_trgm_ops);
@@ -0,0 +1 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS verse_count INTEGER;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS link_checks(
+    song_id UUID REFERENCES songs(id) ON DELETE CASCADE,
+    link VARCHAR(2048) NOT NULL,
+    status_code INTEGER NOT NULL,
+    checked_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(song_id)
+);
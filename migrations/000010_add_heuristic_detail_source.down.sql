@@ -0,0 +1,10 @@
+UPDATE songs SET detail_source = 'music_info_api' WHERE detail_source = 'heuristic';
+
+ALTER TYPE song_detail_source RENAME TO song_detail_source_old;
+
+CREATE TYPE song_detail_source AS ENUM ('music_info_api', 'client', 'backfill');
+
+ALTER TABLE songs
+    ALTER COLUMN detail_source TYPE song_detail_source USING detail_source::text::song_detail_source;
+
+DROP TYPE song_detail_source_old;
@@ -0,0 +1 @@
+ALTER TYPE song_detail_source ADD VALUE IF NOT EXISTS 'heuristic';
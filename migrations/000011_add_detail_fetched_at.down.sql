@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS songs_detail_fetched_at_idx;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS detail_fetched_at;
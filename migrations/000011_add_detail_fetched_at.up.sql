@@ -0,0 +1,6 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS detail_fetched_at TIMESTAMPTZ;
+
+UPDATE songs SET detail_fetched_at = updated_at
+WHERE detail_status IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS songs_detail_fetched_at_idx ON songs (detail_fetched_at NULLS FIRST);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS songs_import_id_idx;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS import_id;
@@ -0,0 +1,3 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS import_id UUID;
+
+CREATE INDEX IF NOT EXISTS songs_import_id_idx ON songs (import_id) WHERE import_id IS NOT NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(group_name, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(text, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS songs_search_vector_idx ON songs USING GIN (search_vector);
@@ -84,114 +84,2023 @@ func (_c *MockSongUseCase_AddSong_Call) RunAndReturn(run func(context.Context, e
 	return _c
 }
 
-// FetchSongWithVerses provides a mock function with given fields: ctx, songID, pagination
-func (_m *MockSongUseCase) FetchSongWithVerses(ctx context.Context, songID uuid.UUID, pagination entity.Pagination) (*entity.SongWithVerses, *entity.Pagination, error) {
+// CompareSongTexts provides a mock function with given fields: ctx, songID, otherSongID
+func (_m *MockSongUseCase) CompareSongTexts(ctx context.Context, songID uuid.UUID, otherSongID uuid.UUID) ([]entity.LineDiff, error) {
+	ret := _m.Called(ctx, songID, otherSongID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareSongTexts")
+	}
+
+	var r0 []entity.LineDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]entity.LineDiff, error)); ok {
+		return rf(ctx, songID, otherSongID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []entity.LineDiff); ok {
+		r0 = rf(ctx, songID, otherSongID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LineDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, songID, otherSongID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_CompareSongTexts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareSongTexts'
+type MockSongUseCase_CompareSongTexts_Call struct {
+	*mock.Call
+}
+
+// CompareSongTexts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+//   - otherSongID uuid.UUID
+func (_e *MockSongUseCase_Expecter) CompareSongTexts(ctx interface{}, songID interface{}, otherSongID interface{}) *MockSongUseCase_CompareSongTexts_Call {
+	return &MockSongUseCase_CompareSongTexts_Call{Call: _e.mock.On("CompareSongTexts", ctx, songID, otherSongID)}
+}
+
+func (_c *MockSongUseCase_CompareSongTexts_Call) Run(run func(ctx context.Context, songID uuid.UUID, otherSongID uuid.UUID)) *MockSongUseCase_CompareSongTexts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_CompareSongTexts_Call) Return(_a0 []entity.LineDiff, _a1 error) *MockSongUseCase_CompareSongTexts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_CompareSongTexts_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) ([]entity.LineDiff, error)) *MockSongUseCase_CompareSongTexts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountSongs provides a mock function with given fields: ctx, filters
+func (_m *MockSongUseCase) CountSongs(ctx context.Context, filters ...entity.SongFilter) (uint64, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSongs")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) (uint64, error)); ok {
+		return rf(ctx, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) uint64); ok {
+		r0 = rf(ctx, filters...)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_CountSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountSongs'
+type MockSongUseCase_CountSongs_Call struct {
+	*mock.Call
+}
+
+// CountSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters ...entity.SongFilter
+func (_e *MockSongUseCase_Expecter) CountSongs(ctx interface{}, filters ...interface{}) *MockSongUseCase_CountSongs_Call {
+	return &MockSongUseCase_CountSongs_Call{Call: _e.mock.On("CountSongs",
+		append([]interface{}{ctx}, filters...)...)}
+}
+
+func (_c *MockSongUseCase_CountSongs_Call) Run(run func(ctx context.Context, filters ...entity.SongFilter)) *MockSongUseCase_CountSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_CountSongs_Call) Return(_a0 uint64, _a1 error) *MockSongUseCase_CountSongs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_CountSongs_Call) RunAndReturn(run func(context.Context, ...entity.SongFilter) (uint64, error)) *MockSongUseCase_CountSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchAlphaIndex provides a mock function with given fields: ctx, field
+func (_m *MockSongUseCase) FetchAlphaIndex(ctx context.Context, field entity.AlphaIndexField) ([]entity.LetterCount, error) {
+	ret := _m.Called(ctx, field)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAlphaIndex")
+	}
+
+	var r0 []entity.LetterCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlphaIndexField) ([]entity.LetterCount, error)); ok {
+		return rf(ctx, field)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlphaIndexField) []entity.LetterCount); ok {
+		r0 = rf(ctx, field)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LetterCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AlphaIndexField) error); ok {
+		r1 = rf(ctx, field)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchAlphaIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchAlphaIndex'
+type MockSongUseCase_FetchAlphaIndex_Call struct {
+	*mock.Call
+}
+
+// FetchAlphaIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - field entity.AlphaIndexField
+func (_e *MockSongUseCase_Expecter) FetchAlphaIndex(ctx interface{}, field interface{}) *MockSongUseCase_FetchAlphaIndex_Call {
+	return &MockSongUseCase_FetchAlphaIndex_Call{Call: _e.mock.On("FetchAlphaIndex", ctx, field)}
+}
+
+func (_c *MockSongUseCase_FetchAlphaIndex_Call) Run(run func(ctx context.Context, field entity.AlphaIndexField)) *MockSongUseCase_FetchAlphaIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.AlphaIndexField))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchAlphaIndex_Call) Return(_a0 []entity.LetterCount, _a1 error) *MockSongUseCase_FetchAlphaIndex_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchAlphaIndex_Call) RunAndReturn(run func(context.Context, entity.AlphaIndexField) ([]entity.LetterCount, error)) *MockSongUseCase_FetchAlphaIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchDecadeStats provides a mock function with given fields: ctx
+func (_m *MockSongUseCase) FetchDecadeStats(ctx context.Context) ([]entity.DecadeCount, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDecadeStats")
+	}
+
+	var r0 []entity.DecadeCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.DecadeCount, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.DecadeCount); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DecadeCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchDecadeStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchDecadeStats'
+type MockSongUseCase_FetchDecadeStats_Call struct {
+	*mock.Call
+}
+
+// FetchDecadeStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSongUseCase_Expecter) FetchDecadeStats(ctx interface{}) *MockSongUseCase_FetchDecadeStats_Call {
+	return &MockSongUseCase_FetchDecadeStats_Call{Call: _e.mock.On("FetchDecadeStats", ctx)}
+}
+
+func (_c *MockSongUseCase_FetchDecadeStats_Call) Run(run func(ctx context.Context)) *MockSongUseCase_FetchDecadeStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchDecadeStats_Call) Return(_a0 []entity.DecadeCount, _a1 error) *MockSongUseCase_FetchDecadeStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchDecadeStats_Call) RunAndReturn(run func(context.Context) ([]entity.DecadeCount, error)) *MockSongUseCase_FetchDecadeStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchGroupFacets provides a mock function with given fields: ctx, groupName
+func (_m *MockSongUseCase) FetchGroupFacets(ctx context.Context, groupName string) (*entity.GroupFacets, error) {
+	ret := _m.Called(ctx, groupName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchGroupFacets")
+	}
+
+	var r0 *entity.GroupFacets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.GroupFacets, error)); ok {
+		return rf(ctx, groupName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.GroupFacets); ok {
+		r0 = rf(ctx, groupName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.GroupFacets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchGroupFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchGroupFacets'
+type MockSongUseCase_FetchGroupFacets_Call struct {
+	*mock.Call
+}
+
+// FetchGroupFacets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupName string
+func (_e *MockSongUseCase_Expecter) FetchGroupFacets(ctx interface{}, groupName interface{}) *MockSongUseCase_FetchGroupFacets_Call {
+	return &MockSongUseCase_FetchGroupFacets_Call{Call: _e.mock.On("FetchGroupFacets", ctx, groupName)}
+}
+
+func (_c *MockSongUseCase_FetchGroupFacets_Call) Run(run func(ctx context.Context, groupName string)) *MockSongUseCase_FetchGroupFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchGroupFacets_Call) Return(_a0 *entity.GroupFacets, _a1 error) *MockSongUseCase_FetchGroupFacets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchGroupFacets_Call) RunAndReturn(run func(context.Context, string) (*entity.GroupFacets, error)) *MockSongUseCase_FetchGroupFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchGroupReleaseRange provides a mock function with given fields: ctx, groupName
+func (_m *MockSongUseCase) FetchGroupReleaseRange(ctx context.Context, groupName string) (*entity.GroupReleaseRange, error) {
+	ret := _m.Called(ctx, groupName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchGroupReleaseRange")
+	}
+
+	var r0 *entity.GroupReleaseRange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.GroupReleaseRange, error)); ok {
+		return rf(ctx, groupName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.GroupReleaseRange); ok {
+		r0 = rf(ctx, groupName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.GroupReleaseRange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchGroupReleaseRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchGroupReleaseRange'
+type MockSongUseCase_FetchGroupReleaseRange_Call struct {
+	*mock.Call
+}
+
+// FetchGroupReleaseRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupName string
+func (_e *MockSongUseCase_Expecter) FetchGroupReleaseRange(ctx interface{}, groupName interface{}) *MockSongUseCase_FetchGroupReleaseRange_Call {
+	return &MockSongUseCase_FetchGroupReleaseRange_Call{Call: _e.mock.On("FetchGroupReleaseRange", ctx, groupName)}
+}
+
+func (_c *MockSongUseCase_FetchGroupReleaseRange_Call) Run(run func(ctx context.Context, groupName string)) *MockSongUseCase_FetchGroupReleaseRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchGroupReleaseRange_Call) Return(_a0 *entity.GroupReleaseRange, _a1 error) *MockSongUseCase_FetchGroupReleaseRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchGroupReleaseRange_Call) RunAndReturn(run func(context.Context, string) (*entity.GroupReleaseRange, error)) *MockSongUseCase_FetchGroupReleaseRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchIncompleteSongs provides a mock function with given fields: ctx, pagination, filters
+func (_m *MockSongUseCase) FetchIncompleteSongs(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, pagination)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchIncompleteSongs")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, pagination, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) []*entity.Song); ok {
+		r0 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination, ...entity.SongFilter) *entity.Pagination); ok {
+		r1 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination, ...entity.SongFilter) error); ok {
+		r2 = rf(ctx, pagination, filters...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_FetchIncompleteSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchIncompleteSongs'
+type MockSongUseCase_FetchIncompleteSongs_Call struct {
+	*mock.Call
+}
+
+// FetchIncompleteSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pagination entity.Pagination
+//   - filters ...entity.SongFilter
+func (_e *MockSongUseCase_Expecter) FetchIncompleteSongs(ctx interface{}, pagination interface{}, filters ...interface{}) *MockSongUseCase_FetchIncompleteSongs_Call {
+	return &MockSongUseCase_FetchIncompleteSongs_Call{Call: _e.mock.On("FetchIncompleteSongs",
+		append([]interface{}{ctx, pagination}, filters...)...)}
+}
+
+func (_c *MockSongUseCase_FetchIncompleteSongs_Call) Run(run func(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter)) *MockSongUseCase_FetchIncompleteSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(entity.Pagination), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchIncompleteSongs_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_FetchIncompleteSongs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchIncompleteSongs_Call) RunAndReturn(run func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)) *MockSongUseCase_FetchIncompleteSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchRandomVerse provides a mock function with given fields: ctx, songID
+func (_m *MockSongUseCase) FetchRandomVerse(ctx context.Context, songID uuid.UUID) (*entity.Verse, error) {
+	ret := _m.Called(ctx, songID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRandomVerse")
+	}
+
+	var r0 *entity.Verse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Verse, error)); ok {
+		return rf(ctx, songID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Verse); ok {
+		r0 = rf(ctx, songID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Verse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, songID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchRandomVerse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchRandomVerse'
+type MockSongUseCase_FetchRandomVerse_Call struct {
+	*mock.Call
+}
+
+// FetchRandomVerse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+func (_e *MockSongUseCase_Expecter) FetchRandomVerse(ctx interface{}, songID interface{}) *MockSongUseCase_FetchRandomVerse_Call {
+	return &MockSongUseCase_FetchRandomVerse_Call{Call: _e.mock.On("FetchRandomVerse", ctx, songID)}
+}
+
+func (_c *MockSongUseCase_FetchRandomVerse_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongUseCase_FetchRandomVerse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchRandomVerse_Call) Return(_a0 *entity.Verse, _a1 error) *MockSongUseCase_FetchRandomVerse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchRandomVerse_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Verse, error)) *MockSongUseCase_FetchRandomVerse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchRecentSongs provides a mock function with given fields: ctx, limit
+func (_m *MockSongUseCase) FetchRecentSongs(ctx context.Context, limit uint64) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRecentSongs")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*entity.Song, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*entity.Song); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchRecentSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchRecentSongs'
+type MockSongUseCase_FetchRecentSongs_Call struct {
+	*mock.Call
+}
+
+// FetchRecentSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit uint64
+func (_e *MockSongUseCase_Expecter) FetchRecentSongs(ctx interface{}, limit interface{}) *MockSongUseCase_FetchRecentSongs_Call {
+	return &MockSongUseCase_FetchRecentSongs_Call{Call: _e.mock.On("FetchRecentSongs", ctx, limit)}
+}
+
+func (_c *MockSongUseCase_FetchRecentSongs_Call) Run(run func(ctx context.Context, limit uint64)) *MockSongUseCase_FetchRecentSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchRecentSongs_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongUseCase_FetchRecentSongs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchRecentSongs_Call) RunAndReturn(run func(context.Context, uint64) ([]*entity.Song, error)) *MockSongUseCase_FetchRecentSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchRecentlyUpdatedSongs provides a mock function with given fields: ctx, pagination, filters
+func (_m *MockSongUseCase) FetchRecentlyUpdatedSongs(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, pagination)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRecentlyUpdatedSongs")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, pagination, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) []*entity.Song); ok {
+		r0 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination, ...entity.SongFilter) *entity.Pagination); ok {
+		r1 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination, ...entity.SongFilter) error); ok {
+		r2 = rf(ctx, pagination, filters...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_FetchRecentlyUpdatedSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchRecentlyUpdatedSongs'
+type MockSongUseCase_FetchRecentlyUpdatedSongs_Call struct {
+	*mock.Call
+}
+
+// FetchRecentlyUpdatedSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pagination entity.Pagination
+//   - filters ...entity.SongFilter
+func (_e *MockSongUseCase_Expecter) FetchRecentlyUpdatedSongs(ctx interface{}, pagination interface{}, filters ...interface{}) *MockSongUseCase_FetchRecentlyUpdatedSongs_Call {
+	return &MockSongUseCase_FetchRecentlyUpdatedSongs_Call{Call: _e.mock.On("FetchRecentlyUpdatedSongs",
+		append([]interface{}{ctx, pagination}, filters...)...)}
+}
+
+func (_c *MockSongUseCase_FetchRecentlyUpdatedSongs_Call) Run(run func(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter)) *MockSongUseCase_FetchRecentlyUpdatedSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(entity.Pagination), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchRecentlyUpdatedSongs_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_FetchRecentlyUpdatedSongs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchRecentlyUpdatedSongs_Call) RunAndReturn(run func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)) *MockSongUseCase_FetchRecentlyUpdatedSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSimilarSongs provides a mock function with given fields: ctx, songID, pagination
+func (_m *MockSongUseCase) FetchSimilarSongs(ctx context.Context, songID uuid.UUID, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error) {
 	ret := _m.Called(ctx, songID, pagination)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FetchSongWithVerses")
+		panic("no return value specified for FetchSimilarSongs")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, songID, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) []*entity.Song); ok {
+		r0 = rf(ctx, songID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Pagination) *entity.Pagination); ok {
+		r1 = rf(ctx, songID, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.Pagination) error); ok {
+		r2 = rf(ctx, songID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_FetchSimilarSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSimilarSongs'
+type MockSongUseCase_FetchSimilarSongs_Call struct {
+	*mock.Call
+}
+
+// FetchSimilarSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+//   - pagination entity.Pagination
+func (_e *MockSongUseCase_Expecter) FetchSimilarSongs(ctx interface{}, songID interface{}, pagination interface{}) *MockSongUseCase_FetchSimilarSongs_Call {
+	return &MockSongUseCase_FetchSimilarSongs_Call{Call: _e.mock.On("FetchSimilarSongs", ctx, songID, pagination)}
+}
+
+func (_c *MockSongUseCase_FetchSimilarSongs_Call) Run(run func(ctx context.Context, songID uuid.UUID, pagination entity.Pagination)) *MockSongUseCase_FetchSimilarSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.Pagination))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSimilarSongs_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_FetchSimilarSongs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSimilarSongs_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)) *MockSongUseCase_FetchSimilarSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSong provides a mock function with given fields: ctx, songID
+func (_m *MockSongUseCase) FetchSong(ctx context.Context, songID uuid.UUID) (*entity.Song, error) {
+	ret := _m.Called(ctx, songID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSong")
+	}
+
+	var r0 *entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Song, error)); ok {
+		return rf(ctx, songID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Song); ok {
+		r0 = rf(ctx, songID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, songID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchSong_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSong'
+type MockSongUseCase_FetchSong_Call struct {
+	*mock.Call
+}
+
+// FetchSong is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+func (_e *MockSongUseCase_Expecter) FetchSong(ctx interface{}, songID interface{}) *MockSongUseCase_FetchSong_Call {
+	return &MockSongUseCase_FetchSong_Call{Call: _e.mock.On("FetchSong", ctx, songID)}
+}
+
+func (_c *MockSongUseCase_FetchSong_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongUseCase_FetchSong_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSong_Call) Return(_a0 *entity.Song, _a1 error) *MockSongUseCase_FetchSong_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSong_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Song, error)) *MockSongUseCase_FetchSong_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSongFacets provides a mock function with given fields: ctx, fields, filters
+func (_m *MockSongUseCase) FetchSongFacets(ctx context.Context, fields []entity.FacetField, filters ...entity.SongFilter) (map[entity.FacetField][]entity.FacetCount, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, fields)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSongFacets")
+	}
+
+	var r0 map[entity.FacetField][]entity.FacetCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.FacetField, ...entity.SongFilter) (map[entity.FacetField][]entity.FacetCount, error)); ok {
+		return rf(ctx, fields, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.FacetField, ...entity.SongFilter) map[entity.FacetField][]entity.FacetCount); ok {
+		r0 = rf(ctx, fields, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[entity.FacetField][]entity.FacetCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.FacetField, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, fields, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchSongFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSongFacets'
+type MockSongUseCase_FetchSongFacets_Call struct {
+	*mock.Call
+}
+
+// FetchSongFacets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fields []entity.FacetField
+//   - filters ...entity.SongFilter
+func (_e *MockSongUseCase_Expecter) FetchSongFacets(ctx interface{}, fields interface{}, filters ...interface{}) *MockSongUseCase_FetchSongFacets_Call {
+	return &MockSongUseCase_FetchSongFacets_Call{Call: _e.mock.On("FetchSongFacets",
+		append([]interface{}{ctx, fields}, filters...)...)}
+}
+
+func (_c *MockSongUseCase_FetchSongFacets_Call) Run(run func(ctx context.Context, fields []entity.FacetField, filters ...entity.SongFilter)) *MockSongUseCase_FetchSongFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].([]entity.FacetField), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongFacets_Call) Return(_a0 map[entity.FacetField][]entity.FacetCount, _a1 error) *MockSongUseCase_FetchSongFacets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongFacets_Call) RunAndReturn(run func(context.Context, []entity.FacetField, ...entity.SongFilter) (map[entity.FacetField][]entity.FacetCount, error)) *MockSongUseCase_FetchSongFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSongLines provides a mock function with given fields: ctx, songID, numberBlank
+func (_m *MockSongUseCase) FetchSongLines(ctx context.Context, songID uuid.UUID, numberBlank bool) (*entity.Song, []entity.NumberedLine, error) {
+	ret := _m.Called(ctx, songID, numberBlank)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSongLines")
+	}
+
+	var r0 *entity.Song
+	var r1 []entity.NumberedLine
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) (*entity.Song, []entity.NumberedLine, error)); ok {
+		return rf(ctx, songID, numberBlank)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) *entity.Song); ok {
+		r0 = rf(ctx, songID, numberBlank)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool) []entity.NumberedLine); ok {
+		r1 = rf(ctx, songID, numberBlank)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]entity.NumberedLine)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, bool) error); ok {
+		r2 = rf(ctx, songID, numberBlank)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_FetchSongLines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSongLines'
+type MockSongUseCase_FetchSongLines_Call struct {
+	*mock.Call
+}
+
+// FetchSongLines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+//   - numberBlank bool
+func (_e *MockSongUseCase_Expecter) FetchSongLines(ctx interface{}, songID interface{}, numberBlank interface{}) *MockSongUseCase_FetchSongLines_Call {
+	return &MockSongUseCase_FetchSongLines_Call{Call: _e.mock.On("FetchSongLines", ctx, songID, numberBlank)}
+}
+
+func (_c *MockSongUseCase_FetchSongLines_Call) Run(run func(ctx context.Context, songID uuid.UUID, numberBlank bool)) *MockSongUseCase_FetchSongLines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongLines_Call) Return(_a0 *entity.Song, _a1 []entity.NumberedLine, _a2 error) *MockSongUseCase_FetchSongLines_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongLines_Call) RunAndReturn(run func(context.Context, uuid.UUID, bool) (*entity.Song, []entity.NumberedLine, error)) *MockSongUseCase_FetchSongLines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSongWithVerses provides a mock function with given fields: ctx, songID, pagination, granularity, dedupe
+func (_m *MockSongUseCase) FetchSongWithVerses(ctx context.Context, songID uuid.UUID, pagination entity.Pagination, granularity entity.TextGranularity, dedupe bool) (*entity.SongWithVerses, *entity.Pagination, error) {
+	ret := _m.Called(ctx, songID, pagination, granularity, dedupe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSongWithVerses")
+	}
+
+	var r0 *entity.SongWithVerses
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination, entity.TextGranularity, bool) (*entity.SongWithVerses, *entity.Pagination, error)); ok {
+		return rf(ctx, songID, pagination, granularity, dedupe)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination, entity.TextGranularity, bool) *entity.SongWithVerses); ok {
+		r0 = rf(ctx, songID, pagination, granularity, dedupe)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.SongWithVerses)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Pagination, entity.TextGranularity, bool) *entity.Pagination); ok {
+		r1 = rf(ctx, songID, pagination, granularity, dedupe)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.Pagination, entity.TextGranularity, bool) error); ok {
+		r2 = rf(ctx, songID, pagination, granularity, dedupe)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_FetchSongWithVerses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSongWithVerses'
+type MockSongUseCase_FetchSongWithVerses_Call struct {
+	*mock.Call
+}
+
+// FetchSongWithVerses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+//   - pagination entity.Pagination
+//   - granularity entity.TextGranularity
+//   - dedupe bool
+func (_e *MockSongUseCase_Expecter) FetchSongWithVerses(ctx interface{}, songID interface{}, pagination interface{}, granularity interface{}, dedupe interface{}) *MockSongUseCase_FetchSongWithVerses_Call {
+	return &MockSongUseCase_FetchSongWithVerses_Call{Call: _e.mock.On("FetchSongWithVerses", ctx, songID, pagination, granularity, dedupe)}
+}
+
+func (_c *MockSongUseCase_FetchSongWithVerses_Call) Run(run func(ctx context.Context, songID uuid.UUID, pagination entity.Pagination, granularity entity.TextGranularity, dedupe bool)) *MockSongUseCase_FetchSongWithVerses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.Pagination), args[3].(entity.TextGranularity), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongWithVerses_Call) Return(_a0 *entity.SongWithVerses, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_FetchSongWithVerses_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongWithVerses_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.Pagination, entity.TextGranularity, bool) (*entity.SongWithVerses, *entity.Pagination, error)) *MockSongUseCase_FetchSongWithVerses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSongs provides a mock function with given fields: ctx, pagination, filters
+func (_m *MockSongUseCase) FetchSongs(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, pagination)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSongs")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, pagination, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) []*entity.Song); ok {
+		r0 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination, ...entity.SongFilter) *entity.Pagination); ok {
+		r1 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination, ...entity.SongFilter) error); ok {
+		r2 = rf(ctx, pagination, filters...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_FetchSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSongs'
+type MockSongUseCase_FetchSongs_Call struct {
+	*mock.Call
+}
+
+// FetchSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pagination entity.Pagination
+//   - filters ...entity.SongFilter
+func (_e *MockSongUseCase_Expecter) FetchSongs(ctx interface{}, pagination interface{}, filters ...interface{}) *MockSongUseCase_FetchSongs_Call {
+	return &MockSongUseCase_FetchSongs_Call{Call: _e.mock.On("FetchSongs",
+		append([]interface{}{ctx, pagination}, filters...)...)}
+}
+
+func (_c *MockSongUseCase_FetchSongs_Call) Run(run func(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter)) *MockSongUseCase_FetchSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(entity.Pagination), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongs_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_FetchSongs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongs_Call) RunAndReturn(run func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)) *MockSongUseCase_FetchSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSongsWithBrokenLinks provides a mock function with given fields: ctx, pagination
+func (_m *MockSongUseCase) FetchSongsWithBrokenLinks(ctx context.Context, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error) {
+	ret := _m.Called(ctx, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSongsWithBrokenLinks")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination) []*entity.Song); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination) *entity.Pagination); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_FetchSongsWithBrokenLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSongsWithBrokenLinks'
+type MockSongUseCase_FetchSongsWithBrokenLinks_Call struct {
+	*mock.Call
+}
+
+// FetchSongsWithBrokenLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pagination entity.Pagination
+func (_e *MockSongUseCase_Expecter) FetchSongsWithBrokenLinks(ctx interface{}, pagination interface{}) *MockSongUseCase_FetchSongsWithBrokenLinks_Call {
+	return &MockSongUseCase_FetchSongsWithBrokenLinks_Call{Call: _e.mock.On("FetchSongsWithBrokenLinks", ctx, pagination)}
+}
+
+func (_c *MockSongUseCase_FetchSongsWithBrokenLinks_Call) Run(run func(ctx context.Context, pagination entity.Pagination)) *MockSongUseCase_FetchSongsWithBrokenLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.Pagination))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongsWithBrokenLinks_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_FetchSongsWithBrokenLinks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongsWithBrokenLinks_Call) RunAndReturn(run func(context.Context, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)) *MockSongUseCase_FetchSongsWithBrokenLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchSongsWithVerses provides a mock function with given fields: ctx, songIDs, pagination
+func (_m *MockSongUseCase) FetchSongsWithVerses(ctx context.Context, songIDs []uuid.UUID, pagination entity.Pagination) ([]entity.SongVersesPage, []uuid.UUID, error) {
+	ret := _m.Called(ctx, songIDs, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchSongsWithVerses")
+	}
+
+	var r0 []entity.SongVersesPage
+	var r1 []uuid.UUID
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, entity.Pagination) ([]entity.SongVersesPage, []uuid.UUID, error)); ok {
+		return rf(ctx, songIDs, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, entity.Pagination) []entity.SongVersesPage); ok {
+		r0 = rf(ctx, songIDs, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SongVersesPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, entity.Pagination) []uuid.UUID); ok {
+		r1 = rf(ctx, songIDs, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []uuid.UUID, entity.Pagination) error); ok {
+		r2 = rf(ctx, songIDs, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_FetchSongsWithVerses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSongsWithVerses'
+type MockSongUseCase_FetchSongsWithVerses_Call struct {
+	*mock.Call
+}
+
+// FetchSongsWithVerses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songIDs []uuid.UUID
+//   - pagination entity.Pagination
+func (_e *MockSongUseCase_Expecter) FetchSongsWithVerses(ctx interface{}, songIDs interface{}, pagination interface{}) *MockSongUseCase_FetchSongsWithVerses_Call {
+	return &MockSongUseCase_FetchSongsWithVerses_Call{Call: _e.mock.On("FetchSongsWithVerses", ctx, songIDs, pagination)}
+}
+
+func (_c *MockSongUseCase_FetchSongsWithVerses_Call) Run(run func(ctx context.Context, songIDs []uuid.UUID, pagination entity.Pagination)) *MockSongUseCase_FetchSongsWithVerses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(entity.Pagination))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongsWithVerses_Call) Return(_a0 []entity.SongVersesPage, _a1 []uuid.UUID, _a2 error) *MockSongUseCase_FetchSongsWithVerses_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchSongsWithVerses_Call) RunAndReturn(run func(context.Context, []uuid.UUID, entity.Pagination) ([]entity.SongVersesPage, []uuid.UUID, error)) *MockSongUseCase_FetchSongsWithVerses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FetchTextStats provides a mock function with given fields: ctx, songID
+func (_m *MockSongUseCase) FetchTextStats(ctx context.Context, songID uuid.UUID) (*entity.TextStats, error) {
+	ret := _m.Called(ctx, songID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchTextStats")
+	}
+
+	var r0 *entity.TextStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.TextStats, error)); ok {
+		return rf(ctx, songID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.TextStats); ok {
+		r0 = rf(ctx, songID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TextStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, songID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_FetchTextStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchTextStats'
+type MockSongUseCase_FetchTextStats_Call struct {
+	*mock.Call
+}
+
+// FetchTextStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+func (_e *MockSongUseCase_Expecter) FetchTextStats(ctx interface{}, songID interface{}) *MockSongUseCase_FetchTextStats_Call {
+	return &MockSongUseCase_FetchTextStats_Call{Call: _e.mock.On("FetchTextStats", ctx, songID)}
+}
+
+func (_c *MockSongUseCase_FetchTextStats_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongUseCase_FetchTextStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchTextStats_Call) Return(_a0 *entity.TextStats, _a1 error) *MockSongUseCase_FetchTextStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_FetchTextStats_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.TextStats, error)) *MockSongUseCase_FetchTextStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportSongs provides a mock function with given fields: ctx, songs, fetchInfo
+func (_m *MockSongUseCase) ImportSongs(ctx context.Context, songs []entity.Song, fetchInfo bool) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, songs, fetchInfo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportSongs")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Song, bool) ([]*entity.Song, error)); ok {
+		return rf(ctx, songs, fetchInfo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Song, bool) []*entity.Song); ok {
+		r0 = rf(ctx, songs, fetchInfo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Song, bool) error); ok {
+		r1 = rf(ctx, songs, fetchInfo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_ImportSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportSongs'
+type MockSongUseCase_ImportSongs_Call struct {
+	*mock.Call
+}
+
+// ImportSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songs []entity.Song
+//   - fetchInfo bool
+func (_e *MockSongUseCase_Expecter) ImportSongs(ctx interface{}, songs interface{}, fetchInfo interface{}) *MockSongUseCase_ImportSongs_Call {
+	return &MockSongUseCase_ImportSongs_Call{Call: _e.mock.On("ImportSongs", ctx, songs, fetchInfo)}
+}
+
+func (_c *MockSongUseCase_ImportSongs_Call) Run(run func(ctx context.Context, songs []entity.Song, fetchInfo bool)) *MockSongUseCase_ImportSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.Song), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_ImportSongs_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongUseCase_ImportSongs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_ImportSongs_Call) RunAndReturn(run func(context.Context, []entity.Song, bool) ([]*entity.Song, error)) *MockSongUseCase_ImportSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ModifySong provides a mock function with given fields: ctx, songID, song
+func (_m *MockSongUseCase) ModifySong(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error) {
+	ret := _m.Called(ctx, songID, song)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModifySong")
+	}
+
+	var r0 *entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Song) (*entity.Song, error)); ok {
+		return rf(ctx, songID, song)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Song) *entity.Song); ok {
+		r0 = rf(ctx, songID, song)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Song) error); ok {
+		r1 = rf(ctx, songID, song)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_ModifySong_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModifySong'
+type MockSongUseCase_ModifySong_Call struct {
+	*mock.Call
+}
+
+// ModifySong is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+//   - song entity.Song
+func (_e *MockSongUseCase_Expecter) ModifySong(ctx interface{}, songID interface{}, song interface{}) *MockSongUseCase_ModifySong_Call {
+	return &MockSongUseCase_ModifySong_Call{Call: _e.mock.On("ModifySong", ctx, songID, song)}
+}
+
+func (_c *MockSongUseCase_ModifySong_Call) Run(run func(ctx context.Context, songID uuid.UUID, song entity.Song)) *MockSongUseCase_ModifySong_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.Song))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_ModifySong_Call) Return(_a0 *entity.Song, _a1 error) *MockSongUseCase_ModifySong_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_ModifySong_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.Song) (*entity.Song, error)) *MockSongUseCase_ModifySong_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ModifySongs provides a mock function with given fields: ctx, updates
+func (_m *MockSongUseCase) ModifySongs(ctx context.Context, updates []entity.SongUpdate) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModifySongs")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SongUpdate) ([]*entity.Song, error)); ok {
+		return rf(ctx, updates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SongUpdate) []*entity.Song); ok {
+		r0 = rf(ctx, updates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.SongUpdate) error); ok {
+		r1 = rf(ctx, updates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_ModifySongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModifySongs'
+type MockSongUseCase_ModifySongs_Call struct {
+	*mock.Call
+}
+
+// ModifySongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - updates []entity.SongUpdate
+func (_e *MockSongUseCase_Expecter) ModifySongs(ctx interface{}, updates interface{}) *MockSongUseCase_ModifySongs_Call {
+	return &MockSongUseCase_ModifySongs_Call{Call: _e.mock.On("ModifySongs", ctx, updates)}
+}
+
+func (_c *MockSongUseCase_ModifySongs_Call) Run(run func(ctx context.Context, updates []entity.SongUpdate)) *MockSongUseCase_ModifySongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.SongUpdate))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_ModifySongs_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongUseCase_ModifySongs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_ModifySongs_Call) RunAndReturn(run func(context.Context, []entity.SongUpdate) ([]*entity.Song, error)) *MockSongUseCase_ModifySongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ModifySongsWhere provides a mock function with given fields: ctx, song, filters
+func (_m *MockSongUseCase) ModifySongsWhere(ctx context.Context, song entity.Song, filters ...entity.SongFilter) (int64, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, song)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModifySongsWhere")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song, ...entity.SongFilter) (int64, error)); ok {
+		return rf(ctx, song, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song, ...entity.SongFilter) int64); ok {
+		r0 = rf(ctx, song, filters...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Song, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, song, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_ModifySongsWhere_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModifySongsWhere'
+type MockSongUseCase_ModifySongsWhere_Call struct {
+	*mock.Call
+}
+
+// ModifySongsWhere is a helper method to define mock.On call
+//   - ctx context.Context
+//   - song entity.Song
+//   - filters ...entity.SongFilter
+func (_e *MockSongUseCase_Expecter) ModifySongsWhere(ctx interface{}, song interface{}, filters ...interface{}) *MockSongUseCase_ModifySongsWhere_Call {
+	return &MockSongUseCase_ModifySongsWhere_Call{Call: _e.mock.On("ModifySongsWhere",
+		append([]interface{}{ctx, song}, filters...)...)}
+}
+
+func (_c *MockSongUseCase_ModifySongsWhere_Call) Run(run func(ctx context.Context, song entity.Song, filters ...entity.SongFilter)) *MockSongUseCase_ModifySongsWhere_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(entity.Song), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_ModifySongsWhere_Call) Return(_a0 int64, _a1 error) *MockSongUseCase_ModifySongsWhere_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_ModifySongsWhere_Call) RunAndReturn(run func(context.Context, entity.Song, ...entity.SongFilter) (int64, error)) *MockSongUseCase_ModifySongsWhere_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewVerses provides a mock function with given fields: text
+func (_m *MockSongUseCase) PreviewVerses(text string) []string {
+	ret := _m.Called(text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewVerses")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MockSongUseCase_PreviewVerses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewVerses'
+type MockSongUseCase_PreviewVerses_Call struct {
+	*mock.Call
+}
+
+// PreviewVerses is a helper method to define mock.On call
+//   - text string
+func (_e *MockSongUseCase_Expecter) PreviewVerses(text interface{}) *MockSongUseCase_PreviewVerses_Call {
+	return &MockSongUseCase_PreviewVerses_Call{Call: _e.mock.On("PreviewVerses", text)}
+}
+
+func (_c *MockSongUseCase_PreviewVerses_Call) Run(run func(text string)) *MockSongUseCase_PreviewVerses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_PreviewVerses_Call) Return(_a0 []string) *MockSongUseCase_PreviewVerses_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSongUseCase_PreviewVerses_Call) RunAndReturn(run func(string) []string) *MockSongUseCase_PreviewVerses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecomputeSortNames provides a mock function with given fields: ctx
+func (_m *MockSongUseCase) RecomputeSortNames(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecomputeSortNames")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_RecomputeSortNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecomputeSortNames'
+type MockSongUseCase_RecomputeSortNames_Call struct {
+	*mock.Call
+}
+
+// RecomputeSortNames is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSongUseCase_Expecter) RecomputeSortNames(ctx interface{}) *MockSongUseCase_RecomputeSortNames_Call {
+	return &MockSongUseCase_RecomputeSortNames_Call{Call: _e.mock.On("RecomputeSortNames", ctx)}
+}
+
+func (_c *MockSongUseCase_RecomputeSortNames_Call) Run(run func(ctx context.Context)) *MockSongUseCase_RecomputeSortNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_RecomputeSortNames_Call) Return(_a0 int64, _a1 error) *MockSongUseCase_RecomputeSortNames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_RecomputeSortNames_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockSongUseCase_RecomputeSortNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveSong provides a mock function with given fields: ctx, songID
+func (_m *MockSongUseCase) RemoveSong(ctx context.Context, songID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, songID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveSong")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, songID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, songID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, songID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_RemoveSong_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveSong'
+type MockSongUseCase_RemoveSong_Call struct {
+	*mock.Call
+}
+
+// RemoveSong is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+func (_e *MockSongUseCase_Expecter) RemoveSong(ctx interface{}, songID interface{}) *MockSongUseCase_RemoveSong_Call {
+	return &MockSongUseCase_RemoveSong_Call{Call: _e.mock.On("RemoveSong", ctx, songID)}
+}
+
+func (_c *MockSongUseCase_RemoveSong_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongUseCase_RemoveSong_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_RemoveSong_Call) Return(_a0 int64, _a1 error) *MockSongUseCase_RemoveSong_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_RemoveSong_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockSongUseCase_RemoveSong_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveSongs provides a mock function with given fields: ctx, songIDs
+func (_m *MockSongUseCase) RemoveSongs(ctx context.Context, songIDs []uuid.UUID) ([]uuid.UUID, []uuid.UUID, error) {
+	ret := _m.Called(ctx, songIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveSongs")
+	}
+
+	var r0 []uuid.UUID
+	var r1 []uuid.UUID
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]uuid.UUID, []uuid.UUID, error)); ok {
+		return rf(ctx, songIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, songIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) []uuid.UUID); ok {
+		r1 = rf(ctx, songIDs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []uuid.UUID) error); ok {
+		r2 = rf(ctx, songIDs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongUseCase_RemoveSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveSongs'
+type MockSongUseCase_RemoveSongs_Call struct {
+	*mock.Call
+}
+
+// RemoveSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songIDs []uuid.UUID
+func (_e *MockSongUseCase_Expecter) RemoveSongs(ctx interface{}, songIDs interface{}) *MockSongUseCase_RemoveSongs_Call {
+	return &MockSongUseCase_RemoveSongs_Call{Call: _e.mock.On("RemoveSongs", ctx, songIDs)}
+}
+
+func (_c *MockSongUseCase_RemoveSongs_Call) Run(run func(ctx context.Context, songIDs []uuid.UUID)) *MockSongUseCase_RemoveSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_RemoveSongs_Call) Return(_a0 []uuid.UUID, _a1 []uuid.UUID, _a2 error) *MockSongUseCase_RemoveSongs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongUseCase_RemoveSongs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]uuid.UUID, []uuid.UUID, error)) *MockSongUseCase_RemoveSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReorderVerses provides a mock function with given fields: ctx, songID, order
+func (_m *MockSongUseCase) ReorderVerses(ctx context.Context, songID uuid.UUID, order []int) (*entity.Song, error) {
+	ret := _m.Called(ctx, songID, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderVerses")
+	}
+
+	var r0 *entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []int) (*entity.Song, error)); ok {
+		return rf(ctx, songID, order)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []int) *entity.Song); ok {
+		r0 = rf(ctx, songID, order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []int) error); ok {
+		r1 = rf(ctx, songID, order)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_ReorderVerses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderVerses'
+type MockSongUseCase_ReorderVerses_Call struct {
+	*mock.Call
+}
+
+// ReorderVerses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+//   - order []int
+func (_e *MockSongUseCase_Expecter) ReorderVerses(ctx interface{}, songID interface{}, order interface{}) *MockSongUseCase_ReorderVerses_Call {
+	return &MockSongUseCase_ReorderVerses_Call{Call: _e.mock.On("ReorderVerses", ctx, songID, order)}
+}
+
+func (_c *MockSongUseCase_ReorderVerses_Call) Run(run func(ctx context.Context, songID uuid.UUID, order []int)) *MockSongUseCase_ReorderVerses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_ReorderVerses_Call) Return(_a0 *entity.Song, _a1 error) *MockSongUseCase_ReorderVerses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_ReorderVerses_Call) RunAndReturn(run func(context.Context, uuid.UUID, []int) (*entity.Song, error)) *MockSongUseCase_ReorderVerses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResplitVerses provides a mock function with given fields: ctx
+func (_m *MockSongUseCase) ResplitVerses(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResplitVerses")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_ResplitVerses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResplitVerses'
+type MockSongUseCase_ResplitVerses_Call struct {
+	*mock.Call
+}
+
+// ResplitVerses is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSongUseCase_Expecter) ResplitVerses(ctx interface{}) *MockSongUseCase_ResplitVerses_Call {
+	return &MockSongUseCase_ResplitVerses_Call{Call: _e.mock.On("ResplitVerses", ctx)}
+}
+
+func (_c *MockSongUseCase_ResplitVerses_Call) Run(run func(ctx context.Context)) *MockSongUseCase_ResplitVerses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_ResplitVerses_Call) Return(_a0 int64, _a1 error) *MockSongUseCase_ResplitVerses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_ResplitVerses_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockSongUseCase_ResplitVerses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackImport provides a mock function with given fields: ctx, importID
+func (_m *MockSongUseCase) RollbackImport(ctx context.Context, importID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, importID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackImport")
 	}
 
-	var r0 *entity.SongWithVerses
-	var r1 *entity.Pagination
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) (*entity.SongWithVerses, *entity.Pagination, error)); ok {
-		return rf(ctx, songID, pagination)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, importID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) *entity.SongWithVerses); ok {
-		r0 = rf(ctx, songID, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, importID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.SongWithVerses)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Pagination) *entity.Pagination); ok {
-		r1 = rf(ctx, songID, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, importID)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*entity.Pagination)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongUseCase_RollbackImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackImport'
+type MockSongUseCase_RollbackImport_Call struct {
+	*mock.Call
+}
+
+// RollbackImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - importID uuid.UUID
+func (_e *MockSongUseCase_Expecter) RollbackImport(ctx interface{}, importID interface{}) *MockSongUseCase_RollbackImport_Call {
+	return &MockSongUseCase_RollbackImport_Call{Call: _e.mock.On("RollbackImport", ctx, importID)}
+}
+
+func (_c *MockSongUseCase_RollbackImport_Call) Run(run func(ctx context.Context, importID uuid.UUID)) *MockSongUseCase_RollbackImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_RollbackImport_Call) Return(_a0 int64, _a1 error) *MockSongUseCase_RollbackImport_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongUseCase_RollbackImport_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockSongUseCase_RollbackImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSongVerses provides a mock function with given fields: ctx, songID, query, contextVerses
+func (_m *MockSongUseCase) SearchSongVerses(ctx context.Context, songID uuid.UUID, query string, contextVerses int) ([]entity.VerseMatch, error) {
+	ret := _m.Called(ctx, songID, query, contextVerses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSongVerses")
+	}
+
+	var r0 []entity.VerseMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int) ([]entity.VerseMatch, error)); ok {
+		return rf(ctx, songID, query, contextVerses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int) []entity.VerseMatch); ok {
+		r0 = rf(ctx, songID, query, contextVerses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.VerseMatch)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.Pagination) error); ok {
-		r2 = rf(ctx, songID, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, int) error); ok {
+		r1 = rf(ctx, songID, query, contextVerses)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockSongUseCase_FetchSongWithVerses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSongWithVerses'
-type MockSongUseCase_FetchSongWithVerses_Call struct {
+// MockSongUseCase_SearchSongVerses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSongVerses'
+type MockSongUseCase_SearchSongVerses_Call struct {
 	*mock.Call
 }
 
-// FetchSongWithVerses is a helper method to define mock.On call
+// SearchSongVerses is a helper method to define mock.On call
 //   - ctx context.Context
 //   - songID uuid.UUID
-//   - pagination entity.Pagination
-func (_e *MockSongUseCase_Expecter) FetchSongWithVerses(ctx interface{}, songID interface{}, pagination interface{}) *MockSongUseCase_FetchSongWithVerses_Call {
-	return &MockSongUseCase_FetchSongWithVerses_Call{Call: _e.mock.On("FetchSongWithVerses", ctx, songID, pagination)}
+//   - query string
+//   - contextVerses int
+func (_e *MockSongUseCase_Expecter) SearchSongVerses(ctx interface{}, songID interface{}, query interface{}, contextVerses interface{}) *MockSongUseCase_SearchSongVerses_Call {
+	return &MockSongUseCase_SearchSongVerses_Call{Call: _e.mock.On("SearchSongVerses", ctx, songID, query, contextVerses)}
 }
 
-func (_c *MockSongUseCase_FetchSongWithVerses_Call) Run(run func(ctx context.Context, songID uuid.UUID, pagination entity.Pagination)) *MockSongUseCase_FetchSongWithVerses_Call {
+func (_c *MockSongUseCase_SearchSongVerses_Call) Run(run func(ctx context.Context, songID uuid.UUID, query string, contextVerses int)) *MockSongUseCase_SearchSongVerses_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.Pagination))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(int))
 	})
 	return _c
 }
 
-func (_c *MockSongUseCase_FetchSongWithVerses_Call) Return(_a0 *entity.SongWithVerses, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_FetchSongWithVerses_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockSongUseCase_SearchSongVerses_Call) Return(_a0 []entity.VerseMatch, _a1 error) *MockSongUseCase_SearchSongVerses_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSongUseCase_FetchSongWithVerses_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.Pagination) (*entity.SongWithVerses, *entity.Pagination, error)) *MockSongUseCase_FetchSongWithVerses_Call {
+func (_c *MockSongUseCase_SearchSongVerses_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, int) ([]entity.VerseMatch, error)) *MockSongUseCase_SearchSongVerses_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FetchSongs provides a mock function with given fields: ctx, pagination, filters
-func (_m *MockSongUseCase) FetchSongs(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error) {
-	_va := make([]interface{}, len(filters))
-	for _i := range filters {
-		_va[_i] = filters[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, ctx, pagination)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// SearchSongs provides a mock function with given fields: ctx, query, pagination
+func (_m *MockSongUseCase) SearchSongs(ctx context.Context, query string, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error) {
+	ret := _m.Called(ctx, query, pagination)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FetchSongs")
+		panic("no return value specified for SearchSongs")
 	}
 
 	var r0 []*entity.Song
 	var r1 *entity.Pagination
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)); ok {
-		return rf(ctx, pagination, filters...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, query, pagination)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) []*entity.Song); ok {
-		r0 = rf(ctx, pagination, filters...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Pagination) []*entity.Song); ok {
+		r0 = rf(ctx, query, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entity.Song)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination, ...entity.SongFilter) *entity.Pagination); ok {
-		r1 = rf(ctx, pagination, filters...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.Pagination) *entity.Pagination); ok {
+		r1 = rf(ctx, query, pagination)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*entity.Pagination)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination, ...entity.SongFilter) error); ok {
-		r2 = rf(ctx, pagination, filters...)
+	if rf, ok := ret.Get(2).(func(context.Context, string, entity.Pagination) error); ok {
+		r2 = rf(ctx, query, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -199,66 +2108,154 @@ func (_m *MockSongUseCase) FetchSongs(ctx context.Context, pagination entity.Pag
 	return r0, r1, r2
 }
 
-// MockSongUseCase_FetchSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchSongs'
-type MockSongUseCase_FetchSongs_Call struct {
+// MockSongUseCase_SearchSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSongs'
+type MockSongUseCase_SearchSongs_Call struct {
 	*mock.Call
 }
 
-// FetchSongs is a helper method to define mock.On call
+// SearchSongs is a helper method to define mock.On call
 //   - ctx context.Context
+//   - query string
 //   - pagination entity.Pagination
-//   - filters ...entity.SongFilter
-func (_e *MockSongUseCase_Expecter) FetchSongs(ctx interface{}, pagination interface{}, filters ...interface{}) *MockSongUseCase_FetchSongs_Call {
-	return &MockSongUseCase_FetchSongs_Call{Call: _e.mock.On("FetchSongs",
-		append([]interface{}{ctx, pagination}, filters...)...)}
+func (_e *MockSongUseCase_Expecter) SearchSongs(ctx interface{}, query interface{}, pagination interface{}) *MockSongUseCase_SearchSongs_Call {
+	return &MockSongUseCase_SearchSongs_Call{Call: _e.mock.On("SearchSongs", ctx, query, pagination)}
 }
 
-func (_c *MockSongUseCase_FetchSongs_Call) Run(run func(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter)) *MockSongUseCase_FetchSongs_Call {
+func (_c *MockSongUseCase_SearchSongs_Call) Run(run func(ctx context.Context, query string, pagination entity.Pagination)) *MockSongUseCase_SearchSongs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]entity.SongFilter, len(args)-2)
-		for i, a := range args[2:] {
-			if a != nil {
-				variadicArgs[i] = a.(entity.SongFilter)
-			}
-		}
-		run(args[0].(context.Context), args[1].(entity.Pagination), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.Pagination))
 	})
 	return _c
 }
 
-func (_c *MockSongUseCase_FetchSongs_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_FetchSongs_Call {
+func (_c *MockSongUseCase_SearchSongs_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongUseCase_SearchSongs_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockSongUseCase_FetchSongs_Call) RunAndReturn(run func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)) *MockSongUseCase_FetchSongs_Call {
+func (_c *MockSongUseCase_SearchSongs_Call) RunAndReturn(run func(context.Context, string, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)) *MockSongUseCase_SearchSongs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ModifySong provides a mock function with given fields: ctx, songID, song
-func (_m *MockSongUseCase) ModifySong(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error) {
-	ret := _m.Called(ctx, songID, song)
+// StartLinkCheck provides a mock function with given fields: ctx, done
+func (_m *MockSongUseCase) StartLinkCheck(ctx context.Context, done func(int64, error)) error {
+	ret := _m.Called(ctx, done)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ModifySong")
+		panic("no return value specified for StartLinkCheck")
 	}
 
-	var r0 *entity.Song
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(int64, error)) error); ok {
+		r0 = rf(ctx, done)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSongUseCase_StartLinkCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartLinkCheck'
+type MockSongUseCase_StartLinkCheck_Call struct {
+	*mock.Call
+}
+
+// StartLinkCheck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - done func(int64 , error)
+func (_e *MockSongUseCase_Expecter) StartLinkCheck(ctx interface{}, done interface{}) *MockSongUseCase_StartLinkCheck_Call {
+	return &MockSongUseCase_StartLinkCheck_Call{Call: _e.mock.On("StartLinkCheck", ctx, done)}
+}
+
+func (_c *MockSongUseCase_StartLinkCheck_Call) Run(run func(ctx context.Context, done func(int64, error))) *MockSongUseCase_StartLinkCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(int64, error)))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_StartLinkCheck_Call) Return(_a0 error) *MockSongUseCase_StartLinkCheck_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSongUseCase_StartLinkCheck_Call) RunAndReturn(run func(context.Context, func(int64, error)) error) *MockSongUseCase_StartLinkCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeSongEvents provides a mock function with given fields: ctx
+func (_m *MockSongUseCase) SubscribeSongEvents(ctx context.Context) <-chan entity.SongEvent {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeSongEvents")
+	}
+
+	var r0 <-chan entity.SongEvent
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan entity.SongEvent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan entity.SongEvent)
+		}
+	}
+
+	return r0
+}
+
+// MockSongUseCase_SubscribeSongEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeSongEvents'
+type MockSongUseCase_SubscribeSongEvents_Call struct {
+	*mock.Call
+}
+
+// SubscribeSongEvents is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSongUseCase_Expecter) SubscribeSongEvents(ctx interface{}) *MockSongUseCase_SubscribeSongEvents_Call {
+	return &MockSongUseCase_SubscribeSongEvents_Call{Call: _e.mock.On("SubscribeSongEvents", ctx)}
+}
+
+func (_c *MockSongUseCase_SubscribeSongEvents_Call) Run(run func(ctx context.Context)) *MockSongUseCase_SubscribeSongEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_SubscribeSongEvents_Call) Return(_a0 <-chan entity.SongEvent) *MockSongUseCase_SubscribeSongEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSongUseCase_SubscribeSongEvents_Call) RunAndReturn(run func(context.Context) <-chan entity.SongEvent) *MockSongUseCase_SubscribeSongEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuggestNames provides a mock function with given fields: ctx, field, prefix, limit
+func (_m *MockSongUseCase) SuggestNames(ctx context.Context, field entity.SuggestField, prefix string, limit uint64) ([]string, error) {
+	ret := _m.Called(ctx, field, prefix, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestNames")
+	}
+
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Song) (*entity.Song, error)); ok {
-		return rf(ctx, songID, song)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SuggestField, string, uint64) ([]string, error)); ok {
+		return rf(ctx, field, prefix, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Song) *entity.Song); ok {
-		r0 = rf(ctx, songID, song)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SuggestField, string, uint64) []string); ok {
+		r0 = rf(ctx, field, prefix, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Song)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Song) error); ok {
-		r1 = rf(ctx, songID, song)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.SuggestField, string, uint64) error); ok {
+		r1 = rf(ctx, field, prefix, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -266,57 +2263,65 @@ func (_m *MockSongUseCase) ModifySong(ctx context.Context, songID uuid.UUID, son
 	return r0, r1
 }
 
-// MockSongUseCase_ModifySong_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModifySong'
-type MockSongUseCase_ModifySong_Call struct {
+// MockSongUseCase_SuggestNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestNames'
+type MockSongUseCase_SuggestNames_Call struct {
 	*mock.Call
 }
 
-// ModifySong is a helper method to define mock.On call
+// SuggestNames is a helper method to define mock.On call
 //   - ctx context.Context
-//   - songID uuid.UUID
-//   - song entity.Song
-func (_e *MockSongUseCase_Expecter) ModifySong(ctx interface{}, songID interface{}, song interface{}) *MockSongUseCase_ModifySong_Call {
-	return &MockSongUseCase_ModifySong_Call{Call: _e.mock.On("ModifySong", ctx, songID, song)}
+//   - field entity.SuggestField
+//   - prefix string
+//   - limit uint64
+func (_e *MockSongUseCase_Expecter) SuggestNames(ctx interface{}, field interface{}, prefix interface{}, limit interface{}) *MockSongUseCase_SuggestNames_Call {
+	return &MockSongUseCase_SuggestNames_Call{Call: _e.mock.On("SuggestNames", ctx, field, prefix, limit)}
 }
 
-func (_c *MockSongUseCase_ModifySong_Call) Run(run func(ctx context.Context, songID uuid.UUID, song entity.Song)) *MockSongUseCase_ModifySong_Call {
+func (_c *MockSongUseCase_SuggestNames_Call) Run(run func(ctx context.Context, field entity.SuggestField, prefix string, limit uint64)) *MockSongUseCase_SuggestNames_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.Song))
+		run(args[0].(context.Context), args[1].(entity.SuggestField), args[2].(string), args[3].(uint64))
 	})
 	return _c
 }
 
-func (_c *MockSongUseCase_ModifySong_Call) Return(_a0 *entity.Song, _a1 error) *MockSongUseCase_ModifySong_Call {
+func (_c *MockSongUseCase_SuggestNames_Call) Return(_a0 []string, _a1 error) *MockSongUseCase_SuggestNames_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSongUseCase_ModifySong_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.Song) (*entity.Song, error)) *MockSongUseCase_ModifySong_Call {
+func (_c *MockSongUseCase_SuggestNames_Call) RunAndReturn(run func(context.Context, entity.SuggestField, string, uint64) ([]string, error)) *MockSongUseCase_SuggestNames_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveSong provides a mock function with given fields: ctx, songID
-func (_m *MockSongUseCase) RemoveSong(ctx context.Context, songID uuid.UUID) (int64, error) {
-	ret := _m.Called(ctx, songID)
+// TouchSongs provides a mock function with given fields: ctx, filters
+func (_m *MockSongUseCase) TouchSongs(ctx context.Context, filters ...entity.SongFilter) (int64, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveSong")
+		panic("no return value specified for TouchSongs")
 	}
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
-		return rf(ctx, songID)
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) (int64, error)); ok {
+		return rf(ctx, filters...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
-		r0 = rf(ctx, songID)
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) int64); ok {
+		r0 = rf(ctx, filters...)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, songID)
+	if rf, ok := ret.Get(1).(func(context.Context, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, filters...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -324,31 +2329,100 @@ func (_m *MockSongUseCase) RemoveSong(ctx context.Context, songID uuid.UUID) (in
 	return r0, r1
 }
 
-// MockSongUseCase_RemoveSong_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveSong'
-type MockSongUseCase_RemoveSong_Call struct {
+// MockSongUseCase_TouchSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchSongs'
+type MockSongUseCase_TouchSongs_Call struct {
 	*mock.Call
 }
 
-// RemoveSong is a helper method to define mock.On call
+// TouchSongs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - songID uuid.UUID
-func (_e *MockSongUseCase_Expecter) RemoveSong(ctx interface{}, songID interface{}) *MockSongUseCase_RemoveSong_Call {
-	return &MockSongUseCase_RemoveSong_Call{Call: _e.mock.On("RemoveSong", ctx, songID)}
+//   - filters ...entity.SongFilter
+func (_e *MockSongUseCase_Expecter) TouchSongs(ctx interface{}, filters ...interface{}) *MockSongUseCase_TouchSongs_Call {
+	return &MockSongUseCase_TouchSongs_Call{Call: _e.mock.On("TouchSongs",
+		append([]interface{}{ctx}, filters...)...)}
 }
 
-func (_c *MockSongUseCase_RemoveSong_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongUseCase_RemoveSong_Call {
+func (_c *MockSongUseCase_TouchSongs_Call) Run(run func(ctx context.Context, filters ...entity.SongFilter)) *MockSongUseCase_TouchSongs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		variadicArgs := make([]entity.SongFilter, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockSongUseCase_RemoveSong_Call) Return(_a0 int64, _a1 error) *MockSongUseCase_RemoveSong_Call {
+func (_c *MockSongUseCase_TouchSongs_Call) Return(_a0 int64, _a1 error) *MockSongUseCase_TouchSongs_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSongUseCase_RemoveSong_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockSongUseCase_RemoveSong_Call {
+func (_c *MockSongUseCase_TouchSongs_Call) RunAndReturn(run func(context.Context, ...entity.SongFilter) (int64, error)) *MockSongUseCase_TouchSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WalkSongs provides a mock function with given fields: ctx, fn, filters
+func (_m *MockSongUseCase) WalkSongs(ctx context.Context, fn func(*entity.Song) error, filters ...entity.SongFilter) error {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, fn)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WalkSongs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*entity.Song) error, ...entity.SongFilter) error); ok {
+		r0 = rf(ctx, fn, filters...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSongUseCase_WalkSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WalkSongs'
+type MockSongUseCase_WalkSongs_Call struct {
+	*mock.Call
+}
+
+// WalkSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*entity.Song) error
+//   - filters ...entity.SongFilter
+func (_e *MockSongUseCase_Expecter) WalkSongs(ctx interface{}, fn interface{}, filters ...interface{}) *MockSongUseCase_WalkSongs_Call {
+	return &MockSongUseCase_WalkSongs_Call{Call: _e.mock.On("WalkSongs",
+		append([]interface{}{ctx, fn}, filters...)...)}
+}
+
+func (_c *MockSongUseCase_WalkSongs_Call) Run(run func(ctx context.Context, fn func(*entity.Song) error, filters ...entity.SongFilter)) *MockSongUseCase_WalkSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(func(*entity.Song) error), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongUseCase_WalkSongs_Call) Return(_a0 error) *MockSongUseCase_WalkSongs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSongUseCase_WalkSongs_Call) RunAndReturn(run func(context.Context, func(*entity.Song) error, ...entity.SongFilter) error) *MockSongUseCase_WalkSongs_Call {
 	_c.Call.Return(run)
 	return _c
 }
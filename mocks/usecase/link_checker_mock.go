@@ -0,0 +1,93 @@
+// Code generated by mockery v2.46.0. DO NOT EDIT.
+
+package usecase
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLinkChecker is an autogenerated mock type for the linkChecker type
+type MockLinkChecker struct {
+	mock.Mock
+}
+
+type MockLinkChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLinkChecker) EXPECT() *MockLinkChecker_Expecter {
+	return &MockLinkChecker_Expecter{mock: &_m.Mock}
+}
+
+// CheckLink provides a mock function with given fields: ctx, link
+func (_m *MockLinkChecker) CheckLink(ctx context.Context, link string) (int, error) {
+	ret := _m.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLink")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, link)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, link)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLinkChecker_CheckLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckLink'
+type MockLinkChecker_CheckLink_Call struct {
+	*mock.Call
+}
+
+// CheckLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+func (_e *MockLinkChecker_Expecter) CheckLink(ctx interface{}, link interface{}) *MockLinkChecker_CheckLink_Call {
+	return &MockLinkChecker_CheckLink_Call{Call: _e.mock.On("CheckLink", ctx, link)}
+}
+
+func (_c *MockLinkChecker_CheckLink_Call) Run(run func(ctx context.Context, link string)) *MockLinkChecker_CheckLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLinkChecker_CheckLink_Call) Return(_a0 int, _a1 error) *MockLinkChecker_CheckLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLinkChecker_CheckLink_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockLinkChecker_CheckLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLinkChecker creates a new instance of MockLinkChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLinkChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLinkChecker {
+	mock := &MockLinkChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 	entity "github.com/vadimbarashkov/online-song-library/internal/entity"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -24,27 +26,34 @@ func (_m *MockSongRepository) EXPECT() *MockSongRepository_Expecter {
 	return &MockSongRepository_Expecter{mock: &_m.Mock}
 }
 
-// Delete provides a mock function with given fields: ctx, songID
-func (_m *MockSongRepository) Delete(ctx context.Context, songID uuid.UUID) (int64, error) {
-	ret := _m.Called(ctx, songID)
+// Count provides a mock function with given fields: ctx, filters
+func (_m *MockSongRepository) Count(ctx context.Context, filters ...entity.SongFilter) (uint64, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for Count")
 	}
 
-	var r0 int64
+	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
-		return rf(ctx, songID)
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) (uint64, error)); ok {
+		return rf(ctx, filters...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
-		r0 = rf(ctx, songID)
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) uint64); ok {
+		r0 = rf(ctx, filters...)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, songID)
+	if rf, ok := ret.Get(1).(func(context.Context, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, filters...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,96 +61,211 @@ func (_m *MockSongRepository) Delete(ctx context.Context, songID uuid.UUID) (int
 	return r0, r1
 }
 
-// MockSongRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockSongRepository_Delete_Call struct {
+// MockSongRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockSongRepository_Count_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// Count is a helper method to define mock.On call
 //   - ctx context.Context
-//   - songID uuid.UUID
-func (_e *MockSongRepository_Expecter) Delete(ctx interface{}, songID interface{}) *MockSongRepository_Delete_Call {
-	return &MockSongRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, songID)}
+//   - filters ...entity.SongFilter
+func (_e *MockSongRepository_Expecter) Count(ctx interface{}, filters ...interface{}) *MockSongRepository_Count_Call {
+	return &MockSongRepository_Count_Call{Call: _e.mock.On("Count",
+		append([]interface{}{ctx}, filters...)...)}
 }
 
-func (_c *MockSongRepository_Delete_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongRepository_Delete_Call {
+func (_c *MockSongRepository_Count_Call) Run(run func(ctx context.Context, filters ...entity.SongFilter)) *MockSongRepository_Count_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		variadicArgs := make([]entity.SongFilter, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockSongRepository_Delete_Call) Return(_a0 int64, _a1 error) *MockSongRepository_Delete_Call {
+func (_c *MockSongRepository_Count_Call) Return(_a0 uint64, _a1 error) *MockSongRepository_Count_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSongRepository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockSongRepository_Delete_Call {
+func (_c *MockSongRepository_Count_Call) RunAndReturn(run func(context.Context, ...entity.SongFilter) (uint64, error)) *MockSongRepository_Count_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAll provides a mock function with given fields: ctx, pagination, filters
-func (_m *MockSongRepository) GetAll(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error) {
+// CountByDecade provides a mock function with given fields: ctx
+func (_m *MockSongRepository) CountByDecade(ctx context.Context) ([]entity.DecadeCount, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByDecade")
+	}
+
+	var r0 []entity.DecadeCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.DecadeCount, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.DecadeCount); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DecadeCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_CountByDecade_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByDecade'
+type MockSongRepository_CountByDecade_Call struct {
+	*mock.Call
+}
+
+// CountByDecade is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSongRepository_Expecter) CountByDecade(ctx interface{}) *MockSongRepository_CountByDecade_Call {
+	return &MockSongRepository_CountByDecade_Call{Call: _e.mock.On("CountByDecade", ctx)}
+}
+
+func (_c *MockSongRepository_CountByDecade_Call) Run(run func(ctx context.Context)) *MockSongRepository_CountByDecade_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_CountByDecade_Call) Return(_a0 []entity.DecadeCount, _a1 error) *MockSongRepository_CountByDecade_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_CountByDecade_Call) RunAndReturn(run func(context.Context) ([]entity.DecadeCount, error)) *MockSongRepository_CountByDecade_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByFirstLetter provides a mock function with given fields: ctx, field
+func (_m *MockSongRepository) CountByFirstLetter(ctx context.Context, field entity.AlphaIndexField) ([]entity.LetterCount, error) {
+	ret := _m.Called(ctx, field)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByFirstLetter")
+	}
+
+	var r0 []entity.LetterCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlphaIndexField) ([]entity.LetterCount, error)); ok {
+		return rf(ctx, field)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AlphaIndexField) []entity.LetterCount); ok {
+		r0 = rf(ctx, field)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LetterCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AlphaIndexField) error); ok {
+		r1 = rf(ctx, field)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_CountByFirstLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByFirstLetter'
+type MockSongRepository_CountByFirstLetter_Call struct {
+	*mock.Call
+}
+
+// CountByFirstLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - field entity.AlphaIndexField
+func (_e *MockSongRepository_Expecter) CountByFirstLetter(ctx interface{}, field interface{}) *MockSongRepository_CountByFirstLetter_Call {
+	return &MockSongRepository_CountByFirstLetter_Call{Call: _e.mock.On("CountByFirstLetter", ctx, field)}
+}
+
+func (_c *MockSongRepository_CountByFirstLetter_Call) Run(run func(ctx context.Context, field entity.AlphaIndexField)) *MockSongRepository_CountByFirstLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.AlphaIndexField))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_CountByFirstLetter_Call) Return(_a0 []entity.LetterCount, _a1 error) *MockSongRepository_CountByFirstLetter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_CountByFirstLetter_Call) RunAndReturn(run func(context.Context, entity.AlphaIndexField) ([]entity.LetterCount, error)) *MockSongRepository_CountByFirstLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountFacet provides a mock function with given fields: ctx, field, filters
+func (_m *MockSongRepository) CountFacet(ctx context.Context, field entity.FacetField, filters ...entity.SongFilter) ([]entity.FacetCount, error) {
 	_va := make([]interface{}, len(filters))
 	for _i := range filters {
 		_va[_i] = filters[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, pagination)
+	_ca = append(_ca, ctx, field)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for CountFacet")
 	}
 
-	var r0 []*entity.Song
-	var r1 *entity.Pagination
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)); ok {
-		return rf(ctx, pagination, filters...)
+	var r0 []entity.FacetCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.FacetField, ...entity.SongFilter) ([]entity.FacetCount, error)); ok {
+		return rf(ctx, field, filters...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) []*entity.Song); ok {
-		r0 = rf(ctx, pagination, filters...)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.FacetField, ...entity.SongFilter) []entity.FacetCount); ok {
+		r0 = rf(ctx, field, filters...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.Song)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination, ...entity.SongFilter) *entity.Pagination); ok {
-		r1 = rf(ctx, pagination, filters...)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*entity.Pagination)
+			r0 = ret.Get(0).([]entity.FacetCount)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination, ...entity.SongFilter) error); ok {
-		r2 = rf(ctx, pagination, filters...)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.FacetField, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, field, filters...)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockSongRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type MockSongRepository_GetAll_Call struct {
+// MockSongRepository_CountFacet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountFacet'
+type MockSongRepository_CountFacet_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
+// CountFacet is a helper method to define mock.On call
 //   - ctx context.Context
-//   - pagination entity.Pagination
+//   - field entity.FacetField
 //   - filters ...entity.SongFilter
-func (_e *MockSongRepository_Expecter) GetAll(ctx interface{}, pagination interface{}, filters ...interface{}) *MockSongRepository_GetAll_Call {
-	return &MockSongRepository_GetAll_Call{Call: _e.mock.On("GetAll",
-		append([]interface{}{ctx, pagination}, filters...)...)}
+func (_e *MockSongRepository_Expecter) CountFacet(ctx interface{}, field interface{}, filters ...interface{}) *MockSongRepository_CountFacet_Call {
+	return &MockSongRepository_CountFacet_Call{Call: _e.mock.On("CountFacet",
+		append([]interface{}{ctx, field}, filters...)...)}
 }
 
-func (_c *MockSongRepository_GetAll_Call) Run(run func(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter)) *MockSongRepository_GetAll_Call {
+func (_c *MockSongRepository_CountFacet_Call) Run(run func(ctx context.Context, field entity.FacetField, filters ...entity.SongFilter)) *MockSongRepository_CountFacet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]entity.SongFilter, len(args)-2)
 		for i, a := range args[2:] {
@@ -149,40 +273,38 @@ func (_c *MockSongRepository_GetAll_Call) Run(run func(ctx context.Context, pagi
 				variadicArgs[i] = a.(entity.SongFilter)
 			}
 		}
-		run(args[0].(context.Context), args[1].(entity.Pagination), variadicArgs...)
+		run(args[0].(context.Context), args[1].(entity.FacetField), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockSongRepository_GetAll_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongRepository_GetAll_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockSongRepository_CountFacet_Call) Return(_a0 []entity.FacetCount, _a1 error) *MockSongRepository_CountFacet_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSongRepository_GetAll_Call) RunAndReturn(run func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)) *MockSongRepository_GetAll_Call {
+func (_c *MockSongRepository_CountFacet_Call) RunAndReturn(run func(context.Context, entity.FacetField, ...entity.SongFilter) ([]entity.FacetCount, error)) *MockSongRepository_CountFacet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function with given fields: ctx, songID
-func (_m *MockSongRepository) GetByID(ctx context.Context, songID uuid.UUID) (*entity.Song, error) {
+// Delete provides a mock function with given fields: ctx, songID
+func (_m *MockSongRepository) Delete(ctx context.Context, songID uuid.UUID) (int64, error) {
 	ret := _m.Called(ctx, songID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for Delete")
 	}
 
-	var r0 *entity.Song
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Song, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
 		return rf(ctx, songID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Song); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
 		r0 = rf(ctx, songID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Song)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -194,58 +316,58 @@ func (_m *MockSongRepository) GetByID(ctx context.Context, songID uuid.UUID) (*e
 	return r0, r1
 }
 
-// MockSongRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockSongRepository_GetByID_Call struct {
+// MockSongRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSongRepository_Delete_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
 //   - songID uuid.UUID
-func (_e *MockSongRepository_Expecter) GetByID(ctx interface{}, songID interface{}) *MockSongRepository_GetByID_Call {
-	return &MockSongRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, songID)}
+func (_e *MockSongRepository_Expecter) Delete(ctx interface{}, songID interface{}) *MockSongRepository_Delete_Call {
+	return &MockSongRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, songID)}
 }
 
-func (_c *MockSongRepository_GetByID_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongRepository_GetByID_Call {
+func (_c *MockSongRepository_Delete_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockSongRepository_GetByID_Call) Return(_a0 *entity.Song, _a1 error) *MockSongRepository_GetByID_Call {
+func (_c *MockSongRepository_Delete_Call) Return(_a0 int64, _a1 error) *MockSongRepository_Delete_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSongRepository_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Song, error)) *MockSongRepository_GetByID_Call {
+func (_c *MockSongRepository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockSongRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function with given fields: ctx, song
-func (_m *MockSongRepository) Save(ctx context.Context, song entity.Song) (*entity.Song, error) {
-	ret := _m.Called(ctx, song)
+// DeleteBatch provides a mock function with given fields: ctx, songIDs
+func (_m *MockSongRepository) DeleteBatch(ctx context.Context, songIDs []uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, songIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Save")
+		panic("no return value specified for DeleteBatch")
 	}
 
-	var r0 *entity.Song
+	var r0 []uuid.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Song) (*entity.Song, error)); ok {
-		return rf(ctx, song)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(ctx, songIDs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Song) *entity.Song); ok {
-		r0 = rf(ctx, song)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(ctx, songIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Song)
+			r0 = ret.Get(0).([]uuid.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, entity.Song) error); ok {
-		r1 = rf(ctx, song)
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, songIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -253,58 +375,65 @@ func (_m *MockSongRepository) Save(ctx context.Context, song entity.Song) (*enti
 	return r0, r1
 }
 
-// MockSongRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
-type MockSongRepository_Save_Call struct {
+// MockSongRepository_DeleteBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBatch'
+type MockSongRepository_DeleteBatch_Call struct {
 	*mock.Call
 }
 
-// Save is a helper method to define mock.On call
+// DeleteBatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - song entity.Song
-func (_e *MockSongRepository_Expecter) Save(ctx interface{}, song interface{}) *MockSongRepository_Save_Call {
-	return &MockSongRepository_Save_Call{Call: _e.mock.On("Save", ctx, song)}
+//   - songIDs []uuid.UUID
+func (_e *MockSongRepository_Expecter) DeleteBatch(ctx interface{}, songIDs interface{}) *MockSongRepository_DeleteBatch_Call {
+	return &MockSongRepository_DeleteBatch_Call{Call: _e.mock.On("DeleteBatch", ctx, songIDs)}
 }
 
-func (_c *MockSongRepository_Save_Call) Run(run func(ctx context.Context, song entity.Song)) *MockSongRepository_Save_Call {
+func (_c *MockSongRepository_DeleteBatch_Call) Run(run func(ctx context.Context, songIDs []uuid.UUID)) *MockSongRepository_DeleteBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(entity.Song))
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockSongRepository_Save_Call) Return(_a0 *entity.Song, _a1 error) *MockSongRepository_Save_Call {
+func (_c *MockSongRepository_DeleteBatch_Call) Return(_a0 []uuid.UUID, _a1 error) *MockSongRepository_DeleteBatch_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSongRepository_Save_Call) RunAndReturn(run func(context.Context, entity.Song) (*entity.Song, error)) *MockSongRepository_Save_Call {
+func (_c *MockSongRepository_DeleteBatch_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]uuid.UUID, error)) *MockSongRepository_DeleteBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function with given fields: ctx, songID, song
-func (_m *MockSongRepository) Update(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error) {
-	ret := _m.Called(ctx, songID, song)
+// DeleteWhere provides a mock function with given fields: ctx, filters
+func (_m *MockSongRepository) DeleteWhere(ctx context.Context, filters ...entity.SongFilter) ([]uuid.UUID, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for DeleteWhere")
 	}
 
-	var r0 *entity.Song
+	var r0 []uuid.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Song) (*entity.Song, error)); ok {
-		return rf(ctx, songID, song)
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) ([]uuid.UUID, error)); ok {
+		return rf(ctx, filters...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Song) *entity.Song); ok {
-		r0 = rf(ctx, songID, song)
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) []uuid.UUID); ok {
+		r0 = rf(ctx, filters...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Song)
+			r0 = ret.Get(0).([]uuid.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Song) error); ok {
-		r1 = rf(ctx, songID, song)
+	if rf, ok := ret.Get(1).(func(context.Context, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, filters...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -312,32 +441,1765 @@ func (_m *MockSongRepository) Update(ctx context.Context, songID uuid.UUID, song
 	return r0, r1
 }
 
-// MockSongRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockSongRepository_Update_Call struct {
+// MockSongRepository_DeleteWhere_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWhere'
+type MockSongRepository_DeleteWhere_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// DeleteWhere is a helper method to define mock.On call
 //   - ctx context.Context
-//   - songID uuid.UUID
-//   - song entity.Song
-func (_e *MockSongRepository_Expecter) Update(ctx interface{}, songID interface{}, song interface{}) *MockSongRepository_Update_Call {
-	return &MockSongRepository_Update_Call{Call: _e.mock.On("Update", ctx, songID, song)}
+//   - filters ...entity.SongFilter
+func (_e *MockSongRepository_Expecter) DeleteWhere(ctx interface{}, filters ...interface{}) *MockSongRepository_DeleteWhere_Call {
+	return &MockSongRepository_DeleteWhere_Call{Call: _e.mock.On("DeleteWhere",
+		append([]interface{}{ctx}, filters...)...)}
 }
 
-func (_c *MockSongRepository_Update_Call) Run(run func(ctx context.Context, songID uuid.UUID, song entity.Song)) *MockSongRepository_Update_Call {
+func (_c *MockSongRepository_DeleteWhere_Call) Run(run func(ctx context.Context, filters ...entity.SongFilter)) *MockSongRepository_DeleteWhere_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.Song))
+		variadicArgs := make([]entity.SongFilter, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockSongRepository_Update_Call) Return(_a0 *entity.Song, _a1 error) *MockSongRepository_Update_Call {
+func (_c *MockSongRepository_DeleteWhere_Call) Return(_a0 []uuid.UUID, _a1 error) *MockSongRepository_DeleteWhere_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSongRepository_Update_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.Song) (*entity.Song, error)) *MockSongRepository_Update_Call {
+func (_c *MockSongRepository_DeleteWhere_Call) RunAndReturn(run func(context.Context, ...entity.SongFilter) ([]uuid.UUID, error)) *MockSongRepository_DeleteWhere_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExistsByName provides a mock function with given fields: ctx, groupName, name
+func (_m *MockSongRepository) ExistsByName(ctx context.Context, groupName string, name string) (bool, error) {
+	ret := _m.Called(ctx, groupName, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsByName")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, groupName, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, groupName, name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupName, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_ExistsByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsByName'
+type MockSongRepository_ExistsByName_Call struct {
+	*mock.Call
+}
+
+// ExistsByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupName string
+//   - name string
+func (_e *MockSongRepository_Expecter) ExistsByName(ctx interface{}, groupName interface{}, name interface{}) *MockSongRepository_ExistsByName_Call {
+	return &MockSongRepository_ExistsByName_Call{Call: _e.mock.On("ExistsByName", ctx, groupName, name)}
+}
+
+func (_c *MockSongRepository_ExistsByName_Call) Run(run func(ctx context.Context, groupName string, name string)) *MockSongRepository_ExistsByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_ExistsByName_Call) Return(_a0 bool, _a1 error) *MockSongRepository_ExistsByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_ExistsByName_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockSongRepository_ExistsByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAfter provides a mock function with given fields: ctx, afterID, limit, filters
+func (_m *MockSongRepository) GetAfter(ctx context.Context, afterID uuid.UUID, limit uint64, filters ...entity.SongFilter) ([]*entity.Song, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, afterID, limit)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAfter")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uint64, ...entity.SongFilter) ([]*entity.Song, error)); ok {
+		return rf(ctx, afterID, limit, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uint64, ...entity.SongFilter) []*entity.Song); ok {
+		r0 = rf(ctx, afterID, limit, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uint64, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, afterID, limit, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_GetAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAfter'
+type MockSongRepository_GetAfter_Call struct {
+	*mock.Call
+}
+
+// GetAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID uuid.UUID
+//   - limit uint64
+//   - filters ...entity.SongFilter
+func (_e *MockSongRepository_Expecter) GetAfter(ctx interface{}, afterID interface{}, limit interface{}, filters ...interface{}) *MockSongRepository_GetAfter_Call {
+	return &MockSongRepository_GetAfter_Call{Call: _e.mock.On("GetAfter",
+		append([]interface{}{ctx, afterID, limit}, filters...)...)}
+}
+
+func (_c *MockSongRepository_GetAfter_Call) Run(run func(ctx context.Context, afterID uuid.UUID, limit uint64, filters ...entity.SongFilter)) *MockSongRepository_GetAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uint64), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetAfter_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongRepository_GetAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_GetAfter_Call) RunAndReturn(run func(context.Context, uuid.UUID, uint64, ...entity.SongFilter) ([]*entity.Song, error)) *MockSongRepository_GetAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: ctx, pagination, filters
+func (_m *MockSongRepository) GetAll(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, pagination)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, pagination, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) []*entity.Song); ok {
+		r0 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination, ...entity.SongFilter) *entity.Pagination); ok {
+		r1 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination, ...entity.SongFilter) error); ok {
+		r2 = rf(ctx, pagination, filters...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockSongRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pagination entity.Pagination
+//   - filters ...entity.SongFilter
+func (_e *MockSongRepository_Expecter) GetAll(ctx interface{}, pagination interface{}, filters ...interface{}) *MockSongRepository_GetAll_Call {
+	return &MockSongRepository_GetAll_Call{Call: _e.mock.On("GetAll",
+		append([]interface{}{ctx, pagination}, filters...)...)}
+}
+
+func (_c *MockSongRepository_GetAll_Call) Run(run func(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter)) *MockSongRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(entity.Pagination), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetAll_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongRepository_GetAll_Call) RunAndReturn(run func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)) *MockSongRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, songID
+func (_m *MockSongRepository) GetByID(ctx context.Context, songID uuid.UUID) (*entity.Song, error) {
+	ret := _m.Called(ctx, songID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Song, error)); ok {
+		return rf(ctx, songID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Song); ok {
+		r0 = rf(ctx, songID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, songID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockSongRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+func (_e *MockSongRepository_Expecter) GetByID(ctx interface{}, songID interface{}) *MockSongRepository_GetByID_Call {
+	return &MockSongRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, songID)}
+}
+
+func (_c *MockSongRepository_GetByID_Call) Run(run func(ctx context.Context, songID uuid.UUID)) *MockSongRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetByID_Call) Return(_a0 *entity.Song, _a1 error) *MockSongRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Song, error)) *MockSongRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDs provides a mock function with given fields: ctx, songIDs
+func (_m *MockSongRepository) GetByIDs(ctx context.Context, songIDs []uuid.UUID) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, songIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]*entity.Song, error)); ok {
+		return rf(ctx, songIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*entity.Song); ok {
+		r0 = rf(ctx, songIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, songIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockSongRepository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songIDs []uuid.UUID
+func (_e *MockSongRepository_Expecter) GetByIDs(ctx interface{}, songIDs interface{}) *MockSongRepository_GetByIDs_Call {
+	return &MockSongRepository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, songIDs)}
+}
+
+func (_c *MockSongRepository_GetByIDs_Call) Run(run func(ctx context.Context, songIDs []uuid.UUID)) *MockSongRepository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetByIDs_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongRepository_GetByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_GetByIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]*entity.Song, error)) *MockSongRepository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupFacets provides a mock function with given fields: ctx, groupName
+func (_m *MockSongRepository) GetGroupFacets(ctx context.Context, groupName string) (*entity.GroupFacets, error) {
+	ret := _m.Called(ctx, groupName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupFacets")
+	}
+
+	var r0 *entity.GroupFacets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.GroupFacets, error)); ok {
+		return rf(ctx, groupName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.GroupFacets); ok {
+		r0 = rf(ctx, groupName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.GroupFacets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_GetGroupFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupFacets'
+type MockSongRepository_GetGroupFacets_Call struct {
+	*mock.Call
+}
+
+// GetGroupFacets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupName string
+func (_e *MockSongRepository_Expecter) GetGroupFacets(ctx interface{}, groupName interface{}) *MockSongRepository_GetGroupFacets_Call {
+	return &MockSongRepository_GetGroupFacets_Call{Call: _e.mock.On("GetGroupFacets", ctx, groupName)}
+}
+
+func (_c *MockSongRepository_GetGroupFacets_Call) Run(run func(ctx context.Context, groupName string)) *MockSongRepository_GetGroupFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetGroupFacets_Call) Return(_a0 *entity.GroupFacets, _a1 error) *MockSongRepository_GetGroupFacets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_GetGroupFacets_Call) RunAndReturn(run func(context.Context, string) (*entity.GroupFacets, error)) *MockSongRepository_GetGroupFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupReleaseRange provides a mock function with given fields: ctx, groupName
+func (_m *MockSongRepository) GetGroupReleaseRange(ctx context.Context, groupName string) (*entity.GroupReleaseRange, error) {
+	ret := _m.Called(ctx, groupName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupReleaseRange")
+	}
+
+	var r0 *entity.GroupReleaseRange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.GroupReleaseRange, error)); ok {
+		return rf(ctx, groupName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.GroupReleaseRange); ok {
+		r0 = rf(ctx, groupName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.GroupReleaseRange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, groupName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_GetGroupReleaseRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupReleaseRange'
+type MockSongRepository_GetGroupReleaseRange_Call struct {
+	*mock.Call
+}
+
+// GetGroupReleaseRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupName string
+func (_e *MockSongRepository_Expecter) GetGroupReleaseRange(ctx interface{}, groupName interface{}) *MockSongRepository_GetGroupReleaseRange_Call {
+	return &MockSongRepository_GetGroupReleaseRange_Call{Call: _e.mock.On("GetGroupReleaseRange", ctx, groupName)}
+}
+
+func (_c *MockSongRepository_GetGroupReleaseRange_Call) Run(run func(ctx context.Context, groupName string)) *MockSongRepository_GetGroupReleaseRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetGroupReleaseRange_Call) Return(_a0 *entity.GroupReleaseRange, _a1 error) *MockSongRepository_GetGroupReleaseRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_GetGroupReleaseRange_Call) RunAndReturn(run func(context.Context, string) (*entity.GroupReleaseRange, error)) *MockSongRepository_GetGroupReleaseRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIncomplete provides a mock function with given fields: ctx, pagination, filters
+func (_m *MockSongRepository) GetIncomplete(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, pagination)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIncomplete")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, pagination, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) []*entity.Song); ok {
+		r0 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination, ...entity.SongFilter) *entity.Pagination); ok {
+		r1 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination, ...entity.SongFilter) error); ok {
+		r2 = rf(ctx, pagination, filters...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongRepository_GetIncomplete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIncomplete'
+type MockSongRepository_GetIncomplete_Call struct {
+	*mock.Call
+}
+
+// GetIncomplete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pagination entity.Pagination
+//   - filters ...entity.SongFilter
+func (_e *MockSongRepository_Expecter) GetIncomplete(ctx interface{}, pagination interface{}, filters ...interface{}) *MockSongRepository_GetIncomplete_Call {
+	return &MockSongRepository_GetIncomplete_Call{Call: _e.mock.On("GetIncomplete",
+		append([]interface{}{ctx, pagination}, filters...)...)}
+}
+
+func (_c *MockSongRepository_GetIncomplete_Call) Run(run func(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter)) *MockSongRepository_GetIncomplete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(entity.Pagination), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetIncomplete_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongRepository_GetIncomplete_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongRepository_GetIncomplete_Call) RunAndReturn(run func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)) *MockSongRepository_GetIncomplete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLibraryStats provides a mock function with given fields: ctx
+func (_m *MockSongRepository) GetLibraryStats(ctx context.Context) (*entity.LibraryStats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLibraryStats")
+	}
+
+	var r0 *entity.LibraryStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*entity.LibraryStats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *entity.LibraryStats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LibraryStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_GetLibraryStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLibraryStats'
+type MockSongRepository_GetLibraryStats_Call struct {
+	*mock.Call
+}
+
+// GetLibraryStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSongRepository_Expecter) GetLibraryStats(ctx interface{}) *MockSongRepository_GetLibraryStats_Call {
+	return &MockSongRepository_GetLibraryStats_Call{Call: _e.mock.On("GetLibraryStats", ctx)}
+}
+
+func (_c *MockSongRepository_GetLibraryStats_Call) Run(run func(ctx context.Context)) *MockSongRepository_GetLibraryStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetLibraryStats_Call) Return(_a0 *entity.LibraryStats, _a1 error) *MockSongRepository_GetLibraryStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_GetLibraryStats_Call) RunAndReturn(run func(context.Context) (*entity.LibraryStats, error)) *MockSongRepository_GetLibraryStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecent provides a mock function with given fields: ctx, limit
+func (_m *MockSongRepository) GetRecent(ctx context.Context, limit uint64) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecent")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*entity.Song, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*entity.Song); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_GetRecent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecent'
+type MockSongRepository_GetRecent_Call struct {
+	*mock.Call
+}
+
+// GetRecent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit uint64
+func (_e *MockSongRepository_Expecter) GetRecent(ctx interface{}, limit interface{}) *MockSongRepository_GetRecent_Call {
+	return &MockSongRepository_GetRecent_Call{Call: _e.mock.On("GetRecent", ctx, limit)}
+}
+
+func (_c *MockSongRepository_GetRecent_Call) Run(run func(ctx context.Context, limit uint64)) *MockSongRepository_GetRecent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetRecent_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongRepository_GetRecent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_GetRecent_Call) RunAndReturn(run func(context.Context, uint64) ([]*entity.Song, error)) *MockSongRepository_GetRecent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecentlyUpdated provides a mock function with given fields: ctx, pagination, filters
+func (_m *MockSongRepository) GetRecentlyUpdated(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, pagination)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentlyUpdated")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, pagination, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination, ...entity.SongFilter) []*entity.Song); ok {
+		r0 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination, ...entity.SongFilter) *entity.Pagination); ok {
+		r1 = rf(ctx, pagination, filters...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination, ...entity.SongFilter) error); ok {
+		r2 = rf(ctx, pagination, filters...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongRepository_GetRecentlyUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentlyUpdated'
+type MockSongRepository_GetRecentlyUpdated_Call struct {
+	*mock.Call
+}
+
+// GetRecentlyUpdated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pagination entity.Pagination
+//   - filters ...entity.SongFilter
+func (_e *MockSongRepository_Expecter) GetRecentlyUpdated(ctx interface{}, pagination interface{}, filters ...interface{}) *MockSongRepository_GetRecentlyUpdated_Call {
+	return &MockSongRepository_GetRecentlyUpdated_Call{Call: _e.mock.On("GetRecentlyUpdated",
+		append([]interface{}{ctx, pagination}, filters...)...)}
+}
+
+func (_c *MockSongRepository_GetRecentlyUpdated_Call) Run(run func(ctx context.Context, pagination entity.Pagination, filters ...entity.SongFilter)) *MockSongRepository_GetRecentlyUpdated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(entity.Pagination), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetRecentlyUpdated_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongRepository_GetRecentlyUpdated_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongRepository_GetRecentlyUpdated_Call) RunAndReturn(run func(context.Context, entity.Pagination, ...entity.SongFilter) ([]*entity.Song, *entity.Pagination, error)) *MockSongRepository_GetRecentlyUpdated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSimilar provides a mock function with given fields: ctx, song, pagination
+func (_m *MockSongRepository) GetSimilar(ctx context.Context, song entity.Song, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error) {
+	ret := _m.Called(ctx, song, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSimilar")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, song, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song, entity.Pagination) []*entity.Song); ok {
+		r0 = rf(ctx, song, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Song, entity.Pagination) *entity.Pagination); ok {
+		r1 = rf(ctx, song, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Song, entity.Pagination) error); ok {
+		r2 = rf(ctx, song, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongRepository_GetSimilar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSimilar'
+type MockSongRepository_GetSimilar_Call struct {
+	*mock.Call
+}
+
+// GetSimilar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - song entity.Song
+//   - pagination entity.Pagination
+func (_e *MockSongRepository_Expecter) GetSimilar(ctx interface{}, song interface{}, pagination interface{}) *MockSongRepository_GetSimilar_Call {
+	return &MockSongRepository_GetSimilar_Call{Call: _e.mock.On("GetSimilar", ctx, song, pagination)}
+}
+
+func (_c *MockSongRepository_GetSimilar_Call) Run(run func(ctx context.Context, song entity.Song, pagination entity.Pagination)) *MockSongRepository_GetSimilar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.Song), args[2].(entity.Pagination))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetSimilar_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongRepository_GetSimilar_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongRepository_GetSimilar_Call) RunAndReturn(run func(context.Context, entity.Song, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)) *MockSongRepository_GetSimilar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStaleDetails provides a mock function with given fields: ctx, staleBefore, limit
+func (_m *MockSongRepository) GetStaleDetails(ctx context.Context, staleBefore time.Time, limit uint64) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, staleBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStaleDetails")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint64) ([]*entity.Song, error)); ok {
+		return rf(ctx, staleBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint64) []*entity.Song); ok {
+		r0 = rf(ctx, staleBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, uint64) error); ok {
+		r1 = rf(ctx, staleBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_GetStaleDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStaleDetails'
+type MockSongRepository_GetStaleDetails_Call struct {
+	*mock.Call
+}
+
+// GetStaleDetails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - staleBefore time.Time
+//   - limit uint64
+func (_e *MockSongRepository_Expecter) GetStaleDetails(ctx interface{}, staleBefore interface{}, limit interface{}) *MockSongRepository_GetStaleDetails_Call {
+	return &MockSongRepository_GetStaleDetails_Call{Call: _e.mock.On("GetStaleDetails", ctx, staleBefore, limit)}
+}
+
+func (_c *MockSongRepository_GetStaleDetails_Call) Run(run func(ctx context.Context, staleBefore time.Time, limit uint64)) *MockSongRepository_GetStaleDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetStaleDetails_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongRepository_GetStaleDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_GetStaleDetails_Call) RunAndReturn(run func(context.Context, time.Time, uint64) ([]*entity.Song, error)) *MockSongRepository_GetStaleDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithBrokenLinks provides a mock function with given fields: ctx, pagination
+func (_m *MockSongRepository) GetWithBrokenLinks(ctx context.Context, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error) {
+	ret := _m.Called(ctx, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithBrokenLinks")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pagination) []*entity.Song); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Pagination) *entity.Pagination); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, entity.Pagination) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongRepository_GetWithBrokenLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithBrokenLinks'
+type MockSongRepository_GetWithBrokenLinks_Call struct {
+	*mock.Call
+}
+
+// GetWithBrokenLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pagination entity.Pagination
+func (_e *MockSongRepository_Expecter) GetWithBrokenLinks(ctx interface{}, pagination interface{}) *MockSongRepository_GetWithBrokenLinks_Call {
+	return &MockSongRepository_GetWithBrokenLinks_Call{Call: _e.mock.On("GetWithBrokenLinks", ctx, pagination)}
+}
+
+func (_c *MockSongRepository_GetWithBrokenLinks_Call) Run(run func(ctx context.Context, pagination entity.Pagination)) *MockSongRepository_GetWithBrokenLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.Pagination))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_GetWithBrokenLinks_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongRepository_GetWithBrokenLinks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongRepository_GetWithBrokenLinks_Call) RunAndReturn(run func(context.Context, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)) *MockSongRepository_GetWithBrokenLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, song
+func (_m *MockSongRepository) Save(ctx context.Context, song entity.Song) (*entity.Song, error) {
+	ret := _m.Called(ctx, song)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song) (*entity.Song, error)); ok {
+		return rf(ctx, song)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song) *entity.Song); ok {
+		r0 = rf(ctx, song)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Song) error); ok {
+		r1 = rf(ctx, song)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockSongRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - song entity.Song
+func (_e *MockSongRepository_Expecter) Save(ctx interface{}, song interface{}) *MockSongRepository_Save_Call {
+	return &MockSongRepository_Save_Call{Call: _e.mock.On("Save", ctx, song)}
+}
+
+func (_c *MockSongRepository_Save_Call) Run(run func(ctx context.Context, song entity.Song)) *MockSongRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.Song))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_Save_Call) Return(_a0 *entity.Song, _a1 error) *MockSongRepository_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_Save_Call) RunAndReturn(run func(context.Context, entity.Song) (*entity.Song, error)) *MockSongRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveBatch provides a mock function with given fields: ctx, songs
+func (_m *MockSongRepository) SaveBatch(ctx context.Context, songs []entity.Song) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, songs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBatch")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Song) ([]*entity.Song, error)); ok {
+		return rf(ctx, songs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Song) []*entity.Song); ok {
+		r0 = rf(ctx, songs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Song) error); ok {
+		r1 = rf(ctx, songs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_SaveBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBatch'
+type MockSongRepository_SaveBatch_Call struct {
+	*mock.Call
+}
+
+// SaveBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songs []entity.Song
+func (_e *MockSongRepository_Expecter) SaveBatch(ctx interface{}, songs interface{}) *MockSongRepository_SaveBatch_Call {
+	return &MockSongRepository_SaveBatch_Call{Call: _e.mock.On("SaveBatch", ctx, songs)}
+}
+
+func (_c *MockSongRepository_SaveBatch_Call) Run(run func(ctx context.Context, songs []entity.Song)) *MockSongRepository_SaveBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.Song))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_SaveBatch_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongRepository_SaveBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_SaveBatch_Call) RunAndReturn(run func(context.Context, []entity.Song) ([]*entity.Song, error)) *MockSongRepository_SaveBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveBatchWithinGroupLimit provides a mock function with given fields: ctx, songs, limit
+func (_m *MockSongRepository) SaveBatchWithinGroupLimit(ctx context.Context, songs []entity.Song, limit uint64) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, songs, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBatchWithinGroupLimit")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Song, uint64) ([]*entity.Song, error)); ok {
+		return rf(ctx, songs, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Song, uint64) []*entity.Song); ok {
+		r0 = rf(ctx, songs, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Song, uint64) error); ok {
+		r1 = rf(ctx, songs, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_SaveBatchWithinGroupLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBatchWithinGroupLimit'
+type MockSongRepository_SaveBatchWithinGroupLimit_Call struct {
+	*mock.Call
+}
+
+// SaveBatchWithinGroupLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songs []entity.Song
+//   - limit uint64
+func (_e *MockSongRepository_Expecter) SaveBatchWithinGroupLimit(ctx interface{}, songs interface{}, limit interface{}) *MockSongRepository_SaveBatchWithinGroupLimit_Call {
+	return &MockSongRepository_SaveBatchWithinGroupLimit_Call{Call: _e.mock.On("SaveBatchWithinGroupLimit", ctx, songs, limit)}
+}
+
+func (_c *MockSongRepository_SaveBatchWithinGroupLimit_Call) Run(run func(ctx context.Context, songs []entity.Song, limit uint64)) *MockSongRepository_SaveBatchWithinGroupLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.Song), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_SaveBatchWithinGroupLimit_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongRepository_SaveBatchWithinGroupLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_SaveBatchWithinGroupLimit_Call) RunAndReturn(run func(context.Context, []entity.Song, uint64) ([]*entity.Song, error)) *MockSongRepository_SaveBatchWithinGroupLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveLinkChecks provides a mock function with given fields: ctx, checks
+func (_m *MockSongRepository) SaveLinkChecks(ctx context.Context, checks []entity.LinkCheck) error {
+	ret := _m.Called(ctx, checks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLinkChecks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.LinkCheck) error); ok {
+		r0 = rf(ctx, checks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSongRepository_SaveLinkChecks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveLinkChecks'
+type MockSongRepository_SaveLinkChecks_Call struct {
+	*mock.Call
+}
+
+// SaveLinkChecks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - checks []entity.LinkCheck
+func (_e *MockSongRepository_Expecter) SaveLinkChecks(ctx interface{}, checks interface{}) *MockSongRepository_SaveLinkChecks_Call {
+	return &MockSongRepository_SaveLinkChecks_Call{Call: _e.mock.On("SaveLinkChecks", ctx, checks)}
+}
+
+func (_c *MockSongRepository_SaveLinkChecks_Call) Run(run func(ctx context.Context, checks []entity.LinkCheck)) *MockSongRepository_SaveLinkChecks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.LinkCheck))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_SaveLinkChecks_Call) Return(_a0 error) *MockSongRepository_SaveLinkChecks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSongRepository_SaveLinkChecks_Call) RunAndReturn(run func(context.Context, []entity.LinkCheck) error) *MockSongRepository_SaveLinkChecks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveWithinGroupLimit provides a mock function with given fields: ctx, song, limit
+func (_m *MockSongRepository) SaveWithinGroupLimit(ctx context.Context, song entity.Song, limit uint64) (*entity.Song, error) {
+	ret := _m.Called(ctx, song, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWithinGroupLimit")
+	}
+
+	var r0 *entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song, uint64) (*entity.Song, error)); ok {
+		return rf(ctx, song, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song, uint64) *entity.Song); ok {
+		r0 = rf(ctx, song, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Song, uint64) error); ok {
+		r1 = rf(ctx, song, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_SaveWithinGroupLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveWithinGroupLimit'
+type MockSongRepository_SaveWithinGroupLimit_Call struct {
+	*mock.Call
+}
+
+// SaveWithinGroupLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - song entity.Song
+//   - limit uint64
+func (_e *MockSongRepository_Expecter) SaveWithinGroupLimit(ctx interface{}, song interface{}, limit interface{}) *MockSongRepository_SaveWithinGroupLimit_Call {
+	return &MockSongRepository_SaveWithinGroupLimit_Call{Call: _e.mock.On("SaveWithinGroupLimit", ctx, song, limit)}
+}
+
+func (_c *MockSongRepository_SaveWithinGroupLimit_Call) Run(run func(ctx context.Context, song entity.Song, limit uint64)) *MockSongRepository_SaveWithinGroupLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.Song), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_SaveWithinGroupLimit_Call) Return(_a0 *entity.Song, _a1 error) *MockSongRepository_SaveWithinGroupLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_SaveWithinGroupLimit_Call) RunAndReturn(run func(context.Context, entity.Song, uint64) (*entity.Song, error)) *MockSongRepository_SaveWithinGroupLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, query, pagination
+func (_m *MockSongRepository) Search(ctx context.Context, query string, pagination entity.Pagination) ([]*entity.Song, *entity.Pagination, error) {
+	ret := _m.Called(ctx, query, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*entity.Song
+	var r1 *entity.Pagination
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)); ok {
+		return rf(ctx, query, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Pagination) []*entity.Song); ok {
+		r0 = rf(ctx, query, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.Pagination) *entity.Pagination); ok {
+		r1 = rf(ctx, query, pagination)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Pagination)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, entity.Pagination) error); ok {
+		r2 = rf(ctx, query, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSongRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSongRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - pagination entity.Pagination
+func (_e *MockSongRepository_Expecter) Search(ctx interface{}, query interface{}, pagination interface{}) *MockSongRepository_Search_Call {
+	return &MockSongRepository_Search_Call{Call: _e.mock.On("Search", ctx, query, pagination)}
+}
+
+func (_c *MockSongRepository_Search_Call) Run(run func(ctx context.Context, query string, pagination entity.Pagination)) *MockSongRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.Pagination))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_Search_Call) Return(_a0 []*entity.Song, _a1 *entity.Pagination, _a2 error) *MockSongRepository_Search_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSongRepository_Search_Call) RunAndReturn(run func(context.Context, string, entity.Pagination) ([]*entity.Song, *entity.Pagination, error)) *MockSongRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Suggest provides a mock function with given fields: ctx, field, prefix, limit
+func (_m *MockSongRepository) Suggest(ctx context.Context, field entity.SuggestField, prefix string, limit uint64) ([]string, error) {
+	ret := _m.Called(ctx, field, prefix, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suggest")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SuggestField, string, uint64) ([]string, error)); ok {
+		return rf(ctx, field, prefix, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SuggestField, string, uint64) []string); ok {
+		r0 = rf(ctx, field, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.SuggestField, string, uint64) error); ok {
+		r1 = rf(ctx, field, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_Suggest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suggest'
+type MockSongRepository_Suggest_Call struct {
+	*mock.Call
+}
+
+// Suggest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - field entity.SuggestField
+//   - prefix string
+//   - limit uint64
+func (_e *MockSongRepository_Expecter) Suggest(ctx interface{}, field interface{}, prefix interface{}, limit interface{}) *MockSongRepository_Suggest_Call {
+	return &MockSongRepository_Suggest_Call{Call: _e.mock.On("Suggest", ctx, field, prefix, limit)}
+}
+
+func (_c *MockSongRepository_Suggest_Call) Run(run func(ctx context.Context, field entity.SuggestField, prefix string, limit uint64)) *MockSongRepository_Suggest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.SuggestField), args[2].(string), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_Suggest_Call) Return(_a0 []string, _a1 error) *MockSongRepository_Suggest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_Suggest_Call) RunAndReturn(run func(context.Context, entity.SuggestField, string, uint64) ([]string, error)) *MockSongRepository_Suggest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchWhere provides a mock function with given fields: ctx, filters
+func (_m *MockSongRepository) TouchWhere(ctx context.Context, filters ...entity.SongFilter) ([]uuid.UUID, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchWhere")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) ([]uuid.UUID, error)); ok {
+		return rf(ctx, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.SongFilter) []uuid.UUID); ok {
+		r0 = rf(ctx, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_TouchWhere_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchWhere'
+type MockSongRepository_TouchWhere_Call struct {
+	*mock.Call
+}
+
+// TouchWhere is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters ...entity.SongFilter
+func (_e *MockSongRepository_Expecter) TouchWhere(ctx interface{}, filters ...interface{}) *MockSongRepository_TouchWhere_Call {
+	return &MockSongRepository_TouchWhere_Call{Call: _e.mock.On("TouchWhere",
+		append([]interface{}{ctx}, filters...)...)}
+}
+
+func (_c *MockSongRepository_TouchWhere_Call) Run(run func(ctx context.Context, filters ...entity.SongFilter)) *MockSongRepository_TouchWhere_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_TouchWhere_Call) Return(_a0 []uuid.UUID, _a1 error) *MockSongRepository_TouchWhere_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_TouchWhere_Call) RunAndReturn(run func(context.Context, ...entity.SongFilter) ([]uuid.UUID, error)) *MockSongRepository_TouchWhere_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, songID, song
+func (_m *MockSongRepository) Update(ctx context.Context, songID uuid.UUID, song entity.Song) (*entity.Song, error) {
+	ret := _m.Called(ctx, songID, song)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Song) (*entity.Song, error)); ok {
+		return rf(ctx, songID, song)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Song) *entity.Song); ok {
+		r0 = rf(ctx, songID, song)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Song) error); ok {
+		r1 = rf(ctx, songID, song)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockSongRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - songID uuid.UUID
+//   - song entity.Song
+func (_e *MockSongRepository_Expecter) Update(ctx interface{}, songID interface{}, song interface{}) *MockSongRepository_Update_Call {
+	return &MockSongRepository_Update_Call{Call: _e.mock.On("Update", ctx, songID, song)}
+}
+
+func (_c *MockSongRepository_Update_Call) Run(run func(ctx context.Context, songID uuid.UUID, song entity.Song)) *MockSongRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(entity.Song))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_Update_Call) Return(_a0 *entity.Song, _a1 error) *MockSongRepository_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_Update_Call) RunAndReturn(run func(context.Context, uuid.UUID, entity.Song) (*entity.Song, error)) *MockSongRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBatch provides a mock function with given fields: ctx, updates
+func (_m *MockSongRepository) UpdateBatch(ctx context.Context, updates []entity.SongUpdate) ([]*entity.Song, error) {
+	ret := _m.Called(ctx, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBatch")
+	}
+
+	var r0 []*entity.Song
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SongUpdate) ([]*entity.Song, error)); ok {
+		return rf(ctx, updates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SongUpdate) []*entity.Song); ok {
+		r0 = rf(ctx, updates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Song)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.SongUpdate) error); ok {
+		r1 = rf(ctx, updates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_UpdateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBatch'
+type MockSongRepository_UpdateBatch_Call struct {
+	*mock.Call
+}
+
+// UpdateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - updates []entity.SongUpdate
+func (_e *MockSongRepository_Expecter) UpdateBatch(ctx interface{}, updates interface{}) *MockSongRepository_UpdateBatch_Call {
+	return &MockSongRepository_UpdateBatch_Call{Call: _e.mock.On("UpdateBatch", ctx, updates)}
+}
+
+func (_c *MockSongRepository_UpdateBatch_Call) Run(run func(ctx context.Context, updates []entity.SongUpdate)) *MockSongRepository_UpdateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.SongUpdate))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_UpdateBatch_Call) Return(_a0 []*entity.Song, _a1 error) *MockSongRepository_UpdateBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_UpdateBatch_Call) RunAndReturn(run func(context.Context, []entity.SongUpdate) ([]*entity.Song, error)) *MockSongRepository_UpdateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSortNames provides a mock function with given fields: ctx, sortNames
+func (_m *MockSongRepository) UpdateSortNames(ctx context.Context, sortNames []entity.SongSortName) (int64, error) {
+	ret := _m.Called(ctx, sortNames)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSortNames")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SongSortName) (int64, error)); ok {
+		return rf(ctx, sortNames)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SongSortName) int64); ok {
+		r0 = rf(ctx, sortNames)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.SongSortName) error); ok {
+		r1 = rf(ctx, sortNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_UpdateSortNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSortNames'
+type MockSongRepository_UpdateSortNames_Call struct {
+	*mock.Call
+}
+
+// UpdateSortNames is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sortNames []entity.SongSortName
+func (_e *MockSongRepository_Expecter) UpdateSortNames(ctx interface{}, sortNames interface{}) *MockSongRepository_UpdateSortNames_Call {
+	return &MockSongRepository_UpdateSortNames_Call{Call: _e.mock.On("UpdateSortNames", ctx, sortNames)}
+}
+
+func (_c *MockSongRepository_UpdateSortNames_Call) Run(run func(ctx context.Context, sortNames []entity.SongSortName)) *MockSongRepository_UpdateSortNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.SongSortName))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_UpdateSortNames_Call) Return(_a0 int64, _a1 error) *MockSongRepository_UpdateSortNames_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_UpdateSortNames_Call) RunAndReturn(run func(context.Context, []entity.SongSortName) (int64, error)) *MockSongRepository_UpdateSortNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVerseCounts provides a mock function with given fields: ctx, counts
+func (_m *MockSongRepository) UpdateVerseCounts(ctx context.Context, counts []entity.SongVerseCount) (int64, error) {
+	ret := _m.Called(ctx, counts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVerseCounts")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SongVerseCount) (int64, error)); ok {
+		return rf(ctx, counts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SongVerseCount) int64); ok {
+		r0 = rf(ctx, counts)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.SongVerseCount) error); ok {
+		r1 = rf(ctx, counts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_UpdateVerseCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVerseCounts'
+type MockSongRepository_UpdateVerseCounts_Call struct {
+	*mock.Call
+}
+
+// UpdateVerseCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - counts []entity.SongVerseCount
+func (_e *MockSongRepository_Expecter) UpdateVerseCounts(ctx interface{}, counts interface{}) *MockSongRepository_UpdateVerseCounts_Call {
+	return &MockSongRepository_UpdateVerseCounts_Call{Call: _e.mock.On("UpdateVerseCounts", ctx, counts)}
+}
+
+func (_c *MockSongRepository_UpdateVerseCounts_Call) Run(run func(ctx context.Context, counts []entity.SongVerseCount)) *MockSongRepository_UpdateVerseCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]entity.SongVerseCount))
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_UpdateVerseCounts_Call) Return(_a0 int64, _a1 error) *MockSongRepository_UpdateVerseCounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_UpdateVerseCounts_Call) RunAndReturn(run func(context.Context, []entity.SongVerseCount) (int64, error)) *MockSongRepository_UpdateVerseCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWhere provides a mock function with given fields: ctx, song, filters
+func (_m *MockSongRepository) UpdateWhere(ctx context.Context, song entity.Song, filters ...entity.SongFilter) ([]uuid.UUID, error) {
+	_va := make([]interface{}, len(filters))
+	for _i := range filters {
+		_va[_i] = filters[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, song)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWhere")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song, ...entity.SongFilter) ([]uuid.UUID, error)); ok {
+		return rf(ctx, song, filters...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Song, ...entity.SongFilter) []uuid.UUID); ok {
+		r0 = rf(ctx, song, filters...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Song, ...entity.SongFilter) error); ok {
+		r1 = rf(ctx, song, filters...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSongRepository_UpdateWhere_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWhere'
+type MockSongRepository_UpdateWhere_Call struct {
+	*mock.Call
+}
+
+// UpdateWhere is a helper method to define mock.On call
+//   - ctx context.Context
+//   - song entity.Song
+//   - filters ...entity.SongFilter
+func (_e *MockSongRepository_Expecter) UpdateWhere(ctx interface{}, song interface{}, filters ...interface{}) *MockSongRepository_UpdateWhere_Call {
+	return &MockSongRepository_UpdateWhere_Call{Call: _e.mock.On("UpdateWhere",
+		append([]interface{}{ctx, song}, filters...)...)}
+}
+
+func (_c *MockSongRepository_UpdateWhere_Call) Run(run func(ctx context.Context, song entity.Song, filters ...entity.SongFilter)) *MockSongRepository_UpdateWhere_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]entity.SongFilter, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(entity.SongFilter)
+			}
+		}
+		run(args[0].(context.Context), args[1].(entity.Song), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSongRepository_UpdateWhere_Call) Return(_a0 []uuid.UUID, _a1 error) *MockSongRepository_UpdateWhere_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSongRepository_UpdateWhere_Call) RunAndReturn(run func(context.Context, entity.Song, ...entity.SongFilter) ([]uuid.UUID, error)) *MockSongRepository_UpdateWhere_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,6 +3,9 @@ package postgres
 import (
 	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 
@@ -26,3 +29,56 @@ func RunMigrations(path string, dsn string) error {
 
 	return nil
 }
+
+// MigrationStatus describes the state of the database migrations.
+type MigrationStatus struct {
+	Version uint     // Version is the version of the last applied migration, 0 when none is applied.
+	Dirty   bool     // Dirty reports that the last migration failed midway and must be fixed by hand.
+	Files   []string // Files are the names of the migration files available at the migrations path, sorted.
+}
+
+// ReadMigrationStatus reads the migration version of the database using the provided Data Source Name (DSN)
+// and lists the migration files at the specified path. Every call opens a new connection to the database.
+func ReadMigrationStatus(path string, dsn string) (*MigrationStatus, error) {
+	const op = "postgres.ReadMigrationStatus"
+
+	files, err := migrationFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list migration files: %w", op, err)
+	}
+
+	m, err := migrate.New(fmt.Sprintf("file://%s", path), dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to initialize migrations: %w", op, err)
+	}
+	defer m.Close()
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("%s: failed to get migration version: %w", op, err)
+	}
+
+	return &MigrationStatus{
+		Version: version,
+		Dirty:   dirty,
+		Files:   files,
+	}, nil
+}
+
+// migrationFiles returns the sorted names of the SQL files in the directory at path.
+func migrationFiles(path string) ([]string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), ".sql") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
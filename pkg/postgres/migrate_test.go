@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationFiles(t *testing.T) {
+	t.Run("non-existent path", func(t *testing.T) {
+		files, err := migrationFiles("/non-existent/path")
+
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.Nil(t, files)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+
+		for _, name := range []string{"000002_add.up.sql", "000001_init.up.sql", "000001_init.down.sql", "README.md"} {
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+		}
+		assert.NoError(t, os.Mkdir(filepath.Join(dir, "nested.sql"), 0o700))
+
+		files, err := migrationFiles(dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"000001_init.down.sql", "000001_init.up.sql", "000002_add.up.sql"}, files)
+	})
+}
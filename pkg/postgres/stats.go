@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultStatsInterval = 15 * time.Second
+
+// StatsReporter periodically reads connection pool statistics and exposes them as Prometheus metrics.
+// It implements prometheus.Collector, so it can be registered in any Prometheus registry.
+type StatsReporter struct {
+	stats    func() sql.DBStats
+	interval time.Duration
+
+	mu           sync.Mutex
+	lastWait     int64
+	lastWaitTime time.Duration
+
+	openConns    prometheus.Gauge
+	inUseConns   prometheus.Gauge
+	idleConns    prometheus.Gauge
+	waitCount    prometheus.Counter
+	waitDuration prometheus.Counter
+}
+
+// NewStatsReporter creates a new StatsReporter that reads pool statistics using the provided function,
+// usually the Stats method of *sqlx.DB, every interval. A non-positive interval falls back to 15 seconds.
+func NewStatsReporter(stats func() sql.DBStats, interval time.Duration) *StatsReporter {
+	if interval <= 0 {
+		interval = defaultStatsInterval
+	}
+
+	opts := func(name, help string) prometheus.Opts {
+		return prometheus.Opts{
+			Namespace: "online_song_library",
+			Subsystem: "db_pool",
+			Name:      name,
+			Help:      help,
+		}
+	}
+
+	return &StatsReporter{
+		stats:        stats,
+		interval:     interval,
+		openConns:    prometheus.NewGauge(prometheus.GaugeOpts(opts("open_connections", "Number of established connections, both in use and idle."))),
+		inUseConns:   prometheus.NewGauge(prometheus.GaugeOpts(opts("in_use_connections", "Number of connections currently in use."))),
+		idleConns:    prometheus.NewGauge(prometheus.GaugeOpts(opts("idle_connections", "Number of idle connections."))),
+		waitCount:    prometheus.NewCounter(prometheus.CounterOpts(opts("wait_count_total", "Total number of connections waited for."))),
+		waitDuration: prometheus.NewCounter(prometheus.CounterOpts(opts("wait_duration_seconds_total", "Total time blocked waiting for a new connection in seconds."))),
+	}
+}
+
+// Run reports the pool statistics immediately and then on every tick until the context is done.
+func (s *StatsReporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.report()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.report()
+		}
+	}
+}
+
+// report reads the current pool statistics and updates the metrics.
+// Cumulative statistics are added to the counters as the difference from the previous report.
+func (s *StatsReporter) report() {
+	stats := s.stats()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.openConns.Set(float64(stats.OpenConnections))
+	s.inUseConns.Set(float64(stats.InUse))
+	s.idleConns.Set(float64(stats.Idle))
+
+	if delta := stats.WaitCount - s.lastWait; delta > 0 {
+		s.waitCount.Add(float64(delta))
+	}
+	if delta := stats.WaitDuration - s.lastWaitTime; delta > 0 {
+		s.waitDuration.Add(delta.Seconds())
+	}
+
+	s.lastWait = stats.WaitCount
+	s.lastWaitTime = stats.WaitDuration
+}
+
+// Describe implements prometheus.Collector.
+func (s *StatsReporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range s.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (s *StatsReporter) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range s.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (s *StatsReporter) collectors() []prometheus.Collector {
+	return []prometheus.Collector{s.openConns, s.inUseConns, s.idleConns, s.waitCount, s.waitDuration}
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsReporter(t *testing.T) {
+	stats := sql.DBStats{
+		OpenConnections: 10,
+		InUse:           7,
+		Idle:            3,
+		WaitCount:       4,
+		WaitDuration:    2 * time.Second,
+	}
+
+	r := NewStatsReporter(func() sql.DBStats { return stats }, time.Minute)
+
+	t.Run("initial report", func(t *testing.T) {
+		r.report()
+
+		assert.Equal(t, 10.0, testutil.ToFloat64(r.openConns))
+		assert.Equal(t, 7.0, testutil.ToFloat64(r.inUseConns))
+		assert.Equal(t, 3.0, testutil.ToFloat64(r.idleConns))
+		assert.Equal(t, 4.0, testutil.ToFloat64(r.waitCount))
+		assert.Equal(t, 2.0, testutil.ToFloat64(r.waitDuration))
+	})
+
+	t.Run("subsequent report", func(t *testing.T) {
+		stats.OpenConnections = 5
+		stats.InUse = 1
+		stats.Idle = 4
+		stats.WaitCount = 6
+		stats.WaitDuration = 3500 * time.Millisecond
+
+		r.report()
+
+		assert.Equal(t, 5.0, testutil.ToFloat64(r.openConns))
+		assert.Equal(t, 1.0, testutil.ToFloat64(r.inUseConns))
+		assert.Equal(t, 4.0, testutil.ToFloat64(r.idleConns))
+		assert.Equal(t, 6.0, testutil.ToFloat64(r.waitCount))
+		assert.Equal(t, 3.5, testutil.ToFloat64(r.waitDuration))
+	})
+
+	t.Run("collects all metrics", func(t *testing.T) {
+		assert.Equal(t, 5, testutil.CollectAndCount(r))
+	})
+}
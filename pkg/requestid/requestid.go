@@ -0,0 +1,16 @@
+package requestid
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the ID of the request it serves.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or an empty string without one.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
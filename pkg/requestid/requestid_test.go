@@ -0,0 +1,20 @@
+package requestid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Run("without request id in context", func(t *testing.T) {
+		assert.Empty(t, FromContext(context.Background()))
+	})
+
+	t.Run("request id from context", func(t *testing.T) {
+		ctx := NewContext(context.Background(), "test-request-id")
+
+		assert.Equal(t, "test-request-id", FromContext(ctx))
+	})
+}
@@ -0,0 +1,79 @@
+package servertiming
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Names of the metrics reported in the Server-Timing header.
+const (
+	MetricDB        = "db"
+	MetricMusicInfo = "music-info"
+	MetricTotal     = "total"
+)
+
+type contextKey struct{}
+
+type metric struct {
+	name     string
+	duration time.Duration
+}
+
+// Timings accumulates the time spent in named phases of handling a single request.
+// It is safe for concurrent use.
+type Timings struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+// NewContext returns a copy of ctx carrying new Timings, so phases timed with Start are recorded into them.
+func NewContext(ctx context.Context) (context.Context, *Timings) {
+	t := &Timings{}
+	return context.WithValue(ctx, contextKey{}, t), t
+}
+
+// Start begins timing a phase and returns the function that ends it. The duration is added to the Timings
+// carried by ctx, summed with earlier durations of the same metric. Without Timings in ctx it does nothing.
+func Start(ctx context.Context, name string) func() {
+	t, ok := ctx.Value(contextKey{}).(*Timings)
+	if !ok {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		t.Add(name, time.Since(start))
+	}
+}
+
+// Add adds d to the duration of the named metric.
+func (t *Timings) Add(name string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.metrics {
+		if t.metrics[i].name == name {
+			t.metrics[i].duration += d
+			return
+		}
+	}
+
+	t.metrics = append(t.metrics, metric{name: name, duration: d})
+}
+
+// Header formats the recorded metrics as a Server-Timing header value, with durations in milliseconds.
+// Metrics are listed in the order they were first recorded.
+func (t *Timings) Header() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries := make([]string, 0, len(t.metrics))
+	for _, m := range t.metrics {
+		entries = append(entries, fmt.Sprintf("%s;dur=%.2f", m.name, float64(m.duration)/float64(time.Millisecond)))
+	}
+
+	return strings.Join(entries, ", ")
+}
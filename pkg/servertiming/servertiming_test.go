@@ -0,0 +1,36 @@
+package servertiming
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimings(t *testing.T) {
+	t.Run("without timings in context", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			Start(context.Background(), MetricDB)()
+		})
+	})
+
+	t.Run("start records into context", func(t *testing.T) {
+		ctx, timings := NewContext(context.Background())
+
+		Start(ctx, MetricDB)()
+
+		assert.Regexp(t, `^db;dur=\d+\.\d{2}$`, timings.Header())
+	})
+
+	t.Run("durations of a metric are summed", func(t *testing.T) {
+		_, timings := NewContext(context.Background())
+
+		timings.Add(MetricDB, 1500*time.Microsecond)
+		timings.Add(MetricMusicInfo, 20*time.Millisecond)
+		timings.Add(MetricDB, 500*time.Microsecond)
+		timings.Add(MetricTotal, 25*time.Millisecond)
+
+		assert.Equal(t, "db;dur=2.00, music-info;dur=20.00, total;dur=25.00", timings.Header())
+	})
+}